@@ -0,0 +1,13 @@
+// Command validatetags checks `validate` struct tags. Run it on its own or
+// as a go vet tool:
+//
+//	go vet -vettool=$(which validatetags) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/aatuh/validate/v3/analysis/validatetags"
+)
+
+func main() { singlechecker.Main(validatetags.Analyzer) }
@@ -0,0 +1,15 @@
+module github.com/aatuh/validate/v3/analysis
+
+go 1.23.0
+
+require (
+	github.com/aatuh/validate/v3 v3.0.7
+	golang.org/x/tools v0.35.0
+)
+
+require (
+	golang.org/x/mod v0.26.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+)
+
+replace github.com/aatuh/validate/v3 => ../
@@ -0,0 +1,34 @@
+// Package validatetags provides a golang.org/x/tools/go/analysis Analyzer
+// that reports `validate` struct tags and rule expressions that fail to
+// parse or compile, and base types that do not match the field type. It
+// runs tagcheck.Checker on each package.
+//
+// The package is a separate module, so the validate module itself stays
+// dependency-free. Run it with go vet through cmd/validatetags, or load it
+// into gopls or another analysis driver.
+package validatetags
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/aatuh/validate/v3/tagcheck"
+)
+
+// Analyzer checks `validate` tags with a tagcheck.Checker using
+// validate.New(), so the root package's plugins are known.
+var Analyzer = &analysis.Analyzer{
+	Name: "validatetags",
+	Doc:  "check validate struct tags and rule expressions\n\nReports unknown rules, unparseable parameters, cross-field references to missing fields and base types that do not match the field type.",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	for _, d := range (tagcheck.Checker{}).Check(pass.Files, pass.TypesInfo) {
+		if d.Field != "" {
+			pass.Reportf(d.Pos, "%s: %s", d.Field, d.Message)
+		} else {
+			pass.Reportf(d.Pos, "%s", d.Message)
+		}
+	}
+	return nil, nil
+}
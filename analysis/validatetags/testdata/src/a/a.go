@@ -0,0 +1,9 @@
+package a
+
+type Request struct {
+	Name  string `validate:"string;min=1"`
+	Age   int    `validate:"int;min=x"`              // want `Age: .*min=x`
+	Email string `validate:"string;nosuchrule"`      // want `Email: .*nosuchrule`
+	Count string `validate:"int;positive"`           // want `Count: int rules on field of type string`
+	Other string `validate:"string;eqField=Missing"` // want `Other: .*Missing`
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"sort"
+	"sync"
+)
+
+// Accumulator collects named counters across a single struct validation
+// call. Struct rules reach it through StructRuleContext.Accumulator; engine
+// quotas registered with WithQuota are checked against it after the walk.
+type Accumulator struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// NewAccumulator returns an empty Accumulator.
+func NewAccumulator() *Accumulator {
+	return &Accumulator{counts: make(map[string]int64)}
+}
+
+// Add increments counter name by n and returns the new total.
+func (a *Accumulator) Add(name string, n int64) int64 {
+	if a == nil {
+		return 0
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.counts[name] += n
+	return a.counts[name]
+}
+
+// Value returns the current total of counter name.
+func (a *Accumulator) Value(name string) int64 {
+	if a == nil {
+		return 0
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.counts[name]
+}
+
+// WithQuota returns a new Engine that limits the named accumulator counter
+// to max per struct validation call. Fields contribute with the `quota=name`
+// tag token.
+func (e *Engine) WithQuota(name string, max int64) *Engine {
+	ne := e.Copy()
+	if ne.quotas == nil {
+		ne.quotas = make(map[string]int64)
+	}
+	ne.quotas[name] = max
+	return ne
+}
+
+// Quota returns the limit registered for name.
+func (e *Engine) Quota(name string) (int64, bool) {
+	max, ok := e.quotas[name]
+	return max, ok
+}
+
+// QuotaNames returns the registered quota names in sorted order.
+func (e *Engine) QuotaNames() []string {
+	names := make([]string, 0, len(e.quotas))
+	for name := range e.quotas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func copyQuotas(in map[string]int64) map[string]int64 {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]int64, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
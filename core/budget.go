@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// BudgetContext bounds ctx by opts.Deadline, set by ApplyOpts from
+// ValidateOpts.Budget. Without a deadline it returns ctx unchanged.
+//
+// Parameters:
+//   - ctx: The caller's context.
+//   - opts: Options already passed through ApplyOpts.
+//
+// Returns:
+//   - context.Context: The context to validate with.
+//   - context.CancelFunc: Releases the context's timer; always non-nil.
+func BudgetContext(ctx context.Context, opts ValidateOpts) (context.Context, context.CancelFunc) {
+	if opts.Deadline.IsZero() {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, opts.Deadline)
+}
+
+// TimeoutError converts err, which stopped a call validated with the
+// context from BudgetContext, into a validation.timeout error when the
+// budget ran out rather than the caller's ctx.
+//
+// Parameters:
+//   - ctx: The caller's context, not the one from BudgetContext.
+//   - err: The error that stopped the call.
+//   - path: The path validation reached.
+//   - checked: The number of fields validated before it stopped.
+//
+// Returns:
+//   - verrs.FieldError: The timeout error, with checked as Param.
+//   - bool: False if err is not the budget's deadline.
+func (e *Engine) TimeoutError(ctx context.Context, err error, path string, checked int) (verrs.FieldError, bool) {
+	if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+		return verrs.FieldError{}, false
+	}
+	msg := fmt.Sprintf("validation time budget exceeded after %d fields", checked)
+	if tr := e.Translator(); tr != nil {
+		if translated := tr.T(verrs.CodeTimeout, checked); translated != "" {
+			msg = translated
+		}
+	}
+	return verrs.FieldError{Path: path, Code: verrs.CodeTimeout, Param: checked, Msg: msg}, true
+}
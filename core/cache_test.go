@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestWithResultCache_SharedAcrossDerivedEngines(t *testing.T) {
+	calls := 0
+	rc := types.NewResultCache(100, time.Minute)
+	base := New().WithContextRuleCompiler("mx", func(c *types.Compiler, rule types.Rule) (types.ContextValidatorFunc, error) {
+		return func(ctx context.Context, v any) error {
+			calls++
+			return nil
+		}, nil
+	}).WithResultCache("mx", rc)
+
+	for _, e := range []*Engine{base, base.WithOutagePolicy(types.OutagePolicy{Mode: types.OutagePass})} {
+		fn, err := e.FromRulesContext([]string{"string", "mx"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fn(context.Background(), "a@example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+	if stats := rc.Stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
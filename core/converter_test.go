@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type nullString struct {
+	String string
+	Valid  bool
+}
+
+func nullStringConverter(v any) (any, error) {
+	ns := v.(nullString)
+	if !ns.Valid {
+		return nil, nil
+	}
+	return ns.String, nil
+}
+
+func TestWithConverter_AdaptsWrapperType(t *testing.T) {
+	base := New()
+	v := base.WithConverter(reflect.TypeOf(nullString{}), types.KString, nullStringConverter)
+
+	fn, err := v.FromRules([]string{"string;omitempty;min=3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn(nullString{String: "abcd", Valid: true}); err != nil {
+		t.Fatalf("valid wrapped value: %v", err)
+	}
+	if err := fn(nullString{}); err != nil {
+		t.Fatalf("null value with omitempty: %v", err)
+	}
+	var es verrs.Errors
+	if err := fn(nullString{String: "ab", Valid: true}); !errors.As(err, &es) || es[0].Code != verrs.CodeStringMin {
+		t.Fatalf("expected string.min, got %v", err)
+	}
+	if err := fn("abcd"); err != nil {
+		t.Fatalf("plain string must be unaffected: %v", err)
+	}
+
+	ctxFn, err := v.FromRulesContext([]string{"string;required"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctxFn(context.Background(), nullString{}); !errors.As(err, &es) || es[0].Code != verrs.CodeRequired {
+		t.Fatalf("expected required, got %v", err)
+	}
+
+	// The original engine is unchanged.
+	baseFn, _ := base.FromRules([]string{"string"})
+	if err := baseFn(nullString{String: "abcd", Valid: true}); err == nil {
+		t.Fatal("converter leaked into original engine")
+	}
+}
+
+func TestWithConverter_ErrorFailsValidation(t *testing.T) {
+	v := New().WithConverter(reflect.TypeOf(nullString{}), types.KString, func(any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	fn, err := v.FromRules([]string{"string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var es verrs.Errors
+	if err := fn(nullString{Valid: true}); !errors.As(err, &es) || es[0].Code != verrs.CodeUnknown {
+		t.Fatalf("expected unknown, got %v", err)
+	}
+}
+
+func TestRegisterConverter_DropsCompiledValidators(t *testing.T) {
+	e := New()
+	derived := e.Copy()
+	value := nullString{String: "abcd", Valid: true}
+	fn, err := e.FromRules([]string{"string;min=3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn(value); err == nil {
+		t.Fatal("wrapper type passed without a converter")
+	}
+
+	e.RegisterConverter(reflect.TypeOf(nullString{}), types.KString, nullStringConverter)
+	for _, c := range []struct {
+		engine *Engine
+		valid  bool
+	}{{e, true}, {derived, false}} {
+		fn, err := c.engine.FromRules([]string{"string;min=3"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fn(value) == nil; got != c.valid {
+			t.Fatalf("valid = %v, want %v", got, c.valid)
+		}
+	}
+}
@@ -3,8 +3,10 @@ package core
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/aatuh/validate/v3/translator"
 	"github.com/aatuh/validate/v3/types"
@@ -28,22 +30,116 @@ type Engine struct {
 	typeRegistry         *types.TypeRegistry
 	translator           translator.Translator
 	pathSep              string
+	schemaVersions       map[schemaVersionKey]map[string]string
+	shadowRules          map[types.Kind]float64
+	shadowHook           types.ShadowHook
+	compileHooks         map[types.Kind][]types.CompileHook
+	middleware           []types.Middleware
+	retryPolicies        map[types.Kind]types.RetryPolicy
+	outagePolicy         types.OutagePolicy
+	resultCaches         map[types.Kind]*types.ResultCache
+	quotas               map[string]int64
+	converters           []converter
+	nilAsEmpty           bool
+	nilPolicy            types.NilPolicy
+	limits               types.DefaultLimits
+	playgroundTags       bool
+	tagNames             []string
+	noFusion             bool
+	behavior             types.Behavior
+	cacheSize            int
+	observer             Observer
+	recorder             *Recorder
+
+	shareCache bool
+
+	// compiled caches compiled validators. Derived engines share it only
+	// when shareCache is set and the derivation keeps rule semantics.
+	// RegisterRule and RegisterConverter swap it for an empty cache.
+	compiled atomic.Pointer[compiledCache]
+	// compiler is built on first compile and rebuilt when the engine's
+	// rules or the global rule registry change.
+	compilerMu      sync.Mutex
+	compiler        *types.Compiler
+	compilerVersion uint64
+	// typeCache holds per-type compiled state owned by other packages,
+	// such as struct validation plans.
+	typeCache sync.Map
+	// translated holds engines returned by ForTranslator, keyed by
+	// translator; translatedCount caps it at maxTranslators.
+	translated      sync.Map
+	translatedCount atomic.Int64
+}
+
+// maxTranslators caps the translators an engine keeps engines for in
+// ForTranslator, and those a compiled cache keeps key prefixes for. Past it,
+// validators for further translators are compiled without being cached, so
+// a translator built per request cannot grow either without bound.
+const maxTranslators = 64
+
+// compiledCache holds compiled validators. Keys are compiledKey values
+// made by Engine.cacheKey: a translator prefix followed by ckTag or ckAST.
+//
+// Fields:
+//   - validators: map[compiledKey]types.ValidatorFunc.
+//   - context: map[compiledKey]types.ContextValidatorFunc.
+//   - count: Entries in both maps, so cacheSize can be enforced.
+//   - translators: map[translator.Translator]string of key prefixes, at
+//     most maxTranslators.
+//   - nextTranslator: Last prefix number handed out.
+type compiledCache struct {
+	validators     sync.Map
+	context        sync.Map
+	count          atomic.Int64
+	translators    sync.Map
+	nextTranslator atomic.Uint64
+}
 
-	// compiled caches compiled validators.
-	// Keys are compiledKey values with ckTag or ckAST prefixes.
-	compiled        sync.Map // map[compiledKey]types.ValidatorFunc
-	compiledContext sync.Map // map[compiledKey]types.ContextValidatorFunc
+// translatorPrefix returns the key prefix for validators compiled with t.
+// Compiled validators render messages with the translator they were
+// compiled with, so engines sharing the cache with different translators
+// must not see each other's entries. Translators of comparable types get
+// one prefix per value; others cannot be map keys and get one per owner.
+// It returns false once maxTranslators translators have prefixes, and
+// validators compiled with t are then not cached.
+func (cc *compiledCache) translatorPrefix(t translator.Translator, owner *Engine) (string, bool) {
+	if t == nil {
+		return "", true
+	}
+	var id any = t
+	if !reflect.TypeOf(t).Comparable() {
+		id = owner
+	}
+	if prefix, ok := cc.translators.Load(id); ok {
+		return prefix.(string), true
+	}
+	n := cc.nextTranslator.Add(1)
+	if n > maxTranslators {
+		return "", false
+	}
+	stored, _ := cc.translators.LoadOrStore(id, fmt.Sprintf("tr%d:", n))
+	return stored.(string), true
 }
 
-// NewEngine creates a new Engine with sane defaults.
-func NewEngine() *Engine {
-	return &Engine{
+// NewEngine creates a new Engine with sane defaults, configured by opts.
+// Options are applied in order to the same Engine, so configuring an
+// engine this way allocates once and keeps its cache, unlike chained With*
+// methods.
+func NewEngine(opts ...Option) *Engine {
+	e := &Engine{
 		customRules:          make(map[string]func(any) error),
 		ruleCompilers:        make(map[types.Kind]types.RuleCompiler),
 		contextRuleCompilers: make(map[types.Kind]types.ContextRuleCompiler),
 		structRuleCompilers:  make(map[types.Kind]StructRuleCompiler),
 		pathSep:              ".",
 	}
+	e.compiled.Store(&compiledCache{})
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+	return e
 }
 
 // NewEngineWithCustomRules seeds the engine with custom rules.
@@ -61,6 +157,10 @@ func (e *Engine) Copy() *Engine {
 	if e == nil {
 		return nil
 	}
+	// RegisterRule and RegisterConverter change rule compilers and
+	// converters under compilerMu.
+	e.compilerMu.Lock()
+	defer e.compilerMu.Unlock()
 	// Create new Engine with same config but new cache
 	newEngine := &Engine{
 		customRules:          copyCustomRules(e.customRules),
@@ -70,125 +170,371 @@ func (e *Engine) Copy() *Engine {
 		typeRegistry:         copyTypeRegistry(e.typeRegistry),
 		translator:           e.translator,
 		pathSep:              e.pathSep,
-		// Note: compiled cache is intentionally not copied (new empty cache)
-	}
+		schemaVersions:       copySchemaVersions(e.schemaVersions),
+		shadowRules:          copyShadowRules(e.shadowRules),
+		shadowHook:           e.shadowHook,
+		compileHooks:         copyCompileHooks(e.compileHooks),
+		middleware:           append([]types.Middleware(nil), e.middleware...),
+		retryPolicies:        copyRetryPolicies(e.retryPolicies),
+		outagePolicy:         e.outagePolicy,
+		resultCaches:         copyResultCaches(e.resultCaches),
+		quotas:               copyQuotas(e.quotas),
+		converters:           append([]converter(nil), e.converters...),
+		nilAsEmpty:           e.nilAsEmpty,
+		nilPolicy:            e.nilPolicy,
+		limits:               e.limits,
+		playgroundTags:       e.playgroundTags,
+		tagNames:             e.tagNames,
+		noFusion:             e.noFusion,
+		behavior:             e.behavior,
+		cacheSize:            e.cacheSize,
+		observer:             e.observer,
+		recorder:             e.recorder,
+		shareCache:           e.shareCache,
+	}
+	// Note: compiled cache is intentionally not copied (new empty cache)
+	newEngine.compiled.Store(&compiledCache{})
 
 	return newEngine
 }
 
-// WithCustomRule returns a new Engine with the rule registered.
+// WithCustomRule returns a new Engine with the rule registered. Custom rules
+// never reach compiled validators, so the new Engine shares the compiled
+// cache when cache sharing is enabled.
 func (e *Engine) WithCustomRule(name string, rule func(any) error) *Engine {
-	newCustom := make(map[string]func(any) error, len(e.customRules)+1)
-	for k, v := range e.customRules {
-		newCustom[k] = v
-	}
-	newCustom[name] = rule
-
-	return &Engine{
-		customRules:          newCustom,
-		ruleCompilers:        copyRuleCompilers(e.ruleCompilers),
-		contextRuleCompilers: copyContextRuleCompilers(e.contextRuleCompilers),
-		structRuleCompilers:  copyStructRuleCompilers(e.structRuleCompilers),
-		typeRegistry:         copyTypeRegistry(e.typeRegistry),
-		translator:           e.translator,
-		pathSep:              e.pathSep,
-		// Note: compiled cache is intentionally not copied (new empty cache)
-	}
+	ne := e.Copy()
+	ne.customRules[name] = rule
+	ne.inheritCache(e)
+	return ne
 }
 
 // WithRuleCompiler returns a new Engine with a per-instance rule compiler.
 func (e *Engine) WithRuleCompiler(kind types.Kind, rc types.RuleCompiler) *Engine {
-	newCompilers := copyRuleCompilers(e.ruleCompilers)
-	newCompilers[kind] = rc
-	return &Engine{
-		customRules:          copyCustomRules(e.customRules),
-		ruleCompilers:        newCompilers,
-		contextRuleCompilers: copyContextRuleCompilers(e.contextRuleCompilers),
-		structRuleCompilers:  copyStructRuleCompilers(e.structRuleCompilers),
-		typeRegistry:         copyTypeRegistry(e.typeRegistry),
-		translator:           e.translator,
-		pathSep:              e.pathSep,
-	}
+	ne := e.Copy()
+	ne.ruleCompilers[kind] = rc
+	return ne
 }
 
 // WithContextRuleCompiler returns a new Engine with a per-instance
 // context-aware rule compiler.
 func (e *Engine) WithContextRuleCompiler(kind types.Kind, rc types.ContextRuleCompiler) *Engine {
-	newCompilers := copyContextRuleCompilers(e.contextRuleCompilers)
-	newCompilers[kind] = rc
-	return &Engine{
-		customRules:          copyCustomRules(e.customRules),
-		ruleCompilers:        copyRuleCompilers(e.ruleCompilers),
-		contextRuleCompilers: newCompilers,
-		structRuleCompilers:  copyStructRuleCompilers(e.structRuleCompilers),
-		typeRegistry:         copyTypeRegistry(e.typeRegistry),
-		translator:           e.translator,
-		pathSep:              e.pathSep,
-	}
+	ne := e.Copy()
+	ne.contextRuleCompilers[kind] = rc
+	return ne
 }
 
 // WithStructRuleCompiler returns a new Engine with a per-instance struct rule compiler.
 func (e *Engine) WithStructRuleCompiler(kind types.Kind, compiler StructRuleCompiler) *Engine {
-	newCompilers := copyStructRuleCompilers(e.structRuleCompilers)
-	newCompilers[kind] = compiler
-	return &Engine{
-		customRules:          copyCustomRules(e.customRules),
-		ruleCompilers:        copyRuleCompilers(e.ruleCompilers),
-		contextRuleCompilers: copyContextRuleCompilers(e.contextRuleCompilers),
-		structRuleCompilers:  newCompilers,
-		typeRegistry:         copyTypeRegistry(e.typeRegistry),
-		translator:           e.translator,
-		pathSep:              e.pathSep,
-	}
+	ne := e.Copy()
+	ne.structRuleCompilers[kind] = compiler
+	return ne
 }
 
 // WithTypeValidator returns a new Engine with a per-instance custom type validator.
 func (e *Engine) WithTypeValidator(name string, factory types.TypeValidatorFactory) *Engine {
-	newRegistry := copyTypeRegistry(e.typeRegistry)
-	if newRegistry == nil {
-		newRegistry = types.NewTypeRegistry()
-	}
-	newRegistry.RegisterType(name, factory)
-	return &Engine{
-		customRules:          copyCustomRules(e.customRules),
-		ruleCompilers:        copyRuleCompilers(e.ruleCompilers),
-		contextRuleCompilers: copyContextRuleCompilers(e.contextRuleCompilers),
-		structRuleCompilers:  copyStructRuleCompilers(e.structRuleCompilers),
-		typeRegistry:         newRegistry,
-		translator:           e.translator,
-		pathSep:              e.pathSep,
+	ne := e.Copy()
+	if ne.typeRegistry == nil {
+		ne.typeRegistry = types.NewTypeRegistry()
 	}
+	ne.typeRegistry.RegisterType(name, factory)
+	return ne
 }
 
-// WithTranslator returns a new Engine with a translator.
+// WithTranslator returns a new Engine with a translator. Compiled
+// validators are cached per translator, so the new Engine shares the
+// compiled cache when cache sharing is enabled, and swapping between
+// translators reuses the validators compiled for each.
 func (e *Engine) WithTranslator(t translator.Translator) *Engine {
-	return &Engine{
-		customRules:          copyCustomRules(e.customRules),
-		ruleCompilers:        copyRuleCompilers(e.ruleCompilers),
-		contextRuleCompilers: copyContextRuleCompilers(e.contextRuleCompilers),
-		structRuleCompilers:  copyStructRuleCompilers(e.structRuleCompilers),
-		typeRegistry:         copyTypeRegistry(e.typeRegistry),
-		translator:           t,
-		pathSep:              e.pathSep,
-		// Note: compiled cache is intentionally not copied (new empty cache)
+	ne := e.Copy()
+	ne.translator = t
+	ne.inheritCache(e)
+	return ne
+}
+
+// ForTranslator returns an engine that renders messages with t and is
+// otherwise configured like e, for ValidateOpts.Translator. It shares e's
+// compiled cache, whose entries are kept per translator, and is kept for
+// later calls with the same t, so each rule set and struct type compiles
+// once per translator. Map translators are kept per map. Other
+// non-comparable translators, such as funcs, cannot be identified: they get
+// a fresh engine with its own cache per call, so nothing is shared and
+// every call compiles again; use pointer types. The same applies to
+// translators after the first maxTranslators (64), so translators should be
+// long-lived rather than built per request. A nil t, or e's own translator,
+// returns e.
+func (e *Engine) ForTranslator(t translator.Translator) *Engine {
+	if t == nil {
+		return e
+	}
+	id, ok := translatorIdentity(t)
+	if !ok {
+		return e.uncachedCopy(t)
+	}
+	if e.translator != nil {
+		if own, ok := translatorIdentity(e.translator); ok && own == id {
+			return e
+		}
+	}
+	if ne, ok := e.translated.Load(id); ok {
+		return ne.(*Engine)
+	}
+	if e.translatedCount.Add(1) > maxTranslators {
+		e.translatedCount.Add(-1)
+		return e.uncachedCopy(t)
+	}
+	ne, loaded := e.translated.LoadOrStore(id, e.translatedCopy(t))
+	if loaded {
+		e.translatedCount.Add(-1)
+	}
+	return ne.(*Engine)
+}
+
+// uncachedCopy returns a copy of e that renders messages with t and has its
+// own compiled cache.
+func (e *Engine) uncachedCopy(t translator.Translator) *Engine {
+	ne := e.Copy()
+	ne.translator = t
+	return ne
+}
+
+// mapTranslatorKey identifies a map translator by its type and map. The
+// engine kept under the key retains the map, so its address is not reused
+// while the key is in use.
+type mapTranslatorKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// translatorIdentity returns a comparable key for t, and false when t has
+// none.
+func translatorIdentity(t translator.Translator) (any, bool) {
+	typ := reflect.TypeOf(t)
+	switch {
+	case typ.Comparable():
+		return t, true
+	case typ.Kind() == reflect.Map:
+		return mapTranslatorKey{typ: typ, ptr: reflect.ValueOf(t).Pointer()}, true
+	default:
+		return nil, false
+	}
+}
+
+func (e *Engine) translatedCopy(t translator.Translator) *Engine {
+	ne := e.Copy()
+	ne.translator = t
+	ne.compiled.Store(e.compiled.Load())
+	return ne
+}
+
+// WithShadowRule returns a new Engine that runs rules of kind in shadow
+// mode: failures go to the shadow hook instead of failing validation.
+// sampleRate is the fraction of validations that execute the rule.
+func (e *Engine) WithShadowRule(kind types.Kind, sampleRate float64) *Engine {
+	ne := e.Copy()
+	if ne.shadowRules == nil {
+		ne.shadowRules = make(map[types.Kind]float64)
+	}
+	ne.shadowRules[kind] = sampleRate
+	return ne
+}
+
+// WithShadowHook returns a new Engine that reports shadow rule failures to
+// hook.
+func (e *Engine) WithShadowHook(hook types.ShadowHook) *Engine {
+	ne := e.Copy()
+	ne.shadowHook = hook
+	return ne
+}
+
+// WithCompileHook returns a new Engine that compiles rules of kind inside
+// hook, after any hooks already registered for kind; see
+// types.Compiler.OnCompile. A hook that rejects a kind makes every tag
+// using it fail to compile.
+func (e *Engine) WithCompileHook(kind types.Kind, hook types.CompileHook) *Engine {
+	ne := e.Copy()
+	if ne.compileHooks == nil {
+		ne.compileHooks = make(map[types.Kind][]types.CompileHook)
+	}
+	ne.compileHooks[kind] = append(ne.compileHooks[kind], hook)
+	return ne
+}
+
+// Use returns a new Engine that runs every compiled rule inside mw, after
+// any middleware already registered; see types.Compiler.Use. Struct
+// validation passes field paths to middleware through types.FieldPath.
+func (e *Engine) Use(mw ...types.Middleware) *Engine {
+	ne := e.Copy()
+	ne.middleware = append(ne.middleware, mw...)
+	return ne
+}
+
+// HasMiddleware reports whether middleware is registered with Use.
+func (e *Engine) HasMiddleware() bool {
+	return len(e.middleware) > 0
+}
+
+// WithRetryPolicy returns a new Engine that retries context-aware custom
+// rules of kind per p when they fail with a transient error. A circuit
+// breaker in p is shared with every engine derived from this one.
+func (e *Engine) WithRetryPolicy(kind types.Kind, p types.RetryPolicy) *Engine {
+	ne := e.Copy()
+	if ne.retryPolicies == nil {
+		ne.retryPolicies = make(map[types.Kind]types.RetryPolicy)
+	}
+	ne.retryPolicies[kind] = p
+	return ne
+}
+
+// WithOutagePolicy returns a new Engine where outages of context-aware
+// custom rules, errors other than validation failures, are handled per p:
+// failing validation, passing with a hook call, or failing with
+// rule.unavailable.
+func (e *Engine) WithOutagePolicy(p types.OutagePolicy) *Engine {
+	ne := e.Copy()
+	ne.outagePolicy = p
+	return ne
+}
+
+// WithResultCache returns a new Engine that caches the results of
+// context-aware custom rules of kind in rc. The cache is shared with every
+// engine derived from this one, so results are reused across requests.
+func (e *Engine) WithResultCache(kind types.Kind, rc *types.ResultCache) *Engine {
+	ne := e.Copy()
+	if ne.resultCaches == nil {
+		ne.resultCaches = make(map[types.Kind]*types.ResultCache)
+	}
+	ne.resultCaches[kind] = rc
+	return ne
+}
+
+// WithConverter returns a new Engine that converts values of type from with
+// fn before rule chains of kind to run. It adapts wrapper types such as
+// null.String or decimal.Decimal to built-in rules without per-rule plugins.
+func (e *Engine) WithConverter(from reflect.Type, to types.Kind, fn types.ConverterFunc) *Engine {
+	ne := e.Copy()
+	ne.converters = append(ne.converters, converter{from: from, to: to, fn: fn})
+	return ne
+}
+
+// WithNilPolicy returns a new Engine where validators for built-in base
+// types treat nil values, and nil pointers under Behavior.DerefPointers, per
+// p.
+func (e *Engine) WithNilPolicy(p types.NilPolicy) *Engine {
+	ne := e.Copy()
+	ne.nilPolicy = p
+	return ne
+}
+
+// WithDefaultLimits returns a new Engine that bounds string, slice and map
+// rule chains without an explicit maximum by l; see types.DefaultLimits.
+// Tags opt out field by field with unbounded or set their own max.
+func (e *Engine) WithDefaultLimits(l types.DefaultLimits) *Engine {
+	ne := e.Copy()
+	ne.limits = l
+	return ne
+}
+
+// WithNilCollectionsAsEmpty returns a new Engine where slice and map rules
+// treat an untyped nil value (for example a nil *[]T field) as an empty
+// collection. By default such values fail with slice.type or map.type, or
+// with value.nil under Behavior.NilValueCode.
+func (e *Engine) WithNilCollectionsAsEmpty(enabled bool) *Engine {
+	ne := e.Copy()
+	ne.nilAsEmpty = enabled
+	return ne
+}
+
+// WithPlaygroundTags returns a new Engine whose struct validation reads
+// `validate` tags in go-playground/validator syntax, such as
+// "required,min=3,oneof=a b c" or "dive,email", and translates them into
+// this package's rules by field type. Schema version overrides keep the
+// native syntax.
+func (e *Engine) WithPlaygroundTags(enabled bool) *Engine {
+	ne := e.Copy()
+	ne.playgroundTags = enabled
+	return ne
+}
+
+// PlaygroundTags reports whether struct tags use go-playground syntax.
+func (e *Engine) PlaygroundTags() bool { return e.playgroundTags }
+
+// WithTagNames returns a new Engine whose struct validation reads rules from
+// the struct tag keys names, such as "binding" or "valid", instead of
+// `validate`. Rules of several keys are merged in order. No names restores
+// the default.
+func (e *Engine) WithTagNames(names ...string) *Engine {
+	ne := e.Copy()
+	ne.tagNames = append([]string(nil), names...)
+	return ne
+}
+
+// TagNames returns the struct tag keys struct validation reads rules from.
+func (e *Engine) TagNames() []string {
+	if len(e.tagNames) == 0 {
+		return []string{"validate"}
 	}
+	return append([]string(nil), e.tagNames...)
+}
+
+// NativeTags reports whether struct validation reads plain `validate` tags
+// in this package's syntax, the tags generated validators are built from.
+func (e *Engine) NativeTags() bool {
+	if e.playgroundTags {
+		return false
+	}
+	return len(e.tagNames) == 0 || len(e.tagNames) == 1 && e.tagNames[0] == "validate"
+}
+
+// WithRuleFusion returns a new Engine with the compiler's rule fusion pass
+// on or off. Fusion is on by default and never changes results; turn it off
+// to debug or benchmark rule chains. See types.Compiler.SetRuleFusion.
+func (e *Engine) WithRuleFusion(enabled bool) *Engine {
+	ne := e.Copy()
+	ne.noFusion = !enabled
+	return ne
+}
+
+// WithBehavior returns a new Engine whose built-in rules apply the fixes
+// enabled in b; see types.Behavior. The zero Behavior, the default, keeps
+// the outcomes of earlier releases.
+func (e *Engine) WithBehavior(b types.Behavior) *Engine {
+	ne := e.Copy()
+	ne.behavior = b
+	return ne
 }
 
-// PathSeparator returns a new Engine with a different path separator.
+// Behavior returns the behavior fixes the engine applies.
+func (e *Engine) Behavior() types.Behavior { return e.behavior }
+
+// PathSeparator returns a new Engine with a different path separator. It
+// shares the compiled cache when cache sharing is enabled.
 func (e *Engine) PathSeparator(sep string) *Engine {
-	newPathSep := e.pathSep
+	ne := e.Copy()
 	if sep != "" {
-		newPathSep = sep
+		ne.pathSep = sep
 	}
-	return &Engine{
-		customRules:          copyCustomRules(e.customRules),
-		ruleCompilers:        copyRuleCompilers(e.ruleCompilers),
-		contextRuleCompilers: copyContextRuleCompilers(e.contextRuleCompilers),
-		structRuleCompilers:  copyStructRuleCompilers(e.structRuleCompilers),
-		typeRegistry:         copyTypeRegistry(e.typeRegistry),
-		translator:           e.translator,
-		pathSep:              newPathSep,
-		// Note: compiled cache is intentionally not copied (new empty cache)
+	ne.inheritCache(e)
+	return ne
+}
+
+// WithSharedCache returns a new Engine that shares its compiled validator
+// cache with e. When enabled, engines derived from it by WithCustomRule,
+// PathSeparator, or WithTranslator share the cache too: those derivations
+// leave rule semantics unchanged, and entries are kept per translator, so
+// configuring per request does not recompile every rule set. Other With*
+// methods change how rules compile and always start a fresh cache, as does
+// Copy. Per-type state such as struct plans is never shared.
+func (e *Engine) WithSharedCache(enabled bool) *Engine {
+	ne := e.Copy()
+	ne.shareCache = enabled
+	ne.compiled.Store(e.compiled.Load())
+	return ne
+}
+
+// inheritCache makes e use parent's compiled cache if sharing is enabled.
+func (e *Engine) inheritCache(parent *Engine) {
+	if e.shareCache {
+		e.compiled.Store(parent.compiled.Load())
 	}
 }
 
@@ -204,6 +550,94 @@ func (e *Engine) StructRuleCompiler(kind types.Kind) (StructRuleCompiler, bool)
 	return compiler, ok
 }
 
+// AltersBuiltinRules reports whether the engine changes how built-in rule
+// kinds behave, through shadow rules, compile hooks, middleware, converters,
+// default limits, behavior fixes, or per-instance compilers registered for
+// documented kinds, or records them with a Recorder. Generated validators inline built-in
+// rules and are only used when it returns false.
+func (e *Engine) AltersBuiltinRules() bool {
+	e.compilerMu.Lock()
+	defer e.compilerMu.Unlock()
+	if len(e.shadowRules) > 0 || len(e.compileHooks) > 0 || len(e.middleware) > 0 ||
+		len(e.converters) > 0 || e.limits != (types.DefaultLimits{}) || e.recorder != nil ||
+		e.behavior != (types.Behavior{}) {
+		return true
+	}
+	for kind := range e.ruleCompilers {
+		if _, ok := types.DescribeKind(kind); ok {
+			return true
+		}
+	}
+	for kind := range e.contextRuleCompilers {
+		if _, ok := types.DescribeKind(kind); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CachedType returns the per-type compiled state stored under key.
+func (e *Engine) CachedType(key any) (any, bool) {
+	v, ok := e.typeCache.Load(key)
+	e.observeCache(CacheType, ok)
+	return v, ok
+}
+
+// CacheType stores per-type compiled state under key unless another
+// goroutine stored it first, and returns the stored value. Like compiled
+// validators, the cache is not shared with engines returned by With*
+// methods, so configuration changes never see stale entries.
+func (e *Engine) CacheType(key, value any) any {
+	stored, _ := e.typeCache.LoadOrStore(key, value)
+	return stored
+}
+
+// cacheKey returns the compiled cache and its key for key, scoped to the
+// engine's translator, and false when validators compiled with the
+// translator are not cached. Callers use the returned cache throughout, so
+// a concurrent RegisterRule never sees entries compiled before it.
+func (e *Engine) cacheKey(key string) (*compiledCache, compiledKey, bool) {
+	cc := e.compiled.Load()
+	prefix, ok := cc.translatorPrefix(e.translator, e)
+	return cc, compiledKey(prefix + key), ok
+}
+
+// loadCompiled returns the validator cached under key in m. With cache
+// false, the key is not usable and it reports a miss.
+func (e *Engine) loadCompiled(m *sync.Map, key compiledKey, cache bool) (any, bool) {
+	if !cache {
+		e.observeCache(CacheValidator, false)
+		return nil, false
+	}
+	v, ok := m.Load(key)
+	e.observeCache(CacheValidator, ok)
+	return v, ok
+}
+
+// storeCompiled caches fn under key in m unless another goroutine stored
+// it first, and returns the cached value. Once the engine holds cacheSize
+// compiled validators, new ones are returned without being cached.
+func (e *Engine) storeCompiled(cc *compiledCache, m *sync.Map, key compiledKey, fn any) any {
+	if e.cacheSize > 0 {
+		if cc.count.Add(1) > int64(e.cacheSize) {
+			cc.count.Add(-1)
+			return fn
+		}
+	}
+	if existing, loaded := m.LoadOrStore(key, fn); loaded {
+		if e.cacheSize > 0 {
+			cc.count.Add(-1)
+		}
+		return existing
+	}
+	return fn
+}
+
+// ParseRules parses rule tokens into rules using this engine's custom types.
+func (e *Engine) ParseRules(tokens []string) ([]types.Rule, error) {
+	return types.ParseTagWithRegistry(strings.Join(tokens, ";"), e.typeRegistry)
+}
+
 // FromRules compiles validators from rule tokens (e.g. "string","min=2").
 func (e *Engine) FromRules(tokens []string) (func(any) error, error) {
 	return e.FromRulesWithOpts(tokens, types.CompileOpts{})
@@ -222,9 +656,10 @@ func (e *Engine) FromRulesWithOpts(tokens []string, opts types.CompileOpts) (fun
 
 	// Normalize tokens to a tag string and cache by it.
 	tag := strings.Join(tokens, ";")
-	key := compiledKey(ckTag + compileOptsKeyPart(opts) + tag)
+	cc, key, cache := e.cacheKey(ckTag + compileOptsKeyPart(opts) + tag)
 
-	if v, ok := e.compiled.Load(key); ok {
+	v, ok := e.loadCompiled(&cc.validators, key, cache)
+	if ok {
 		return v.(types.ValidatorFunc), nil
 	}
 
@@ -232,15 +667,18 @@ func (e *Engine) FromRulesWithOpts(tokens []string, opts types.CompileOpts) (fun
 	if err != nil {
 		return nil, fmt.Errorf("parse rules: %w", err)
 	}
-	fn, err := e.newCompiler().CompileWithOptsE(ast, opts)
+	fn, err := e.Compiler().CompileWithOptsE(ast, opts)
 	if err != nil {
 		return nil, err
 	}
+	if e.recorder != nil && !opts.Sensitive {
+		fn = e.recorder.recordRule(tag, fn)
+	}
 
-	if existing, loaded := e.compiled.LoadOrStore(key, fn); loaded {
-		return existing.(types.ValidatorFunc), nil
+	if !cache {
+		return fn, nil
 	}
-	return fn, nil
+	return e.storeCompiled(cc, &cc.validators, key, fn).(types.ValidatorFunc), nil
 }
 
 // FromRulesContext compiles a context-aware validator from rule tokens.
@@ -267,9 +705,10 @@ func (e *Engine) FromRulesContextWithOpts(tokens []string, opts types.CompileOpt
 	}
 
 	tag := strings.Join(tokens, ";")
-	key := compiledKey(ckTag + "ctx:" + compileOptsKeyPart(opts) + tag)
+	cc, key, cache := e.cacheKey(ckTag + "ctx:" + compileOptsKeyPart(opts) + tag)
 
-	if v, ok := e.compiledContext.Load(key); ok {
+	v, ok := e.loadCompiled(&cc.context, key, cache)
+	if ok {
 		return v.(types.ContextValidatorFunc), nil
 	}
 
@@ -277,14 +716,17 @@ func (e *Engine) FromRulesContextWithOpts(tokens []string, opts types.CompileOpt
 	if err != nil {
 		return nil, fmt.Errorf("parse rules: %w", err)
 	}
-	fn, err := e.newCompiler().CompileContextWithOptsE(ast, opts)
+	fn, err := e.Compiler().CompileContextWithOptsE(ast, opts)
 	if err != nil {
 		return nil, err
 	}
-	if existing, loaded := e.compiledContext.LoadOrStore(key, fn); loaded {
-		return existing.(types.ContextValidatorFunc), nil
+	if e.recorder != nil && !opts.Sensitive {
+		fn = e.recorder.recordContextRule(tag, fn)
+	}
+	if !cache {
+		return fn, nil
 	}
-	return fn, nil
+	return e.storeCompiled(cc, &cc.context, key, fn).(types.ContextValidatorFunc), nil
 }
 
 // CompileRules compiles AST rules. We cache deterministically unless any
@@ -316,24 +758,25 @@ func (e *Engine) CompileRulesWithOpts(rules []types.Rule, opts types.CompileOpts
 func (e *Engine) CompileRulesWithOptsE(rules []types.Rule, opts types.CompileOpts) (func(any) error, error) {
 	// If any arg is a func (directly or nested), skip cache by design.
 	if HasFuncArgs(rules) {
-		return e.newCompiler().CompileWithOptsE(rules, opts)
+		return e.Compiler().CompileWithOptsE(rules, opts)
 	}
 
 	serialized := SerializeRules(rules) // canonical, deterministic
-	key := compiledKey(ckAST + compileOptsKeyPart(opts) + serialized)
+	cc, key, cache := e.cacheKey(ckAST + compileOptsKeyPart(opts) + serialized)
 
-	if v, ok := e.compiled.Load(key); ok {
+	v, ok := e.loadCompiled(&cc.validators, key, cache)
+	if ok {
 		return v.(types.ValidatorFunc), nil
 	}
 
-	fn, err := e.newCompiler().CompileWithOptsE(rules, opts)
+	fn, err := e.Compiler().CompileWithOptsE(rules, opts)
 	if err != nil {
 		return nil, err
 	}
-	if existing, loaded := e.compiled.LoadOrStore(key, fn); loaded {
-		return existing.(types.ValidatorFunc), nil
+	if !cache {
+		return fn, nil
 	}
-	return fn, nil
+	return e.storeCompiled(cc, &cc.validators, key, fn).(types.ValidatorFunc), nil
 }
 
 // CompileRulesContext compiles AST rules into a context-aware validator.
@@ -365,24 +808,73 @@ func (e *Engine) CompileRulesContextWithOpts(rules []types.Rule, opts types.Comp
 // validator with options and returns compile errors.
 func (e *Engine) CompileRulesContextWithOptsE(rules []types.Rule, opts types.CompileOpts) (types.ContextValidatorFunc, error) {
 	if HasFuncArgs(rules) {
-		return e.newCompiler().CompileContextWithOptsE(rules, opts)
+		return e.Compiler().CompileContextWithOptsE(rules, opts)
 	}
 
 	serialized := SerializeRules(rules)
-	key := compiledKey(ckAST + "ctx:" + compileOptsKeyPart(opts) + serialized)
+	cc, key, cache := e.cacheKey(ckAST + "ctx:" + compileOptsKeyPart(opts) + serialized)
 
-	if v, ok := e.compiledContext.Load(key); ok {
+	v, ok := e.loadCompiled(&cc.context, key, cache)
+	if ok {
 		return v.(types.ContextValidatorFunc), nil
 	}
 
-	fn, err := e.newCompiler().CompileContextWithOptsE(rules, opts)
+	fn, err := e.Compiler().CompileContextWithOptsE(rules, opts)
 	if err != nil {
 		return nil, err
 	}
-	if existing, loaded := e.compiledContext.LoadOrStore(key, fn); loaded {
-		return existing.(types.ContextValidatorFunc), nil
+	if !cache {
+		return fn, nil
 	}
-	return fn, nil
+	return e.storeCompiled(cc, &cc.context, key, fn).(types.ContextValidatorFunc), nil
+}
+
+// Compiler returns the compiler the engine compiles rules with, configured
+// with the engine's translator, rule compilers, and policies. It is built
+// once and reused, and rebuilt when a global rule is registered afterwards.
+// Use RegisterRule rather than registering on the returned compiler, whose
+// registrations are lost when it is rebuilt.
+func (e *Engine) Compiler() *types.Compiler {
+	version := types.RegistryVersion()
+	e.compilerMu.Lock()
+	defer e.compilerMu.Unlock()
+	if e.compiler == nil || e.compilerVersion != version {
+		e.compiler = e.newCompiler()
+		e.compilerVersion = version
+	}
+	return e.compiler
+}
+
+// RegisterRule registers a rule compiler on e itself, unlike
+// WithRuleCompiler, which returns a new Engine. It drops validators and
+// per-type state compiled by e so they pick up rc. It is safe to call
+// while e is in use, but validations running meanwhile may still use, and
+// cache per-type state built with, the previous rules; register during setup
+// when every validation must see rc. Engines already derived from e are not
+// affected.
+func (e *Engine) RegisterRule(kind types.Kind, rc types.RuleCompiler) {
+	e.compilerMu.Lock()
+	e.ruleCompilers[kind] = rc
+	e.compiler = nil
+	e.compiled.Store(&compiledCache{})
+	e.compilerMu.Unlock()
+	e.typeCache.Clear()
+	e.translated.Clear()
+	e.translatedCount.Store(0)
+}
+
+// RegisterConverter registers a converter on e itself, unlike
+// WithConverter, which returns a new Engine. Like RegisterRule, it drops
+// what e has compiled and is safe, but best called during setup.
+func (e *Engine) RegisterConverter(from reflect.Type, to types.Kind, fn types.ConverterFunc) {
+	e.compilerMu.Lock()
+	e.converters = append(e.converters, converter{from: from, to: to, fn: fn})
+	e.compiler = nil
+	e.compiled.Store(&compiledCache{})
+	e.compilerMu.Unlock()
+	e.typeCache.Clear()
+	e.translated.Clear()
+	e.translatedCount.Store(0)
 }
 
 func (e *Engine) newCompiler() *types.Compiler {
@@ -394,14 +886,51 @@ func (e *Engine) newCompiler() *types.Compiler {
 	for kind, rc := range e.contextRuleCompilers {
 		c.RegisterContextRule(kind, rc)
 	}
+	for kind, rate := range e.shadowRules {
+		c.SetShadowRule(kind, rate)
+	}
+	c.SetShadowHook(e.shadowHook)
+	for kind, hooks := range e.compileHooks {
+		for _, hook := range hooks {
+			c.OnCompile(kind, hook)
+		}
+	}
+	for _, mw := range e.middleware {
+		c.Use(mw)
+	}
+	for kind, p := range e.retryPolicies {
+		c.SetRetryPolicy(kind, p)
+	}
+	c.SetOutagePolicy(e.outagePolicy)
+	for kind, rc := range e.resultCaches {
+		c.SetResultCache(kind, rc)
+	}
+	c.SetNilCollectionsAsEmpty(e.nilAsEmpty)
+	c.SetNilPolicy(e.nilPolicy)
+	c.SetDefaultLimits(e.limits)
+	c.SetRuleFusion(!e.noFusion)
+	c.SetBehavior(e.behavior)
+	for _, conv := range e.converters {
+		c.RegisterConverter(conv.from, conv.to, conv.fn)
+	}
 	return c
 }
 
+type converter struct {
+	from reflect.Type
+	to   types.Kind
+	fn   types.ConverterFunc
+}
+
 func compileOptsKeyPart(opts types.CompileOpts) string {
+	part := ""
 	if opts.CollectAll {
-		return "all:"
+		part = "all:"
+	}
+	if opts.Sensitive {
+		part += "sensitive:"
 	}
-	return ""
+	return part
 }
 
 func copyCustomRules(in map[string]func(any) error) map[string]func(any) error {
@@ -436,6 +965,50 @@ func copyStructRuleCompilers(in map[types.Kind]StructRuleCompiler) map[types.Kin
 	return out
 }
 
+func copyShadowRules(in map[types.Kind]float64) map[types.Kind]float64 {
+	if in == nil {
+		return nil
+	}
+	out := make(map[types.Kind]float64, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func copyCompileHooks(in map[types.Kind][]types.CompileHook) map[types.Kind][]types.CompileHook {
+	if in == nil {
+		return nil
+	}
+	out := make(map[types.Kind][]types.CompileHook, len(in))
+	for k, v := range in {
+		out[k] = append([]types.CompileHook(nil), v...)
+	}
+	return out
+}
+
+func copyResultCaches(in map[types.Kind]*types.ResultCache) map[types.Kind]*types.ResultCache {
+	if in == nil {
+		return nil
+	}
+	out := make(map[types.Kind]*types.ResultCache, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func copyRetryPolicies(in map[types.Kind]types.RetryPolicy) map[types.Kind]types.RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := make(map[types.Kind]types.RetryPolicy, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
 func copyTypeRegistry(in *types.TypeRegistry) *types.TypeRegistry {
 	return in.Clone()
 }
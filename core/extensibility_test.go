@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -107,6 +108,102 @@ func TestCompileRulesE_ReturnsCustomCompilerErrors(t *testing.T) {
 	}
 }
 
+func TestEngineCompiler_IsReusedAndTracksGlobalRules(t *testing.T) {
+	e := New()
+	c := e.Compiler()
+	if _, err := e.FromRules([]string{"string", "min=1"}); err != nil {
+		t.Fatal(err)
+	}
+	if e.Compiler() != c {
+		t.Fatal("compiler rebuilt without configuration change")
+	}
+	if e.WithTranslator(nil).Compiler() == c {
+		t.Fatal("derived engine reused the parent compiler")
+	}
+
+	kind := types.Kind(uniqueCoreTypeName(t, "lateRule"))
+	types.RegisterRule(kind, func(*types.Compiler, types.Rule) (func(any) error, error) {
+		return func(any) error { return nil }, nil
+	})
+	if e.Compiler() == c {
+		t.Fatal("compiler not rebuilt after global registration")
+	}
+	if _, err := e.FromRules([]string{"string", string(kind)}); err != nil {
+		t.Fatalf("late global rule: %v", err)
+	}
+}
+
+func TestEngineRegisterRule_AppliesInPlace(t *testing.T) {
+	e := New()
+	rejectAll := func(*types.Compiler, types.Rule) (func(any) error, error) {
+		return func(any) error {
+			return verrs.Errors{verrs.FieldError{Code: "reject", Msg: "rejected"}}
+		}, nil
+	}
+	e.RegisterRule("reject", rejectAll)
+	fn, err := e.FromRules([]string{"string", "reject"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn("x"); err == nil {
+		t.Fatal("registered rule did not run")
+	}
+
+	minOne := func() error {
+		fn, err := e.FromRules([]string{"string", "min=1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fn("x")
+	}
+	if err := minOne(); err != nil {
+		t.Fatal(err)
+	}
+	e.RegisterRule(types.KMinLength, rejectAll)
+	if err := minOne(); err == nil {
+		t.Fatal("cached validator survived RegisterRule")
+	}
+}
+
+// Run with -race: RegisterRule and RegisterConverter swap the compiled
+// cache while other goroutines compile through it.
+func TestEngineRegisterRule_ConcurrentWithValidation(t *testing.T) {
+	e := New()
+	stop := make(chan struct{})
+	var started, wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		started.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if _, err := e.FromRules([]string{"string", "min=1"}); err != nil {
+					t.Error(err)
+					return
+				}
+				e.CompileRules([]types.Rule{types.NewRule(types.KString, nil)})
+				_ = e.AltersBuiltinRules()
+				_ = e.Copy()
+			}
+		}()
+	}
+	started.Wait()
+	for i := 0; i < 200; i++ {
+		e.RegisterRule(types.Kind(fmt.Sprintf("noop%d", i)), func(*types.Compiler, types.Rule) (func(any) error, error) {
+			return func(any) error { return nil }, nil
+		})
+		e.RegisterConverter(reflect.TypeOf(i), types.KInt, func(v any) (any, error) { return v, nil })
+	}
+	close(stop)
+	wg.Wait()
+}
+
 func TestGlobalRegistriesConcurrentAccess(t *testing.T) {
 	const n = 50
 	var wg sync.WaitGroup
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestWithNilCollectionsAsEmpty(t *testing.T) {
+	cases := []struct {
+		tag      string
+		typeCode string
+	}{
+		{"slice;max=3", verrs.CodeSliceType},
+		{"slice;unique", verrs.CodeSliceType},
+		{"map", verrs.CodeMapType},
+		{"map;max=2", verrs.CodeMapType},
+	}
+	base := New()
+	nilCode := base.WithBehavior(types.Behavior{NilValueCode: true})
+	lenient := base.WithNilCollectionsAsEmpty(true)
+	for _, tc := range cases {
+		for _, run := range []struct {
+			engine *Engine
+			want   string
+		}{{base, tc.typeCode}, {nilCode, verrs.CodeValueNil}} {
+			fn, err := run.engine.FromRules([]string{tc.tag})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var es verrs.Errors
+			if err := fn(nil); !errors.As(err, &es) || es[0].Code != run.want {
+				t.Fatalf("%s: nil error = %v, want %s", tc.tag, err, run.want)
+			}
+		}
+
+		fn, err := lenient.FromRules([]string{tc.tag})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fn(nil); err != nil {
+			t.Fatalf("%s: nil should be empty: %v", tc.tag, err)
+		}
+	}
+
+	fn, err := lenient.FromRules([]string{"slice;min=1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var es verrs.Errors
+	if err := fn(nil); !errors.As(err, &es) || es[0].Code != verrs.CodeSliceMin {
+		t.Fatalf("nil slice should fail min like an empty one, got %v", err)
+	}
+}
+
+func TestPointerDereferenceAndNilPolicy(t *testing.T) {
+	base := New().WithBehavior(types.Behavior{DerefPointers: true, NilValueCode: true})
+	s, n := "abc", 7
+	var nilString *string
+	for tag, value := range map[string]any{"string;min=2": &s, "int;min=5": &n} {
+		fn, err := base.FromRules([]string{tag})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fn(value); err != nil {
+			t.Fatalf("%s: pointer value failed: %v", tag, err)
+		}
+		fn, err = New().FromRules([]string{tag})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var es verrs.Errors
+		if err := fn(value); !errors.As(err, &es) || !strings.HasSuffix(es[0].Code, ".type") {
+			t.Fatalf("%s: pointer without DerefPointers = %v, want a type error", tag, err)
+		}
+	}
+
+	cases := []struct {
+		tag     string
+		strict  string
+		lenient string
+	}{
+		{"string;min=2", verrs.CodeValueNil, ""},
+		{"string;omitempty;min=2", "", ""},
+		{"string;required;min=2", verrs.CodeRequired, verrs.CodeRequired},
+	}
+	lenient := base.WithNilPolicy(types.NilAllow)
+	for _, tc := range cases {
+		for _, run := range []struct {
+			engine *Engine
+			want   string
+		}{{base, tc.strict}, {lenient, tc.lenient}} {
+			fn, err := run.engine.FromRules([]string{tc.tag})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = fn(nilString)
+			var es verrs.Errors
+			switch {
+			case run.want == "" && err != nil:
+				t.Fatalf("%s: nil pointer error = %v, want nil", tc.tag, err)
+			case run.want != "" && (!errors.As(err, &es) || es[0].Code != run.want):
+				t.Fatalf("%s: nil pointer error = %v, want %s", tc.tag, err, run.want)
+			}
+		}
+	}
+}
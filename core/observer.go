@@ -0,0 +1,60 @@
+package core
+
+import "time"
+
+// Observer receives engine events for metrics, such as the counters of the
+// instrument package. Methods run on validation hot paths, so they must be
+// cheap and safe for concurrent use.
+type Observer interface {
+	// ValidationDone reports one struct or schema validation call: its
+	// duration and its result, nil, errors.Errors or another error.
+	ValidationDone(d time.Duration, err error)
+	// CacheLookup reports a lookup in a compile cache: "validator" for
+	// compiled rule chains and "type" for struct plans and named schemas.
+	CacheLookup(cache string, hit bool)
+}
+
+// Cache names passed to Observer.CacheLookup.
+const (
+	CacheValidator = "validator"
+	CacheType      = "type"
+)
+
+// WithObserver sets the observer that receives validation and cache
+// events. See Engine.WithObserver.
+func WithObserver(o Observer) Option {
+	return func(e *Engine) { e.observer = o }
+}
+
+// WithObserver returns a new Engine that reports validations and compile
+// cache lookups to o; nil turns reporting off. Observers do not change how
+// rules compile, so the new Engine shares the compiled cache when cache
+// sharing is enabled.
+func (e *Engine) WithObserver(o Observer) *Engine {
+	ne := e.Copy()
+	ne.observer = o
+	ne.inheritCache(e)
+	return ne
+}
+
+// Observer returns the observer set by WithObserver, or nil.
+func (e *Engine) Observer() Observer { return e.observer }
+
+// observeCache reports a cache lookup to the observer, if any.
+func (e *Engine) observeCache(cache string, hit bool) {
+	if e.observer != nil {
+		e.observer.CacheLookup(cache, hit)
+	}
+}
+
+// Observe runs validate and reports its duration and result to e's
+// observer; without one it just runs validate.
+func (e *Engine) Observe(validate func() error) error {
+	if e.observer == nil {
+		return validate()
+	}
+	start := time.Now()
+	err := validate()
+	e.observer.ValidationDone(time.Since(start), err)
+	return err
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aatuh/validate/v3/types"
+)
+
+type recordingObserver struct {
+	validations []error
+	lookups     []string
+}
+
+func (o *recordingObserver) ValidationDone(_ time.Duration, err error) {
+	o.validations = append(o.validations, err)
+}
+
+func (o *recordingObserver) CacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	o.lookups = append(o.lookups, cache+":"+result)
+}
+
+func TestWithObserver(t *testing.T) {
+	o := &recordingObserver{}
+	e := NewEngine(WithObserver(o))
+	if e.Observer() != o || NewEngine().WithObserver(o).Observer() != o {
+		t.Fatal("observer not set")
+	}
+
+	rules := []types.Rule{types.NewRule(types.KString, nil)}
+	_ = e.CompileRules(rules)
+	_ = e.CompileRules(rules)
+	if got := o.lookups; len(got) != 2 || got[0] != "validator:miss" || got[1] != "validator:hit" {
+		t.Fatalf("lookups = %v", got)
+	}
+
+	s, err := e.CompileSchema(map[string]string{"name": "string;required"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = s.Validate(map[string]any{"name": "x"})
+	_ = s.Validate(map[string]any{})
+	if len(o.validations) != 2 || o.validations[0] != nil || o.validations[1] == nil {
+		t.Fatalf("validations = %v", o.validations)
+	}
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Option configures an Engine under construction. Pass options to NewEngine.
+type Option func(*Engine)
+
+// WithTranslator sets the translator used for error messages.
+func WithTranslator(t translator.Translator) Option {
+	return func(e *Engine) { e.translator = t }
+}
+
+// WithPathSep sets the separator used to join field paths. An empty sep
+// keeps the default ".".
+func WithPathSep(sep string) Option {
+	return func(e *Engine) {
+		if sep != "" {
+			e.pathSep = sep
+		}
+	}
+}
+
+// WithCustomRule registers a custom rule usable as a single tag token.
+func WithCustomRule(name string, rule func(any) error) Option {
+	return func(e *Engine) { e.customRules[name] = rule }
+}
+
+// WithCacheSize bounds the number of compiled validators the engine caches.
+// Once the bound is reached, new rule sets are still compiled but not
+// cached. Values <= 0 leave the cache unbounded, the default.
+func WithCacheSize(n int) Option {
+	return func(e *Engine) { e.cacheSize = n }
+}
+
+// WithSharedCache makes engines derived by WithCustomRule, PathSeparator, or
+// WithTranslator share the compiled validator cache. See
+// Engine.WithSharedCache.
+func WithSharedCache(enabled bool) Option {
+	return func(e *Engine) { e.shareCache = enabled }
+}
+
+// WithPlaygroundTags makes struct validation read go-playground/validator
+// tag syntax. See Engine.WithPlaygroundTags.
+func WithPlaygroundTags(enabled bool) Option {
+	return func(e *Engine) { e.playgroundTags = enabled }
+}
+
+// WithTagNames sets the struct tag keys struct validation reads rules from.
+// See Engine.WithTagNames.
+func WithTagNames(names ...string) Option {
+	return func(e *Engine) { e.tagNames = append([]string(nil), names...) }
+}
+
+// WithRuleFusion turns the compiler's rule fusion pass on or off. See
+// Engine.WithRuleFusion.
+func WithRuleFusion(enabled bool) Option {
+	return func(e *Engine) { e.noFusion = !enabled }
+}
+
+// WithBehavior sets the behavior fixes built-in rules apply. See
+// Engine.WithBehavior.
+func WithBehavior(b types.Behavior) Option {
+	return func(e *Engine) { e.behavior = b }
+}
@@ -0,0 +1,305 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestNewEngine_AppliesOptions(t *testing.T) {
+	errOdd := errors.New("odd")
+	tr := translator.NewSimpleTranslator(map[string]string{})
+	e := NewEngine(
+		WithTranslator(tr),
+		WithPathSep("/"),
+		WithPathSep(""),
+		WithCustomRule("even", func(v any) error {
+			if v.(int)%2 != 0 {
+				return errOdd
+			}
+			return nil
+		}),
+		WithPlaygroundTags(true),
+		nil,
+	)
+	if !e.PlaygroundTags() || !e.Copy().PlaygroundTags() {
+		t.Fatal("playground tags not applied or not copied")
+	}
+	if e.Translator() != tr {
+		t.Fatal("translator not applied")
+	}
+	if sep := e.GetPathSeparator(); sep != "/" {
+		t.Fatalf("path separator = %q, want /", sep)
+	}
+	fn, err := e.FromRules([]string{"even"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn(3); !errors.Is(err, errOdd) {
+		t.Fatalf("even(3) = %v, want %v", err, errOdd)
+	}
+}
+
+func TestNewEngine_CacheSurvivesConfiguration(t *testing.T) {
+	e := NewEngine(WithPathSep("/"), WithCustomRule("noop", func(any) error { return nil }))
+	if _, err := e.FromRules([]string{"string", "min=1"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countCompiled(&e.compiled.Load().validators); n != 1 {
+		t.Fatalf("cached validators = %d, want 1", n)
+	}
+}
+
+func TestWithCacheSize_BoundsCompiledValidators(t *testing.T) {
+	e := NewEngine(WithCacheSize(2))
+	for i := 1; i <= 4; i++ {
+		fn, err := e.FromRules([]string{"string", "min=" + strings.Repeat("1", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fn(""); err == nil {
+			t.Fatalf("min=%s accepted an empty string", strings.Repeat("1", i))
+		}
+	}
+	if _, err := e.FromRules([]string{"string", "min=1"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countCompiled(&e.compiled.Load().validators); n != 2 {
+		t.Fatalf("cached validators = %d, want 2", n)
+	}
+	if n := countCompiled(&NewEngine().compiled.Load().validators); n != 0 {
+		t.Fatalf("new engine cache = %d, want 0", n)
+	}
+}
+
+func countCompiled(m *sync.Map) int {
+	n := 0
+	m.Range(func(any, any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func TestWithSharedCache_SharesAcrossSemanticPreservingDerivations(t *testing.T) {
+	compile := func(e *Engine) {
+		t.Helper()
+		if _, err := e.FromRules([]string{"string", "min=1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	base := NewEngine(WithSharedCache(true))
+	compile(base)
+	for _, derived := range []*Engine{
+		base.WithCustomRule("noop", func(any) error { return nil }),
+		base.PathSeparator("/"),
+		base.PathSeparator("/").WithCustomRule("noop", func(any) error { return nil }),
+		base.WithTranslator(translator.NewSimpleTranslator(map[string]string{})),
+	} {
+		if derived.compiled.Load() != base.compiled.Load() {
+			t.Fatal("derived engine did not share the compiled cache")
+		}
+	}
+	if e := base.WithNilCollectionsAsEmpty(true); e.compiled.Load() == base.compiled.Load() {
+		t.Fatal("WithNilCollectionsAsEmpty shared the compiled cache")
+	}
+
+	unshared := NewEngine()
+	compile(unshared)
+	if e := unshared.PathSeparator("/"); countCompiled(&e.compiled.Load().validators) != 0 {
+		t.Fatal("cache shared without WithSharedCache")
+	}
+	shared := unshared.WithSharedCache(true)
+	if n := countCompiled(&shared.WithCustomRule("noop", nil).compiled.Load().validators); n != 1 {
+		t.Fatalf("cached validators = %d, want 1", n)
+	}
+}
+
+func TestSharedCache_KeepsMessagesPerTranslator(t *testing.T) {
+	en := translator.NewSimpleTranslator(map[string]string{"string.min": "shorter than %d"})
+	fi := translator.NewSimpleTranslator(map[string]string{"string.min": "lyhyempi kuin %d"})
+	base := NewEngine(WithTranslator(en), WithSharedCache(true))
+	message := func(e *Engine) string {
+		t.Helper()
+		fn, err := e.FromRules([]string{"string", "min=3"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var es verrs.Errors
+		if !errors.As(fn("x"), &es) {
+			t.Fatal("expected validation errors")
+		}
+		return es[0].Msg
+	}
+	for i := 0; i < 2; i++ {
+		for _, tc := range []struct {
+			e    *Engine
+			want string
+		}{
+			{base, "shorter than 3"},
+			{base.WithTranslator(fi), "lyhyempi kuin 3"},
+			{base.WithTranslator(fi).WithTranslator(en), "shorter than 3"},
+		} {
+			if got := message(tc.e); got != tc.want {
+				t.Fatalf("message = %q, want %q", got, tc.want)
+			}
+		}
+	}
+	if n := countCompiled(&base.compiled.Load().validators); n != 2 {
+		t.Fatalf("cached validators = %d, want one per translator", n)
+	}
+}
+
+func TestValidateOpts_TranslatorOverridesPerCall(t *testing.T) {
+	fi := translator.NewSimpleTranslator(map[string]string{"string.min": "lyhyempi kuin %d"})
+	e := NewEngine(WithTranslator(translator.NewSimpleTranslator(translator.DefaultEnglishTranslations())))
+	if e.ForTranslator(fi) != e.ForTranslator(fi) || e.ForTranslator(nil) != e || e.ForTranslator(e.Translator()) != e {
+		t.Fatal("ForTranslator did not reuse engines")
+	}
+
+	schema, err := e.CompileSchema(map[string]string{"name": "string;min=3"})
+	if err != nil {
+		t.Fatalf("CompileSchema: %v", err)
+	}
+	data := map[string]any{"name": "x"}
+	for _, tt := range []struct {
+		opts ValidateOpts
+		want string
+	}{
+		{ValidateOpts{Translator: fi}, "lyhyempi kuin 3"},
+		{ValidateOpts{}, "minimum length is 3"},
+		{ValidateOpts{Translator: fi, CollectAllRules: true}, "lyhyempi kuin 3"},
+	} {
+		var es verrs.Errors
+		if err := schema.ValidateContextWithOpts(context.Background(), data, tt.opts); !errors.As(err, &es) || es[0].Msg != tt.want {
+			t.Errorf("opts %+v: err = %v, want message %q", tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestWithDefaultLimits(t *testing.T) {
+	base := NewEngine()
+	limited := base.WithDefaultLimits(types.DefaultLimits{String: 3})
+	long := strings.Repeat("x", 10)
+	fn, err := limited.FromRules([]string{"string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn(long); err == nil {
+		t.Fatal("default limit not applied")
+	}
+	if fn, _ = limited.FromRules([]string{"string", "unbounded"}); fn(long) != nil {
+		t.Fatal("unbounded did not opt out")
+	}
+	if fn, _ = base.FromRules([]string{"string"}); fn(long) != nil {
+		t.Fatal("base engine affected")
+	}
+	if !limited.AltersBuiltinRules() {
+		t.Fatal("AltersBuiltinRules ignores default limits")
+	}
+}
+
+func TestWithBehavior(t *testing.T) {
+	e := NewEngine()
+	if e.AltersBuiltinRules() {
+		t.Fatal("default engine alters built-in rules")
+	}
+	fixed := e.WithBehavior(types.Behavior{OneOfCaseFold: true})
+	if !fixed.AltersBuiltinRules() || fixed.Copy().Behavior() != (types.Behavior{OneOfCaseFold: true}) {
+		t.Fatalf("behavior = %+v", fixed.Copy().Behavior())
+	}
+	if NewEngine(WithBehavior(types.LatestBehavior())).Behavior() != types.LatestBehavior() {
+		t.Fatal("option not applied")
+	}
+	for _, c := range []struct {
+		e    *Engine
+		want bool
+	}{{e, false}, {fixed, true}} {
+		fn, err := c.e.FromRules([]string{"string", "oneof=red,green"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fn("Green") == nil; got != c.want {
+			t.Fatalf("oneof(Green) valid = %v, want %v", got, c.want)
+		}
+	}
+}
+
+type funcTranslator func(key string, params ...any) string
+
+func (f funcTranslator) T(key string, params ...any) string { return f(key, params...) }
+
+type mapTranslator map[string]string
+
+func (m mapTranslator) T(key string, _ ...any) string { return m[key] }
+
+func TestForTranslator_NonComparable(t *testing.T) {
+	e := NewEngine(WithSharedCache(true))
+	fn := funcTranslator(func(key string, _ ...any) string { return key })
+	for i := 0; i < 3; i++ {
+		fe := e.ForTranslator(fn)
+		if fe == e || fe.compiled.Load() == e.compiled.Load() {
+			t.Fatal("func translator engine shares the cache")
+		}
+		if _, err := fe.FromRules([]string{"string", "min=3"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := countCompiled(&e.compiled.Load().validators); n != 0 {
+		t.Fatalf("shared cache holds %d validators, want 0", n)
+	}
+
+	m := mapTranslator{"string.min": "short"}
+	me := e.ForTranslator(m)
+	if me == e || e.ForTranslator(m) != me || me.ForTranslator(m) != me || e.ForTranslator(mapTranslator{}) == me {
+		t.Fatal("map translator engines not kept per map")
+	}
+}
+
+type indexTranslator int
+
+func (i indexTranslator) T(key string, _ ...any) string { return fmt.Sprintf("%s#%d", key, int(i)) }
+
+func TestSharedCache_BoundsTranslators(t *testing.T) {
+	e := NewEngine(WithSharedCache(true))
+	for i := 0; i < 2*maxTranslators; i++ {
+		fn, err := e.WithTranslator(indexTranslator(i)).FromRules([]string{"string", "min=3"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var es verrs.Errors
+		if want := fmt.Sprintf("string.min#%d", i); !errors.As(fn("x"), &es) || es[0].Msg != want {
+			t.Fatalf("translator %d: err = %v, want message %q", i, fn("x"), want)
+		}
+	}
+	if n := countCompiled(&e.compiled.Load().translators); n != maxTranslators {
+		t.Errorf("translator prefixes = %d, want %d", n, maxTranslators)
+	}
+	if n := countCompiled(&e.compiled.Load().validators); n != maxTranslators {
+		t.Errorf("cached validators = %d, want %d", n, maxTranslators)
+	}
+}
+
+func TestForTranslator_BoundsKeptEngines(t *testing.T) {
+	e := NewEngine()
+	for i := 0; i < 2*maxTranslators; i++ {
+		tr := indexTranslator(i)
+		if kept := e.ForTranslator(tr) == e.ForTranslator(tr); kept != (i < maxTranslators) {
+			t.Fatalf("translator %d: engine kept = %v", i, kept)
+		}
+	}
+	if n := countCompiled(&e.translated); n != maxTranslators {
+		t.Fatalf("kept engines = %d, want %d", n, maxTranslators)
+	}
+	e.RegisterRule("noop", func(*types.Compiler, types.Rule) (func(any) error, error) { return nil, nil })
+	if e.ForTranslator(indexTranslator(0)) != e.ForTranslator(indexTranslator(0)) {
+		t.Fatal("RegisterRule did not reset the kept engine count")
+	}
+}
@@ -1,6 +1,25 @@
 package core
 
-import "reflect"
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aatuh/validate/v3/translator"
+)
+
+// FieldMode selects the direction of a struct validation call. It controls
+// how fields tagged `readonly` or `writeonly` are treated.
+type FieldMode int
+
+const (
+	// ModeAny applies no direction-specific restrictions (default).
+	ModeAny FieldMode = iota
+	// ModeInput rejects non-zero `readonly` fields, e.g. request bodies.
+	ModeInput
+	// ModeOutput rejects non-zero `writeonly` fields, e.g. response bodies.
+	ModeOutput
+)
 
 // ValidateOpts tunes validation behavior per call.
 type ValidateOpts struct {
@@ -8,6 +27,30 @@ type ValidateOpts struct {
 	CollectAllRules bool
 	PathSep         string
 	FieldNameFunc   func(reflect.StructField) string
+	Mode            FieldMode
+	// SchemaVersion selects tag overrides registered with WithSchemaVersion.
+	// Empty uses the struct tags as declared.
+	SchemaVersion string
+	// IncludeValues attaches the failing field's value to FieldError.Value,
+	// truncated, for debugging. Fields tagged `sensitive` are never attached.
+	IncludeValues bool
+	// UseJSONNames reports fields by their `json` tag names, e.g. "user_name"
+	// instead of "UserName", falling back to Go names. FieldNameFunc, when
+	// set, takes precedence.
+	UseJSONNames bool
+	// UseXMLNames reports fields by their `xml` tag names, see XMLFieldName.
+	// FieldNameFunc and UseJSONNames take precedence.
+	UseXMLNames bool
+	// Translator renders the call's messages instead of the engine's
+	// translator, e.g. one per request locale. See Engine.ForTranslator.
+	Translator translator.Translator
+	// Budget bounds the call's duration. Once it is spent, validation stops
+	// and returns the errors found so far plus a validation.timeout error
+	// at the path it reached. Zero means no budget.
+	Budget time.Duration
+	// Deadline is the absolute form of Budget; the earlier of the two
+	// applies. Nested calls share it, so they do not restart the budget.
+	Deadline time.Time
 }
 
 // WithDefaults keeps the door open for future defaults.
@@ -16,6 +59,12 @@ func (o ValidateOpts) WithDefaults() ValidateOpts { return o }
 // ApplyOpts fills missing values using the given *Validate instance.
 func ApplyOpts(v *Validate, o ValidateOpts) ValidateOpts {
 	o = o.WithDefaults()
+	if o.Budget > 0 {
+		if d := time.Now().Add(o.Budget); o.Deadline.IsZero() || d.Before(o.Deadline) {
+			o.Deadline = d
+		}
+		o.Budget = 0
+	}
 	if o.PathSep == "" {
 		if v != nil {
 			o.PathSep = v.pathSep
@@ -23,5 +72,48 @@ func ApplyOpts(v *Validate, o ValidateOpts) ValidateOpts {
 			o.PathSep = "."
 		}
 	}
+	if o.FieldNameFunc == nil {
+		switch {
+		case o.UseJSONNames:
+			o.FieldNameFunc = JSONFieldName
+		case o.UseXMLNames:
+			o.FieldNameFunc = XMLFieldName
+		}
+	}
 	return o
 }
+
+// JSONFieldName returns a field's JSON tag name, falling back to the Go name.
+func JSONFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// XMLFieldName returns a field's XML element or attribute name as
+// encoding/xml uses it, falling back to the Go name. Namespaces are
+// dropped, attributes are prefixed with "@", and parent>child paths are
+// joined with dots, e.g. "@id" or "items.item".
+func XMLFieldName(field reflect.StructField) string {
+	name, flags, _ := strings.Cut(field.Tag.Get("xml"), ",")
+	if name == "-" {
+		return field.Name
+	}
+	if _, local, ok := strings.Cut(name, " "); ok {
+		name = local
+	}
+	if name == "" {
+		name = field.Name
+	}
+	for _, flag := range strings.Split(flags, ",") {
+		switch flag {
+		case "attr":
+			return "@" + name
+		case "chardata", "cdata", "innerxml", "comment", "any":
+			return field.Name
+		}
+	}
+	return strings.ReplaceAll(name, ">", ".")
+}
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// DefaultRecorderLimit is the entry limit of a Recorder without MaxEntries.
+const DefaultRecorderLimit = 1000
+
+// CorpusEntry is one recorded failure in a replay corpus.
+//
+// Fields:
+//   - Tag: The rule tag the value failed, such as "string;email".
+//   - Type: The value's kind, such as "string", "int64" or "time.Time".
+//   - Value: The anonymized value as JSON.
+//   - Codes: Error codes the anonymized value produced when recorded.
+type CorpusEntry struct {
+	Tag   string          `json:"tag"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+	Codes []string        `json:"codes"`
+}
+
+// Recorder captures values failing tag rules, anonymized, into a replay
+// corpus of JSON lines. Replay the corpus with Engine.Replay against a new
+// library version to catch behavioral changes before upgrading. Attach it
+// with Engine.WithRecorder; it is safe for concurrent use.
+//
+// Only string, bool, integer, float and time.Time values are recorded.
+// Rules compiled from tags are recorded, covering struct fields and
+// CheckTag, and each distinct tag and anonymized value is written once.
+//
+// Fields:
+//   - Anonymize: Replaces a value before it is recorded; it must return a
+//     value of the same type. Nil uses MaskString on strings and keeps
+//     other values.
+//   - MaxEntries: Maximum entries written; 0 uses DefaultRecorderLimit.
+type Recorder struct {
+	Anonymize  func(v any) any
+	MaxEntries int
+
+	mu   sync.Mutex
+	w    io.Writer
+	seen map[string]struct{}
+	err  error
+}
+
+// NewRecorder returns a Recorder writing its corpus to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, seen: map[string]struct{}{}}
+}
+
+// Err returns the first error writing the corpus, if any.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// MaskString anonymizes s keeping its shape: letters become "x" or "X",
+// digits "0", and other characters, such as "@", "." or "-", stay.
+func MaskString(s string) string {
+	return strings.Map(func(c rune) rune {
+		switch {
+		case unicode.IsUpper(c):
+			return 'X'
+		case unicode.IsLetter(c):
+			return 'x'
+		case unicode.IsDigit(c):
+			return '0'
+		default:
+			return c
+		}
+	}, s)
+}
+
+// WithRecorder returns a new Engine whose tag rules report failing values
+// to r; nil stops recording. Recording needs every rule to run through the
+// engine, so generated validators are not used.
+func (e *Engine) WithRecorder(r *Recorder) *Engine {
+	ne := e.Copy()
+	ne.recorder = r
+	return ne
+}
+
+// recordRule returns fn reporting its failures on tag to the recorder.
+func (r *Recorder) recordRule(tag string, fn types.ValidatorFunc) types.ValidatorFunc {
+	return func(v any) error {
+		err := fn(v)
+		if err != nil {
+			r.record(tag, v, func(v any) error { return fn(v) })
+		}
+		return err
+	}
+}
+
+// recordContextRule is recordRule for context-aware validators.
+func (r *Recorder) recordContextRule(tag string, fn types.ContextValidatorFunc) types.ContextValidatorFunc {
+	return func(ctx context.Context, v any) error {
+		err := fn(ctx, v)
+		if err != nil && (ctx == nil || ctx.Err() == nil) {
+			r.record(tag, v, func(v any) error { return fn(ctx, v) })
+		}
+		return err
+	}
+}
+
+// record anonymizes v, reruns check on it and writes the entry unless the
+// value is unsupported, already recorded, or the limit is reached.
+func (r *Recorder) record(tag string, v any, check func(any) error) {
+	typ, ok := corpusType(v)
+	if !ok {
+		return
+	}
+	if r.Anonymize != nil {
+		v = r.Anonymize(v)
+	} else if rv := reflect.ValueOf(v); rv.Kind() == reflect.String {
+		v = reflect.ValueOf(MaskString(rv.String())).Convert(rv.Type()).Interface()
+	}
+	value, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	key := tag + "\x00" + string(value)
+	limit := r.MaxEntries
+	if limit <= 0 {
+		limit = DefaultRecorderLimit
+	}
+
+	r.mu.Lock()
+	if _, dup := r.seen[key]; dup || len(r.seen) >= limit || r.err != nil {
+		r.mu.Unlock()
+		return
+	}
+	r.seen[key] = struct{}{}
+	r.mu.Unlock()
+
+	line, err := json.Marshal(CorpusEntry{Tag: tag, Type: typ, Value: value, Codes: errorCodes(check(v))})
+	if err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		_, r.err = r.w.Write(append(line, '\n'))
+	}
+}
+
+// corpusType returns the corpus type name of v, and false for values the
+// corpus cannot restore.
+func corpusType(v any) (string, bool) {
+	if _, ok := v.(time.Time); ok {
+		return "time.Time", true
+	}
+	if v == nil {
+		return "", false
+	}
+	switch k := reflect.TypeOf(v).Kind(); k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return k.String(), true
+	default:
+		return "", false
+	}
+}
+
+// corpusKinds maps corpus type names to their Go types.
+var corpusKinds = map[string]reflect.Type{
+	"string":    reflect.TypeOf(""),
+	"bool":      reflect.TypeOf(false),
+	"int":       reflect.TypeOf(int(0)),
+	"int8":      reflect.TypeOf(int8(0)),
+	"int16":     reflect.TypeOf(int16(0)),
+	"int32":     reflect.TypeOf(int32(0)),
+	"int64":     reflect.TypeOf(int64(0)),
+	"uint":      reflect.TypeOf(uint(0)),
+	"uint8":     reflect.TypeOf(uint8(0)),
+	"uint16":    reflect.TypeOf(uint16(0)),
+	"uint32":    reflect.TypeOf(uint32(0)),
+	"uint64":    reflect.TypeOf(uint64(0)),
+	"float32":   reflect.TypeOf(float32(0)),
+	"float64":   reflect.TypeOf(float64(0)),
+	"time.Time": reflect.TypeOf(time.Time{}),
+}
+
+// errorCodes returns the codes of err in order, or a single "error: ..."
+// entry for errors other than errors.Errors.
+func errorCodes(err error) []string {
+	if err == nil {
+		return []string{}
+	}
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		return []string{"error: " + err.Error()}
+	}
+	codes := make([]string, len(es))
+	for i, fe := range es {
+		codes[i] = fe.Code
+	}
+	return codes
+}
+
+// ReplayDiff is a corpus entry whose replay produced different codes.
+//
+// Fields:
+//   - Line: 1-based line of the entry in the corpus.
+//   - Entry: The recorded entry.
+//   - Got: The codes the engine produced.
+type ReplayDiff struct {
+	Line  int
+	Entry CorpusEntry
+	Got   []string
+}
+
+// Replay validates every entry of a corpus written by a Recorder with e
+// and reports the entries whose error codes differ from the recorded ones.
+// Tags that no longer compile report "error: ..." codes.
+//
+// Parameters:
+//   - r: The corpus, one JSON entry per line.
+//
+// Returns:
+//   - []ReplayDiff: Differing entries in corpus order.
+//   - error: A malformed line or read error.
+func (e *Engine) Replay(r io.Reader) ([]ReplayDiff, error) {
+	var diffs []ReplayDiff
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry CorpusEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return diffs, fmt.Errorf("corpus line %d: %w", line, err)
+		}
+		typ, ok := corpusKinds[entry.Type]
+		if !ok {
+			return diffs, fmt.Errorf("corpus line %d: unsupported type %q", line, entry.Type)
+		}
+		value := reflect.New(typ)
+		if err := json.Unmarshal(entry.Value, value.Interface()); err != nil {
+			return diffs, fmt.Errorf("corpus line %d: %w", line, err)
+		}
+		var got []string
+		if fn, err := e.FromRulesContext(types.SplitTag(entry.Tag)); err != nil {
+			got = errorCodes(err)
+		} else {
+			got = errorCodes(fn(context.Background(), value.Elem().Interface()))
+		}
+		if !reflect.DeepEqual(got, entry.Codes) {
+			diffs = append(diffs, ReplayDiff{Line: line, Entry: entry, Got: got})
+		}
+	}
+	return diffs, scanner.Err()
+}
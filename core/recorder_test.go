@@ -0,0 +1,92 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestRecorder_RecordsAnonymizedFailures(t *testing.T) {
+	var corpus bytes.Buffer
+	rec := NewRecorder(&corpus)
+	e := NewEngine().WithRecorder(rec)
+
+	fn, err := e.FromRules([]string{"string;min=5;regex=^[a-z]+$"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = fn("Ab1")
+	_ = fn("Cd2") // same shape after masking
+	_ = fn("valid")
+	intFn, _ := e.FromRulesContext([]string{"int;max=3"})
+	_ = intFn(context.Background(), 7)
+	sensitive, _ := e.FromRulesWithOpts([]string{"string;min=5"}, types.CompileOpts{Sensitive: true})
+	_ = sensitive("secret")
+	_ = sensitive("abc")
+	_ = fn([]string{"unsupported"})
+	if rec.Err() != nil {
+		t.Fatal(rec.Err())
+	}
+
+	lines := strings.Split(strings.TrimSpace(corpus.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("corpus:\n%s", corpus.String())
+	}
+	var entry CorpusEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	want := CorpusEntry{Tag: "string;min=5;regex=^[a-z]+$", Type: "string", Value: json.RawMessage(`"Xx0"`), Codes: []string{"string.min"}}
+	if !reflect.DeepEqual(entry, want) {
+		t.Fatalf("entry = %+v, want %+v", entry, want)
+	}
+	if !strings.Contains(lines[1], `"type":"int","value":7,"codes":["int.max"]`) {
+		t.Fatalf("int entry = %s", lines[1])
+	}
+
+	diffs, err := NewEngine().Replay(strings.NewReader(corpus.String()))
+	if err != nil || len(diffs) != 0 {
+		t.Fatalf("replay = %+v, %v", diffs, err)
+	}
+}
+
+func TestRecorder_LimitAndAnonymize(t *testing.T) {
+	var corpus bytes.Buffer
+	rec := NewRecorder(&corpus)
+	rec.MaxEntries = 1
+	rec.Anonymize = func(v any) any { return "zz" }
+	fn, _ := NewEngine().WithRecorder(rec).FromRules([]string{"string;len=3"})
+	_ = fn("abcd")
+	_ = fn("abcdef")
+	if got := strings.TrimSpace(corpus.String()); got != `{"tag":"string;len=3","type":"string","value":"zz","codes":["string.length"]}` {
+		t.Fatalf("corpus = %s", got)
+	}
+}
+
+func TestReplay_ReportsDifferences(t *testing.T) {
+	corpus := strings.Join([]string{
+		`{"tag":"string;min=3","type":"string","value":"ab","codes":["string.min"]}`,
+		`{"tag":"string;max=3","type":"string","value":"abcd","codes":[]}`,
+		``,
+		`{"tag":"nosuchrule","type":"int64","value":1,"codes":[]}`,
+	}, "\n")
+	diffs, err := NewEngine().Replay(strings.NewReader(corpus))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 2 || diffs[0].Line != 2 || diffs[0].Got[0] != "string.max" || diffs[1].Line != 4 || !strings.HasPrefix(diffs[1].Got[0], "error: ") {
+		t.Fatalf("diffs = %+v", diffs)
+	}
+
+	if _, err := NewEngine().Replay(strings.NewReader(`{"tag":"int","type":"complex128","value":1,"codes":[]}`)); err == nil {
+		t.Fatal("unsupported type accepted")
+	}
+	if _, err := NewEngine().Replay(strings.NewReader(`{`)); err == nil || !strings.Contains(err.Error(), "corpus line 1") {
+		t.Fatalf("malformed line error = %v", err)
+	}
+}
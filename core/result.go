@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Result is the outcome of a validation stamped with the rule set that
+// produced it, so audit logs can record exactly which rules accepted a
+// payload.
+//
+// Fields:
+//   - Fingerprint: Digest of the effective rule set, see Fingerprint.
+//   - Version: ValidateOpts.SchemaVersion, or "" for the default rules.
+//   - Err: The validation error; nil when the value passed.
+type Result struct {
+	Fingerprint string `json:"fingerprint"`
+	Version     string `json:"version,omitempty"`
+	Err         error  `json:"-"`
+}
+
+// Valid reports whether the value passed validation.
+func (r Result) Valid() bool {
+	return r.Err == nil
+}
+
+// Fingerprint returns a stable digest of a rule set given as field paths
+// mapped to tags, in the form "sha256:<hex>". Map order does not matter;
+// any change to a path or tag changes the digest.
+//
+// The digest covers tags only. Rule compilers, translators and other
+// Engine configuration are identified by the application's own version.
+func Fingerprint(rules map[string]string) string {
+	paths := make([]string, 0, len(rules))
+	for path := range rules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	h := sha256.New()
+	for _, path := range paths {
+		// Neither field paths nor tags contain NUL in practice.
+		h.Write([]byte(path + "\x00" + rules[path] + "\n"))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Fingerprint returns the Fingerprint of the schema's fields and, under
+// "schema:<name>." prefixes, of the registered schemas they reference.
+func (s *Schema) Fingerprint() string {
+	rules := map[string]string{}
+	for _, f := range s.fields {
+		rules[f.name] = f.tag
+	}
+	var collect func(ref string)
+	collect = func(ref string) {
+		prefix := "schema:" + ref + "."
+		if _, seen := rules[prefix]; seen || ref == "" {
+			return
+		}
+		fields, _ := LookupSchema(ref)
+		rules[prefix] = ""
+		for name, tag := range fields {
+			rules[prefix+name] = tag
+			_, sub, _ := splitSchemaRef(types.SplitTag(tag))
+			collect(sub)
+		}
+	}
+	for _, f := range s.fields {
+		collect(f.ref)
+	}
+	return Fingerprint(rules)
+}
+
+// ValidateResult validates value like ValidateValueContextWithOpts and
+// stamps the result with the schema's Fingerprint and opts.SchemaVersion.
+func (s *Schema) ValidateResult(ctx context.Context, value any, opts ValidateOpts) Result {
+	return Result{
+		Fingerprint: s.Fingerprint(),
+		Version:     opts.SchemaVersion,
+		Err:         s.ValidateValueContextWithOpts(ctx, value, opts),
+	}
+}
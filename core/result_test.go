@@ -0,0 +1,49 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint(map[string]string{"name": "string;min=2", "age": "int"})
+	if !strings.HasPrefix(a, "sha256:") || len(a) != len("sha256:")+64 {
+		t.Fatalf("Fingerprint = %q", a)
+	}
+	if b := Fingerprint(map[string]string{"age": "int", "name": "string;min=2"}); b != a {
+		t.Fatalf("order changed fingerprint: %q vs %q", b, a)
+	}
+	for _, rules := range []map[string]string{
+		{"name": "string;min=3", "age": "int"},
+		{"nick": "string;min=2", "age": "int"},
+		{"name": "string;min=2"},
+		{"name": "string;min=2\x00age", "": "int"},
+	} {
+		if Fingerprint(rules) == a {
+			t.Fatalf("%v has the same fingerprint", rules)
+		}
+	}
+}
+
+func TestSchemaValidateResult(t *testing.T) {
+	RegisterSchema("coretest.ResultAddress", map[string]string{"city": "string;required"})
+	fields := map[string]string{"name": "string;required", "address": "schema=coretest.ResultAddress"}
+	s, err := New().CompileSchema(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := s.ValidateResult(context.Background(), map[string]any{"name": "Ada"}, ValidateOpts{SchemaVersion: "v2"})
+	if !res.Valid() || res.Version != "v2" || res.Fingerprint != s.Fingerprint() {
+		t.Fatalf("result = %+v", res)
+	}
+	if res := s.ValidateResult(context.Background(), map[string]any{}, ValidateOpts{}); res.Valid() {
+		t.Fatalf("missing name passed: %+v", res)
+	}
+
+	before := s.Fingerprint()
+	RegisterSchema("coretest.ResultAddress", map[string]string{"city": "string;required;min=2"})
+	if s.Fingerprint() == before {
+		t.Fatal("referenced schema change kept the fingerprint")
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestWithRetryPolicy_RetriesContextRules(t *testing.T) {
+	calls := 0
+	base := New().WithContextRuleCompiler("mx", func(c *types.Compiler, rule types.Rule) (types.ContextValidatorFunc, error) {
+		return func(ctx context.Context, v any) error {
+			calls++
+			if calls%2 == 1 {
+				return errors.New("dns timeout")
+			}
+			return nil
+		}, nil
+	})
+
+	fn, err := base.FromRulesContext([]string{"string", "mx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn(context.Background(), "a@example.com"); err == nil {
+		t.Fatal("expected transient error without a retry policy")
+	}
+
+	calls = 0
+	v := base.WithRetryPolicy("mx", types.RetryPolicy{MaxAttempts: 2})
+	fn, err = v.FromRulesContext([]string{"string", "mx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn(context.Background(), "a@example.com"); err != nil {
+		t.Fatalf("expected retry to succeed: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
@@ -0,0 +1,519 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Schema validates dynamic map[string]any payloads, such as decoded JSON,
+// against field tags. Build one with Engine.CompileSchema; it is safe for
+// concurrent use.
+type Schema struct {
+	engine *Engine
+	fields []schemaField
+}
+
+type schemaField struct {
+	name     string
+	tag      string
+	segments []string // map keys, "[]" for every element of a list
+	tokens   []string
+	rules    []types.Rule
+	base     types.Kind
+	required bool
+	validate types.ContextValidatorFunc
+	ref      string // registered schema applied to the value, if any
+}
+
+// CompileSchema compiles a schema for map payloads from field names to
+// tags, using the struct tag syntax. Names address nested values with dots
+// and list elements with "[]", e.g. "address.city" or "items[].sku". A "*"
+// segment addresses every entry of an object, e.g. "module.*.source".
+//
+// Absent keys only fail `required`; other rules apply to present values,
+// including JSON null. Integer rules accept whole float64 and json.Number
+// values, as produced by encoding/json. A `schema=name` token validates the
+// value with a schema registered by RegisterSchema.
+//
+// Parameters:
+//   - fields: Field name to tag mappings.
+//
+// Returns:
+//   - *Schema: The compiled schema.
+//   - error: Joined errors of malformed names and tags, in name order.
+func (e *Engine) CompileSchema(fields map[string]string) (*Schema, error) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s := &Schema{engine: e}
+	var errs []error
+	for _, name := range names {
+		f, err := e.compileSchemaField(name, fields[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", name, err))
+			continue
+		}
+		s.fields = append(s.fields, f)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return s, nil
+}
+
+func (e *Engine) compileSchemaField(name, tag string) (schemaField, error) {
+	segments, err := schemaSegments(name)
+	if err != nil {
+		return schemaField{}, err
+	}
+	tokens, ref, err := splitSchemaRef(types.SplitTag(tag))
+	if err != nil {
+		return schemaField{}, err
+	}
+	f := schemaField{name: name, tag: tag, segments: segments, tokens: tokens, ref: ref}
+	if len(tokens) == 0 {
+		return f, nil
+	}
+	rules, err := e.ParseRules(tokens)
+	if err != nil {
+		return schemaField{}, err
+	}
+	if f.validate, err = e.FromRulesContext(tokens); err != nil {
+		return schemaField{}, err
+	}
+	f.rules = rules
+	for i, r := range rules {
+		if i == 0 {
+			f.base = r.Kind
+		}
+		if r.Kind == types.KRequired {
+			f.required = true
+		}
+	}
+	return f, nil
+}
+
+func schemaSegments(name string) ([]string, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty field name")
+	}
+	var segments []string
+	for _, part := range strings.Split(name, ".") {
+		key, lists := part, 0
+		for strings.HasSuffix(key, "[]") {
+			key = strings.TrimSuffix(key, "[]")
+			lists++
+		}
+		if key == "" || strings.ContainsAny(key, "[]") {
+			return nil, fmt.Errorf("invalid field name %q", name)
+		}
+		segments = append(segments, key)
+		for ; lists > 0; lists-- {
+			segments = append(segments, "[]")
+		}
+	}
+	return segments, nil
+}
+
+// splitSchemaRef removes a `schema=name` token from tag tokens.
+func splitSchemaRef(tokens []string) ([]string, string, error) {
+	out := tokens[:0:0]
+	ref := ""
+	for _, token := range tokens {
+		name, ok := strings.CutPrefix(strings.TrimSpace(token), "schema=")
+		if !ok {
+			out = append(out, token)
+			continue
+		}
+		if ref != "" {
+			return nil, "", fmt.Errorf("multiple schema references")
+		}
+		if _, ok := LookupSchema(name); !ok {
+			return nil, "", fmt.Errorf("unknown schema %q", name)
+		}
+		ref = name
+	}
+	return out, ref, nil
+}
+
+// Validate validates data against the schema.
+func (s *Schema) Validate(data map[string]any) error {
+	return s.ValidateContextWithOpts(context.Background(), data, ValidateOpts{})
+}
+
+// ValidateContext validates data against the schema with ctx passed to
+// context-aware rules.
+func (s *Schema) ValidateContext(ctx context.Context, data map[string]any) error {
+	return s.ValidateContextWithOpts(ctx, data, ValidateOpts{})
+}
+
+// ValidateContextWithOpts validates data against the schema. StopOnFirst,
+// CollectAllRules, PathSep, Translator and Budget apply; struct-specific
+// options are ignored.
+//
+// Returns:
+//   - error: nil, errors.Errors with paths such as "items[0].sku", or the
+//     context error when ctx is done.
+func (s *Schema) ValidateContextWithOpts(ctx context.Context, data map[string]any, opts ValidateOpts) error {
+	return s.ValidateValueContextWithOpts(ctx, data, opts)
+}
+
+// ValidateValueContextWithOpts validates value against the schema. Besides
+// maps with string keys, value may be a struct or pointer to struct, whose
+// exported fields are looked up by Go name.
+func (s *Schema) ValidateValueContextWithOpts(ctx context.Context, value any, opts ValidateOpts) error {
+	return s.validate(ctx, value, opts, false)
+}
+
+// validate runs the schema against value, with JSON Pointer paths when
+// pointer is set, and reports the call to the engine's observer.
+func (s *Schema) validate(ctx context.Context, value any, opts ValidateOpts, pointer bool) error {
+	return s.engine.Observe(func() error { return s.check(ctx, value, opts, pointer) })
+}
+
+// check runs the schema against value; nested schemas call it directly so
+// only the outer call is observed.
+func (s *Schema) check(ctx context.Context, value any, opts ValidateOpts, pointer bool) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	opts = ApplyOpts(s.engine, opts)
+	callerCtx := ctx
+	ctx, cancel := BudgetContext(ctx, opts)
+	defer cancel()
+	engine := s.engine.ForTranslator(opts.Translator)
+	run := &schemaRun{ctx: ctx, engine: engine, opts: opts, pointer: pointer}
+	for _, f := range s.fields {
+		if run.stop() {
+			break
+		}
+		fn := f.validate
+		// Field validators render messages with s.engine's translator;
+		// other translators and CollectAllRules need their own compile,
+		// which the compiled cache makes cheap after the first call.
+		if (opts.CollectAllRules || engine != s.engine) && fn != nil {
+			var err error
+			if fn, err = engine.FromRulesContextWithOpts(f.tokens, types.CompileOpts{CollectAll: opts.CollectAllRules}); err != nil {
+				return err
+			}
+		}
+		run.walk(f, fn, value, f.segments, "")
+	}
+	if run.terminal != nil {
+		timeout, ok := engine.TimeoutError(callerCtx, run.terminal, run.reached, run.checked)
+		if !ok {
+			return run.terminal
+		}
+		run.errs = append(run.errs, timeout)
+	}
+	if len(run.errs) > 0 {
+		return run.errs
+	}
+	return nil
+}
+
+type schemaRun struct {
+	ctx      context.Context
+	engine   *Engine
+	opts     ValidateOpts
+	pointer  bool // JSON Pointer paths, see ValidateJSON
+	errs     verrs.Errors
+	terminal error
+	reached  string          // path of the last value validated
+	checked  int             // number of values validated
+	shapes   map[string]bool // type errors already reported, by path and code
+}
+
+func (r *schemaRun) stop() bool {
+	if r.terminal == nil {
+		r.terminal = r.ctx.Err()
+	}
+	return r.terminal != nil || (r.opts.StopOnFirst && len(r.errs) > 0)
+}
+
+// walk follows segments from value, which is present, and validates the
+// values they reach.
+func (r *schemaRun) walk(f schemaField, fn types.ContextValidatorFunc, value any, segments []string, path string) {
+	if len(segments) == 0 {
+		r.leaf(f, fn, value, path)
+		return
+	}
+	if segments[0] == "[]" {
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() || rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			r.failShape(path, verrs.CodeSliceType, "expected slice")
+			return
+		}
+		for i := 0; i < rv.Len() && !r.stop(); i++ {
+			r.walk(f, fn, rv.Index(i).Interface(), segments[1:], r.index(path, i))
+		}
+		return
+	}
+	if segments[0] == "*" {
+		keys, entries, ok := schemaEntries(value)
+		if !ok {
+			r.failShape(path, verrs.CodeMapType, "expected map")
+			return
+		}
+		for i := 0; i < len(keys) && !r.stop(); i++ {
+			r.walk(f, fn, entries[i], segments[1:], r.key(path, keys[i]))
+		}
+		return
+	}
+	next, present, ok := schemaLookup(value, segments[0])
+	switch {
+	case !ok:
+		r.failShape(path, verrs.CodeMapType, "expected map")
+	case !present:
+		r.absent(f, segments, path)
+	default:
+		r.walk(f, fn, next, segments[1:], r.key(path, segments[0]))
+	}
+}
+
+// leaf validates a value reached by a field's segments with its rules and
+// then, if it passed and is not a nil map or pointer, with its referenced
+// schema.
+func (r *schemaRun) leaf(f schemaField, fn types.ContextValidatorFunc, value any, path string) {
+	r.reached = path
+	r.checked++
+	if fn != nil {
+		if err := fn(r.ctx, schemaValue(value, f.base)); err != nil {
+			r.check(err, path, false)
+			return
+		}
+	}
+	if rv := reflect.ValueOf(value); f.ref == "" || !rv.IsValid() || (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map) && rv.IsNil() {
+		return
+	}
+	sub, err := r.engine.NamedSchema(f.ref)
+	if err != nil {
+		r.check(err, path, false)
+		return
+	}
+	r.check(sub.check(r.ctx, value, r.opts, r.pointer), path, true)
+}
+
+// absent fails required fields whose value is missing at segments. Fields
+// inside absent lists and wildcard objects have no values to require.
+func (r *schemaRun) absent(f schemaField, segments []string, path string) {
+	if !f.required {
+		return
+	}
+	for _, seg := range segments {
+		if seg == "[]" || seg == "*" {
+			return
+		}
+		path = r.key(path, seg)
+	}
+	r.fail(path, verrs.CodeRequired, "value is required")
+}
+
+// check records the errors of a rule chain, or of a nested schema run,
+// under path.
+func (r *schemaRun) check(err error, path string, nested bool) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		r.terminal = err
+		return
+	}
+	var fieldErrors verrs.Errors
+	if !errors.As(err, &fieldErrors) {
+		r.errs = append(r.errs, verrs.FieldError{Path: path, Code: verrs.CodeUnknown, Msg: err.Error()})
+		return
+	}
+	for _, fe := range fieldErrors {
+		switch {
+		case r.pointer && nested:
+			fe.Path = path + fe.Path
+		case r.pointer:
+			fe.Path = path + pointerSuffix(fe.Path, r.opts.PathSep)
+		default:
+			fe.Path = schemaPathJoin(path, fe.Path, r.opts.PathSep)
+		}
+		r.errs = append(r.errs, fe)
+	}
+}
+
+func (r *schemaRun) fail(path, code, fallback string) {
+	msg := fallback
+	if tr := r.engine.Translator(); tr != nil {
+		if translated := tr.T(code); translated != "" {
+			msg = translated
+		}
+	}
+	r.errs = append(r.errs, verrs.FieldError{Path: path, Code: code, Msg: msg})
+}
+
+// failShape reports a type mismatch at path once, however many schema
+// fields pass through it.
+func (r *schemaRun) failShape(path, code, fallback string) {
+	key := path + "\x00" + code
+	if r.shapes[key] {
+		return
+	}
+	if r.shapes == nil {
+		r.shapes = make(map[string]bool)
+	}
+	r.shapes[key] = true
+	r.fail(path, code, fallback)
+}
+
+// schemaLookup returns the entry key of a map with string or interface
+// keys, as decoded from YAML, or the exported field key of a struct. ok is
+// false for other values.
+func schemaLookup(value any, key string) (next any, present, ok bool) {
+	if m, isMap := value.(map[string]any); isMap {
+		next, present = m[key]
+		return next, present, true
+	}
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Map:
+		var mv reflect.Value
+		switch kt := rv.Type().Key(); kt.Kind() {
+		case reflect.String:
+			mv = rv.MapIndex(reflect.ValueOf(key).Convert(kt))
+		case reflect.Interface:
+			mv = rv.MapIndex(reflect.ValueOf(key))
+		default:
+			return nil, false, false
+		}
+		if !mv.IsValid() {
+			return nil, false, true
+		}
+		return mv.Interface(), true, true
+	case reflect.Struct:
+		sf, found := rv.Type().FieldByName(key)
+		if !found || !sf.IsExported() {
+			return nil, false, true
+		}
+		return rv.FieldByIndex(sf.Index).Interface(), true, true
+	}
+	return nil, false, false
+}
+
+// schemaEntries returns the entries of a map with string or interface keys
+// in key order, with keys formatted by fmt.Sprint. ok is false for other
+// values.
+func schemaEntries(value any) (keys []string, entries []any, ok bool) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Map {
+		return nil, nil, false
+	}
+	if k := rv.Type().Key().Kind(); k != reflect.String && k != reflect.Interface {
+		return nil, nil, false
+	}
+	byKey := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		keys = append(keys, key)
+		byKey[key] = iter.Value().Interface()
+	}
+	sort.Strings(keys)
+	entries = make([]any, len(keys))
+	for i, key := range keys {
+		entries[i] = byKey[key]
+	}
+	return keys, entries, true
+}
+
+// schemaValue adapts decoded JSON numbers to the integer kinds; other kinds
+// see json.Number values as float64. Integers decoded from binary payloads
+// are float64 for the float kind, as JSON numbers would be, and uint64 for
+// the unsigned kinds when not negative.
+func schemaValue(v any, base types.Kind) any {
+	integer := base == types.KInt || base == types.KInt64
+	if base == types.KUint || base == types.KUint64 {
+		return schemaUintValue(v)
+	}
+	if rv := reflect.ValueOf(v); base == types.KFloat && rv.IsValid() {
+		switch {
+		case rv.CanInt():
+			return float64(rv.Int())
+		case rv.CanUint():
+			return float64(rv.Uint())
+		}
+	}
+	switch n := v.(type) {
+	case float64:
+		if integer && n == math.Trunc(n) && n >= math.MinInt64 && n < math.MaxInt64 {
+			return int64(n)
+		}
+	case json.Number:
+		if i, err := n.Int64(); err == nil && integer {
+			return i
+		}
+		if f, err := n.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+// schemaUintValue converts non-negative whole numbers to uint64 for the
+// unsigned kinds and leaves other values for the type check to reject.
+func schemaUintValue(v any) any {
+	if rv := reflect.ValueOf(v); rv.IsValid() && rv.CanInt() && rv.Int() >= 0 {
+		return uint64(rv.Int())
+	}
+	switch n := v.(type) {
+	case float64:
+		if n >= 0 && n == math.Trunc(n) && n < math.MaxUint64 {
+			return uint64(n)
+		}
+	case json.Number:
+		if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+			return u
+		}
+	}
+	return v
+}
+
+func (r *schemaRun) key(path, key string) string {
+	if r.pointer {
+		return path + "/" + pointerEscape(key)
+	}
+	return schemaPathJoin(path, key, r.opts.PathSep)
+}
+
+func (r *schemaRun) index(path string, i int) string {
+	if r.pointer {
+		return path + "/" + strconv.Itoa(i)
+	}
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func schemaPathJoin(base, name, sep string) string {
+	switch {
+	case base == "":
+		return name
+	case name == "":
+		return base
+	case name[0] == '[':
+		return base + name
+	}
+	return base + sep + name
+}
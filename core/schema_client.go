@@ -0,0 +1,79 @@
+package core
+
+import (
+	"github.com/aatuh/validate/v3/types"
+)
+
+// ClientSchema describes a Schema for evaluation outside Go, such as
+// mirroring validation in a front-end, and serializes to JSON. Each field
+// is evaluated as the server does: names address values with dots and
+// "[]", absent keys only fail required, and a field's rules follow the
+// contract of types.ClientRules. A field with Schema set validates its
+// non-null value against Schemas[Schema], relative to that value.
+//
+// Fields:
+//   - Fields: The schema's fields, in name order.
+//   - Schemas: Registered schemas referenced directly or transitively.
+type ClientSchema struct {
+	Fields  []ClientField            `json:"fields"`
+	Schemas map[string][]ClientField `json:"schemas,omitempty"`
+}
+
+// ClientField describes one schema field.
+//
+// Fields:
+//   - Name: The field name as compiled, e.g. "items[].sku".
+//   - Rules: The field's rules; nil when it only references a schema.
+//   - Schema: Name of the referenced schema, if any.
+type ClientField struct {
+	Name   string             `json:"name"`
+	Rules  *types.ClientRules `json:"rules,omitempty"`
+	Schema string             `json:"schema,omitempty"`
+}
+
+// ClientSchema returns the client description of s, including the
+// registered schemas it references.
+//
+// Returns:
+//   - *ClientSchema: The description, ready for json.Marshal.
+//   - error: If a referenced schema fails to compile.
+func (s *Schema) ClientSchema() (*ClientSchema, error) {
+	cs := &ClientSchema{}
+	refs := map[string][]ClientField{}
+	var err error
+	if cs.Fields, err = s.clientFields(refs); err != nil {
+		return nil, err
+	}
+	if len(refs) > 0 {
+		cs.Schemas = refs
+	}
+	return cs, nil
+}
+
+// clientFields describes the fields of s and adds the schemas they
+// reference to refs.
+func (s *Schema) clientFields(refs map[string][]ClientField) ([]ClientField, error) {
+	fields := make([]ClientField, 0, len(s.fields))
+	for _, f := range s.fields {
+		cf := ClientField{Name: f.name, Schema: f.ref}
+		if len(f.rules) > 0 {
+			cf.Rules = types.ClientRulesFor(f.rules)
+		}
+		fields = append(fields, cf)
+		if f.ref == "" {
+			continue
+		}
+		if _, seen := refs[f.ref]; seen {
+			continue
+		}
+		sub, err := s.engine.NamedSchema(f.ref)
+		if err != nil {
+			return nil, err
+		}
+		refs[f.ref] = nil // marks the schema as visited for recursive refs
+		if refs[f.ref], err = sub.clientFields(refs); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
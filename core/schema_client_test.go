@@ -0,0 +1,37 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSchema_ClientSchema(t *testing.T) {
+	RegisterSchema("coretest.ClientNode", map[string]string{
+		"name":       "string;required;max=10",
+		"children[]": "schema=coretest.ClientNode",
+	})
+	s, err := NewEngine().CompileSchema(map[string]string{
+		"root":  "required;schema=coretest.ClientNode",
+		"email": "string;required;url",
+	})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	cs, err := s.ClientSchema()
+	if err != nil {
+		t.Fatalf("ClientSchema: %v", err)
+	}
+	got, err := json.Marshal(cs)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"fields":[` +
+		`{"name":"email","rules":{"type":"string","required":true,"serverOnly":["url"]}},` +
+		`{"name":"root","rules":{"required":true},"schema":"coretest.ClientNode"}],` +
+		`"schemas":{"coretest.ClientNode":[` +
+		`{"name":"children[]","schema":"coretest.ClientNode"},` +
+		`{"name":"name","rules":{"type":"string","required":true,"checks":[{"rule":"maxLength","code":"string.max","arg":10}]}}]}}`
+	if string(got) != want {
+		t.Fatalf("got  %s\nwant %s", got, want)
+	}
+}
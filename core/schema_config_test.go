@@ -0,0 +1,54 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestValidateConfig_WildcardKeysAndYAMLMaps(t *testing.T) {
+	RegisterSchema("coretest.Config", map[string]string{
+		"module.*.source":            "string;required;prefix=git::",
+		"module.*.instances[].size":  "string;oneof=small large",
+		"module.*.instances[].count": "int;min=1",
+		"provider":                   "map;minKeys=1",
+		"provider.*.region":          "string;required",
+	})
+	e := New()
+
+	// YAML decoders produce map[any]any for nested objects.
+	doc := map[string]any{
+		"module": map[any]any{
+			"web": map[any]any{"source": "git::https://example.com/web"},
+			"db": map[any]any{
+				"source": "./db",
+				"instances": []any{
+					map[any]any{"size": "huge", "count": 1},
+					map[any]any{"size": "small", "count": 0.0},
+				},
+			},
+		},
+		"provider": map[string]any{"aws": map[string]any{}},
+	}
+	got := schemaCodes(t, e.ValidateConfig("coretest.Config", doc))
+	want := map[string]string{
+		"module.db.source":             verrs.CodeStringPrefix,
+		"module.db.instances[0].size":  verrs.CodeStringOneOf,
+		"module.db.instances[1].count": verrs.CodeIntMin,
+		"provider.aws.region":          verrs.CodeRequired,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+
+	got = schemaCodes(t, e.ValidateConfig("coretest.Config", map[string]any{"module": "web"}))
+	want = map[string]string{"module": verrs.CodeMapType}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("non-object module: %v, want %v", got, want)
+	}
+
+	if err := e.ValidateConfig("coretest.Missing", doc); err == nil {
+		t.Fatalf("expected error for unknown schema")
+	}
+}
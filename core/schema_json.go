@@ -0,0 +1,83 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ValidateJSON decodes data and validates it against the schema. Error
+// paths are JSON Pointers (RFC 6901) to the wire fields, e.g.
+// "/items/0/sku", so they can be returned without mapping Go names. Numbers
+// are decoded as json.Number, keeping integers exact.
+//
+// Returns:
+//   - error: nil, errors.Errors, or an error for malformed JSON.
+func (s *Schema) ValidateJSON(data []byte) error {
+	return s.ValidateJSONContextWithOpts(context.Background(), bytes.NewReader(data), ValidateOpts{})
+}
+
+// ValidateJSONContext is ValidateJSON with ctx passed to context-aware
+// rules.
+func (s *Schema) ValidateJSONContext(ctx context.Context, data []byte) error {
+	return s.ValidateJSONContextWithOpts(ctx, bytes.NewReader(data), ValidateOpts{})
+}
+
+// ValidateJSONContextWithOpts decodes one JSON value from r, such as a
+// request body, and validates it like ValidateJSON. PathSep is ignored.
+func (s *Schema) ValidateJSONContextWithOpts(ctx context.Context, r io.Reader, opts ValidateOpts) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("decode JSON: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("decode JSON: unexpected data after top-level value")
+	}
+	return s.validate(ctx, value, opts, true)
+}
+
+// pointerEscape escapes a JSON Pointer reference token.
+func pointerEscape(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// pointerSuffix converts a relative rule error path, such as "[0]" from
+// forEach or "[key].name", to JSON Pointer form.
+func pointerSuffix(rel, sep string) string {
+	if rel == "" {
+		return ""
+	}
+	if sep == "" {
+		sep = "."
+	}
+	var b strings.Builder
+	for rel != "" {
+		switch {
+		case rel[0] == '[':
+			end := strings.IndexByte(rel, ']')
+			if end < 0 {
+				end = len(rel) - 1
+			}
+			b.WriteString("/" + pointerEscape(rel[1:end]))
+			rel = rel[end+1:]
+		case strings.HasPrefix(rel, sep):
+			rel = rel[len(sep):]
+		default:
+			end := len(rel)
+			if i := strings.IndexByte(rel, '['); i >= 0 {
+				end = i
+			}
+			if i := strings.Index(rel, sep); i >= 0 && i < end {
+				end = i
+			}
+			b.WriteString("/" + pointerEscape(rel[:end]))
+			rel = rel[end:]
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestSchemaValidateJSON_ReportsJSONPointers(t *testing.T) {
+	RegisterSchema("coretest.JSONAddress", map[string]string{"zip/code": "string;len=5"})
+	s, err := New().CompileSchema(map[string]string{
+		"id":          "int64;min=1",
+		"email":       "string;required",
+		"items[].sku": "string;len=4",
+		"tags":        "slice;foreach=(string;min=2)",
+		"address":     "schema=coretest.JSONAddress",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = s.ValidateJSON([]byte(`{
+		"id": 9007199254740993,
+		"items": [{"sku": "ABCD"}, {"sku": "X"}],
+		"tags": ["ok", "x"],
+		"address": {"zip/code": "1"}
+	}`))
+	got := schemaCodes(t, err)
+	want := map[string]string{
+		"/email":             verrs.CodeRequired,
+		"/items/1/sku":       verrs.CodeStringLength,
+		"/tags/1":            verrs.CodeStringMin,
+		"/address/zip~1code": verrs.CodeStringLength,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+
+	if err := s.ValidateJSON([]byte(`{"id": 1, "email": "a@b.c"}`)); err != nil {
+		t.Fatalf("valid document: %v", err)
+	}
+	if got := schemaCodes(t, s.ValidateJSON([]byte(`{"id": 1.5, "email": "a"}`))); got["/id"] != verrs.CodeInt64Type {
+		t.Fatalf("fractional id: %v", got)
+	}
+}
+
+func TestSchemaValidateJSON_MalformedInput(t *testing.T) {
+	s, err := New().CompileSchema(map[string]string{"name": "string"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range []string{`{"name":`, `{} {}`, ``} {
+		if err := s.ValidateJSON([]byte(doc)); err == nil || !strings.Contains(err.Error(), "decode JSON") {
+			t.Fatalf("%q: error = %v", doc, err)
+		}
+	}
+	if got := schemaCodes(t, s.ValidateJSON([]byte(`[1]`))); got[""] != verrs.CodeMapType {
+		t.Fatalf("top-level array: %v", got)
+	}
+}
+
+func TestPointerSuffix(t *testing.T) {
+	tests := map[string]string{
+		"":           "",
+		"[0]":        "/0",
+		"[k/1].name": "/k~11/name",
+		"name[2][x]": "/name/2/x",
+		"a.b":        "/a/b",
+	}
+	for in, want := range tests {
+		if got := pointerSuffix(in, "."); got != want {
+			t.Fatalf("pointerSuffix(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// PayloadDecoder decodes a binary payload, such as MessagePack or CBOR,
+// into generic values: maps with string or interface keys, slices, strings,
+// booleans and numbers of any Go numeric type. The package has no codec
+// dependencies; wrap a codec of your choice, e.g. with PayloadDecoderFunc.
+type PayloadDecoder interface {
+	Decode(r io.Reader) (any, error)
+}
+
+// PayloadDecoderFunc adapts a function to PayloadDecoder, e.g. for a
+// MessagePack codec:
+//
+//	core.PayloadDecoderFunc(func(r io.Reader) (any, error) {
+//		var v any
+//		err := msgpack.NewDecoder(r).Decode(&v)
+//		return v, err
+//	})
+type PayloadDecoderFunc func(r io.Reader) (any, error)
+
+// Decode calls f(r).
+func (f PayloadDecoderFunc) Decode(r io.Reader) (any, error) {
+	return f(r)
+}
+
+// ValidatePayload decodes data with dec and validates it against the
+// schema. Error paths are JSON Pointers to the payload fields, e.g.
+// "/readings/0/value", as in ValidateJSON.
+//
+// Returns:
+//   - error: nil, errors.Errors, or the wrapped decode error.
+func (s *Schema) ValidatePayload(data []byte, dec PayloadDecoder) error {
+	return s.ValidatePayloadContextWithOpts(context.Background(), bytes.NewReader(data), dec, ValidateOpts{})
+}
+
+// ValidatePayloadContextWithOpts decodes one payload from r with dec and
+// validates it like ValidatePayload. PathSep is ignored.
+func (s *Schema) ValidatePayloadContextWithOpts(ctx context.Context, r io.Reader, dec PayloadDecoder, opts ValidateOpts) error {
+	if dec == nil {
+		return fmt.Errorf("decode payload: nil decoder")
+	}
+	value, err := dec.Decode(r)
+	if err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	return s.validate(ctx, value, opts, true)
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// testPayloadDecoder returns values shaped like MessagePack and CBOR
+// decoders produce them: interface-keyed maps and sized integers.
+var testPayloadDecoder = PayloadDecoderFunc(func(r io.Reader) (any, error) {
+	data, err := io.ReadAll(r)
+	if err != nil || string(data) != "reading" {
+		return nil, errors.New("unexpected payload")
+	}
+	return map[any]any{
+		"device":  "",
+		"battery": uint8(120),
+		"readings": []any{
+			map[any]any{"sensor": "t1", "value": float32(21.5)},
+			map[any]any{"sensor": "t2", "value": int64(-300)},
+		},
+	}, nil
+})
+
+func TestSchemaValidatePayload(t *testing.T) {
+	s, err := New().CompileSchema(map[string]string{
+		"device":           "string;required",
+		"battery":          "int;max=100",
+		"readings[].value": "float;between=-50,100",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := schemaCodes(t, s.ValidatePayload([]byte("reading"), testPayloadDecoder))
+	want := map[string]string{
+		"/device":           verrs.CodeRequired,
+		"/battery":          verrs.CodeIntMax,
+		"/readings/1/value": verrs.CodeNumberBetween,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+
+	if err := s.ValidatePayload([]byte("garbage"), testPayloadDecoder); err == nil || err.Error() != "decode payload: unexpected payload" {
+		t.Fatalf("decode error = %v", err)
+	}
+	if err := s.ValidatePayload(nil, nil); err == nil {
+		t.Fatal("expected nil decoder error")
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+var (
+	schemaRegistryMu sync.RWMutex
+	schemaRegistry   = map[string]map[string]string{}
+)
+
+// namedSchemaKey keys compiled named schemas in an engine's type cache.
+type namedSchemaKey string
+
+// RegisterSchema registers fields, field names to tags as for
+// CompileSchema, under name so other packages can reference the schema in
+// tags with `schema=name`. Names are conventionally qualified by package,
+// e.g. "billing.Address". Registering a name again replaces its schema for
+// engines that have not compiled it yet; register from init functions.
+func RegisterSchema(name string, fields map[string]string) {
+	copied := make(map[string]string, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	schemaRegistryMu.Lock()
+	defer schemaRegistryMu.Unlock()
+	schemaRegistry[name] = copied
+}
+
+// LookupSchema returns a copy of the fields registered under name.
+func LookupSchema(name string) (map[string]string, bool) {
+	schemaRegistryMu.RLock()
+	defer schemaRegistryMu.RUnlock()
+	fields, ok := schemaRegistry[name]
+	if !ok {
+		return nil, false
+	}
+	copied := make(map[string]string, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return copied, true
+}
+
+// NamedSchema returns the schema registered under name, compiled once per
+// engine. Schemas referenced by its fields are compiled on first use, so
+// schemas may reference each other recursively.
+func (e *Engine) NamedSchema(name string) (*Schema, error) {
+	if cached, ok := e.CachedType(namedSchemaKey(name)); ok {
+		return cached.(*Schema), nil
+	}
+	fields, ok := LookupSchema(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q", name)
+	}
+	s, err := e.CompileSchema(fields)
+	if err != nil {
+		return nil, fmt.Errorf("schema %s: %w", name, err)
+	}
+	return e.CacheType(namedSchemaKey(name), s).(*Schema), nil
+}
+
+// ValidateConfig validates a configuration document, such as decoded JSON
+// or YAML, against the schema registered under name. See
+// ValidateConfigContextWithOpts.
+func (e *Engine) ValidateConfig(name string, doc any) error {
+	return e.ValidateConfigContextWithOpts(context.Background(), name, doc, ValidateOpts{})
+}
+
+// ValidateConfigContextWithOpts validates a configuration document against
+// the schema registered under name. Documents may nest maps with string or
+// interface keys, slices and structs to any depth; "*" segments in the
+// schema match user-chosen keys, so errors carry paths such as
+// "module.db.instances[0].size".
+//
+// Returns:
+//   - error: nil, errors.Errors, the context error, or an error when the
+//     schema is unknown or does not compile.
+func (e *Engine) ValidateConfigContextWithOpts(ctx context.Context, name string, doc any, opts ValidateOpts) error {
+	s, err := e.NamedSchema(name)
+	if err != nil {
+		return err
+	}
+	return s.ValidateValueContextWithOpts(ctx, doc, opts)
+}
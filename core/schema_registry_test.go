@@ -0,0 +1,73 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestNamedSchema_ComposesRegisteredSchemas(t *testing.T) {
+	RegisterSchema("coretest.Address", map[string]string{
+		"City": "string;required;min=2",
+		"Zip":  "string;len=5",
+	})
+	RegisterSchema("coretest.Invoice", map[string]string{
+		"number":     "string;required",
+		"billing":    "required;schema=coretest.Address",
+		"shipping[]": "schema=coretest.Address",
+	})
+
+	s, err := New().NamedSchema("coretest.Invoice")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	type address struct {
+		City string
+		Zip  string
+	}
+	got := schemaCodes(t, s.Validate(map[string]any{
+		"number":   "INV-1",
+		"billing":  map[string]any{"Zip": "123"},
+		"shipping": []any{map[string]any{"City": "Oulu", "Zip": "90100"}, &address{City: "X", Zip: "90100"}},
+	}))
+	want := map[string]string{
+		"billing.City":     verrs.CodeRequired,
+		"billing.Zip":      verrs.CodeStringLength,
+		"shipping[1].City": verrs.CodeStringMin,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+
+	got = schemaCodes(t, s.Validate(map[string]any{"number": "INV-1"}))
+	if !reflect.DeepEqual(got, map[string]string{"billing": verrs.CodeRequired}) {
+		t.Fatalf("missing billing: %v", got)
+	}
+}
+
+func TestNamedSchema_RecursiveAndUnknownSchemas(t *testing.T) {
+	RegisterSchema("coretest.Node", map[string]string{
+		"name":       "string;required",
+		"children[]": "schema=coretest.Node",
+	})
+	s, err := New().NamedSchema("coretest.Node")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tree := map[string]any{"name": "root", "children": []any{
+		map[string]any{"name": "a", "children": []any{map[string]any{}}},
+	}}
+	if got := schemaCodes(t, s.Validate(tree)); got["children[0].children[0].name"] != verrs.CodeRequired {
+		t.Fatalf("errors = %v", got)
+	}
+
+	if _, err := New().NamedSchema("coretest.Missing"); err == nil {
+		t.Fatal("expected error for unknown schema")
+	}
+	_, err = New().CompileSchema(map[string]string{"a": "schema=coretest.Missing"})
+	if err == nil || !strings.Contains(err.Error(), `unknown schema "coretest.Missing"`) {
+		t.Fatalf("unknown reference: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func schemaCodes(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("got %T %v, want errors.Errors", err, err)
+	}
+	got := map[string]string{}
+	for _, fe := range es {
+		got[fe.Path] = fe.Code
+	}
+	return got
+}
+
+func TestCompileSchema_ValidatesNestedPayloads(t *testing.T) {
+	s, err := New().CompileSchema(map[string]string{
+		"name":          "string;required;min=2",
+		"age":           "int;min=18",
+		"address.city":  "string;required",
+		"items[].sku":   "string;len=4",
+		"items[].qty":   "int;min=1",
+		"tags[]":        "string;max=3",
+		"nickname":      "string;omitempty;min=3",
+		"address.notes": "string;max=5",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"name": "A",
+		"age": 17,
+		"address": {},
+		"items": [{"sku": "ABCD", "qty": 1}, {"sku": "X", "qty": 0.5}],
+		"tags": ["ok", "toolong"],
+		"nickname": ""
+	}`), &payload); err != nil {
+		t.Fatal(err)
+	}
+	got := schemaCodes(t, s.Validate(payload))
+	want := map[string]string{
+		"name":         verrs.CodeStringMin,
+		"age":          verrs.CodeIntMin,
+		"address.city": verrs.CodeRequired,
+		"items[1].sku": verrs.CodeStringLength,
+		"items[1].qty": verrs.CodeIntType,
+		"tags[1]":      verrs.CodeStringMax,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+
+	valid := map[string]any{
+		"name":    "Ada",
+		"age":     json.Number("30"),
+		"address": map[string]any{"city": "Oulu"},
+		"items":   []any{map[string]any{"sku": "ABCD", "qty": float64(2)}},
+	}
+	if err := s.Validate(valid); err != nil {
+		t.Fatalf("valid payload: %v", err)
+	}
+}
+
+func TestCompileSchema_ShapeErrorsAndOptions(t *testing.T) {
+	s, err := New().CompileSchema(map[string]string{
+		"address.city": "string;required",
+		"items[].sku":  "string;required",
+		"name":         "string;min=3",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := schemaCodes(t, s.Validate(map[string]any{"address": "Oulu", "items": map[string]any{}, "name": "A"}))
+	want := map[string]string{"address": verrs.CodeMapType, "items": verrs.CodeSliceType, "name": verrs.CodeStringMin}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+
+	err = s.ValidateContextWithOpts(context.Background(), map[string]any{"name": "A"}, ValidateOpts{StopOnFirst: true, PathSep: "/"})
+	if got := schemaCodes(t, err); len(got) != 1 || got["address/city"] != verrs.CodeRequired {
+		t.Fatalf("StopOnFirst errors = %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.ValidateContext(ctx, map[string]any{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled context: %v", err)
+	}
+}
+
+func TestCompileSchema_ReportsShapeErrorsOnce(t *testing.T) {
+	s, err := New().CompileSchema(map[string]string{
+		"address.street": "string;required",
+		"address.city":   "string;required",
+		"address.zip":    "string",
+		"items[].sku":    "string;required",
+		"items[].qty":    "int",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tc := range []struct {
+		payload any
+		want    []string
+	}{
+		{map[string]any{"address": "Oulu", "items": "x"}, []string{"address:" + verrs.CodeMapType, "items:" + verrs.CodeSliceType}},
+		{[]any{1}, []string{":" + verrs.CodeMapType}},
+	} {
+		var es verrs.Errors
+		if !errors.As(s.ValidateValueContextWithOpts(context.Background(), tc.payload, ValidateOpts{}), &es) {
+			t.Fatalf("%v: want errors", tc.payload)
+		}
+		var got []string
+		for _, fe := range es {
+			got = append(got, fe.Path+":"+fe.Code)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%v: errors = %v, want %v", tc.payload, got, tc.want)
+		}
+	}
+}
+
+func TestCompileSchema_BudgetStopsWithTimeout(t *testing.T) {
+	e := New()
+	e.RegisterRule("slow", func(*types.Compiler, types.Rule) (func(any) error, error) {
+		return func(any) error {
+			time.Sleep(2 * time.Millisecond)
+			return verrs.Errors{verrs.FieldError{Code: "slow", Msg: "slow"}}
+		}, nil
+	})
+	s, err := e.CompileSchema(map[string]string{"items[].sku": "string;slow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := make([]any, 1000)
+	for i := range items {
+		items[i] = map[string]any{"sku": "x"}
+	}
+	data := map[string]any{"items": items}
+
+	err = s.ValidateContextWithOpts(context.Background(), data, ValidateOpts{Budget: 10 * time.Millisecond})
+	var es verrs.Errors
+	if !errors.As(err, &es) || len(es) < 2 || len(es) == len(items)+1 {
+		t.Fatalf("budgeted errors = %v", err)
+	}
+	last, partial := es[len(es)-1], es[:len(es)-1]
+	if last.Code != verrs.CodeTimeout || last.Param != len(partial) || last.Path != partial[len(partial)-1].Path {
+		t.Fatalf("timeout error = %+v after %d errors", last, len(partial))
+	}
+
+	// The caller's own deadline is returned as is.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.ValidateContextWithOpts(ctx, data, ValidateOpts{Budget: time.Hour}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("caller deadline: %v", err)
+	}
+}
+
+func TestCompileSchema_ReportsMalformedFields(t *testing.T) {
+	_, err := New().CompileSchema(map[string]string{
+		"a..b":  "string",
+		"name":  "string;min=x",
+		"items": "string",
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, want := range []string{"field a..b", "field name"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q does not mention %q", err, want)
+		}
+	}
+}
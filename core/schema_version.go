@@ -0,0 +1,91 @@
+package core
+
+import (
+	"reflect"
+	"sort"
+)
+
+// schemaVersionKey identifies the tag overrides of one struct type version.
+type schemaVersionKey struct {
+	typ     reflect.Type
+	version string
+}
+
+// WithSchemaVersion returns a new Engine with version-specific `validate`
+// tags for the struct type of sample (a struct value or pointer to one).
+// Tags are keyed by Go field name. Fields without an override keep their
+// struct tag, so a new version only declares the fields whose rules changed.
+// An empty tag disables validation of that field in the version.
+func (e *Engine) WithSchemaVersion(sample any, version string, tags map[string]string) *Engine {
+	ne := e.Copy()
+	typ := structType(sample)
+	if typ == nil || version == "" {
+		return ne
+	}
+	if ne.schemaVersions == nil {
+		ne.schemaVersions = make(map[schemaVersionKey]map[string]string)
+	}
+	key := schemaVersionKey{typ: typ, version: version}
+	merged := make(map[string]string, len(ne.schemaVersions[key])+len(tags))
+	for field, tag := range ne.schemaVersions[key] {
+		merged[field] = tag
+	}
+	for field, tag := range tags {
+		merged[field] = tag
+	}
+	ne.schemaVersions[key] = merged
+	return ne
+}
+
+// SchemaFieldTag returns the `validate` tag registered for a struct field in
+// the given schema version. ok is false when the version has no override for
+// the field and the struct tag applies.
+func (e *Engine) SchemaFieldTag(typ reflect.Type, version, field string) (tag string, ok bool) {
+	if e == nil || version == "" || len(e.schemaVersions) == 0 {
+		return "", false
+	}
+	tags, found := e.schemaVersions[schemaVersionKey{typ: typ, version: version}]
+	if !found {
+		return "", false
+	}
+	tag, ok = tags[field]
+	return tag, ok
+}
+
+// SchemaVersions returns the versions registered for typ in sorted order.
+func (e *Engine) SchemaVersions(typ reflect.Type) []string {
+	var out []string
+	for key := range e.schemaVersions {
+		if key.typ == typ {
+			out = append(out, key.version)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func structType(sample any) reflect.Type {
+	typ := reflect.TypeOf(sample)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+	return typ
+}
+
+func copySchemaVersions(in map[schemaVersionKey]map[string]string) map[schemaVersionKey]map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[schemaVersionKey]map[string]string, len(in))
+	for k, tags := range in {
+		cp := make(map[string]string, len(tags))
+		for field, tag := range tags {
+			cp[field] = tag
+		}
+		out[k] = cp
+	}
+	return out
+}
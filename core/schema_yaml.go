@@ -0,0 +1,66 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// ValidateYAML decodes a YAML document, such as a configuration file, and
+// validates it against the schema. Error paths are JSON Pointers like
+// ValidateJSON's, and FieldError.Line and Column locate the field in data;
+// errors of absent fields point at the nearest enclosing field.
+//
+// The decoder supports the block YAML subset configuration files use:
+// mappings with plain or quoted keys, sequences, flow collections that
+// close on the line they open, single-line plain and quoted scalars, block
+// scalars (| and >), comments, directives and one leading "---". Anchors,
+// aliases, merge keys, tags, complex keys, plain or quoted scalars spanning
+// lines, flow collections spanning lines and multiple documents are decode
+// errors that name the construct and its line. Numbers decode as
+// json.Number. For full YAML, decode with a YAML library through
+// ValidatePayload and a PayloadDecoder; errors then carry no positions.
+//
+// Returns:
+//   - error: nil, errors.Errors, or an error for malformed or unsupported
+//     YAML.
+func (s *Schema) ValidateYAML(data []byte) error {
+	return s.ValidateYAMLContextWithOpts(context.Background(), bytes.NewReader(data), ValidateOpts{})
+}
+
+// ValidateYAMLContextWithOpts reads a YAML document from r and validates it
+// like ValidateYAML. PathSep is ignored.
+func (s *Schema) ValidateYAMLContextWithOpts(ctx context.Context, r io.Reader, opts ValidateOpts) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("decode YAML: %w", err)
+	}
+	value, pos, err := decodeYAML(data)
+	if err != nil {
+		return fmt.Errorf("decode YAML: %w", err)
+	}
+	err = s.validate(ctx, value, opts, true)
+	es, ok := err.(verrs.Errors)
+	if !ok {
+		return err
+	}
+	for i := range es {
+		path := es[i].Path
+		for {
+			if p, ok := pos[path]; ok {
+				es[i].Line, es[i].Column = p.line, p.column
+				break
+			}
+			cut := strings.LastIndex(path, "/")
+			if cut < 0 {
+				break
+			}
+			path = path[:cut]
+		}
+	}
+	return es
+}
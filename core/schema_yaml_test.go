@@ -0,0 +1,151 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	doc := `%YAML 1.2
+---
+# service config
+name: api   # trailing comment
+port: 0x1F90
+ratio: 1.5
+debug: false
+owner: ~
+url: "http://example.com/#top"
+quote: 'it''s'
+path: C:/temp
+tags: [a, "b c", {k: v}]
+env:
+- name: A
+  value: '1'
+-
+  name: B
+nested:
+  - - x
+    - y
+script: |
+  echo one
+
+  echo two
+summary: >-
+  folded
+  text
+...
+`
+	got, pos, err := decodeYAML([]byte(doc))
+	if err != nil {
+		t.Fatalf("decodeYAML: %v", err)
+	}
+	want := map[string]any{
+		"name":    "api",
+		"port":    json.Number("8080"),
+		"ratio":   json.Number("1.5"),
+		"debug":   false,
+		"owner":   nil,
+		"url":     "http://example.com/#top",
+		"quote":   "it's",
+		"path":    "C:/temp",
+		"tags":    []any{"a", "b c", map[string]any{"k": "v"}},
+		"env":     []any{map[string]any{"name": "A", "value": "1"}, map[string]any{"name": "B"}},
+		"nested":  []any{[]any{"x", "y"}},
+		"script":  "echo one\n\necho two\n",
+		"summary": "folded text",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("decoded = %#v\nwant %#v", got, want)
+	}
+	for path, p := range map[string]yamlPos{
+		"/name":       {4, 1},
+		"/tags/1":     {12, 11},
+		"/tags/2/k":   {12, 19},
+		"/env/0/name": {14, 3},
+		"/env/1/name": {17, 3},
+		"/nested/0/1": {20, 7},
+	} {
+		if pos[path] != p {
+			t.Errorf("pos[%q] = %v, want %v", path, pos[path], p)
+		}
+	}
+}
+
+func TestDecodeYAML_RejectsUnsupported(t *testing.T) {
+	for doc, want := range map[string]string{
+		"a: 1\na: 2":              `line 2: duplicate key "a"`,
+		"a: &x 1\nb: *x":          "line 1: anchors (&) are not supported",
+		"a: *x":                   "line 1: aliases (*) are not supported",
+		"base: &b\n  x: 1":        "line 1: anchors (&) are not supported",
+		"- &b\n  x: 1":            "line 1: anchors (&) are not supported",
+		"a:\n  <<: *base\n  x: 1": "line 2: merge keys (<<) are not supported",
+		"a: !!str 1":              "line 1: tags (!) are not supported",
+		"? a\n: 1":                "line 1: complex keys are not supported",
+		"a: 1\n---\nb: 2":         "line 2: multiple documents are not supported",
+		"a: [1,\n  2]":            "line 1: multi-line flow collections are not supported",
+		"a: {b: 1,\n  c: 2}":      "line 1: multi-line flow collections are not supported",
+		"a: first\n  second":      "line 2: multi-line plain scalars are not supported",
+		"a: \"first\n  second\"":  "line 2: multi-line quoted scalars are not supported",
+		"a: \"first\"\n  b: 1":    "line 2: unexpected indentation",
+		"a:\n\tb: 1":              "line 2: tabs are not allowed in indentation",
+		"a: 1\n   b: 2":           "line 2: multi-line plain scalars are not supported",
+		"a: 'open":                "line 1: unterminated quoted scalar",
+	} {
+		_, _, err := decodeYAML([]byte(doc))
+		if err == nil || err.Error() != "yaml: "+want {
+			t.Errorf("%q: error = %v, want yaml: %s", doc, err, want)
+		}
+	}
+}
+
+func TestSchemaValidateYAML_ReportsPositions(t *testing.T) {
+	s, err := New().CompileSchema(map[string]string{
+		"name":          "string;required;min=3",
+		"port":          "int;min=1024",
+		"env[].name":    "string;required",
+		"limits.memory": "string;required",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = s.ValidateYAML([]byte(`name: ab
+port: 80
+env:
+  - name: A
+  - value: x
+limits:
+  cpu: 1
+`))
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("got %T %v, want errors.Errors", err, err)
+	}
+	got := map[string][2]int{}
+	for _, fe := range es {
+		got[fe.Path+" "+fe.Code] = [2]int{fe.Line, fe.Column}
+	}
+	want := map[string][2]int{
+		"/name " + verrs.CodeStringMin:         {1, 1},
+		"/port " + verrs.CodeIntMin:            {2, 1},
+		"/env/1/name " + verrs.CodeRequired:    {5, 5},
+		"/limits/memory " + verrs.CodeRequired: {6, 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %v, want %v", got, want)
+	}
+	if s := es[0].String(); !strings.Contains(s, "(") {
+		t.Fatalf("String() = %q, want position", s)
+	}
+
+	if err := s.ValidateYAML([]byte("name: abc\nport: 8080\nlimits: {memory: 1Gi}\n")); err != nil {
+		t.Fatalf("valid document: %v", err)
+	}
+	if err := s.ValidateYAML([]byte("name: [abc")); err == nil || !strings.Contains(err.Error(), "decode YAML") {
+		t.Fatalf("malformed document: %v", err)
+	}
+}
@@ -40,22 +40,7 @@ nested inside maps/slices). This is used to decide whether to skip
 caching, because function pointer addresses are not deterministic.
 */
 func HasFuncArgs(rules []types.Rule) bool {
-	for _, r := range rules {
-		if ruleHasFunc(r) {
-			return true
-		}
-	}
-	return false
-}
-
-func ruleHasFunc(r types.Rule) bool {
-	if argHasFunc(r.Args) {
-		return true
-	}
-	if r.Elem != nil {
-		return ruleHasFunc(*r.Elem)
-	}
-	return false
+	return types.HasFuncArgs(rules)
 }
 
 func serializeRule(b *strings.Builder, r types.Rule) {
@@ -86,50 +71,12 @@ func serializeRule(b *strings.Builder, r types.Rule) {
 		serializeRule(b, *r.Elem)
 	}
 
-	b.WriteByte('}')
-}
-
-func argHasFunc(v any) bool {
-	if v == nil {
-		return false
-	}
-	rv := reflect.ValueOf(v)
-	switch rv.Kind() {
-	case reflect.Func:
-		return true
-	case reflect.Pointer, reflect.Interface:
-		if rv.IsNil() {
-			return false
-		}
-		return argHasFunc(rv.Elem().Interface())
-	case reflect.Slice, reflect.Array:
-		for i := 0; i < rv.Len(); i++ {
-			if argHasFunc(rv.Index(i).Interface()) {
-				return true
-			}
-		}
-		return false
-	case reflect.Map:
-		iter := rv.MapRange()
-		for iter.Next() {
-			if argHasFunc(iter.Value().Interface()) {
-				return true
-			}
-		}
-		return false
-	case reflect.Struct:
-		// Best-effort: iterate exported fields only.
-		rt := rv.Type()
-		for i := 0; i < rv.NumField(); i++ {
-			if rt.Field(i).IsExported() &&
-				argHasFunc(rv.Field(i).Interface()) {
-				return true
-			}
-		}
-		return false
-	default:
-		return false
+	if r.Msg != "" {
+		b.WriteString(",msg:")
+		b.WriteString(strconv.Quote(r.Msg))
 	}
+
+	b.WriteByte('}')
 }
 
 // serializeArg emits a deterministic representation of a rule argument.
@@ -174,6 +121,8 @@ func serializeArg(b *strings.Builder, v any) {
 		b.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
 	case time.Time:
 		b.WriteString(strconv.Quote(x.UTC().Format(time.RFC3339Nano)))
+	case types.RelativeTime:
+		b.WriteString(strconv.Quote(x.String()))
 
 	case []string:
 		cp := append([]string(nil), x...)
@@ -156,3 +156,12 @@ func TestSerializeRules_IncludesElemAndDetectsElemFunctions(t *testing.T) {
 		t.Fatalf("HasFuncArgs did not inspect nested Elem rule")
 	}
 }
+
+func TestSerializeRules_IncludesMessageOverride(t *testing.T) {
+	plain := types.NewRule(types.KMinLength, map[string]any{"n": 3})
+	custom := plain
+	custom.Msg = "too short"
+	if SerializeRules([]types.Rule{plain}) == SerializeRules([]types.Rule{custom}) {
+		t.Fatal("SerializeRules ignores message overrides")
+	}
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestWithShadowRule_ReportsWithoutFailing(t *testing.T) {
+	var mu sync.Mutex
+	var got []types.ShadowFailure
+	hook := func(f types.ShadowFailure) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, f)
+	}
+
+	base := New()
+	v := base.WithShadowRule(types.KMaxLength, 1).WithShadowHook(hook)
+
+	fn, err := v.FromRules([]string{"string;min=2;max=3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn("too long"); err != nil {
+		t.Fatalf("shadow rule must not fail validation: %v", err)
+	}
+	if len(got) != 1 || got[0].Kind != types.KMaxLength {
+		t.Fatalf("shadow failures = %#v", got)
+	}
+	var es verrs.Errors
+	if !errors.As(got[0].Err, &es) || es[0].Code != verrs.CodeStringMax {
+		t.Fatalf("shadow error = %v", got[0].Err)
+	}
+
+	// Enforced rules still fail.
+	if err := fn("x"); err == nil {
+		t.Fatalf("enforced min rule should fail")
+	}
+
+	ctxFn, err := v.FromRulesContext([]string{"string;max=3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctxFn(context.Background(), "too long"); err != nil {
+		t.Fatalf("context shadow rule must not fail validation: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("context shadow failure not reported: %#v", got)
+	}
+
+	// The base engine keeps enforcing the rule.
+	baseFn, _ := base.FromRules([]string{"string;max=3"})
+	if err := baseFn("too long"); err == nil {
+		t.Fatalf("base engine must enforce max")
+	}
+}
+
+func TestWithShadowRule_SampleRateZeroSkipsRule(t *testing.T) {
+	calls := 0
+	v := New().WithShadowRule(types.KMaxLength, 0).WithShadowHook(func(types.ShadowFailure) { calls++ })
+	fn := v.CompileRules([]types.Rule{types.NewRule(types.KString, nil), types.NewRule(types.KMaxLength, map[string]any{"n": 1})})
+	if err := fn("long"); err != nil {
+		t.Fatalf("unsampled shadow rule failed validation: %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("unsampled shadow rule reported %d failures", calls)
+	}
+}
+
+func TestWithCompileHook(t *testing.T) {
+	base := New()
+	banned := base.WithCompileHook(types.KRegex, func(types.Rule, func() error) error {
+		return errors.New("no regex here")
+	})
+	if _, err := banned.FromRules([]string{"string;regex=^a$"}); err == nil {
+		t.Fatal("hook did not reject regex rule")
+	}
+	if _, err := base.FromRules([]string{"string;regex=^a$"}); err != nil {
+		t.Fatalf("base engine affected: %v", err)
+	}
+	if !banned.AltersBuiltinRules() || base.AltersBuiltinRules() {
+		t.Fatal("AltersBuiltinRules ignores compile hooks")
+	}
+}
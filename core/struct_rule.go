@@ -17,6 +17,8 @@ type StructRuleContext struct {
 	Rule       types.Rule
 	Context    context.Context
 	Translator translator.Translator
+	// Accumulator is shared by every rule of one struct validation call.
+	Accumulator *Accumulator
 }
 
 // FieldValue returns an exported same-level field value by Go field name.
@@ -0,0 +1,632 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// yamlPos is a 1-based source position.
+type yamlPos struct {
+	line, column int
+}
+
+// yamlLine is a significant line: its indentation and text with the
+// comment and trailing blanks removed.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlDecoder decodes the block YAML subset used by configuration files:
+// mappings, sequences, flow collections on one line, single-line plain and
+// quoted scalars, block scalars, and comments. Anchors, aliases, merge
+// keys, tags, complex keys, multi-line plain, quoted and flow nodes, and
+// multiple documents are rejected with an error naming the construct.
+// Mappings decode to map[string]any, sequences to []any, and numbers to
+// json.Number, as in ValidateJSON.
+//
+// The positions of mapping keys and sequence items are recorded by JSON
+// Pointer path.
+type yamlDecoder struct {
+	raw []string
+	i   int       // index of the next raw line
+	cur *yamlLine // current significant line, if peeked or rewritten
+	pos map[string]yamlPos
+}
+
+// decodeYAML decodes one YAML document and the positions of its nodes.
+func decodeYAML(data []byte) (any, map[string]yamlPos, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+	d := &yamlDecoder{raw: strings.Split(text, "\n"), pos: map[string]yamlPos{}}
+	if err := d.skipDirectives(); err != nil {
+		return nil, nil, err
+	}
+	l, ok, err := d.peek()
+	if err != nil || !ok {
+		return nil, d.pos, err
+	}
+	value, err := d.node(l.indent, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if l, ok, err := d.peek(); err != nil {
+		return nil, nil, err
+	} else if ok {
+		return nil, nil, d.errorf(l, "unexpected indentation")
+	}
+	return value, d.pos, nil
+}
+
+func (d *yamlDecoder) errorf(l yamlLine, format string, args ...any) error {
+	return fmt.Errorf("yaml: line %d: %s", l.num, fmt.Sprintf(format, args...))
+}
+
+// skipDirectives skips directives and the document start marker.
+func (d *yamlDecoder) skipDirectives() error {
+	for ; d.i < len(d.raw); d.i++ {
+		line := d.raw[d.i]
+		switch {
+		case strings.HasPrefix(line, "%"):
+		case line == "---" || strings.HasPrefix(line, "--- "):
+			if rest := strings.TrimSpace(stripYAMLComment(line[3:])); rest != "" {
+				d.raw[d.i] = rest
+				return nil
+			}
+			d.i++
+			return nil
+		case strings.TrimSpace(stripYAMLComment(line)) == "":
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// peek returns the current significant line without consuming it.
+func (d *yamlDecoder) peek() (yamlLine, bool, error) {
+	if d.cur != nil {
+		return *d.cur, true, nil
+	}
+	for ; d.i < len(d.raw); d.i++ {
+		line := d.raw[d.i]
+		content := strings.TrimRight(stripYAMLComment(line), " \t")
+		trimmed := strings.TrimLeft(content, " ")
+		if trimmed == "" {
+			continue
+		}
+		l := yamlLine{num: d.i + 1, indent: len(content) - len(trimmed), text: trimmed}
+		switch {
+		case trimmed[0] == '\t':
+			return yamlLine{}, false, d.errorf(l, "tabs are not allowed in indentation")
+		case l.indent == 0 && (trimmed == "---" || strings.HasPrefix(trimmed, "--- ")):
+			return yamlLine{}, false, d.errorf(l, "multiple documents are not supported")
+		case l.indent == 0 && trimmed == "...":
+			for j := d.i + 1; j < len(d.raw); j++ {
+				if strings.TrimSpace(stripYAMLComment(d.raw[j])) != "" {
+					return yamlLine{}, false, d.errorf(yamlLine{num: j + 1}, "multiple documents are not supported")
+				}
+			}
+			d.i = len(d.raw)
+			return yamlLine{}, false, nil
+		}
+		d.cur = &l
+		return l, true, nil
+	}
+	return yamlLine{}, false, nil
+}
+
+// advance consumes the current significant line.
+func (d *yamlDecoder) advance() {
+	d.cur = nil
+	d.i++
+}
+
+// rewrite replaces the current line with the content after a sequence
+// indicator, so "- name: a" continues as a mapping at the content column.
+func (d *yamlDecoder) rewrite(l yamlLine, offset int) {
+	l.indent += offset
+	l.text = l.text[offset:]
+	d.cur = &l
+}
+
+// node decodes the block node starting at the current line, which is
+// indented by indent.
+func (d *yamlDecoder) node(indent int, path string) (any, error) {
+	l, _, _ := d.peek()
+	if isYAMLSeqItem(l.text) {
+		return d.sequence(indent, path)
+	}
+	if _, _, ok := splitYAMLKey(l.text); ok {
+		return d.mapping(indent, path)
+	}
+	d.advance()
+	if err := d.single(l, l.text, indent); err != nil {
+		return nil, err
+	}
+	return d.inline(l, l.text, l.indent, path)
+}
+
+// single checks that the inline node s on line l, which is indented by
+// indent, does not continue on the following lines, and that it does not
+// start with an anchor, alias or tag. Flow collections report unterminated
+// lines themselves.
+func (d *yamlDecoder) single(l yamlLine, s string, indent int) error {
+	if err := d.properties(l, s); err != nil || s[0] == '[' || s[0] == '{' {
+		return err
+	}
+	next, ok, err := d.peek()
+	if err != nil || !ok || next.indent <= indent {
+		return err
+	}
+	if s[0] == '"' || s[0] == '\'' {
+		if _, _, err := yamlQuoted(s); err == nil {
+			return d.errorf(next, "unexpected indentation")
+		}
+		return d.errorf(next, "multi-line quoted scalars are not supported")
+	}
+	return d.errorf(next, "multi-line plain scalars are not supported")
+}
+
+// properties rejects a node that starts with an anchor, alias or tag.
+func (d *yamlDecoder) properties(l yamlLine, s string) error {
+	switch s[0] {
+	case '&':
+		return d.errorf(l, "anchors (&) are not supported")
+	case '*':
+		return d.errorf(l, "aliases (*) are not supported")
+	case '!':
+		return d.errorf(l, "tags (!) are not supported")
+	}
+	return nil
+}
+
+func (d *yamlDecoder) mapping(indent int, path string) (any, error) {
+	out := map[string]any{}
+	for {
+		l, ok, err := d.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || l.indent < indent {
+			return out, nil
+		}
+		if l.indent > indent {
+			return nil, d.errorf(l, "unexpected indentation")
+		}
+		rawKey, rest, ok := splitYAMLKey(l.text)
+		if !ok {
+			return nil, d.errorf(l, "expected a mapping key")
+		}
+		if rawKey == "<<" {
+			return nil, d.errorf(l, "merge keys (<<) are not supported")
+		}
+		key, err := d.key(l, rawKey)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := out[key]; dup {
+			return nil, d.errorf(l, "duplicate key %q", key)
+		}
+		child := path + "/" + pointerEscape(key)
+		d.pos[child] = yamlPos{l.num, l.indent + 1}
+		column := l.indent + len(l.text) - len(rest) + 1
+		if out[key], err = d.value(l, rest, indent, column, false, child); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *yamlDecoder) sequence(indent int, path string) (any, error) {
+	out := []any{}
+	for {
+		l, ok, err := d.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || l.indent < indent {
+			return out, nil
+		}
+		if l.indent > indent {
+			return nil, d.errorf(l, "unexpected indentation")
+		}
+		if !isYAMLSeqItem(l.text) {
+			return out, nil
+		}
+		child := path + "/" + strconv.Itoa(len(out))
+		rest := strings.TrimLeft(l.text[1:], " ")
+		offset := len(l.text) - len(rest)
+		d.pos[child] = yamlPos{l.num, l.indent + offset + 1}
+		var item any
+		if _, _, isKey := splitYAMLKey(rest); isKey && rest != "" {
+			d.rewrite(l, offset)
+			item, err = d.mapping(l.indent+offset, child)
+		} else if isYAMLSeqItem(rest) {
+			d.rewrite(l, offset)
+			item, err = d.sequence(l.indent+offset, child)
+		} else {
+			item, err = d.value(l, rest, indent, l.indent+offset+1, true, child)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+}
+
+// value decodes the value after a mapping key or sequence indicator on
+// line l: an inline value, a block scalar, or a nested block on the
+// following lines. A mapping value may start a sequence at the key's
+// indentation.
+func (d *yamlDecoder) value(l yamlLine, rest string, indent, column int, inSeq bool, path string) (any, error) {
+	if rest != "" && (rest[0] == '|' || rest[0] == '>') {
+		d.advance()
+		return d.blockScalar(l, rest, indent)
+	}
+	d.advance()
+	if rest != "" {
+		if err := d.single(l, rest, indent); err != nil {
+			return nil, err
+		}
+		return d.inline(l, rest, column-1, path)
+	}
+	next, ok, err := d.peek()
+	if err != nil || !ok {
+		return nil, err
+	}
+	if next.indent > indent || (!inSeq && next.indent == indent && isYAMLSeqItem(next.text)) {
+		return d.node(next.indent, path)
+	}
+	return nil, nil
+}
+
+// inline decodes a scalar or flow collection at column offset start of l.
+func (d *yamlDecoder) inline(l yamlLine, s string, start int, path string) (any, error) {
+	if err := d.properties(l, s); err != nil {
+		return nil, err
+	}
+	switch s[0] {
+	case '?':
+		if len(s) == 1 || s[1] == ' ' {
+			return nil, d.errorf(l, "complex keys are not supported")
+		}
+	case '[', '{':
+		f := &yamlFlow{d: d, l: l, s: s, start: start}
+		v, err := f.value(path)
+		if err != nil {
+			return nil, err
+		}
+		if f.skipSpace(); f.i < len(s) {
+			return nil, d.errorf(l, "unexpected %q after flow collection", s[f.i:])
+		}
+		return v, nil
+	}
+	return d.scalar(l, s)
+}
+
+func (d *yamlDecoder) key(l yamlLine, raw string) (string, error) {
+	if raw == "" {
+		return "", d.errorf(l, "empty mapping key")
+	}
+	switch raw[0] {
+	case '"', '\'':
+		return d.quoted(l, raw)
+	case '&', '*', '!':
+		return "", d.properties(l, raw)
+	case '?', '[', '{':
+		return "", d.errorf(l, "complex keys are not supported")
+	}
+	return raw, nil
+}
+
+// scalar decodes a quoted or plain scalar. Plain scalars resolve to nil,
+// bool, json.Number or float64 (infinities and NaN) like the YAML 1.2 core
+// schema, and to strings otherwise.
+func (d *yamlDecoder) scalar(l yamlLine, s string) (any, error) {
+	if s[0] == '"' || s[0] == '\'' {
+		return d.quoted(l, s)
+	}
+	switch s {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF":
+		return math.Inf(1), nil
+	case "-.inf", "-.Inf", "-.INF":
+		return math.Inf(-1), nil
+	case ".nan", ".NaN", ".NAN":
+		return math.NaN(), nil
+	}
+	if n, ok := yamlNumber(s); ok {
+		return n, nil
+	}
+	return s, nil
+}
+
+func yamlNumber(s string) (json.Number, bool) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0o") {
+		base := 16
+		if s[1] == 'o' {
+			base = 8
+		}
+		if n, err := strconv.ParseUint(s[2:], base, 64); err == nil {
+			return json.Number(strconv.FormatUint(n, 10)), true
+		}
+		return "", false
+	}
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 || digits == "" || !(digits[0] >= '0' && digits[0] <= '9' || digits[0] == '.') {
+		return "", false
+	}
+	for _, r := range digits {
+		if !(r >= '0' && r <= '9' || r == '.' || r == 'e' || r == 'E' || r == '+' || r == '-') {
+			return "", false
+		}
+	}
+	if _, err := strconv.ParseFloat(s, 64); err != nil {
+		return "", false
+	}
+	return json.Number(strings.TrimPrefix(s, "+")), true
+}
+
+// quoted decodes s, which must be exactly one quoted scalar.
+func (d *yamlDecoder) quoted(l yamlLine, s string) (string, error) {
+	v, n, err := yamlQuoted(s)
+	if err != nil {
+		return "", d.errorf(l, "%v", err)
+	}
+	if n != len(s) {
+		return "", d.errorf(l, "unexpected %q after quoted scalar", s[n:])
+	}
+	return v, nil
+}
+
+// yamlQuoted decodes the quoted scalar at the start of s and returns its
+// length in s.
+func yamlQuoted(s string) (string, int, error) {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case s[i] == q && q == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == q:
+			body := s[1:i]
+			if q == '\'' {
+				return strings.ReplaceAll(body, "''", "'"), i + 1, nil
+			}
+			v, err := strconv.Unquote(`"` + strings.ReplaceAll(body, `\/`, "/") + `"`)
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid escape in %s", s[:i+1])
+			}
+			return v, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted scalar")
+}
+
+// blockScalar decodes a literal (|) or folded (>) scalar whose header is
+// on line l and whose content is indented more than indent.
+func (d *yamlDecoder) blockScalar(l yamlLine, header string, indent int) (any, error) {
+	folded := header[0] == '>'
+	chomp, explicit := byte(0), 0
+	for _, c := range []byte(header[1:]) {
+		switch {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = c
+		case c >= '1' && c <= '9' && explicit == 0:
+			explicit = int(c - '0')
+		default:
+			return nil, d.errorf(l, "invalid block scalar header %q", header)
+		}
+	}
+	blockIndent := 0
+	if explicit > 0 {
+		blockIndent = indent + explicit
+	}
+	var lines []string
+	for ; d.i < len(d.raw); d.i++ {
+		line := strings.TrimRight(d.raw[d.i], " \t")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" {
+			lines = append(lines, "")
+			continue
+		}
+		n := len(line) - len(trimmed)
+		if blockIndent == 0 {
+			if n <= indent {
+				break
+			}
+			blockIndent = n
+		}
+		if n < blockIndent {
+			break
+		}
+		lines = append(lines, line[blockIndent:])
+	}
+	end := len(lines)
+	for end > 0 && lines[end-1] == "" {
+		end--
+	}
+	var b strings.Builder
+	for i, line := range lines[:end] {
+		switch {
+		case i == 0:
+		case folded && line != "" && lines[i-1] != "" && line[0] != ' ' && lines[i-1][0] != ' ':
+			b.WriteByte(' ')
+		case folded && line == "" && i+1 < end && lines[i-1] != "":
+			// A blank line between folded lines is the line break itself.
+		default:
+			b.WriteByte('\n')
+		}
+		b.WriteString(line)
+	}
+	switch {
+	case end == 0:
+	case chomp == '+':
+		b.WriteString(strings.Repeat("\n", len(lines)-end+1))
+	case chomp == 0:
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// yamlFlow decodes a flow collection on a single line.
+type yamlFlow struct {
+	d     *yamlDecoder
+	l     yamlLine
+	s     string
+	i     int
+	start int // column offset of s in the line
+}
+
+func (f *yamlFlow) errorf(format string, args ...any) error {
+	return f.d.errorf(f.l, format, args...)
+}
+
+func (f *yamlFlow) skipSpace() {
+	for f.i < len(f.s) && f.s[f.i] == ' ' {
+		f.i++
+	}
+}
+
+func (f *yamlFlow) value(path string) (any, error) {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return nil, f.errorf("multi-line flow collections are not supported")
+	}
+	f.d.pos[path] = yamlPos{f.l.num, f.start + f.i + 1}
+	switch f.s[f.i] {
+	case '[':
+		return f.collection(']', path)
+	case '{':
+		return f.collection('}', path)
+	case '"', '\'':
+		v, n, err := yamlQuoted(f.s[f.i:])
+		if err != nil {
+			return nil, f.errorf("%v", err)
+		}
+		f.i += n
+		return v, nil
+	}
+	j := f.i
+	for j < len(f.s) && !strings.ContainsRune(",]}", rune(f.s[j])) && !(f.s[j] == ':' && (j+1 == len(f.s) || strings.ContainsRune(" ,]}", rune(f.s[j+1])))) {
+		j++
+	}
+	plain := strings.TrimRight(f.s[f.i:j], " ")
+	f.i = j
+	if plain == "" {
+		return nil, nil
+	}
+	return f.d.inline(f.l, plain, f.start, path)
+}
+
+// collection decodes a flow sequence or mapping closed by end.
+func (f *yamlFlow) collection(end byte, path string) (any, error) {
+	f.i++
+	var seq []any
+	m := map[string]any{}
+	for {
+		f.skipSpace()
+		if f.i >= len(f.s) {
+			return nil, f.errorf("multi-line flow collections are not supported")
+		}
+		if f.s[f.i] == end {
+			f.i++
+			if end == ']' {
+				if seq == nil {
+					seq = []any{}
+				}
+				return seq, nil
+			}
+			return m, nil
+		}
+		if end == ']' {
+			v, err := f.value(path + "/" + strconv.Itoa(len(seq)))
+			if err != nil {
+				return nil, err
+			}
+			seq = append(seq, v)
+		} else {
+			keyPos := f.i
+			k, err := f.value(path + "/~key")
+			if err != nil {
+				return nil, err
+			}
+			delete(f.d.pos, path+"/~key")
+			key := fmt.Sprint(k)
+			if k == nil {
+				key = ""
+			}
+			if _, dup := m[key]; dup {
+				return nil, f.errorf("duplicate key %q", key)
+			}
+			child := path + "/" + pointerEscape(key)
+			var v any
+			if f.skipSpace(); f.i < len(f.s) && f.s[f.i] == ':' {
+				f.i++
+				if v, err = f.value(child); err != nil {
+					return nil, err
+				}
+			}
+			f.d.pos[child] = yamlPos{f.l.num, f.start + keyPos + 1}
+			m[key] = v
+		}
+		f.skipSpace()
+		if f.i < len(f.s) && f.s[f.i] == ',' {
+			f.i++
+		} else if f.i < len(f.s) && f.s[f.i] != end {
+			return nil, f.errorf("expected ',' or %q in flow collection", end)
+		}
+	}
+}
+
+func isYAMLSeqItem(s string) bool {
+	return s == "-" || strings.HasPrefix(s, "- ")
+}
+
+// splitYAMLKey splits "key: value" at the first ": " or trailing ":"
+// outside quotes. Flow collections and quoted scalars without a following
+// colon are not keys.
+func splitYAMLKey(s string) (key, rest string, ok bool) {
+	i := 0
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		_, n, err := yamlQuoted(s)
+		if err != nil {
+			return "", "", false
+		}
+		i = n
+	} else if s == "" || s[0] == '[' || s[0] == '{' || isYAMLSeqItem(s) {
+		return "", "", false
+	}
+	for ; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return strings.TrimRight(s[:i], " "), strings.TrimLeft(s[i+1:], " "), true
+		}
+	}
+	return "", "", false
+}
+
+// stripYAMLComment removes a comment that starts at "#" preceded by
+// whitespace or at the start of the line, outside quoted scalars.
+func stripYAMLComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		case (c == '"' || c == '\'') && (i == 0 || strings.ContainsRune(" \t[{,:-", rune(line[i-1]))):
+			if _, n, err := yamlQuoted(line[i:]); err == nil {
+				i += n - 1
+			}
+		}
+	}
+	return line
+}
@@ -2,15 +2,28 @@ package errors
 
 const (
 	// Generic
-	CodeUnknown        = "unknown"
-	CodeRequired       = "required"
-	CodeRequiredWith   = "required.with"
-	CodeRequiredIf     = "required.if"
-	CodeRequiredUnless = "required.unless"
-	CodeOmitEmpty      = "omitempty" // informational when skipped
-	CodeFieldEqual     = "field.eq"
-	CodeFieldNotEqual  = "field.ne"
-	CodeFieldReference = "field.reference"
+	CodeUnknown         = "unknown"
+	CodeRequired        = "required"
+	CodeRequiredWith    = "required.with"
+	CodeRequiredIf      = "required.if"
+	CodeRequiredUnless  = "required.unless"
+	CodeOmitEmpty       = "omitempty" // informational when skipped
+	CodeValueNil        = "value.nil"
+	CodeFieldEqual      = "field.eq"
+	CodeFieldNotEqual   = "field.ne"
+	CodeFieldReference  = "field.reference"
+	CodeFieldReadOnly   = "field.readonly"
+	CodeFieldWriteOnly  = "field.writeonly"
+	CodeQuotaExceeded   = "quota.exceeded"
+	CodeRuleUnavailable = "rule.unavailable"
+	CodeTimeout         = "validation.timeout"
+
+	// Request body
+	CodeBodySize   = "body.size"
+	CodeBodyDepth  = "body.depth"
+	CodeBodyValues = "body.values"
+	CodeBodySyntax = "body.syntax"
+	CodeBodyType   = "body.type"
 
 	// String
 	CodeStringType                = "string.type"
@@ -24,6 +37,7 @@ const (
 	CodeStringSuffix              = "string.suffix"
 	CodeStringContains            = "string.contains"
 	CodeStringNotContains         = "string.notContains"
+	CodeStringExcludesAll         = "string.excludesAll"
 	CodeStringURL                 = "string.url"
 	CodeStringHost                = "string.hostname"
 	CodeStringIP                  = "string.ip"
@@ -31,6 +45,13 @@ const (
 	CodeStringASCII               = "string.ascii"
 	CodeStringAlpha               = "string.alpha"
 	CodeStringAlnum               = "string.alnum"
+	CodeStringAlphaUnicode        = "string.alphaUnicode"
+	CodeStringAlnumUnicode        = "string.alnumUnicode"
+	CodeStringNumeric             = "string.numeric"
+	CodeStringPrintASCII          = "string.printAscii"
+	CodeStringNumStr              = "string.numStr"
+	CodeStringNumStrMin           = "string.numStr.min"
+	CodeStringNumStrMax           = "string.numStr.max"
 	CodeStringRegexInvalidPattern = "string.regex.invalidPattern"
 	CodeStringRegexInputTooLong   = "string.regex.inputTooLong"
 	CodeStringRegexNoMatch        = "string.regex.noMatch"
@@ -57,16 +78,24 @@ const (
 	CodeNumberType             = "number.type"
 	CodeIntMin                 = "int.min"
 	CodeIntMax                 = "int.max"
+	CodeUintType               = "uint.type"
+	CodeUint64Type             = "uint64.type"
+	CodeUintMin                = "uint.min"
+	CodeUintMax                = "uint.max"
+	CodeIntMultipleOf          = "int.multipleOf"
 	CodeNumberMin              = "number.min"
 	CodeNumberMax              = "number.max"
 	CodeNumberPositive         = "number.positive"
 	CodeNumberNonNeg           = "number.nonnegative"
+	CodeNumberNegative         = "number.negative"
+	CodeNumberNonPos           = "number.nonpositive"
 	CodeNumberBetween          = "number.between"
 	CodeNumberGreaterThan      = "number.gt"
 	CodeNumberGreaterThanEqual = "number.gte"
 	CodeNumberLessThan         = "number.lt"
 	CodeNumberLessThanEqual    = "number.lte"
 	CodeNumberFinite           = "number.finite"
+	CodeNumberMultipleOf       = "number.multipleOf"
 	CodeFloatType              = "float.type"
 
 	// Slice
@@ -99,6 +128,7 @@ const (
 	CodeBoolType  = "bool.type"
 	CodeBoolTrue  = "bool.true"
 	CodeBoolFalse = "bool.false"
+	CodeBoolParse = "bool.parse"
 
 	// Time
 	CodeTimeType    = "time.type"
@@ -106,4 +136,11 @@ const (
 	CodeTimeBefore  = "time.before"
 	CodeTimeAfter   = "time.after"
 	CodeTimeBetween = "time.between"
+	CodeTimeMin     = "time.min"
+	CodeTimeMax     = "time.max"
+	CodeTimeParse   = "time.parse"
+
+	// Enum
+	CodeEnumValue = "enum.value"
+	CodeEnumName  = "enum.name"
 )
@@ -17,6 +17,8 @@ import (
 //   - Code: Stable machine-readable identifier (e.g., "string.min", "int.max").
 //   - Param: Rule parameter (e.g., 3 for min length).
 //   - Msg: Translated, human-readable message if a Translator is set.
+//   - Sensitive: Whether the field is tagged sensitive; see Errors.Redact.
+//   - Value: The offending value, only with ValidateOpts.IncludeValues.
 type FieldError struct {
 	Path string `json:"path"`
 	// Code is a stable machine-readable identifier, e.g. "string.min",
@@ -26,21 +28,40 @@ type FieldError struct {
 	Param any `json:"param,omitempty"`
 	// Msg is the translated, human-readable message if a Translator is set.
 	Msg string `json:"message,omitempty"`
+	// Sensitive marks errors of fields tagged `sensitive`. Redact strips
+	// their Msg and Param.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Value is the offending value, truncated, when requested with
+	// ValidateOpts.IncludeValues. It is never set for sensitive fields.
+	Value any `json:"value,omitempty"`
+	// Line and Column locate the field in a source document, 1-based,
+	// when the validator knows it, e.g. for Schema.ValidateYAML.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
 }
 
-// String returns a concise string for logs.
+// String returns a concise string for logs, truncated according to
+// CurrentTruncationPolicy.
 //
 // Returns:
 //   - string: A formatted string representation of the field error.
 func (e FieldError) String() string {
+	e = CurrentTruncationPolicy().Apply(e)
 	p := ""
 	if e.Param != nil {
 		p = fmt.Sprintf(" param=%v", e.Param)
 	}
+	if e.Value != nil {
+		p += fmt.Sprintf(" value=%q", fmt.Sprint(e.Value))
+	}
+	path := e.Path
+	if e.Line > 0 {
+		path = fmt.Sprintf("%s (%d:%d)", path, e.Line, e.Column)
+	}
 	if e.Msg != "" {
-		return fmt.Sprintf("%s [%s]%s: %s", e.Path, e.Code, p, e.Msg)
+		return fmt.Sprintf("%s [%s]%s: %s", path, e.Code, p, e.Msg)
 	}
-	return fmt.Sprintf("%s [%s]%s", e.Path, e.Code, p)
+	return fmt.Sprintf("%s [%s]%s", path, e.Code, p)
 }
 
 // Errors is a collection of FieldError that implements error.
@@ -104,6 +125,26 @@ func (es Errors) Filter(prefix string) Errors {
 	return out
 }
 
+// Redact returns a copy in which sensitive errors keep only Path, Code and
+// Sensitive, so the result is safe to log.
+//
+// Returns:
+//   - Errors: A new Errors collection with Msg, Param and Value of sensitive
+//     errors cleared.
+func (es Errors) Redact() Errors {
+	if es == nil {
+		return nil
+	}
+	out := make(Errors, len(es))
+	for i, e := range es {
+		if e.Sensitive {
+			e.Msg, e.Param, e.Value = "", nil, nil
+		}
+		out[i] = e
+	}
+	return out
+}
+
 // AsMap groups errors by exact field path. The slice per key preserves
 // original order (stable).
 //
@@ -118,7 +159,8 @@ func (es Errors) AsMap() map[string][]FieldError {
 	return m
 }
 
-// MarshalJSON ensures deterministic key ordering for better diffs.
+// MarshalJSON ensures deterministic key ordering for better diffs and
+// truncates strings according to CurrentTruncationPolicy.
 //
 // Returns:
 //   - []byte: JSON representation of the errors.
@@ -128,9 +170,10 @@ func (es Errors) MarshalJSON() ([]byte, error) {
 		return []byte("[]"), nil
 	}
 	type fe FieldError
+	policy := CurrentTruncationPolicy()
 	cp := make([]fe, len(es))
 	for i := range es {
-		cp[i] = fe(es[i])
+		cp[i] = fe(policy.Apply(es[i]))
 	}
 	// No custom order within fields, but we can keep stable overall.
 	return json.Marshal(cp)
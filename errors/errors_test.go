@@ -3,6 +3,7 @@ package errors
 import (
 	"encoding/json"
 	stderr "errors"
+	"strings"
 	"testing"
 )
 
@@ -69,6 +70,26 @@ func TestErrors_ErrorJoin_Unwrap_JSON(t *testing.T) {
 	}
 }
 
+func TestErrors_Redact(t *testing.T) {
+	es := Errors{
+		{Path: "ssn", Code: CodeStringMin, Param: 9, Msg: "too short", Sensitive: true},
+		{Path: "name", Code: CodeStringMin, Param: 3, Msg: "too short"},
+	}
+	got := es.Redact()
+	if got[0].Msg != "" || got[0].Param != nil || !got[0].Sensitive || got[0].Code != CodeStringMin {
+		t.Fatalf("sensitive error not redacted: %#v", got[0])
+	}
+	if got[1] != es[1] {
+		t.Fatalf("non-sensitive error changed: %#v", got[1])
+	}
+	if es[0].Msg == "" {
+		t.Fatalf("Redact modified the receiver")
+	}
+	if Errors(nil).Redact() != nil {
+		t.Fatalf("nil Redact must stay nil")
+	}
+}
+
 func sameCore(a, b Errors) bool {
 	if len(a) != len(b) {
 		return false
@@ -93,3 +114,47 @@ func indexOf(s, sub string) int {
 	}
 	return -1
 }
+
+func TestFieldError_StringWithValue(t *testing.T) {
+	e := FieldError{Path: "Age", Code: "int.min", Param: 18, Value: 12}
+	if got := e.String(); got != `Age [int.min] param=18 value="12"` {
+		t.Fatalf("String() = %q", got)
+	}
+}
+
+func TestErrors_TruncationPolicy(t *testing.T) {
+	huge := strings.Repeat("x", 10000)
+	es := Errors{{Path: "A", Code: CodeUnknown, Param: huge, Msg: huge, Value: []string{huge}}}
+
+	p := CurrentTruncationPolicy()
+	if p.MaxMsgLen != DefaultMaxMsgLen || p.MaxParamLen != DefaultMaxParamLen {
+		t.Fatalf("default policy = %+v", p)
+	}
+	if got := len(es.Error()); got > DefaultMaxMsgLen+2*DefaultMaxParamLen+64 {
+		t.Fatalf("Error() length = %d, want truncated", got)
+	}
+	b, err := json.Marshal(es)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if len(b) > DefaultMaxMsgLen+2*DefaultMaxParamLen+128 {
+		t.Fatalf("JSON length = %d, want truncated", len(b))
+	}
+	if len(es[0].Msg) != len(huge) {
+		t.Fatal("serialization modified the receiver")
+	}
+
+	short := es.Truncate(TruncationPolicy{MaxMsgLen: 4, MaxParamLen: 3})
+	if short[0].Msg != "xxxx..." || short[0].Param != "xxx..." || short[0].Value != "[xx..." {
+		t.Fatalf("Truncate = %#v", short[0])
+	}
+	if got := truncateString("ééé", 3); got != "é..." {
+		t.Fatalf("truncateString cut a rune: %q", got)
+	}
+
+	SetTruncationPolicy(TruncationPolicy{})
+	defer SetTruncationPolicy(TruncationPolicy{MaxMsgLen: DefaultMaxMsgLen, MaxParamLen: DefaultMaxParamLen})
+	if !strings.Contains(es.Error(), huge) {
+		t.Fatal("zero policy must disable truncation")
+	}
+}
@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ProblemContentType is the media type of ProblemDetails responses.
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is an RFC 7807 problem document for validation errors,
+// with the field errors in the "errors" extension member. It encodes with
+// encoding/json; writing the response is up to the caller, or to
+// WriteProblem in the separate validatehttp module.
+//
+// Fields:
+//   - Type: URI identifying the problem type; "about:blank" by default.
+//   - Title: Short summary; the status text for "about:blank".
+//   - Status: HTTP status code.
+//   - Detail: Explanation of this occurrence.
+//   - Instance: Optional URI of this occurrence, such as the request path.
+//   - Errors: One entry per field error.
+type ProblemDetails struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []ProblemError `json:"errors"`
+}
+
+// ProblemError is one field error of ProblemDetails.
+//
+// Fields:
+//   - Path: The field path.
+//   - Code: The stable error code.
+//   - Param: The rule parameter, if any.
+//   - Message: The human-readable message, if any.
+type ProblemError struct {
+	Path    string `json:"path"`
+	Code    string `json:"code"`
+	Param   any    `json:"param,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ToProblemDetails converts es to an RFC 7807 problem document. Errors of
+// sensitive fields are redacted and strings are truncated according to
+// CurrentTruncationPolicy, as the document is meant for clients.
+//
+// Parameters:
+//   - status: The HTTP status code, such as 400 or 422.
+//
+// Returns:
+//   - ProblemDetails: A document of type "about:blank" titled with the
+//     status text; set Type, Title or Instance on it to customize.
+func (es Errors) ToProblemDetails(status int) ProblemDetails {
+	p := ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Errors: make([]ProblemError, 0, len(es)),
+	}
+	switch len(es) {
+	case 0:
+	case 1:
+		p.Detail = "1 validation error"
+	default:
+		p.Detail = fmt.Sprintf("%d validation errors", len(es))
+	}
+	policy := CurrentTruncationPolicy()
+	for _, e := range es.Redact() {
+		e = policy.Apply(e)
+		p.Errors = append(p.Errors, ProblemError{Path: e.Path, Code: e.Code, Param: e.Param, Message: e.Msg})
+	}
+	return p
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestErrors_ToProblemDetails(t *testing.T) {
+	es := Errors{
+		{Path: "email", Code: "string.email.invalid", Msg: "invalid email"},
+		{Path: "password", Code: "string.min", Param: 12, Msg: "minimum length is 12", Sensitive: true, Value: "short"},
+	}
+	p := es.ToProblemDetails(http.StatusUnprocessableEntity)
+	p.Instance = "/signup"
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"2 validation errors","instance":"/signup","errors":[{"path":"email","code":"string.email.invalid","message":"invalid email"},{"path":"password","code":"string.min"}]}`
+	if string(body) != want {
+		t.Fatalf("body = %s\nwant  %s", body, want)
+	}
+}
+
+func TestErrors_ToProblemDetails_EmptyAndDefaultStatus(t *testing.T) {
+	p := Errors(nil).ToProblemDetails(0)
+	if p.Detail != "" || p.Errors == nil || len(p.Errors) != 0 {
+		t.Fatalf("problem = %+v", p)
+	}
+	if p.Status != 0 || p.Title != "" {
+		t.Fatalf("zero status = %d %q", p.Status, p.Title)
+	}
+	if p := (Errors{{Path: "a", Code: "required"}}).ToProblemDetails(400); p.Detail != "1 validation error" || p.Title != "Bad Request" {
+		t.Fatalf("problem = %+v", p)
+	}
+}
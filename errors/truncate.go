@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Default limits of the truncation policy.
+const (
+	DefaultMaxMsgLen   = 512
+	DefaultMaxParamLen = 128
+)
+
+// TruncationPolicy caps string lengths when errors are serialized, so
+// adversarial giant inputs cannot blow up log lines or API responses.
+// Truncated strings end in "...", like parser error messages.
+//
+// Fields:
+//   - MaxMsgLen: Maximum bytes of Msg; 0 or less disables the limit.
+//   - MaxParamLen: Maximum bytes of Param and Value. Strings are cut and
+//     other values longer than this when formatted with %v are replaced by
+//     their truncated formatting; 0 or less disables the limit.
+type TruncationPolicy struct {
+	MaxMsgLen   int
+	MaxParamLen int
+}
+
+var truncationPolicy atomic.Pointer[TruncationPolicy]
+
+// SetTruncationPolicy sets the policy FieldError.String, Errors.Error and
+// Errors.MarshalJSON apply. It is safe for concurrent use.
+//
+// Parameters:
+//   - p: The new policy; TruncationPolicy{} disables truncation.
+func SetTruncationPolicy(p TruncationPolicy) {
+	truncationPolicy.Store(&p)
+}
+
+// CurrentTruncationPolicy returns the policy set with SetTruncationPolicy,
+// or DefaultMaxMsgLen and DefaultMaxParamLen when none was set.
+func CurrentTruncationPolicy() TruncationPolicy {
+	if p := truncationPolicy.Load(); p != nil {
+		return *p
+	}
+	return TruncationPolicy{MaxMsgLen: DefaultMaxMsgLen, MaxParamLen: DefaultMaxParamLen}
+}
+
+// Apply returns e with Msg, Param and Value truncated.
+func (p TruncationPolicy) Apply(e FieldError) FieldError {
+	e.Msg = truncateString(e.Msg, p.MaxMsgLen)
+	e.Param = truncateAny(e.Param, p.MaxParamLen)
+	e.Value = truncateAny(e.Value, p.MaxParamLen)
+	return e
+}
+
+// Truncate returns a copy of es with p applied to every error.
+//
+// Parameters:
+//   - p: The policy to apply.
+//
+// Returns:
+//   - Errors: A new Errors collection with truncated strings.
+func (es Errors) Truncate(p TruncationPolicy) Errors {
+	if es == nil {
+		return nil
+	}
+	out := make(Errors, len(es))
+	for i, e := range es {
+		out[i] = p.Apply(e)
+	}
+	return out
+}
+
+func truncateAny(v any, maxLen int) any {
+	if v == nil || maxLen <= 0 {
+		return v
+	}
+	if s, ok := v.(string); ok {
+		return truncateString(s, maxLen)
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct, reflect.Ptr, reflect.Interface:
+		if s := fmt.Sprintf("%v", v); len(s) > maxLen {
+			return truncateString(s, maxLen)
+		}
+	}
+	return v
+}
+
+func truncateString(s string, maxLen int) string {
+	if maxLen <= 0 || len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
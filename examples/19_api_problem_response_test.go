@@ -48,7 +48,8 @@ func Test_apiProblemResponse(t *testing.T) {
     },
     {
       "name": "password",
-      "code": "string.min"
+      "code": "string.min",
+      "param": 12
     }
   ]
 }`
@@ -69,7 +70,8 @@ func Test_apiProblemResponse(t *testing.T) {
 	//     },
 	//     {
 	//       "name": "password",
-	//       "code": "string.min"
+	//       "code": "string.min",
+	//       "param": 12
 	//     }
 	//   ]
 	// }
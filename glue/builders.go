@@ -1,6 +1,7 @@
 package glue
 
 import (
+	"strings"
 	"time"
 
 	"github.com/aatuh/validate/v3/core"
@@ -53,6 +54,19 @@ func (b *StringBuilder) Regex(pat string) *StringBuilder {
 	return b
 }
 
+// Enum requires a name registered for the enum with RegisterIntEnum.
+func (b *StringBuilder) Enum(name string) *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KEnum, map[string]any{"name": name}))
+	return b
+}
+
+// Stringer renders fmt.Stringer values with String before the other rules
+// run, to check typed enums with Enum.
+func (b *StringBuilder) Stringer() *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KStringer, nil))
+	return b
+}
+
 func (b *StringBuilder) NonEmpty() *StringBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KNonEmpty, nil))
 	return b
@@ -78,6 +92,18 @@ func (b *StringBuilder) Suffix(value string) *StringBuilder {
 	return b
 }
 
+// StartsWith is an alias of Prefix matching the `startswith=` tag.
+func (b *StringBuilder) StartsWith(value string) *StringBuilder { return b.Prefix(value) }
+
+// EndsWith is an alias of Suffix matching the `endswith=` tag.
+func (b *StringBuilder) EndsWith(value string) *StringBuilder { return b.Suffix(value) }
+
+// ExcludesAll rejects strings containing any character of chars.
+func (b *StringBuilder) ExcludesAll(chars string) *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KExcludesAll, map[string]any{"value": chars}))
+	return b
+}
+
 func (b *StringBuilder) URL() *StringBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KURL, nil))
 	return b
@@ -123,6 +149,49 @@ func (b *StringBuilder) Alnum() *StringBuilder {
 	return b
 }
 
+// AlphaUnicode requires Unicode letters only.
+func (b *StringBuilder) AlphaUnicode() *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KAlphaUni, nil))
+	return b
+}
+
+// AlnumUnicode requires Unicode letters and digits only.
+func (b *StringBuilder) AlnumUnicode() *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KAlnumUni, nil))
+	return b
+}
+
+// Numeric requires ASCII digits only; signs and decimal points fail.
+func (b *StringBuilder) Numeric() *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNumeric, nil))
+	return b
+}
+
+// PrintASCII requires printable ASCII characters, space through tilde.
+func (b *StringBuilder) PrintASCII() *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KPrintASCII, nil))
+	return b
+}
+
+// NumStr requires a plain decimal number such as "-12" or "0.25".
+func (b *StringBuilder) NumStr() *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNumStr, nil))
+	return b
+}
+
+// MinValue requires a decimal number string of at least n, itself a
+// decimal number such as "0" or "1.5".
+func (b *StringBuilder) MinValue(n string) *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNumStrMin, map[string]any{"value": n}))
+	return b
+}
+
+// MaxValue requires a decimal number string of at most n.
+func (b *StringBuilder) MaxValue(n string) *StringBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNumStrMax, map[string]any{"value": n}))
+	return b
+}
+
 func (b *StringBuilder) Slug() *StringBuilder {
 	return b.Rule("slug", nil)
 }
@@ -135,7 +204,12 @@ func (b *StringBuilder) JSON() *StringBuilder {
 	return b.Rule("json", nil)
 }
 
-func (b *StringBuilder) JWT() *StringBuilder {
+// JWT adds a JWT structure check. With algs, the token's alg header must be
+// one of them (jwt plugin).
+func (b *StringBuilder) JWT(algs ...string) *StringBuilder {
+	if len(algs) > 0 {
+		return b.Rule("jwt", map[string]any{"value": strings.Join(algs, ",")})
+	}
 	return b.Rule("jwt", nil)
 }
 
@@ -273,6 +347,18 @@ func (b *IntBuilder) MaxInt(n int64) *IntBuilder {
 	return b
 }
 
+// Enum requires a value registered for the enum with RegisterIntEnum.
+func (b *IntBuilder) Enum(name string) *IntBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KEnum, map[string]any{"name": name}))
+	return b
+}
+
+// MultipleOf requires a whole multiple of n, such as a page size step.
+func (b *IntBuilder) MultipleOf(n int64) *IntBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KMultipleOf, map[string]any{"n": n}))
+	return b
+}
+
 func (b *IntBuilder) GreaterThan(n int64) *IntBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KGreaterThan, map[string]any{"n": float64(n)}))
 	return b
@@ -308,6 +394,16 @@ func (b *IntBuilder) NonNegative() *IntBuilder {
 	return b
 }
 
+func (b *IntBuilder) Negative() *IntBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNegative, nil))
+	return b
+}
+
+func (b *IntBuilder) NonPositive() *IntBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNonPositive, nil))
+	return b
+}
+
 func (b *IntBuilder) Rule(kind types.Kind, args map[string]any) *IntBuilder {
 	b.rules = append(b.rules, types.NewRule(kind, args))
 	return b
@@ -338,6 +434,82 @@ func (b *IntBuilder) BuildContextWithOpts(opts types.CompileOpts) types.ContextV
 	return b.engine.CompileRulesContextWithOpts(b.rules, opts)
 }
 
+// UintBuilder accumulates unsigned integer validation rules. Its bounds are
+// uint64, so limits above math.MaxInt64 compare without overflow.
+type UintBuilder struct {
+	rules  []types.Rule
+	engine *core.Engine
+}
+
+// NewUintBuilder creates a new UintBuilder with the base type rule: uint64
+// when exact is set, any unsigned integer type otherwise.
+func NewUintBuilder(exact bool, engine *core.Engine) *UintBuilder {
+	base := types.KUint
+	if exact {
+		base = types.KUint64
+	}
+	return &UintBuilder{
+		rules:  []types.Rule{types.NewRule(base, nil)},
+		engine: engine,
+	}
+}
+
+func (b *UintBuilder) Required() *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KRequired, nil))
+	return b
+}
+
+func (b *UintBuilder) MinUint(n uint64) *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KMinUint, map[string]any{"n": n}))
+	return b
+}
+
+func (b *UintBuilder) MaxUint(n uint64) *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KMaxUint, map[string]any{"n": n}))
+	return b
+}
+
+// MultipleOf requires a whole multiple of n, such as an alignment.
+func (b *UintBuilder) MultipleOf(n uint64) *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KMultipleOf, map[string]any{"n": n}))
+	return b
+}
+
+func (b *UintBuilder) Positive() *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KPositive, nil))
+	return b
+}
+
+func (b *UintBuilder) Rule(kind types.Kind, args map[string]any) *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(kind, args))
+	return b
+}
+
+func (b *UintBuilder) OmitEmpty() *UintBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KOmitempty, nil))
+	return b
+}
+
+func (b *UintBuilder) Build() func(any) error {
+	return b.engine.CompileRules(b.rules)
+}
+
+func (b *UintBuilder) BuildWithOpts(opts types.CompileOpts) func(any) error {
+	return b.engine.CompileRulesWithOpts(b.rules, opts)
+}
+
+func (b *UintBuilder) BuildAll() func(any) error {
+	return b.BuildWithOpts(types.CompileOpts{CollectAll: true})
+}
+
+func (b *UintBuilder) BuildContext() types.ContextValidatorFunc {
+	return b.engine.CompileRulesContext(b.rules)
+}
+
+func (b *UintBuilder) BuildContextWithOpts(opts types.CompileOpts) types.ContextValidatorFunc {
+	return b.engine.CompileRulesContextWithOpts(b.rules, opts)
+}
+
 // FloatBuilder accumulates floating-point validation rules.
 type FloatBuilder struct {
 	rules  []types.Rule
@@ -401,6 +573,23 @@ func (b *FloatBuilder) NonNegative() *FloatBuilder {
 	return b
 }
 
+func (b *FloatBuilder) Negative() *FloatBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNegative, nil))
+	return b
+}
+
+func (b *FloatBuilder) NonPositive() *FloatBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KNonPositive, nil))
+	return b
+}
+
+// MultipleOf requires a whole multiple of n, such as 0.01 for cents,
+// within a relative tolerance of 1e-9.
+func (b *FloatBuilder) MultipleOf(n float64) *FloatBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KMultipleOf, map[string]any{"n": n}))
+	return b
+}
+
 func (b *FloatBuilder) Finite() *FloatBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KFinite, nil))
 	return b
@@ -485,6 +674,12 @@ func (b *BoolBuilder) False() *BoolBuilder {
 	return b
 }
 
+// Parse accepts "true", "false", "1" and "0" strings, coerced to bools.
+func (b *BoolBuilder) Parse() *BoolBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KBoolParse, nil))
+	return b
+}
+
 func (b *BoolBuilder) OmitEmpty() *BoolBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KOmitempty, nil))
 	return b
@@ -531,6 +726,13 @@ func (b *SliceBuilder) Unique() *SliceBuilder {
 	return b
 }
 
+// UniqueBy requires elements, structs or pointers to structs, to differ in
+// the exported field at the dotted path field, e.g. "Owner.ID".
+func (b *SliceBuilder) UniqueBy(field string) *SliceBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KSliceUnique, map[string]any{"field": field}))
+	return b
+}
+
 func (b *SliceBuilder) Contains(value any) *SliceBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KSliceContains, map[string]any{"value": value}))
 	return b
@@ -632,6 +834,13 @@ func (b *ArrayBuilder) Unique() *ArrayBuilder {
 	return b
 }
 
+// UniqueBy requires elements, structs or pointers to structs, to differ in
+// the exported field at the dotted path field, e.g. "Owner.ID".
+func (b *ArrayBuilder) UniqueBy(field string) *ArrayBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KArrayUnique, map[string]any{"field": field}))
+	return b
+}
+
 func (b *ArrayBuilder) Contains(value any) *ArrayBuilder {
 	b.rules = append(b.rules, types.NewRule(types.KArrayContains, map[string]any{"value": value}))
 	return b
@@ -807,6 +1016,39 @@ func (b *TimeBuilder) Between(start, end time.Time) *TimeBuilder {
 	return b
 }
 
+// Min requires the time to be at or after t.
+func (b *TimeBuilder) Min(t time.Time) *TimeBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KTimeMin, map[string]any{"time": t}))
+	return b
+}
+
+// Max requires the time to be at or before t.
+func (b *TimeBuilder) Max(t time.Time) *TimeBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KTimeMax, map[string]any{"time": t}))
+	return b
+}
+
+// BeforeNow requires the time to be before the moment of validation plus
+// offset, like the tag before=now+offset.
+func (b *TimeBuilder) BeforeNow(offset time.Duration) *TimeBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KTimeBefore, map[string]any{"time": types.Now(offset)}))
+	return b
+}
+
+// AfterNow requires the time to be after the moment of validation plus
+// offset, like the tag after=now+offset.
+func (b *TimeBuilder) AfterNow(offset time.Duration) *TimeBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KTimeAfter, map[string]any{"time": types.Now(offset)}))
+	return b
+}
+
+// Layout sets the layout strings are parsed with; without it strings are
+// parsed as RFC 3339.
+func (b *TimeBuilder) Layout(layout string) *TimeBuilder {
+	b.rules = append(b.rules, types.NewRule(types.KTimeLayout, map[string]any{"layout": layout}))
+	return b
+}
+
 func (b *TimeBuilder) Rule(kind types.Kind, args map[string]any) *TimeBuilder {
 	b.rules = append(b.rules, types.NewRule(kind, args))
 	return b
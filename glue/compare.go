@@ -0,0 +1,136 @@
+package glue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// Difference is an input whose validation outcome differs between two
+// validators. Outcomes are the sorted "path: code" entries of the errors,
+// or a single "error: ..." entry for errors other than errors.Errors, so
+// differing messages alone do not count.
+//
+// Fields:
+//   - Index: Position of the input in the inputs of Compare; 0 for DualRun.
+//   - Input: The validated value.
+//   - Old: The outcome of the old validator; empty when valid.
+//   - New: The outcome of the new validator; empty when valid.
+type Difference struct {
+	Index int
+	Input any
+	Old   []string
+	New   []string
+}
+
+// String renders d for logs and test failures.
+func (d Difference) String() string {
+	return fmt.Sprintf("input %d (%T): old %v, new %v", d.Index, d.Input, d.Old, d.New)
+}
+
+// Compare validates every input, a struct or pointer to struct, with old
+// and candidate and reports the inputs whose outcomes differ. Use it to
+// migrate between behavior flags, rule sets or library versions: run both
+// configurations over recorded or generated inputs and review the
+// differences before switching.
+//
+// Parameters:
+//   - ctx: Context for both validators; Compare stops when it is done.
+//   - old: The validator currently in use.
+//   - candidate: The new validator.
+//   - opts: Options for both validators.
+//   - inputs: The values to validate.
+//
+// Returns:
+//   - []Difference: Differing inputs in input order.
+//   - error: The context's error if it is done before every input ran.
+func Compare(ctx context.Context, old, candidate *Validate, opts core.ValidateOpts, inputs ...any) ([]Difference, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var diffs []Difference
+	for i, input := range inputs {
+		oldErr := old.ValidateStructContextWithOpts(ctx, input, opts)
+		newErr := candidate.ValidateStructContextWithOpts(ctx, input, opts)
+		if err := ctx.Err(); err != nil {
+			return diffs, err
+		}
+		if d, ok := difference(input, oldErr, newErr); ok {
+			d.Index = i
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs, nil
+}
+
+// DualRun validates with Old, whose result callers get, and also with New,
+// reporting differing outcomes to OnDiff. Use it to try a candidate
+// configuration on live traffic before switching to it; New never affects
+// results, but it doubles the validation cost.
+//
+// Fields:
+//   - Old: The validator whose results are returned.
+//   - New: The candidate validator.
+//   - OnDiff: Called with each difference; nil ignores them. It must be
+//     safe for concurrent use when the DualRun is.
+type DualRun struct {
+	Old    *Validate
+	New    *Validate
+	OnDiff func(Difference)
+}
+
+// ValidateStructContextWithOpts validates s with d.Old and d.New and
+// returns the result of d.Old. Runs where the context is done are not
+// compared.
+func (d DualRun) ValidateStructContextWithOpts(ctx context.Context, s any, opts core.ValidateOpts) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	err := d.Old.ValidateStructContextWithOpts(ctx, s, opts)
+	newErr := d.New.ValidateStructContextWithOpts(ctx, s, opts)
+	if d.OnDiff == nil || ctx.Err() != nil {
+		return err
+	}
+	if diff, ok := difference(s, err, newErr); ok {
+		d.OnDiff(diff)
+	}
+	return err
+}
+
+// ValidateStruct validates s with d.Old and d.New and returns the result
+// of d.Old.
+func (d DualRun) ValidateStruct(s any) error {
+	return d.ValidateStructContextWithOpts(context.Background(), s, core.ValidateOpts{})
+}
+
+// difference compares the outcomes of oldErr and newErr for input.
+func difference(input any, oldErr, newErr error) (Difference, bool) {
+	oldOutcome, newOutcome := outcome(oldErr), outcome(newErr)
+	if reflect.DeepEqual(oldOutcome, newOutcome) {
+		return Difference{}, false
+	}
+	return Difference{Input: input, Old: oldOutcome, New: newOutcome}, true
+}
+
+// outcome returns the comparable outcome of a validation error.
+func outcome(err error) []string {
+	if err == nil {
+		return []string{}
+	}
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		return []string{"error: " + err.Error()}
+	}
+	out := make([]string, len(es))
+	for i, fe := range es {
+		out[i] = strings.TrimPrefix(fe.Path+": "+fe.Code, ": ")
+	}
+	sort.Strings(out)
+	return out
+}
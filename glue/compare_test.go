@@ -0,0 +1,66 @@
+package glue
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type compareOrder struct {
+	Status string `validate:"string;oneof=paid,shipped"`
+	Ref    string `validate:"string;regex=A[0-9]+|B"`
+}
+
+func TestCompare(t *testing.T) {
+	old := New()
+	next := old.WithBehavior(types.LatestBehavior())
+	inputs := []any{
+		compareOrder{Status: "paid", Ref: "A1"},
+		&compareOrder{Status: "PAID", Ref: "A1"},
+		compareOrder{Status: "paid", Ref: "A1x"},
+		compareOrder{Status: "lost", Ref: "B"},
+	}
+	diffs, err := Compare(context.Background(), old, next, core.ValidateOpts{}, inputs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Difference{
+		{Index: 1, Input: inputs[1], Old: []string{"Status: string.oneof"}, New: []string{}},
+		{Index: 2, Input: inputs[2], Old: []string{}, New: []string{"Ref: string.regex.noMatch"}},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("diffs = %v, want %v", diffs, want)
+	}
+
+	if diffs, err := Compare(nil, old, next, core.ValidateOpts{}, inputs...); err != nil || len(diffs) != 2 {
+		t.Fatalf("nil ctx Compare = %v, %v", diffs, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Compare(ctx, old, next, core.ValidateOpts{}, inputs...); err != context.Canceled {
+		t.Fatalf("canceled Compare err = %v", err)
+	}
+}
+
+func TestDualRun(t *testing.T) {
+	var got []Difference
+	d := DualRun{Old: New(), New: New().WithBehavior(types.Behavior{OneOfCaseFold: true}), OnDiff: func(diff Difference) {
+		got = append(got, diff)
+	}}
+	if err := d.ValidateStruct(compareOrder{Status: "Shipped", Ref: "B"}); err == nil {
+		t.Fatal("DualRun did not return the old result")
+	}
+	if err := d.ValidateStruct(compareOrder{Status: "shipped", Ref: "B"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ValidateStructContextWithOpts(nil, compareOrder{Status: "Paid", Ref: "B"}, core.ValidateOpts{}); err == nil {
+		t.Fatal("DualRun with nil ctx did not return the old result")
+	}
+	if len(got) != 2 || got[0].String() != "input 0 (glue.compareOrder): old [Status: string.oneof], new []" {
+		t.Fatalf("differences = %v", got)
+	}
+}
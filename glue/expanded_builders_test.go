@@ -28,6 +28,8 @@ func TestExpandedBuilders(t *testing.T) {
 		{"array", v.Array().Required().Unique().Contains("a").Build(), [2]string{"a", "b"}, [2]string{"b", "c"}, verrs.CodeArrayContains},
 		{"map", v.Map().Required().MinKeys(1).KeysRules(types.NewRule(types.KString, nil)).ValuesRules(types.NewRule(types.KInt, nil)).Build(), map[string]int{"a": 1}, map[string]int{}, verrs.CodeRequired},
 		{"time", v.Time().Required().After(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).Build(), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Time{}, verrs.CodeRequired},
+		{"time relative", v.Time().Layout("2006-01-02").Min(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)).BeforeNow(0).Build(), "2020-01-01", "2019-12-31", verrs.CodeTimeMin},
+		{"time after now", v.Time().AfterNow(-time.Hour).Max(time.Now().Add(time.Hour)).Build(), time.Now(), time.Now().Add(-2 * time.Hour), verrs.CodeTimeAfter},
 	}
 
 	for _, tt := range tests {
@@ -47,3 +47,36 @@ func TestValidate_Builders_Fluent(t *testing.T) {
 		t.Fatalf("want max fail")
 	}
 }
+
+func TestValidate_OmitEmpty_TagAndBuilderParity(t *testing.T) {
+	v := New()
+	var nilStr *string
+	short := "ab"
+	tests := []struct {
+		tag     string
+		builder func(any) error
+		zero    any
+		invalid any
+	}{
+		{"string;omitempty;min=3", v.String().MinLength(3).OmitEmpty().Build(), "", "ab"},
+		{"string;omitempty;min=3", v.String().OmitEmpty().MinLength(3).Build(), nilStr, &short},
+		{"int;omitempty;min=5", v.Int().OmitEmpty().MinInt(5).Build(), 0, 3},
+		{"float;omitempty;min=1", v.Float().OmitEmpty().Min(1).Build(), 0.0, 0.5},
+		{"slice;omitempty;min=2", v.Slice().OmitEmpty().MinLength(2).Build(), []string(nil), []string{"a"}},
+		{"slice;omitempty;min=2", v.Slice().OmitEmpty().MinLength(2).Build(), []string{}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		fn, err := v.FromRules([]string{tt.tag})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tag, err)
+		}
+		for name, check := range map[string]func(any) error{"tag": fn, "builder": tt.builder} {
+			if err := check(tt.zero); err != nil {
+				t.Errorf("%s (%s): zero value %#v failed: %v", tt.tag, name, tt.zero, err)
+			}
+			if err := check(tt.invalid); err == nil {
+				t.Errorf("%s (%s): %#v passed", tt.tag, name, tt.invalid)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package glue
 
 import (
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -210,6 +211,66 @@ func TestTagsVsBuildersExpandedErrorCodeEquivalence(t *testing.T) {
 			buildFn: func(v *Validate) func(any) error { return v.Slice().Unique().Build() },
 			value:   []string{"a", "a"},
 		},
+		{
+			name:    "string excludesall",
+			tag:     "string;startswith=a;excludesall=<>",
+			buildFn: func(v *Validate) func(any) error { return v.String().StartsWith("a").ExcludesAll("<>").Build() },
+			value:   "a<b>",
+		},
+		{
+			name:    "string numeric",
+			tag:     "string;numeric",
+			buildFn: func(v *Validate) func(any) error { return v.String().Numeric().Build() },
+			value:   "12a",
+		},
+		{
+			name:    "string numstr bounds",
+			tag:     "string;numstr;min_value=0;max_value=100",
+			buildFn: func(v *Validate) func(any) error { return v.String().NumStr().MinValue("0").MaxValue("100").Build() },
+			value:   "100.5",
+		},
+		{
+			name:    "uint max",
+			tag:     "uint64;max=18446744073709551614",
+			buildFn: func(v *Validate) func(any) error { return v.Uint64().MaxUint(math.MaxUint64 - 1).Build() },
+			value:   uint64(math.MaxUint64),
+		},
+		{
+			name:    "int negative",
+			tag:     "int;negative",
+			buildFn: func(v *Validate) func(any) error { return v.Int().Negative().Build() },
+			value:   0,
+		},
+		{
+			name:    "float nonpositive",
+			tag:     "float;nonpositive",
+			buildFn: func(v *Validate) func(any) error { return v.Float().NonPositive().Build() },
+			value:   0.5,
+		},
+		{
+			name:    "int multipleof",
+			tag:     "int;multipleof=25",
+			buildFn: func(v *Validate) func(any) error { return v.Int().MultipleOf(25).Build() },
+			value:   60,
+		},
+		{
+			name:    "float multipleof",
+			tag:     "float;multipleof=0.05",
+			buildFn: func(v *Validate) func(any) error { return v.Float().MultipleOf(0.05).Build() },
+			value:   1.01,
+		},
+		{
+			name:    "string alphaunicode",
+			tag:     "string;alphaunicode",
+			buildFn: func(v *Validate) func(any) error { return v.String().AlphaUnicode().Build() },
+			value:   "Gödel1",
+		},
+		{
+			name:    "slice unique by field",
+			tag:     "slice;unique=Name",
+			buildFn: func(v *Validate) func(any) error { return v.Slice().UniqueBy("Name").Build() },
+			value:   []struct{ Name string }{{"a"}, {"a"}},
+		},
 		{
 			name:    "map",
 			tag:     "map;minKeys=1",
@@ -1,7 +1,10 @@
 package glue
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"reflect"
 
 	"github.com/aatuh/validate/v3/core"
 	"github.com/aatuh/validate/v3/structvalidator"
@@ -24,7 +27,7 @@ func New() *Validate {
 // NewWithTranslator returns a Validate configured with the provided
 // translator while keeping other defaults.
 func NewWithTranslator(tr translator.Translator) *Validate {
-	engine := core.NewEngine().WithTranslator(tr)
+	engine := core.NewEngine(core.WithTranslator(tr))
 	return &Validate{engine: engine}
 }
 
@@ -87,6 +90,170 @@ func (v *Validate) WithTranslator(t translator.Translator) *Validate {
 	}
 }
 
+// WithSchemaVersion returns a copy with version-specific struct tags for the
+// struct type of sample, selected with ValidateOpts.SchemaVersion.
+func (v *Validate) WithSchemaVersion(
+	sample any, version string, tags map[string]string,
+) *Validate {
+	return &Validate{
+		engine: v.engine.WithSchemaVersion(sample, version, tags),
+	}
+}
+
+// WithShadowRule returns a copy that runs rules of kind in shadow mode,
+// sampling the given fraction of validations.
+func (v *Validate) WithShadowRule(kind types.Kind, sampleRate float64) *Validate {
+	return &Validate{
+		engine: v.engine.WithShadowRule(kind, sampleRate),
+	}
+}
+
+// WithShadowHook returns a copy that reports shadow rule failures to hook.
+func (v *Validate) WithShadowHook(hook types.ShadowHook) *Validate {
+	return &Validate{
+		engine: v.engine.WithShadowHook(hook),
+	}
+}
+
+// WithCompileHook returns a copy that compiles rules of kind inside hook,
+// for example to time regex compilation or reject regex rules outright.
+func (v *Validate) WithCompileHook(kind types.Kind, hook types.CompileHook) *Validate {
+	return &Validate{
+		engine: v.engine.WithCompileHook(kind, hook),
+	}
+}
+
+// Use returns a copy that runs every compiled rule inside mw, for logging,
+// metrics, tracing or panic recovery.
+func (v *Validate) Use(mw ...types.Middleware) *Validate {
+	return &Validate{
+		engine: v.engine.Use(mw...),
+	}
+}
+
+// WithRetryPolicy returns a copy that retries context-aware custom rules of
+// kind on transient errors, with backoff and optional circuit breaking.
+func (v *Validate) WithRetryPolicy(kind types.Kind, p types.RetryPolicy) *Validate {
+	return &Validate{
+		engine: v.engine.WithRetryPolicy(kind, p),
+	}
+}
+
+// WithOutagePolicy returns a copy that handles outages of context-aware
+// custom rules per p.
+func (v *Validate) WithOutagePolicy(p types.OutagePolicy) *Validate {
+	return &Validate{
+		engine: v.engine.WithOutagePolicy(p),
+	}
+}
+
+// WithResultCache returns a copy that caches results of context-aware
+// custom rules of kind in rc.
+func (v *Validate) WithResultCache(kind types.Kind, rc *types.ResultCache) *Validate {
+	return &Validate{
+		engine: v.engine.WithResultCache(kind, rc),
+	}
+}
+
+// WithQuota returns a copy that limits the total of `quota=name` fields to
+// max per struct validation call.
+func (v *Validate) WithQuota(name string, max int64) *Validate {
+	return &Validate{
+		engine: v.engine.WithQuota(name, max),
+	}
+}
+
+// WithConverter returns a copy that converts values of type from with fn
+// before rule chains of kind to run.
+func (v *Validate) WithConverter(from reflect.Type, to types.Kind, fn types.ConverterFunc) *Validate {
+	return &Validate{
+		engine: v.engine.WithConverter(from, to, fn),
+	}
+}
+
+// WithObserver returns a copy that reports validations and compile cache
+// lookups to o, for metrics such as instrument.Metrics.
+func (v *Validate) WithObserver(o core.Observer) *Validate {
+	return &Validate{
+		engine: v.engine.WithObserver(o),
+	}
+}
+
+// WithRecorder returns a copy whose tag rules record failing values,
+// anonymized, into r's replay corpus.
+func (v *Validate) WithRecorder(r *core.Recorder) *Validate {
+	return &Validate{
+		engine: v.engine.WithRecorder(r),
+	}
+}
+
+// Replay validates a corpus recorded by a Recorder and reports the entries
+// whose error codes differ. See core.Engine.Replay.
+func (v *Validate) Replay(r io.Reader) ([]core.ReplayDiff, error) {
+	return v.engine.Replay(r)
+}
+
+// WithDefaultLimits returns a copy that bounds string, slice and map rules
+// without an explicit maximum; tags opt out with unbounded.
+func (v *Validate) WithDefaultLimits(l types.DefaultLimits) *Validate {
+	return &Validate{
+		engine: v.engine.WithDefaultLimits(l),
+	}
+}
+
+// WithNilPolicy returns a copy where nil values and nil pointers are treated
+// per p when the rules have neither omitempty nor required.
+func (v *Validate) WithNilPolicy(p types.NilPolicy) *Validate {
+	return &Validate{
+		engine: v.engine.WithNilPolicy(p),
+	}
+}
+
+// WithNilCollectionsAsEmpty returns a copy where slice and map rules treat
+// untyped nil values as empty collections.
+func (v *Validate) WithNilCollectionsAsEmpty(enabled bool) *Validate {
+	return &Validate{
+		engine: v.engine.WithNilCollectionsAsEmpty(enabled),
+	}
+}
+
+// WithPlaygroundTags returns a copy whose struct validation reads
+// go-playground/validator tag syntax.
+// See core.Engine.WithPlaygroundTags.
+func (v *Validate) WithPlaygroundTags(enabled bool) *Validate {
+	return &Validate{
+		engine: v.engine.WithPlaygroundTags(enabled),
+	}
+}
+
+// WithTagNames returns a copy whose struct validation reads rules from the
+// struct tag keys names, merged in order.
+// See core.Engine.WithTagNames.
+func (v *Validate) WithTagNames(names ...string) *Validate {
+	return &Validate{
+		engine: v.engine.WithTagNames(names...),
+	}
+}
+
+// WithRuleFusion returns a copy with the compiler's rule fusion pass on or
+// off. See core.Engine.WithRuleFusion.
+func (v *Validate) WithRuleFusion(enabled bool) *Validate {
+	return &Validate{
+		engine: v.engine.WithRuleFusion(enabled),
+	}
+}
+
+// WithBehavior returns a copy whose built-in rules apply the fixes enabled
+// in b. See core.Engine.WithBehavior.
+func (v *Validate) WithBehavior(b types.Behavior) *Validate {
+	return &Validate{
+		engine: v.engine.WithBehavior(b),
+	}
+}
+
+// Behavior returns the behavior fixes the built-in rules apply.
+func (v *Validate) Behavior() types.Behavior { return v.engine.Behavior() }
+
 // PathSeparator customizes the nested field path separator.
 func (v *Validate) PathSeparator(sep string) *Validate {
 	return &Validate{
@@ -94,6 +261,16 @@ func (v *Validate) PathSeparator(sep string) *Validate {
 	}
 }
 
+// WithSharedCache returns a copy sharing the compiled validator cache. When
+// enabled, copies derived by WithCustomRule, PathSeparator, or
+// WithTranslator share it too.
+// See core.Engine.WithSharedCache.
+func (v *Validate) WithSharedCache(enabled bool) *Validate {
+	return &Validate{
+		engine: v.engine.WithSharedCache(enabled),
+	}
+}
+
 // FromRules creates a validator function from rule tokens.
 func (v *Validate) FromRules(
 	rules []string,
@@ -266,6 +443,18 @@ func (v *Validate) ValidateStructWithOpts(
 	return v.Struct().ValidateStructWithOpts(s, opts)
 }
 
+// ValidateXML decodes an XML document into dst, a pointer to struct, and
+// validates it with xml tag names in error paths.
+func (v *Validate) ValidateXML(data []byte, dst any) error {
+	return v.Struct().DecodeXMLContextWithOpts(context.Background(), bytes.NewReader(data), dst, core.ValidateOpts{})
+}
+
+// DecodeXMLContextWithOpts decodes an XML document from r into dst and
+// validates it. See structvalidator.StructValidator.DecodeXMLContextWithOpts.
+func (v *Validate) DecodeXMLContextWithOpts(ctx context.Context, r io.Reader, dst any, opts core.ValidateOpts) error {
+	return v.Struct().DecodeXMLContextWithOpts(ctx, r, dst, opts)
+}
+
 // ValidateStructContextWithOpts validates a struct with context and advanced options.
 func (v *Validate) ValidateStructContextWithOpts(
 	ctx context.Context, s any, opts core.ValidateOpts,
@@ -273,6 +462,83 @@ func (v *Validate) ValidateStructContextWithOpts(
 	return v.Struct().ValidateStructContextWithOpts(ctx, s, opts)
 }
 
+// ValidateStructResult validates a struct and stamps the result with the
+// fingerprint of its rules, for audit records.
+func (v *Validate) ValidateStructResult(ctx context.Context, s any, opts core.ValidateOpts) core.Result {
+	return v.Struct().ValidateStructResult(ctx, s, opts)
+}
+
+// Fingerprint returns a digest of the rules reachable from the type of s.
+// See structvalidator.StructValidator.Fingerprint.
+func (v *Validate) Fingerprint(s any, opts core.ValidateOpts) (string, error) {
+	return v.Struct().Fingerprint(s, opts)
+}
+
+// CompileType compiles the field validators of a struct type once and
+// returns a reusable TypedValidator for hot paths.
+func (v *Validate) CompileType(t reflect.Type) (*structvalidator.TypedValidator, error) {
+	return v.Struct().CompileType(t)
+}
+
+// CompileIncremental compiles a struct's slice field for validation that
+// checks only newly appended elements on each call.
+func (v *Validate) CompileIncremental(t reflect.Type, field string, opts core.ValidateOpts) (*structvalidator.Incremental, error) {
+	return v.Struct().CompileIncremental(t, field, opts)
+}
+
+// CompileStruct compiles every tag reachable from the type of s and reports
+// all tag errors at once without validating values.
+func (v *Validate) CompileStruct(s any, opts core.ValidateOpts) error {
+	return v.Struct().CompileStruct(s, opts)
+}
+
+// Coverage reports which exported fields of the type of s have no
+// validation rules.
+func (v *Validate) Coverage(s any, opts core.ValidateOpts) (structvalidator.CoverageReport, error) {
+	return v.Struct().Coverage(s, opts)
+}
+
+// CheckPolicy reports fields reachable from the struct types of schemas
+// whose rules break a policy rule, such as a missing max length.
+func (v *Validate) CheckPolicy(p structvalidator.Policy, opts core.ValidateOpts, schemas ...any) ([]structvalidator.PolicyViolation, error) {
+	return v.Struct().CheckPolicy(p, opts, schemas...)
+}
+
+// AuditRules perturbs a corpus of valid examples and reports rules that
+// never fire or only fire alongside another rule.
+func (v *Validate) AuditRules(corpus []any, opts core.ValidateOpts) (structvalidator.RuleAudit, error) {
+	return v.Struct().AuditRules(corpus, opts)
+}
+
+// ExampleFor generates a value satisfying a tag or struct type.
+func (v *Validate) ExampleFor(schema any, opts core.ValidateOpts) (any, error) {
+	return v.Struct().ExampleFor(schema, opts)
+}
+
+// CounterExamples returns a value violating each rule of a tag or struct
+// type, preferring values that break only that rule.
+func (v *Validate) CounterExamples(schema any, opts core.ValidateOpts) ([]structvalidator.CounterExample, error) {
+	return v.Struct().CounterExamples(schema, opts)
+}
+
+// Generator returns a random value generator for a tag or struct type,
+// for property-based tests.
+func (v *Validate) Generator(schema any, opts core.ValidateOpts) (*structvalidator.Generator, error) {
+	return v.Struct().Generator(schema, opts)
+}
+
+// OpenAPIComponents returns OpenAPI 3.0 component schemas for a struct
+// type and the struct types it references.
+func (v *Validate) OpenAPIComponents(schema any, opts core.ValidateOpts) (map[string]*types.OpenAPISchema, error) {
+	return v.Struct().OpenAPIComponents(schema, opts)
+}
+
+// CompileFieldTag compiles one struct field tag without a reflect.Type.
+// hasField reports whether cross-field references resolve; nil skips them.
+func (v *Validate) CompileFieldTag(tag string, hasField func(name string) bool) error {
+	return v.Struct().CompileFieldTag(tag, hasField)
+}
+
 // String returns a string validator builder.
 func (v *Validate) String() *StringBuilder {
 	return &StringBuilder{
@@ -291,6 +557,16 @@ func (v *Validate) Int64() *IntBuilder {
 	return NewIntBuilder(true, v.engine)
 }
 
+// Uint returns an unsigned integer validator builder.
+func (v *Validate) Uint() *UintBuilder {
+	return NewUintBuilder(false, v.engine)
+}
+
+// Uint64 returns a uint64 validator builder.
+func (v *Validate) Uint64() *UintBuilder {
+	return NewUintBuilder(true, v.engine)
+}
+
 // Float returns a floating-point validator builder.
 func (v *Validate) Float() *FloatBuilder {
 	return NewFloatBuilder(v.engine)
@@ -316,6 +592,31 @@ func (v *Validate) Array() *ArrayBuilder {
 	return NewArrayBuilder(v.engine)
 }
 
+// CompileSchema compiles a schema that validates map[string]any payloads,
+// such as decoded JSON, from field names to tags. See core.Engine.CompileSchema.
+func (v *Validate) CompileSchema(fields map[string]string) (*core.Schema, error) {
+	return v.engine.CompileSchema(fields)
+}
+
+// NamedSchema returns the schema registered under name with RegisterSchema,
+// compiled for this instance.
+func (v *Validate) NamedSchema(name string) (*core.Schema, error) {
+	return v.engine.NamedSchema(name)
+}
+
+// ValidateConfig validates a configuration document, such as decoded JSON
+// or YAML, against the schema registered under name. See
+// core.Engine.ValidateConfigContextWithOpts.
+func (v *Validate) ValidateConfig(name string, doc any) error {
+	return v.engine.ValidateConfig(name, doc)
+}
+
+// ValidateConfigContextWithOpts validates a configuration document against
+// the schema registered under name with ctx and opts.
+func (v *Validate) ValidateConfigContextWithOpts(ctx context.Context, name string, doc any, opts core.ValidateOpts) error {
+	return v.engine.ValidateConfigContextWithOpts(ctx, name, doc, opts)
+}
+
 // Map returns a map validator builder.
 func (v *Validate) Map() *MapBuilder {
 	return NewMapBuilder(v.engine)
@@ -0,0 +1,255 @@
+// Package instrument counts validations, failures by error code, compile
+// cache hits and misses, and validation latency.
+//
+// Metrics implements core.Observer; register it with WithObserver. It keeps
+// its own counters and a latency histogram, exposes them as a Snapshot and
+// serves them in the Prometheus text format, so the module stays
+// dependency-free. OpenTelemetry or other backends can read a Snapshot, or
+// implement core.Observer directly.
+package instrument
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// DefaultBuckets are the latency histogram bounds of New without buckets.
+var DefaultBuckets = []time.Duration{
+	time.Microsecond,
+	5 * time.Microsecond,
+	10 * time.Microsecond,
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+}
+
+// Metrics collects validation metrics. It is safe for concurrent use.
+type Metrics struct {
+	validations atomic.Int64
+	valid       atomic.Int64
+	invalid     atomic.Int64
+	errored     atomic.Int64
+
+	buckets []time.Duration
+	counts  []atomic.Int64 // one per bucket plus +Inf
+	sum     atomic.Int64   // nanoseconds
+
+	caches sync.Map // cache name -> *cacheCounts
+
+	mu    sync.Mutex
+	codes map[string]int64
+}
+
+type cacheCounts struct {
+	hits, misses atomic.Int64
+}
+
+// New returns empty Metrics whose latency histogram uses buckets as upper
+// bounds, or DefaultBuckets when none are given.
+//
+// Parameters:
+//   - buckets: Ascending histogram bounds.
+//
+// Returns:
+//   - *Metrics: The metrics, ready to pass to WithObserver.
+func New(buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]time.Duration(nil), buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	return &Metrics{
+		buckets: buckets,
+		counts:  make([]atomic.Int64, len(buckets)+1),
+		codes:   map[string]int64{},
+	}
+}
+
+// ValidationDone records one validation call. errors.Errors count as
+// invalid, once per call and once per error code; other errors, such as
+// context errors, count as errored.
+func (m *Metrics) ValidationDone(d time.Duration, err error) {
+	m.validations.Add(1)
+	m.sum.Add(int64(d))
+	i := sort.Search(len(m.buckets), func(i int) bool { return d <= m.buckets[i] })
+	m.counts[i].Add(1)
+	if err == nil {
+		m.valid.Add(1)
+		return
+	}
+	var es verrs.Errors
+	if !stderrors.As(err, &es) {
+		m.errored.Add(1)
+		return
+	}
+	m.invalid.Add(1)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, fe := range es {
+		m.codes[fe.Code]++
+	}
+}
+
+// CacheLookup records a compile cache hit or miss.
+func (m *Metrics) CacheLookup(cache string, hit bool) {
+	c, ok := m.caches.Load(cache)
+	if !ok {
+		c, _ = m.caches.LoadOrStore(cache, &cacheCounts{})
+	}
+	if hit {
+		c.(*cacheCounts).hits.Add(1)
+	} else {
+		c.(*cacheCounts).misses.Add(1)
+	}
+}
+
+// Snapshot is a point-in-time copy of Metrics.
+//
+// Fields:
+//   - Validations: Validation calls.
+//   - Valid: Calls that returned nil.
+//   - Invalid: Calls that returned errors.Errors.
+//   - Errored: Calls that returned another error.
+//   - Failures: Field errors per error code.
+//   - CacheHits: Compile cache hits per cache name.
+//   - CacheMisses: Compile cache misses per cache name.
+//   - Latency: The validation latency histogram.
+type Snapshot struct {
+	Validations int64
+	Valid       int64
+	Invalid     int64
+	Errored     int64
+	Failures    map[string]int64
+	CacheHits   map[string]int64
+	CacheMisses map[string]int64
+	Latency     Histogram
+}
+
+// Histogram is a latency histogram.
+//
+// Fields:
+//   - Bounds: Bucket upper bounds, ascending.
+//   - Counts: Calls per bucket, not cumulative; the last entry counts calls
+//     above every bound.
+//   - Sum: Total latency of all calls.
+type Histogram struct {
+	Bounds []time.Duration
+	Counts []int64
+	Sum    time.Duration
+}
+
+// Snapshot returns the current metrics.
+func (m *Metrics) Snapshot() Snapshot {
+	s := Snapshot{
+		Validations: m.validations.Load(),
+		Valid:       m.valid.Load(),
+		Invalid:     m.invalid.Load(),
+		Errored:     m.errored.Load(),
+		Latency: Histogram{
+			Bounds: append([]time.Duration(nil), m.buckets...),
+			Counts: make([]int64, len(m.counts)),
+			Sum:    time.Duration(m.sum.Load()),
+		},
+	}
+	for i := range m.counts {
+		s.Latency.Counts[i] = m.counts[i].Load()
+	}
+	s.CacheHits = map[string]int64{}
+	s.CacheMisses = map[string]int64{}
+	m.caches.Range(func(name, c any) bool {
+		s.CacheHits[name.(string)] = c.(*cacheCounts).hits.Load()
+		s.CacheMisses[name.(string)] = c.(*cacheCounts).misses.Load()
+		return true
+	})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s.Failures = make(map[string]int64, len(m.codes))
+	for code, n := range m.codes {
+		s.Failures[code] = n
+	}
+	return s
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition
+// format, with names prefixed by "validate_".
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	s := m.Snapshot()
+	ew := &errWriter{w: w}
+	ew.printf("# HELP validate_validations_total Validation calls.\n# TYPE validate_validations_total counter\n")
+	ew.printf("validate_validations_total{result=\"valid\"} %d\n", s.Valid)
+	ew.printf("validate_validations_total{result=\"invalid\"} %d\n", s.Invalid)
+	ew.printf("validate_validations_total{result=\"error\"} %d\n", s.Errored)
+	ew.printf("# HELP validate_failures_total Field errors by code.\n# TYPE validate_failures_total counter\n")
+	for _, code := range sortedKeys(s.Failures) {
+		ew.printf("validate_failures_total{code=\"%s\"} %d\n", labelValue(code), s.Failures[code])
+	}
+	ew.printf("# HELP validate_cache_lookups_total Compile cache lookups.\n# TYPE validate_cache_lookups_total counter\n")
+	for _, cache := range sortedKeys(s.CacheHits, s.CacheMisses) {
+		ew.printf("validate_cache_lookups_total{cache=\"%s\",result=\"hit\"} %d\n", labelValue(cache), s.CacheHits[cache])
+		ew.printf("validate_cache_lookups_total{cache=\"%s\",result=\"miss\"} %d\n", labelValue(cache), s.CacheMisses[cache])
+	}
+	ew.printf("# HELP validate_duration_seconds Validation latency.\n# TYPE validate_duration_seconds histogram\n")
+	var cumulative int64
+	for i, bound := range s.Latency.Bounds {
+		cumulative += s.Latency.Counts[i]
+		ew.printf("validate_duration_seconds_bucket{le=\"%g\"} %d\n", bound.Seconds(), cumulative)
+	}
+	cumulative += s.Latency.Counts[len(s.Latency.Bounds)]
+	ew.printf("validate_duration_seconds_bucket{le=\"+Inf\"} %d\n", cumulative)
+	ew.printf("validate_duration_seconds_sum %g\n", s.Latency.Sum.Seconds())
+	ew.printf("validate_duration_seconds_count %d\n", cumulative)
+	return ew.err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text format, so Metrics
+// can be mounted as a scrape endpoint.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_ = m.WritePrometheus(w)
+}
+
+// labelEscaper escapes label values as the text exposition format requires:
+// only backslash, double quote and newline.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func labelValue(s string) string { return labelEscaper.Replace(s) }
+
+func sortedKeys(maps ...map[string]int64) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// errWriter keeps the first write error so formatting code stays linear.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
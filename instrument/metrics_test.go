@@ -0,0 +1,104 @@
+package instrument
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	validate "github.com/aatuh/validate/v3"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type signup struct {
+	Email string `validate:"string;required;email"`
+	Name  string `validate:"string;min=2"`
+}
+
+func TestMetrics_WithObserver(t *testing.T) {
+	m := New()
+	v := validate.New().WithObserver(m)
+
+	if err := v.ValidateStruct(signup{Email: "a@example.com", Name: "Al"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.ValidateStruct(signup{Name: "A"}); err == nil {
+		t.Fatal("expected errors")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := v.ValidateStructContext(ctx, signup{}); err == nil {
+		t.Fatal("expected context error")
+	}
+
+	s := m.Snapshot()
+	if s.Validations != 3 || s.Valid != 1 || s.Invalid != 1 || s.Errored != 1 {
+		t.Fatalf("counts = %d/%d/%d/%d", s.Validations, s.Valid, s.Invalid, s.Errored)
+	}
+	if s.Failures[verrs.CodeRequired] != 1 || s.Failures[verrs.CodeStringMin] != 1 {
+		t.Fatalf("failures = %v", s.Failures)
+	}
+	if s.CacheMisses["type"] != 1 || s.CacheHits["type"] != 2 {
+		t.Fatalf("type cache hits %v misses %v", s.CacheHits, s.CacheMisses)
+	}
+	var total int64
+	for _, n := range s.Latency.Counts {
+		total += n
+	}
+	if total != 3 || len(s.Latency.Counts) != len(DefaultBuckets)+1 {
+		t.Fatalf("histogram = %+v", s.Latency)
+	}
+
+	if err := v.CheckTag("string;min=1", "x"); err != nil {
+		t.Fatal(err)
+	}
+	_ = v.CheckTag("string;min=1", "y")
+	if s := m.Snapshot(); s.CacheMisses["validator"] < 1 || s.CacheHits["validator"] < 1 {
+		t.Fatalf("validator cache hits %v misses %v", s.CacheHits, s.CacheMisses)
+	}
+}
+
+func TestMetrics_Buckets(t *testing.T) {
+	m := New(time.Second, time.Millisecond)
+	m.ValidationDone(500*time.Microsecond, nil)
+	m.ValidationDone(time.Millisecond, nil)
+	m.ValidationDone(2*time.Second, nil)
+	s := m.Snapshot()
+	if s.Latency.Bounds[0] != time.Millisecond || s.Latency.Counts[0] != 2 || s.Latency.Counts[1] != 0 || s.Latency.Counts[2] != 1 {
+		t.Fatalf("histogram = %+v", s.Latency)
+	}
+	if s.Latency.Sum != 2*time.Second+1500*time.Microsecond {
+		t.Fatalf("sum = %v", s.Latency.Sum)
+	}
+}
+
+func TestMetrics_WritePrometheus(t *testing.T) {
+	m := New(time.Millisecond)
+	m.ValidationDone(time.Microsecond, verrs.Errors{{Path: "Name", Code: "string.min"}, {Code: "ünï\"co\\de\n"}})
+	m.ValidationDone(time.Second, nil)
+	m.CacheLookup("type", false)
+	m.CacheLookup("type", true)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`validate_validations_total{result="valid"} 1`,
+		`validate_validations_total{result="invalid"} 1`,
+		`validate_failures_total{code="string.min"} 1`,
+		`validate_failures_total{code="ünï\"co\\de\n"} 1`,
+		`validate_cache_lookups_total{cache="type",result="hit"} 1`,
+		`validate_cache_lookups_total{cache="type",result="miss"} 1`,
+		`validate_duration_seconds_bucket{le="0.001"} 1`,
+		`validate_duration_seconds_bucket{le="+Inf"} 2`,
+		`validate_duration_seconds_count 2`,
+	} {
+		if !strings.Contains(body, want+"\n") {
+			t.Fatalf("missing %q in:\n%s", want, body)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Fatalf("content type %q", ct)
+	}
+}
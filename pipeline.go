@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Validator validates one value of type T, e.g. a closure around
+// Validate.ValidateStruct or a compiled builder.
+type Validator[T any] func(T) error
+
+// ItemError reports an item that failed validation in a Pipeline.
+//
+// Fields:
+//   - Index: Zero-based position of the item in the input channel.
+//   - Item: The rejected item.
+//   - Err: The validation error.
+type ItemError struct {
+	Index int
+	Item  any
+	Err   error
+}
+
+// Error returns the item index and the validation error.
+func (e ItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the validation error.
+func (e ItemError) Unwrap() error { return e.Err }
+
+// Pipeline validates items received from in with GOMAXPROCS workers, for
+// streaming ingestion. See PipelineN.
+func Pipeline[T any](in <-chan T, v Validator[T]) (<-chan T, <-chan ItemError) {
+	return PipelineN(in, v, runtime.GOMAXPROCS(0))
+}
+
+// PipelineN validates items received from in with at most workers
+// concurrent calls to v. Valid items are sent on the first channel and
+// rejected ones on the second; with more than one worker, items may be
+// reordered. Both channels are closed once in is closed and every item is
+// handled, and callers must receive from both until then.
+//
+// Parameters:
+//   - in: Source of items; close it to stop the pipeline.
+//   - v: Validator run for each item.
+//   - workers: Maximum concurrency; values below 1 mean 1.
+//
+// Returns:
+//   - <-chan T: Items that passed validation.
+//   - <-chan ItemError: Items that failed, with their input position.
+func PipelineN[T any](in <-chan T, v Validator[T], workers int) (<-chan T, <-chan ItemError) {
+	if workers < 1 {
+		workers = 1
+	}
+	type indexed struct {
+		index int
+		item  T
+	}
+	items := make(chan indexed)
+	out := make(chan T)
+	errs := make(chan ItemError)
+
+	go func() {
+		defer close(items)
+		index := 0
+		for item := range in {
+			items <- indexed{index: index, item: item}
+			index++
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for it := range items {
+				if err := v(it.item); err != nil {
+					errs <- ItemError{Index: it.index, Item: it.item, Err: err}
+					continue
+				}
+				out <- it.item
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+		close(errs)
+	}()
+	return out, errs
+}
@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestPipelineN_SplitsItemsWithBoundedConcurrency(t *testing.T) {
+	type Event struct {
+		ID string `validate:"string;min=2"`
+	}
+	v := New()
+	var running, peak atomic.Int32
+	check := func(e Event) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		return v.ValidateStruct(e)
+	}
+
+	in := make(chan Event)
+	go func() {
+		defer close(in)
+		for _, id := range []string{"ok", "x", "fine", "", "yes"} {
+			in <- Event{ID: id}
+		}
+	}()
+	out, errs := PipelineN(in, check, 2)
+
+	var valid []string
+	var failed []int
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ie := range errs {
+			var es verrs.Errors
+			if !errors.As(ie, &es) || es[0].Code != verrs.CodeStringMin {
+				t.Errorf("item %d: unexpected error %v", ie.Index, ie.Err)
+			}
+			failed = append(failed, ie.Index)
+		}
+	}()
+	for e := range out {
+		valid = append(valid, e.ID)
+	}
+	wg.Wait()
+
+	sort.Strings(valid)
+	sort.Ints(failed)
+	if len(valid) != 3 || valid[0] != "fine" || len(failed) != 2 || failed[0] != 1 || failed[1] != 3 {
+		t.Fatalf("valid = %v, failed = %v", valid, failed)
+	}
+	if peak.Load() > 2 {
+		t.Fatalf("peak concurrency = %d, want at most 2", peak.Load())
+	}
+}
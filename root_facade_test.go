@@ -2,6 +2,7 @@ package validate
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -64,3 +65,83 @@ func TestRootFacade_OptionsAndContextHelpers(t *testing.T) {
 		t.Fatalf("ValidateStructContextWithOpts errors = %#v, want two structured errors", err)
 	}
 }
+
+func TestRootFacade_TagSpecIncludesPluginsAndStructTokens(t *testing.T) {
+	data, err := TagSpec()
+	if err != nil {
+		t.Fatalf("TagSpec returned error: %v", err)
+	}
+	var spec TagSpecDoc
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("TagSpec JSON is invalid: %v", err)
+	}
+	has := func(tokens []TagToken, name string) bool {
+		for _, token := range tokens {
+			if token.Token == name {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(spec.Plugins, "email") || !has(spec.Plugins, "uuid") {
+		t.Fatalf("TagSpec plugins = %+v, want built-in plugin kinds", spec.Plugins)
+	}
+	if !has(spec.Struct, "eqField") || !has(spec.Struct, "readonly") {
+		t.Fatalf("TagSpec struct tokens = %+v, want struct-only tokens", spec.Struct)
+	}
+}
+
+func TestRootFacade_ExampleForUsesPluginSamples(t *testing.T) {
+	type Account struct {
+		Email string `validate:"string;required;email"`
+		ID    string `validate:"string;uuid"`
+		Slug  string `validate:"string;slug;max=20"`
+	}
+	value, err := ExampleFor(Account{})
+	if err != nil {
+		t.Fatalf("ExampleFor returned error: %v", err)
+	}
+	if got := value.(Account); got.Email == "" || got.ID == "" || got.Slug == "" {
+		t.Fatalf("ExampleFor = %+v, want plugin samples", got)
+	}
+	if v, err := ExampleFor("string;uuidv7"); err != nil || v == "" {
+		t.Fatalf("ExampleFor(uuidv7) = %v, %v", v, err)
+	}
+}
+
+type facadeOrderStatus int
+
+const (
+	facadeOrderPending facadeOrderStatus = iota
+	facadeOrderPaid
+)
+
+func TestRootFacade_IntEnumOnStructFields(t *testing.T) {
+	RegisterIntEnum(map[facadeOrderStatus]string{facadeOrderPending: "pending", facadeOrderPaid: "paid"})
+	v := New()
+
+	type Order struct {
+		Status   facadeOrderStatus  `validate:"int;enum=facadeOrderStatus"`
+		Previous *facadeOrderStatus `validate:"int;omitempty;enum=facadeOrderStatus"`
+		Filter   string             `validate:"string;omitempty;enum=facadeOrderStatus"`
+	}
+
+	paid := facadeOrderPaid
+	if err := v.ValidateStruct(Order{Status: facadeOrderPending, Previous: &paid, Filter: "paid"}); err != nil {
+		t.Fatalf("valid order failed: %v", err)
+	}
+	bad := facadeOrderStatus(7)
+	err := v.ValidateStruct(Order{Status: 5, Previous: &bad, Filter: "lost"})
+	var es verrs.Errors
+	if !errors.As(err, &es) || len(es) != 3 {
+		t.Fatalf("expected three enum errors, got %v", err)
+	}
+	for _, e := range es {
+		if e.Code != verrs.CodeEnumValue && e.Code != verrs.CodeEnumName {
+			t.Fatalf("unexpected code %s in %v", e.Code, err)
+		}
+	}
+	if err := v.Int().Enum("facadeOrderStatus").Build()(facadeOrderPaid); err != nil {
+		t.Fatalf("enum builder failed: %v", err)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !validate_lite
+
 package structvalidator
 
 import (
@@ -5,6 +7,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aatuh/validate/v3/core"
 	verrs "github.com/aatuh/validate/v3/errors"
@@ -65,6 +68,40 @@ func TestStruct_DeterministicMapTraversalAndJSONFieldNames(t *testing.T) {
 	}
 }
 
+func TestStruct_UseJSONNames(t *testing.T) {
+	sv := NewStructValidator(core.New().WithTranslator(dummyTr{}))
+
+	type Line struct {
+		SKU string `json:"sku,omitempty" validate:"string;required"`
+	}
+	type Profile struct {
+		UserName string `json:"user_name" validate:"string;min=3"`
+		Nick     string `json:"-" validate:"string;min=3"`
+	}
+	type Input struct {
+		Profile Profile `json:"profile"`
+		Lines   []Line  `json:"lines"`
+	}
+
+	in := Input{Profile: Profile{UserName: "a", Nick: "b"}, Lines: []Line{{}}}
+	err := sv.ValidateStructWithOpts(in, core.ValidateOpts{UseJSONNames: true})
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("expected structured errors, got %v", err)
+	}
+	got := []string{es[0].Path, es[1].Path, es[2].Path}
+	want := []string{"profile.user_name", "profile.Nick", "lines[0].sku"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %#v, want %#v", got, want)
+	}
+
+	upper := func(f reflect.StructField) string { return strings.ToUpper(f.Name) }
+	err = sv.ValidateStructWithOpts(in, core.ValidateOpts{UseJSONNames: true, FieldNameFunc: upper})
+	if !errors.As(err, &es) || es[0].Path != "PROFILE.USERNAME" {
+		t.Fatalf("FieldNameFunc should take precedence, got %v", err)
+	}
+}
+
 func TestStruct_CrossFieldRules(t *testing.T) {
 	v := core.New().WithTranslator(dummyTr{})
 	sv := NewStructValidator(v)
@@ -92,6 +129,70 @@ func TestStruct_CrossFieldRules(t *testing.T) {
 	}
 }
 
+func TestStruct_ConstantTimeCrossFieldRules(t *testing.T) {
+	sv := NewStructValidator(core.New().WithTranslator(dummyTr{}))
+
+	type Input struct {
+		Provided string `validate:"string;eqField=Key;constantTime"`
+		Key      string
+		Rotated  string `validate:"string;constantTime;neField=Key"`
+	}
+
+	if err := sv.ValidateStruct(Input{Provided: "k3y", Key: "k3y", Rotated: "new"}); err != nil {
+		t.Fatalf("valid input failed: %v", err)
+	}
+	err := sv.ValidateStruct(Input{Provided: "k3x", Key: "k3y", Rotated: "k3y"})
+	for _, code := range []string{verrs.CodeFieldEqual, verrs.CodeFieldNotEqual} {
+		if err == nil || !strings.Contains(err.Error(), code) {
+			t.Fatalf("expected %s in %v", code, err)
+		}
+	}
+	if !fieldValuesEqual([]byte("abc"), "abc", true) || fieldValuesEqual("abc", "abcd", true) {
+		t.Fatal("constant-time comparison mismatch")
+	}
+
+	type Orphan struct {
+		Value string `validate:"string;constantTime"`
+	}
+	if err := sv.CompileStruct(Orphan{}, core.ValidateOpts{}); err == nil || !strings.Contains(err.Error(), "constantTime") {
+		t.Fatalf("expected constantTime compile error, got %v", err)
+	}
+}
+
+func TestStruct_TimeRulesOnTimeAndStringFields(t *testing.T) {
+	sv := NewStructValidator(core.New().WithTranslator(dummyTr{}))
+
+	type Booking struct {
+		Created time.Time  `validate:"time;before=now;after=2020-01-01"`
+		Start   string     `validate:"time;layout=2006-01-02;min=2020-01-01;max=now+8760h"`
+		Ends    *string    `validate:"time;omitempty;after=now"`
+		Expires *time.Time `validate:"time;omitempty;after=now"`
+	}
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	valid := Booking{Created: past, Start: "2024-05-01", Ends: &future}
+	if err := sv.ValidateStruct(valid); err != nil {
+		t.Fatalf("valid input failed: %v", err)
+	}
+
+	late := past.Add(-time.Hour)
+	err := sv.ValidateStruct(Booking{Created: time.Now().Add(time.Hour), Start: "01/05/2024", Expires: &late})
+	for _, code := range []string{verrs.CodeTimeBefore, verrs.CodeTimeParse, verrs.CodeTimeAfter} {
+		if err == nil || !strings.Contains(err.Error(), code) {
+			t.Fatalf("expected %s in %v", code, err)
+		}
+	}
+
+	example, err := sv.ExampleFor(Booking{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("ExampleFor: %v", err)
+	}
+	if err := sv.ValidateStruct(example); err != nil {
+		t.Fatalf("example %+v failed: %v", example, err)
+	}
+}
+
 func TestStruct_InvalidCrossFieldReferences(t *testing.T) {
 	v := core.New().WithTranslator(dummyTr{})
 	sv := NewStructValidator(v)
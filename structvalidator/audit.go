@@ -0,0 +1,425 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// RuleAuditEntry reports how one tag token of one field reacted to
+// perturbed values.
+//
+// Fields:
+//   - Path: Field path; "[]" marks elements of slices, arrays and maps.
+//   - Rule: The tag token, e.g. "min=3".
+//   - Tried: Number of perturbed values checked.
+//   - Fired: Number of perturbed values the rule rejected.
+//   - RedundantWith: Another token of the same field that rejected every
+//     value this one rejected, or "" when the rule catches something unique.
+type RuleAuditEntry struct {
+	Path          string
+	Rule          string
+	Tried         int
+	Fired         int
+	RedundantWith string
+}
+
+// RuleAudit is the result of AuditRules, ordered by path and tag order.
+type RuleAudit []RuleAuditEntry
+
+// Dead returns entries whose rule never fired.
+func (a RuleAudit) Dead() RuleAudit {
+	var out RuleAudit
+	for _, e := range a {
+		if e.Tried > 0 && e.Fired == 0 {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Redundant returns entries whose rule fired only where another rule of the
+// same field fired too.
+func (a RuleAudit) Redundant() RuleAudit {
+	var out RuleAudit
+	for _, e := range a {
+		if e.Fired > 0 && e.RedundantWith != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// AuditRules is a mutation-testing style check of the rules on a struct
+// type. Every tagged field of every corpus example is perturbed (emptied,
+// lengthened, pushed out of range, given the wrong type where the field
+// allows it) and each tag token is evaluated on its own against the
+// perturbed values. Rules that never fire are dead for this corpus and
+// rules that only fire alongside another rule are redundant.
+//
+// Only the field's own rules are audited; cross-field and struct rules are
+// skipped. Results depend on the corpus and the fixed perturbation set, so
+// treat them as review hints rather than proof.
+//
+// Parameters:
+//   - corpus: Valid example values of one struct type.
+//   - opts: PathSep, FieldNameFunc, UseJSONNames and SchemaVersion are
+//     honored.
+//
+// Returns:
+//   - RuleAudit: One entry per audited token.
+//   - error: If the corpus is empty, mixes types or holds invalid examples.
+func (sv *StructValidator) AuditRules(corpus []any, opts core.ValidateOpts) (RuleAudit, error) {
+	opts = core.ApplyOpts(sv.validator, opts)
+	if len(corpus) == 0 {
+		return nil, fmt.Errorf("AuditRules: empty corpus")
+	}
+
+	type ruleKey struct {
+		path  string
+		index int
+	}
+	type ruleState struct {
+		entry RuleAuditEntry
+		fired map[string]bool
+	}
+	states := map[ruleKey]*ruleState{}
+	var order []ruleKey
+	var firstType reflect.Type
+
+	auditField := func(path, tag string, fv reflect.Value, sample string, field reflect.StructField) error {
+		tokens, _ := splitFieldAccess(types.SplitTag(tag))
+		tokens, _ = splitSensitive(tokens)
+		tokens, _ = splitDive(tokens)
+		tokens, _ = splitQuotaTokens(tokens)
+		tokens, _, err := splitStructRules(tokens)
+		if err != nil || len(tokens) == 0 {
+			return err
+		}
+		base := []string{tokens[0]}
+		rules := tokens[1:]
+		for _, token := range rules {
+			if strings.TrimSpace(token) == "omitempty" {
+				base = append(base, token)
+			}
+		}
+		isInterface := field.Type.Kind() == reflect.Interface
+		type audited struct {
+			index int
+			check types.ContextValidatorFunc
+		}
+		var checks []audited
+		compile := func(index int, tokens []string) error {
+			check, err := sv.validator.FromRulesContextWithOpts(tokens, types.CompileOpts{})
+			if err != nil {
+				return err
+			}
+			checks = append(checks, audited{index: index, check: check})
+			return nil
+		}
+		if isInterface {
+			if err := compile(0, base); err != nil {
+				return err
+			}
+		}
+		for i, token := range rules {
+			if strings.TrimSpace(token) == "omitempty" {
+				continue
+			}
+			if err := compile(i+1, append(append([]string(nil), base...), token)); err != nil {
+				return err
+			}
+		}
+		if len(checks) == 0 {
+			return nil
+		}
+		baseCheck, err := sv.validator.FromRulesContextWithOpts(base, types.CompileOpts{})
+		if err != nil {
+			return err
+		}
+
+		for _, c := range checks {
+			key := ruleKey{path: path, index: c.index}
+			if _, ok := states[key]; !ok {
+				states[key] = &ruleState{
+					entry: RuleAuditEntry{Path: path, Rule: strings.TrimSpace(tokens[c.index])},
+					fired: map[string]bool{},
+				}
+				order = append(order, key)
+			}
+		}
+		for m, mutated := range perturbations(fv, isInterface) {
+			value := valueForValidation(mutated)
+			baseFails := baseCheck(context.Background(), value) != nil
+			id := sample + ":" + strconv.Itoa(m)
+			for _, c := range checks {
+				st := states[ruleKey{path: path, index: c.index}]
+				if c.index != 0 && baseFails {
+					continue
+				}
+				st.entry.Tried++
+				if c.check(context.Background(), value) != nil {
+					st.entry.Fired++
+					st.fired[id] = true
+				}
+			}
+		}
+		return nil
+	}
+
+	for n, example := range corpus {
+		val := reflect.ValueOf(example)
+		for val.IsValid() && val.Kind() == reflect.Ptr && !val.IsNil() {
+			val = val.Elem()
+		}
+		if !val.IsValid() || val.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("AuditRules: expected struct, got %T", example)
+		}
+		if firstType == nil {
+			firstType = val.Type()
+		} else if val.Type() != firstType {
+			return nil, fmt.Errorf("AuditRules: corpus mixes %s and %s", firstType, val.Type())
+		}
+		if err := sv.ValidateStructWithOpts(example, opts); err != nil {
+			return nil, fmt.Errorf("AuditRules: corpus example %d is invalid: %w", n, err)
+		}
+
+		var walk func(v reflect.Value, path, sample string) error
+		walk = func(v reflect.Value, path, sample string) error {
+			t := v.Type()
+			for i := 0; i < t.NumField(); i++ {
+				ft := t.Field(i)
+				if ft.PkgPath != "" {
+					continue
+				}
+				fv := v.Field(i)
+				fieldPath := fieldPathJoin(path, fieldDisplayName(ft, opts), opts.PathSep)
+				tag, err := sv.fieldTag(t, ft, opts.SchemaVersion)
+				if err != nil {
+					return fmt.Errorf("AuditRules: %s: %w", fieldPath, err)
+				}
+				if tag != "" {
+					if err := auditField(fieldPath, tag, fv, sample, ft); err != nil {
+						return fmt.Errorf("AuditRules: %s: %w", fieldPath, err)
+					}
+					continue
+				}
+				derefFv := derefPointer(fv)
+				switch derefFv.Kind() {
+				case reflect.Struct:
+					if err := walk(derefFv, fieldPath, sample); err != nil {
+						return err
+					}
+				case reflect.Slice, reflect.Array:
+					for j := 0; j < derefFv.Len(); j++ {
+						if ev := derefPointer(derefFv.Index(j)); ev.Kind() == reflect.Struct {
+							if err := walk(ev, fieldPath+"[]", sample+"/"+strconv.Itoa(j)); err != nil {
+								return err
+							}
+						}
+					}
+				case reflect.Map:
+					for j, mk := range sortedMapKeys(derefFv) {
+						if ev := derefPointer(derefFv.MapIndex(mk)); ev.Kind() == reflect.Struct {
+							if err := walk(ev, fieldPath+"[]", sample+"/"+strconv.Itoa(j)); err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+			return nil
+		}
+		if err := walk(val, "", strconv.Itoa(n)); err != nil {
+			return nil, err
+		}
+	}
+
+	audit := make(RuleAudit, 0, len(order))
+	for i, key := range order {
+		st := states[key]
+		for j, other := range order {
+			if i == j || other.path != key.path || len(st.fired) == 0 {
+				continue
+			}
+			ot := states[other]
+			if !firedSubset(st.fired, ot.fired) {
+				continue
+			}
+			// Equal sets: only the later rule is redundant.
+			if len(st.fired) == len(ot.fired) && j > i {
+				continue
+			}
+			st.entry.RedundantWith = ot.entry.Rule
+			break
+		}
+		audit = append(audit, st.entry)
+	}
+	sort.SliceStable(audit, func(i, j int) bool { return audit[i].Path < audit[j].Path })
+	return audit, nil
+}
+
+func firedSubset(a, b map[string]bool) bool {
+	for id := range a {
+		if !b[id] {
+			return false
+		}
+	}
+	return true
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// perturbations returns values of v's type that are likely to break rules:
+// empty, oversized, out-of-range and boundary values. Values of interface
+// fields also get values of the wrong type.
+func perturbations(v reflect.Value, wrongType bool) []reflect.Value {
+	var out []reflect.Value
+	if wrongType {
+		for _, w := range []any{"x", 42, -1.5, true, []string{"x"}} {
+			out = append(out, reflect.ValueOf(w))
+		}
+		v = v.Elem()
+		if !v.IsValid() {
+			return out
+		}
+		return append(out, perturbations(v, false)...)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		for _, elem := range perturbations(v.Elem(), false) {
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(elem)
+			out = append(out, p)
+		}
+		return out
+	}
+
+	t := v.Type()
+	add := func(x any) {
+		rv := reflect.New(t).Elem()
+		xv := reflect.ValueOf(x)
+		if !xv.Type().ConvertibleTo(t) {
+			return
+		}
+		rv.Set(xv.Convert(t))
+		out = append(out, rv)
+	}
+	if t == timeType {
+		at := v.Interface().(time.Time)
+		for _, x := range []time.Time{{}, at.Add(-time.Nanosecond), at.Add(time.Nanosecond),
+			at.AddDate(-100, 0, 0), at.AddDate(100, 0, 0)} {
+			add(x)
+		}
+		return out
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s := v.String()
+		half := s[:len(s)/2]
+		long := strings.Repeat("x", 64*(len(s)+16))
+		for _, x := range []string{"", " ", "a", half, s + "x", s + s, long,
+			strings.ToUpper(s), s + "é", "<" + s + ">", s + "/../", "not valid"} {
+			add(x)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		for _, x := range []int64{0, -1, 1, n - 1, n + 1, -n, n * 2, math.MinInt64, math.MaxInt64} {
+			if rv := reflect.New(t).Elem(); !rv.OverflowInt(x) {
+				rv.SetInt(x)
+				out = append(out, rv)
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		for _, x := range []uint64{0, 1, n - 1, n + 1, n * 2, math.MaxUint64} {
+			if rv := reflect.New(t).Elem(); !rv.OverflowUint(x) {
+				rv.SetUint(x)
+				out = append(out, rv)
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		for _, x := range []float64{0, -1, 1, f - 1, f + 1, -f, f * 2, 1e300, -1e300,
+			math.NaN(), math.Inf(1), math.Inf(-1)} {
+			rv := reflect.New(t).Elem()
+			rv.SetFloat(x)
+			out = append(out, rv)
+		}
+	case reflect.Bool:
+		add(!v.Bool())
+	case reflect.Slice:
+		out = append(out, reflect.Zero(t), reflect.MakeSlice(t, 0, 0))
+		if n := v.Len(); n > 0 {
+			out = append(out, v.Slice(0, n-1), reflect.Append(v, v.Index(0)))
+			grown := v
+			for i := 0; i < 64; i++ {
+				grown = reflect.Append(grown, v.Index(i%n))
+			}
+			out = append(out, grown)
+			for _, elem := range perturbations(v.Index(0), false) {
+				cp := reflect.MakeSlice(t, n, n)
+				reflect.Copy(cp, v)
+				cp.Index(0).Set(elem)
+				out = append(out, cp)
+			}
+		}
+	case reflect.Array:
+		if n := v.Len(); n > 0 {
+			out = append(out, reflect.Zero(t))
+			for _, elem := range perturbations(v.Index(0), false) {
+				cp := reflect.New(t).Elem()
+				reflect.Copy(cp, v)
+				cp.Index(0).Set(elem)
+				out = append(out, cp)
+			}
+			if n > 1 {
+				cp := reflect.New(t).Elem()
+				reflect.Copy(cp, v)
+				cp.Index(1).Set(v.Index(0))
+				out = append(out, cp)
+			}
+		}
+	case reflect.Map:
+		out = append(out, reflect.Zero(t), reflect.MakeMap(t))
+		keys := sortedMapKeys(v)
+		if len(keys) > 0 {
+			copyMap := func() reflect.Value {
+				cp := reflect.MakeMapWithSize(t, v.Len())
+				for _, k := range keys {
+					cp.SetMapIndex(k, v.MapIndex(k))
+				}
+				return cp
+			}
+			cp := copyMap()
+			cp.SetMapIndex(keys[0], reflect.Value{})
+			out = append(out, cp)
+			for _, elem := range perturbations(v.MapIndex(keys[0]), false) {
+				cp := copyMap()
+				cp.SetMapIndex(keys[0], elem)
+				out = append(out, cp)
+			}
+			for _, key := range perturbations(keys[0], false) {
+				cp := copyMap()
+				cp.SetMapIndex(key, v.MapIndex(keys[0]))
+				out = append(out, cp)
+			}
+		}
+	case reflect.Struct:
+		out = append(out, reflect.Zero(t))
+	}
+	return out
+}
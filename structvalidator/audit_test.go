@@ -0,0 +1,82 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+type auditItem struct {
+	SKU string `json:"sku" validate:"string;min=1;max=1000000"`
+}
+
+type auditRequest struct {
+	Name  string      `json:"name" validate:"string;min=1;nonempty;max=20"`
+	Age   int         `json:"age" validate:"int;min=0;max=150"`
+	Flag  bool        `json:"flag" validate:"bool;true"`
+	Items []auditItem `json:"items"`
+	Any   any         `json:"any" validate:"string"`
+}
+
+func auditFind(t *testing.T, audit RuleAudit, path, rule string) RuleAuditEntry {
+	t.Helper()
+	for _, e := range audit {
+		if e.Path == path && e.Rule == rule {
+			return e
+		}
+	}
+	t.Fatalf("no audit entry for %s %s in %+v", path, rule, audit)
+	return RuleAuditEntry{}
+}
+
+func TestAuditRules_FindsDeadAndRedundantRules(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	corpus := []any{
+		auditRequest{Name: "alice", Age: 30, Flag: true, Items: []auditItem{{SKU: "a-1"}}, Any: "x"},
+		&auditRequest{Name: "bob", Age: 0, Flag: true, Any: "y"},
+	}
+	audit, err := sv.AuditRules(corpus, core.ValidateOpts{FieldNameFunc: JSONFieldName})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e := auditFind(t, audit, "items[].sku", "max=1000000"); e.Fired != 0 || e.Tried == 0 {
+		t.Fatalf("max=1000000 should be dead: %+v", e)
+	}
+	if e := auditFind(t, audit, "name", "max=20"); e.Fired == 0 || e.RedundantWith != "" {
+		t.Fatalf("max=20 should fire uniquely: %+v", e)
+	}
+	if e := auditFind(t, audit, "name", "nonempty"); e.RedundantWith != "min=1" {
+		t.Fatalf("nonempty should be redundant with min=1: %+v", e)
+	}
+	if e := auditFind(t, audit, "age", "min=0"); e.Fired == 0 {
+		t.Fatalf("min=0 should fire on negative ages: %+v", e)
+	}
+	if e := auditFind(t, audit, "any", "string"); e.Fired == 0 {
+		t.Fatalf("type check on interface field should fire: %+v", e)
+	}
+
+	var dead []string
+	for _, e := range audit.Dead() {
+		dead = append(dead, e.Path+" "+e.Rule)
+	}
+	if strings.Join(dead, ",") != "items[].sku max=1000000" {
+		t.Fatalf("dead = %v", dead)
+	}
+}
+
+func TestAuditRules_RejectsInvalidCorpus(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	if _, err := sv.AuditRules(nil, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for empty corpus")
+	}
+	if _, err := sv.AuditRules([]any{auditRequest{Age: -1}}, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for invalid example")
+	}
+	if _, err := sv.AuditRules([]any{auditRequest{Name: "a", Flag: true, Any: "x"}, auditItem{SKU: "x"}}, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for mixed corpus")
+	}
+}
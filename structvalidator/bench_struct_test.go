@@ -1,6 +1,9 @@
+//go:build !validate_lite
+
 package structvalidator
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/aatuh/validate/v3/core"
@@ -55,3 +58,44 @@ func BenchmarkStruct_Medium_StopOnFirst(b *testing.B) {
 		_ = sv.ValidateStructWithOpts(in, opts)
 	}
 }
+
+func BenchmarkStruct_Medium_Typed(b *testing.B) {
+	sv := NewStructValidator(core.New())
+	tv, err := sv.CompileType(reflect.TypeOf(benchOrder{}))
+	if err != nil {
+		b.Fatal(err)
+	}
+	in := benchOrder{
+		ID:    "ORDER001",
+		Lines: []benchItem{{Name: "Alpha", Price: 10}, {Name: "Bravo", Price: 20}},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tv.Validate(in)
+	}
+}
+
+type benchSmall struct {
+	Name string `validate:"string;min=3;max=20"`
+	Code string `validate:"string;alnum;max=12"`
+	Age  int    `validate:"int;min=0;max=150"`
+}
+
+func BenchmarkStruct_Small(b *testing.B) {
+	sv := NewStructValidator(core.New())
+	cases := []struct {
+		name string
+		in   benchSmall
+	}{
+		{"valid", benchSmall{Name: "Alice", Code: "A1B2C3", Age: 30}},
+		{"oneError", benchSmall{Name: "Al", Code: "A1B2C3", Age: 30}},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = sv.ValidateStruct(c.in)
+			}
+		})
+	}
+}
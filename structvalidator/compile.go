@@ -0,0 +1,171 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// TagCompileError describes one struct tag that failed to compile.
+//
+// Fields:
+//   - Path: Field path using the same naming as validation errors; "[]"
+//     marks elements of slices, arrays and maps.
+//   - Tag: The effective tag, after schema version overrides.
+//   - Err: The parse or compile error.
+type TagCompileError struct {
+	Path string
+	Tag  string
+	Err  error
+}
+
+func (e TagCompileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e TagCompileError) Unwrap() error { return e.Err }
+
+// TagCompileErrors collects every tag compile error of a struct type.
+type TagCompileErrors []TagCompileError
+
+func (es TagCompileErrors) Error() string {
+	lines := make([]string, len(es))
+	for i, e := range es {
+		lines[i] = e.Error()
+	}
+	return fmt.Sprintf("%d struct tag error(s):\n%s", len(es), strings.Join(lines, "\n"))
+}
+
+// CompileStruct compiles every `validate` tag reachable from the type of s,
+// including nested structs and collection elements, without validating any
+// values. It returns TagCompileErrors listing all failures, so broken tags
+// surface at startup instead of as runtime field errors one request at a
+// time.
+//
+// Parameters:
+//   - s: A struct value, pointer to struct, or reflect.Type of a struct.
+//   - opts: PathSep, FieldNameFunc, UseJSONNames and SchemaVersion are
+//     honored.
+//
+// Returns:
+//   - error: TagCompileErrors if any tag fails, nil otherwise.
+func (sv *StructValidator) CompileStruct(s any, opts core.ValidateOpts) error {
+	opts = core.ApplyOpts(sv.validator, opts)
+	typ, ok := s.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(s)
+	}
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return fmt.Errorf("CompileStruct: expected struct, got %T", s)
+	}
+
+	var errs TagCompileErrors
+	onStack := map[reflect.Type]bool{}
+	var walk func(t reflect.Type, path string)
+	walk = func(t reflect.Type, path string) {
+		if onStack[t] {
+			return
+		}
+		onStack[t] = true
+		defer delete(onStack, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			ft := t.Field(i)
+			if ft.PkgPath != "" {
+				continue
+			}
+			if sv.skipsField(ft) {
+				continue
+			}
+			fieldPath := fieldPathJoin(path, fieldDisplayName(ft, opts), opts.PathSep)
+			tag, err := sv.fieldTag(t, ft, opts.SchemaVersion)
+			if err != nil {
+				errs = append(errs, TagCompileError{Path: fieldPath, Tag: tag, Err: err})
+				continue
+			}
+			if tag == "" || dives(tag) || sv.nestsTagged(ft) {
+				if elem, elemPath, ok := nestedStructType(ft.Type, fieldPath); ok {
+					walk(elem, elemPath)
+				}
+			}
+			if tag == "" {
+				continue
+			}
+			if err := sv.compileFieldTag(t, tag); err != nil {
+				errs = append(errs, TagCompileError{Path: fieldPath, Tag: tag, Err: err})
+			}
+		}
+	}
+	walk(typ, "")
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// nestedStructType returns the struct type the runtime walk would recurse
+// into for an untagged field of type t.
+func nestedStructType(t reflect.Type, path string) (reflect.Type, string, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t, path, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		elem := t.Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct {
+			return elem, path + "[]", true
+		}
+	}
+	return nil, "", false
+}
+
+// compileFieldTag compiles tag the way the runtime walk does and checks that
+// cross-field rules reference fields of owner.
+func (sv *StructValidator) compileFieldTag(owner reflect.Type, tag string) error {
+	return sv.CompileFieldTag(tag, func(field string) bool {
+		ref, found := owner.FieldByName(field)
+		return found && ref.PkgPath == ""
+	})
+}
+
+// CompileFieldTag compiles a single struct field tag, including struct-only
+// tokens, without a reflect.Type. Static checkers use it with hasField
+// answering whether the owning struct has an exported field of that name.
+func (sv *StructValidator) CompileFieldTag(tag string, hasField func(name string) bool) error {
+	tokens, _ := splitFieldAccess(types.SplitTag(tag))
+	tokens, _ = splitSensitive(tokens)
+	tokens, _ = splitDive(tokens)
+	tokens, _ = splitQuotaTokens(tokens)
+	rules, structRules, err := splitStructRules(tokens)
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		if _, err := sv.validator.FromRulesContextWithOpts(rules, types.CompileOpts{}); err != nil {
+			return err
+		}
+	}
+	for _, rule := range structRules {
+		if _, err := compileStructRule(rule, sv.validator); err != nil {
+			return err
+		}
+		if field, ok := rule.Args["field"].(string); ok && hasField != nil && !hasField(field) {
+			return fmt.Errorf("%s references missing or unexported field %s", rule.Kind, field)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+type compileItem struct {
+	SKU  string `json:"sku" validate:"string;min=abc"`
+	Qty  int    `json:"qty" validate:"int;min=1"`
+	Next *compileItem
+}
+
+type compileOrder struct {
+	ID      string        `json:"id" validate:"string;notARule=1"`
+	Confirm string        `json:"confirm" validate:"string;eqField=Missing"`
+	Items   []compileItem `json:"items"`
+	Meta    map[string]*compileItem
+	Note    string `json:"note" validate:"string;max=10"`
+}
+
+func TestCompileStruct_CollectsAllTagErrors(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	err := sv.CompileStruct(&compileOrder{}, core.ValidateOpts{FieldNameFunc: JSONFieldName})
+
+	var report TagCompileErrors
+	if !errors.As(err, &report) {
+		t.Fatalf("expected TagCompileErrors, got %T %v", err, err)
+	}
+	var paths []string
+	for _, e := range report {
+		paths = append(paths, e.Path)
+	}
+	want := []string{"id", "confirm", "items[].sku", "Meta[].sku"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	if report[2].Tag != "string;min=abc" {
+		t.Fatalf("tag = %q", report[2].Tag)
+	}
+}
+
+func TestCompileStruct_ValidTypes(t *testing.T) {
+	type ok struct {
+		Name  string `validate:"string;min=1"`
+		Other string `validate:"string;neField=Name"`
+	}
+	sv := NewStructValidator(core.NewEngine())
+	if err := sv.CompileStruct(reflect.TypeOf(ok{}), core.ValidateOpts{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sv.CompileStruct(42, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for non-struct")
+	}
+}
@@ -1,3 +1,5 @@
+//go:build !validate_lite
+
 package structvalidator
 
 import (
@@ -21,10 +23,10 @@ func TestStruct_RequiredIfAndRequiredUnless(t *testing.T) {
 	}
 
 	err := sv.ValidateStructWithOpts(Input{Kind: "business"}, core.ValidateOpts{FieldNameFunc: JSONFieldName})
-	requireStructFieldError(t, err, "company", verrs.CodeRequiredIf, nil)
+	requireStructFieldError(t, err, "company", verrs.CodeRequiredIf, "Kind")
 
 	err = sv.ValidateStructWithOpts(Input{Kind: "personal"}, core.ValidateOpts{FieldNameFunc: JSONFieldName})
-	requireStructFieldError(t, err, "first_name", verrs.CodeRequiredUnless, nil)
+	requireStructFieldError(t, err, "first_name", verrs.CodeRequiredUnless, "Kind")
 
 	if err := sv.ValidateStructWithOpts(Input{Kind: "business", Company: "Acme"}, core.ValidateOpts{FieldNameFunc: JSONFieldName}); err != nil {
 		t.Fatalf("valid business input failed: %v", err)
@@ -44,7 +46,7 @@ func TestStruct_RequiredIfPointerReferencesAndMalformedTags(t *testing.T) {
 		Token  *string `validate:"string;requiredIf=Status,active"`
 	}
 	err := sv.ValidateStruct(Input{Status: &active})
-	requireStructFieldError(t, err, "Token", verrs.CodeRequiredIf, nil)
+	requireStructFieldError(t, err, "Token", verrs.CodeRequiredIf, "Status")
 
 	type MissingReference struct {
 		Value string `validate:"string;requiredIf=Missing,yes"`
@@ -0,0 +1,339 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// CounterExample is a value that violates one rule of a schema.
+//
+// Fields:
+//   - Path: Field path of the violated rule; "" for tag schemas.
+//   - Rule: The violated tag token, e.g. "min=3" or "eqField=Password".
+//   - Value: The tag value, or a whole struct of the schema's type in which
+//     only the field at Path differs from the ExampleFor result.
+//   - Exact: Whether Rule is the only rule the value violates.
+//   - Errors: The errors validation reports for Value with all rules
+//     collected.
+type CounterExample struct {
+	Path   string
+	Rule   string
+	Value  any
+	Exact  bool
+	Errors verrs.Errors
+}
+
+// CounterExamples returns, for each rule of schema, a value that violates
+// it, starting from the ExampleFor result. Values that break only the
+// target rule are preferred; when none exists the value with the fewest
+// other failures is returned with Exact false. Rules no candidate breaks,
+// and readonly, writeonly and quota tokens, are left out.
+//
+// Parameters:
+//   - schema: A tag string, a struct value, a pointer to struct, or a
+//     reflect.Type of a struct.
+//   - opts: Options used for validation and field paths.
+func (sv *StructValidator) CounterExamples(schema any, opts core.ValidateOpts) ([]CounterExample, error) {
+	if tag, ok := schema.(string); ok {
+		return sv.tagCounterExamples(tag)
+	}
+	typ, ok := schema.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(schema)
+	}
+	isPtr := typ != nil && typ.Kind() == reflect.Ptr
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("CounterExamples: expected tag or struct, got %T", schema)
+	}
+
+	fresh := func() (reflect.Value, error) {
+		example, err := sv.ExampleFor(reflect.PointerTo(typ), opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(example), nil
+	}
+	base, err := fresh()
+	if err != nil {
+		return nil, err
+	}
+	collect := opts
+	collect.CollectAllRules = true
+	collect.StopOnFirst = false
+
+	var out []CounterExample
+	for _, field := range sv.exampleFields(base.Elem(), opts) {
+		for _, token := range field.tokens {
+			var best *CounterExample
+			for _, candidate := range sv.fieldCounterCandidates(field, token) {
+				ptr, err := fresh()
+				if err != nil {
+					return nil, err
+				}
+				fields := sv.exampleFields(ptr.Elem(), opts)
+				target := findExampleField(fields, field.path)
+				if target == nil {
+					continue
+				}
+				value := reflect.ValueOf(candidate)
+				if !value.IsValid() {
+					value = reflect.Zero(target.value.Type())
+				}
+				if !value.Type().AssignableTo(target.value.Type()) {
+					continue
+				}
+				target.value.Set(value)
+				syncEqualFields(fields, target)
+
+				var errs verrs.Errors
+				if err := sv.ValidateStructWithOpts(ptr.Interface(), collect); err != nil {
+					errs, _ = err.(verrs.Errors)
+				}
+				if !sv.violates(field, token, value, errs) {
+					continue
+				}
+				if best == nil || len(errs) < len(best.Errors) {
+					result := ptr.Interface()
+					if !isPtr {
+						result = ptr.Elem().Interface()
+					}
+					best = &CounterExample{Path: field.path, Rule: token, Value: result, Errors: errs}
+				}
+			}
+			if best != nil {
+				best.Exact = len(best.Errors) == 1 && best.Errors[0].Path == field.path
+				out = append(out, *best)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (sv *StructValidator) tagCounterExamples(tag string) ([]CounterExample, error) {
+	valid, err := sv.ExampleFor(tag, core.ValidateOpts{})
+	if err != nil {
+		return nil, err
+	}
+	tokens := types.SplitTag(tag)
+	for i := range tokens {
+		tokens[i] = strings.TrimSpace(tokens[i])
+	}
+	checks, rules, err := sv.tokenChecks(tokens)
+	if err != nil {
+		return nil, err
+	}
+	full, err := sv.validator.FromRulesContextWithOpts(tokens, types.CompileOpts{CollectAll: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var out []CounterExample
+	for i, token := range tokens {
+		if checks[i] == nil {
+			continue
+		}
+		var best *CounterExample
+		bestOthers := 0
+		for _, candidate := range types.CounterValues(rules[i], valid) {
+			if checks[i](context.Background(), candidate) == nil {
+				continue
+			}
+			others := 0
+			for j, check := range checks {
+				if j != i && check != nil && check(context.Background(), candidate) != nil {
+					others++
+				}
+			}
+			if best == nil || others < bestOthers {
+				var errs verrs.Errors
+				if err := full(context.Background(), candidate); err != nil {
+					errs, _ = err.(verrs.Errors)
+				}
+				best, bestOthers = &CounterExample{Rule: token, Value: candidate, Errors: errs}, others
+			}
+		}
+		if best != nil {
+			best.Exact = bestOthers == 0
+			out = append(out, *best)
+		}
+	}
+	return out, nil
+}
+
+// tokenChecks compiles each rule token on its own, together with the base
+// type and omitempty. Entries for the base type and modifiers are nil.
+func (sv *StructValidator) tokenChecks(tokens []string) ([]types.ContextValidatorFunc, []types.Rule, error) {
+	checks := make([]types.ContextValidatorFunc, len(tokens))
+	rules := make([]types.Rule, len(tokens))
+	if len(tokens) == 0 {
+		return checks, rules, nil
+	}
+	base := []string{tokens[0]}
+	for _, token := range tokens[1:] {
+		if token == "omitempty" {
+			base = append(base, token)
+		}
+	}
+	for i, token := range tokens {
+		if i == 0 || token == "omitempty" {
+			continue
+		}
+		chain := append(append([]string(nil), base...), token)
+		parsed, err := sv.validator.ParseRules(chain)
+		if err != nil {
+			return nil, nil, err
+		}
+		check, err := sv.validator.FromRulesContextWithOpts(chain, types.CompileOpts{})
+		if err != nil {
+			return nil, nil, err
+		}
+		checks[i], rules[i] = check, parsed[len(parsed)-1]
+	}
+	return checks, rules, nil
+}
+
+// exampleField is a tagged field found in a generated example.
+type exampleField struct {
+	path   string
+	name   string
+	value  reflect.Value
+	tokens []string // rule and struct rule tokens, without access and quota markers
+	rules  []string // field rule tokens
+	checks []types.ContextValidatorFunc
+	parsed []types.Rule
+	owner  reflect.Value
+}
+
+// exampleFields lists tagged fields the way the runtime walk visits them,
+// descending into untagged structs, pointers and slice elements.
+func (sv *StructValidator) exampleFields(v reflect.Value, opts core.ValidateOpts) []*exampleField {
+	var out []*exampleField
+	var walk func(v reflect.Value, path string)
+	walk = func(v reflect.Value, path string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			ft := t.Field(i)
+			if ft.PkgPath != "" {
+				continue
+			}
+			fv := v.Field(i)
+			fieldPath := fieldPathJoin(path, fieldDisplayName(ft, opts), opts.PathSep)
+			tag, _ := sv.fieldTag(t, ft, opts.SchemaVersion)
+			if tag == "" {
+				d := derefPointer(fv)
+				switch d.Kind() {
+				case reflect.Struct:
+					walk(d, fieldPath)
+				case reflect.Slice, reflect.Array:
+					for j := 0; j < d.Len(); j++ {
+						if e := derefPointer(d.Index(j)); e.Kind() == reflect.Struct {
+							walk(e, fmt.Sprintf("%s[%d]", fieldPath, j))
+						}
+					}
+				}
+				continue
+			}
+			tokens, _ := splitFieldAccess(types.SplitTag(tag))
+			tokens, _ = splitSensitive(tokens)
+			tokens, _ = splitDive(tokens)
+			tokens, _ = splitQuotaTokens(tokens)
+			var kept []string
+			for _, token := range tokens {
+				if token = strings.TrimSpace(token); token != "" {
+					kept = append(kept, token)
+				}
+			}
+			if len(kept) == 0 {
+				continue
+			}
+			rules, _, _ := splitStructRules(kept)
+			var targets []string
+			for _, token := range kept[1:] {
+				if token != "omitempty" {
+					targets = append(targets, token)
+				}
+			}
+			out = append(out, &exampleField{path: fieldPath, name: ft.Name, value: fv, tokens: targets, rules: rules, owner: v})
+		}
+	}
+	walk(v, "")
+	return out
+}
+
+func findExampleField(fields []*exampleField, path string) *exampleField {
+	for _, field := range fields {
+		if field.path == path {
+			return field
+		}
+	}
+	return nil
+}
+
+// syncEqualFields copies target's new value into sibling fields declared
+// eqField=target, so breaking target does not also break them.
+func syncEqualFields(fields []*exampleField, target *exampleField) {
+	for _, field := range fields {
+		if field == target || field.owner != target.owner {
+			continue
+		}
+		_, structRules, _ := splitStructRules(field.tokens)
+		for _, rule := range structRules {
+			if ref, ok := rule.Args["field"].(string); ok && rule.Kind == structRuleEqual &&
+				ref == target.name && target.value.Type().AssignableTo(field.value.Type()) {
+				field.value.Set(target.value)
+			}
+		}
+	}
+}
+
+// fieldCounterCandidates returns values for a field that may break token.
+func (sv *StructValidator) fieldCounterCandidates(field *exampleField, token string) []any {
+	current := field.value.Interface()
+	_, structRules, _ := splitStructRules([]string{token})
+	if len(structRules) > 0 {
+		candidates := types.CounterValues(types.Rule{Kind: structRules[0].Kind}, current)
+		candidates = append(candidates, reflect.Zero(field.value.Type()).Interface())
+		if other, ok := structRules[0].Args["field"].(string); ok {
+			if ref := field.owner.FieldByName(other); ref.IsValid() && ref.Type().AssignableTo(field.value.Type()) {
+				candidates = append(candidates, ref.Interface())
+			}
+		}
+		return candidates
+	}
+	if field.checks == nil {
+		field.checks, field.parsed, _ = sv.tokenChecks(field.rules)
+	}
+	for i, t := range field.rules {
+		if t == token && field.checks != nil && field.checks[i] != nil {
+			return types.CounterValues(field.parsed[i], current)
+		}
+	}
+	return nil
+}
+
+// violates reports whether value breaks token: field rules are checked on
+// their own, struct rules by an error at the field path.
+func (sv *StructValidator) violates(field *exampleField, token string, value reflect.Value, errs verrs.Errors) bool {
+	for i, t := range field.rules {
+		if t == token && field.checks != nil && field.checks[i] != nil {
+			return field.checks[i](context.Background(), valueForValidation(value)) != nil
+		}
+	}
+	for _, e := range errs {
+		if e.Path == field.path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+func TestCounterExamples_StructCoversEachRule(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	cases, err := sv.CounterExamples(exampleSignup{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := map[string]CounterExample{}
+	for _, c := range cases {
+		if _, ok := c.Value.(exampleSignup); !ok {
+			t.Fatalf("%s %s: value = %T, want exampleSignup", c.Path, c.Rule, c.Value)
+		}
+		if len(c.Errors) == 0 {
+			t.Fatalf("%s %s: expected errors", c.Path, c.Rule)
+		}
+		got[c.Path+" "+c.Rule] = c
+	}
+	for _, key := range []string{
+		"Name required", "Name minRunes=3", "Password min=12",
+		"Confirm eqField=Password", "Age between=18,120", "Role oneof=admin,member",
+		"Tags min=2", "Tags unique", "Address.City max=40", "Address.Zip regex=^[0-9]{5}$",
+		"Others[0].City min=2",
+	} {
+		c, ok := got[key]
+		if !ok {
+			t.Errorf("missing counterexample for %q", key)
+			continue
+		}
+		if !c.Exact {
+			t.Errorf("%q: expected exact counterexample, errors %v", key, c.Errors)
+		}
+	}
+}
+
+func TestCounterExamples_Tag(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	cases, err := sv.CounterExamples("string;min=3;max=5;alpha", core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cases) != 3 {
+		t.Fatalf("got %d counterexamples, want 3: %+v", len(cases), cases)
+	}
+	for _, c := range cases {
+		if !c.Exact || len(c.Errors) != 1 {
+			t.Errorf("%s: value %v not exact: %v", c.Rule, c.Value, c.Errors)
+		}
+	}
+}
+
+func TestCounterExamples_RejectsNonStruct(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	if _, err := sv.CounterExamples(42, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for non-struct schema")
+	}
+}
@@ -0,0 +1,89 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+// CoverageReport lists the exported fields of a struct type by whether
+// struct validation applies any tag to them.
+//
+// Fields:
+//   - Validated: Paths of fields with a `validate` tag.
+//   - Unvalidated: Paths of fields that are never checked: untagged leaf
+//     fields and untagged structs without exported fields, such as
+//     time.Time. Untagged nested structs are walked instead of listed.
+type CoverageReport struct {
+	Validated   []string
+	Unvalidated []string
+}
+
+// Coverage reports which exported fields reachable from the type of s have
+// no validation rules, so security reviews can find unvalidated inputs in
+// large request models. Paths use the same naming as validation errors;
+// "[]" marks elements of slices, arrays and maps.
+//
+// Parameters:
+//   - s: A struct value, pointer to struct, or reflect.Type of a struct.
+//   - opts: PathSep, FieldNameFunc, UseJSONNames and SchemaVersion are
+//     honored.
+func (sv *StructValidator) Coverage(s any, opts core.ValidateOpts) (CoverageReport, error) {
+	opts = core.ApplyOpts(sv.validator, opts)
+	typ, ok := s.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(s)
+	}
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return CoverageReport{}, fmt.Errorf("Coverage: expected struct, got %T", s)
+	}
+
+	var report CoverageReport
+	onStack := map[reflect.Type]bool{}
+	var walk func(t reflect.Type, path string)
+	walk = func(t reflect.Type, path string) {
+		if onStack[t] {
+			return
+		}
+		onStack[t] = true
+		defer delete(onStack, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			ft := t.Field(i)
+			if ft.PkgPath != "" {
+				continue
+			}
+			fieldPath := fieldPathJoin(path, fieldDisplayName(ft, opts), opts.PathSep)
+			tag, _ := sv.fieldTag(t, ft, opts.SchemaVersion)
+			if tag != "" {
+				report.Validated = append(report.Validated, fieldPath)
+				if elem, elemPath, ok := nestedStructType(ft.Type, fieldPath); ok && dives(tag) {
+					walk(elem, elemPath)
+				}
+				continue
+			}
+			if elem, elemPath, ok := nestedStructType(ft.Type, fieldPath); ok && hasExportedField(elem) {
+				walk(elem, elemPath)
+				continue
+			}
+			report.Unvalidated = append(report.Unvalidated, fieldPath)
+		}
+	}
+	walk(typ, "")
+	return report, nil
+}
+
+func hasExportedField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
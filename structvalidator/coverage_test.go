@@ -0,0 +1,42 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+type coverageItem struct {
+	SKU   string `json:"sku" validate:"string;min=1"`
+	Notes string `json:"notes"`
+}
+
+type coverageRequest struct {
+	ID        string          `json:"id" validate:"string;uuid"`
+	Name      string          `json:"name"`
+	CreatedAt time.Time       `json:"created_at"`
+	Items     []*coverageItem `json:"items"`
+	Parent    *coverageRequest
+	internal  string
+}
+
+func TestCoverage_ReportsUnvalidatedFields(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	report, err := sv.Coverage(&coverageRequest{}, core.ValidateOpts{FieldNameFunc: JSONFieldName})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"id", "items[].sku"}; !reflect.DeepEqual(report.Validated, want) {
+		t.Fatalf("validated = %v, want %v", report.Validated, want)
+	}
+	if want := []string{"name", "created_at", "items[].notes"}; !reflect.DeepEqual(report.Unvalidated, want) {
+		t.Fatalf("unvalidated = %v, want %v", report.Unvalidated, want)
+	}
+	if _, err := sv.Coverage("x", core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for non-struct")
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type diveLine struct {
+	SKU string `validate:"string;required"`
+}
+
+type diveAddress struct {
+	City string `validate:"string;max=5"`
+}
+
+type diveOrder struct {
+	Lines   []diveLine   `validate:"slice;min=1;max=3;dive"`
+	Address *diveAddress `validate:"required;dive"`
+	Notes   []diveLine   `validate:"slice;max=3"`
+}
+
+func diveErrorPaths(t *testing.T, err error) []string {
+	t.Helper()
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("expected errors.Errors, got %v", err)
+	}
+	paths := make([]string, len(es))
+	for i, e := range es {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+func TestDive_ValidatesNestedStructsOfTaggedFields(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	order := diveOrder{
+		Lines:   []diveLine{{SKU: "a"}, {}},
+		Address: &diveAddress{City: "Helsinki"},
+		Notes:   []diveLine{{}},
+	}
+	got := diveErrorPaths(t, sv.ValidateStruct(order))
+	if want := []string{"Lines[1].SKU", "Address.City"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %v, want %v", got, want)
+	}
+
+	typed, err := sv.CompileType(reflect.TypeOf(diveOrder{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := diveErrorPaths(t, typed.Validate(order)); !reflect.DeepEqual(got, []string{"Lines[1].SKU", "Address.City"}) {
+		t.Fatalf("typed paths = %v", got)
+	}
+}
+
+func TestDive_FieldRulesRunFirst(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	order := diveOrder{Lines: []diveLine{{}, {}, {}, {}}}
+	got := diveErrorPaths(t, sv.ValidateStruct(order))
+	if want := []string{"Lines", "Address"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %v, want %v", got, want)
+	}
+}
+
+func TestDive_CompileStructAndCoverage(t *testing.T) {
+	type bad struct {
+		City string `validate:"string;nope"`
+	}
+	type holder struct {
+		Inner bad `validate:"dive"`
+	}
+	sv := NewStructValidator(core.New())
+	err := sv.CompileStruct(holder{}, core.ValidateOpts{})
+	var tagErrs TagCompileErrors
+	if !errors.As(err, &tagErrs) || len(tagErrs) != 1 || tagErrs[0].Path != "Inner.City" {
+		t.Fatalf("CompileStruct = %v", err)
+	}
+
+	report, err := sv.Coverage(diveOrder{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Lines", "Lines[].SKU", "Address", "Address.City", "Notes"}; !reflect.DeepEqual(report.Validated, want) {
+		t.Fatalf("validated = %v, want %v", report.Validated, want)
+	}
+}
+
+func TestDive_Incremental(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	inc, err := sv.CompileIncremental(reflect.TypeOf(diveOrder{}), "Lines", core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := diveOrder{Lines: []diveLine{{SKU: "a"}, {}}}
+	if got := diveErrorPaths(t, inc.Validate(nil, order)); !reflect.DeepEqual(got, []string{"Lines[1].SKU"}) {
+		t.Fatalf("paths = %v", got)
+	}
+}
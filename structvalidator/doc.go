@@ -4,4 +4,7 @@
 // The structvalidator package enables automatic validation of struct fields
 // based on validation tags. It uses reflection to examine struct fields and
 // apply appropriate validators based on the `validate` tag.
+//
+// With the validate_lite build tag, for tinygo and WASM targets, the package
+// only contains the runtime of validators generated by validategen.
 package structvalidator
@@ -0,0 +1,166 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// ExampleFor generates a value that satisfies schema, for docs and
+// contract tests. Every tagged field gets a value from types.ExampleValue,
+// untagged nested structs are filled recursively and eqField targets are
+// copied. The result is validated before it is returned.
+//
+// Parameters:
+//   - schema: A tag string, a struct value, a pointer to struct, or a
+//     reflect.Type of a struct.
+//   - opts: Options used to validate the result.
+//
+// Returns:
+//   - any: The example: a value for tags, and a value of the same type as
+//     schema (or of the reflect.Type) for structs.
+//   - error: If generation fails, or, together with the example, if the
+//     best-effort result does not validate.
+func (sv *StructValidator) ExampleFor(schema any, opts core.ValidateOpts) (any, error) {
+	if tag, ok := schema.(string); ok {
+		rules, err := sv.validator.ParseRules(types.SplitTag(tag))
+		if err != nil {
+			return nil, err
+		}
+		value, err := types.ExampleValue(rules, nil)
+		if err != nil {
+			return nil, err
+		}
+		check, err := sv.validator.CompileRulesContextE(rules)
+		if err != nil {
+			return nil, err
+		}
+		if err := check(context.Background(), value); err != nil {
+			return value, fmt.Errorf("example does not validate: %w", err)
+		}
+		return value, nil
+	}
+
+	typ, ok := schema.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(schema)
+	}
+	isPtr := typ != nil && typ.Kind() == reflect.Ptr
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ExampleFor: expected tag or struct, got %T", schema)
+	}
+
+	out := reflect.New(typ)
+	if err := sv.fillExample(out.Elem(), opts, types.ExampleValue, map[reflect.Type]bool{}); err != nil {
+		return nil, err
+	}
+	result := out.Interface()
+	if !isPtr {
+		result = out.Elem().Interface()
+	}
+	if err := sv.ValidateStructWithOpts(result, opts); err != nil {
+		return result, fmt.Errorf("example does not validate: %w", err)
+	}
+	return result, nil
+}
+
+// exampleGen produces a value for the parsed rules of one field.
+type exampleGen func(rules []types.Rule, t reflect.Type) (any, error)
+
+func (sv *StructValidator) fillExample(v reflect.Value, opts core.ValidateOpts, gen exampleGen, onStack map[reflect.Type]bool) error {
+	t := v.Type()
+	if onStack[t] {
+		return nil
+	}
+	onStack[t] = true
+	defer delete(onStack, t)
+
+	equalTo := map[int]string{}
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if ft.PkgPath != "" {
+			continue
+		}
+		fv := v.Field(i)
+		tag, err := sv.fieldTag(t, ft, opts.SchemaVersion)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+		if tag == "" {
+			if err := sv.fillNestedExample(fv, opts, gen, onStack); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tokens, _ := splitFieldAccess(types.SplitTag(tag))
+		tokens, _ = splitSensitive(tokens)
+		tokens, _ = splitDive(tokens)
+		tokens, _ = splitQuotaTokens(tokens)
+		tokens, structRules, err := splitStructRules(tokens)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+		for _, rule := range structRules {
+			if field, ok := rule.Args["field"].(string); ok && rule.Kind == structRuleEqual {
+				equalTo[i] = field
+			}
+		}
+		if len(tokens) == 0 {
+			continue
+		}
+		rules, err := sv.validator.ParseRules(tokens)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+		value, err := gen(rules, ft.Type)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+		fv.Set(reflect.ValueOf(value))
+	}
+	for i, field := range equalTo {
+		if src := v.FieldByName(field); src.IsValid() && src.Type().AssignableTo(v.Field(i).Type()) {
+			v.Field(i).Set(src)
+		}
+	}
+	return nil
+}
+
+// fillNestedExample fills untagged struct fields and gives untagged slices
+// of structs one filled element, mirroring what the runtime walk visits.
+func (sv *StructValidator) fillNestedExample(fv reflect.Value, opts core.ValidateOpts, gen exampleGen, onStack map[reflect.Type]bool) error {
+	t := fv.Type()
+	switch {
+	case t.Kind() == reflect.Struct:
+		return sv.fillExample(fv, opts, gen, onStack)
+	case t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct && !onStack[t.Elem()]:
+		p := reflect.New(t.Elem())
+		if err := sv.fillExample(p.Elem(), opts, gen, onStack); err != nil {
+			return err
+		}
+		fv.Set(p)
+	case t.Kind() == reflect.Slice:
+		elem := reflect.New(t.Elem()).Elem()
+		target := elem
+		if t.Elem().Kind() == reflect.Ptr && t.Elem().Elem().Kind() == reflect.Struct && !onStack[t.Elem().Elem()] {
+			elem = reflect.New(t.Elem().Elem())
+			target = elem.Elem()
+		}
+		if target.Kind() == reflect.Struct && !onStack[target.Type()] {
+			if err := sv.fillExample(target, opts, gen, onStack); err != nil {
+				return err
+			}
+			fv.Set(reflect.Append(reflect.MakeSlice(t, 0, 1), elem))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+type exampleAddress struct {
+	City string `validate:"string;min=2;max=40"`
+	Zip  string `validate:"string;regex=^[0-9]{5}$"`
+}
+
+type exampleSignup struct {
+	Name     string            `validate:"string;required;minRunes=3;maxRunes=40"`
+	Password string            `validate:"string;min=12"`
+	Confirm  string            `validate:"string;eqField=Password"`
+	Age      *int              `validate:"int;between=18,120"`
+	Role     string            `validate:"string;oneof=admin,member"`
+	Tags     []string          `validate:"slice;min=2;unique;foreach=(string;alpha)"`
+	Limits   map[string]uint16 `validate:"map;minKeys=1;values=(int;positive)"`
+	Joined   time.Time         `validate:"time;after=2020-01-01T00:00:00Z"`
+	Address  exampleAddress
+	Others   []*exampleAddress
+	internal string
+}
+
+func TestExampleFor_StructValidates(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	value, err := sv.ExampleFor(&exampleSignup{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, ok := value.(*exampleSignup)
+	if !ok {
+		t.Fatalf("value = %T, want *exampleSignup", value)
+	}
+	if got.Confirm != got.Password || got.Age == nil || len(got.Others) != 1 || got.Address.Zip == "" {
+		t.Fatalf("incomplete example: %+v", got)
+	}
+
+	if _, ok := mustExample(t, sv, exampleSignup{}).(exampleSignup); !ok {
+		t.Fatal("expected a struct value for a struct schema")
+	}
+}
+
+func TestExampleFor_Tag(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	if v := mustExample(t, sv, "string;prefix=id_;len=10"); v != "id_example" {
+		t.Fatalf("value = %#v", v)
+	}
+	if _, err := sv.ExampleFor("string;nosuchrule", core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for unknown rule")
+	}
+	value, err := sv.ExampleFor("string;min=5;max=2", core.ValidateOpts{})
+	if err == nil || !strings.Contains(err.Error(), "does not validate") || value == nil {
+		t.Fatalf("expected best-effort value with error, got %#v, %v", value, err)
+	}
+	if _, err := sv.ExampleFor(42, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for non-struct schema")
+	}
+}
+
+func mustExample(t *testing.T, sv *StructValidator, schema any) any {
+	t.Helper()
+	value, err := sv.ExampleFor(schema, core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("ExampleFor(%#v): %v", schema, err)
+	}
+	return value
+}
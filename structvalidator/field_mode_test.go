@@ -0,0 +1,44 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestStruct_ReadOnlyAndWriteOnlyModes(t *testing.T) {
+	v := core.New().WithTranslator(dummyTr{})
+	sv := NewStructValidator(v)
+
+	type Account struct {
+		ID       string `json:"id" validate:"string;readonly;min=3"`
+		Password string `json:"password" validate:"string;writeonly;min=8"`
+	}
+	opts := func(mode core.FieldMode) core.ValidateOpts {
+		return core.ValidateOpts{FieldNameFunc: JSONFieldName, Mode: mode}
+	}
+
+	err := sv.ValidateStructWithOpts(Account{ID: "acc-1", Password: "long-enough"}, opts(core.ModeInput))
+	requireStructFieldError(t, err, "id", verrs.CodeFieldReadOnly, nil)
+	assertStructCodes(t, err, []string{verrs.CodeFieldReadOnly})
+
+	if err := sv.ValidateStructWithOpts(Account{Password: "long-enough"}, opts(core.ModeInput)); err != nil {
+		t.Fatalf("input without readonly field failed: %v", err)
+	}
+	err = sv.ValidateStructWithOpts(Account{Password: "short"}, opts(core.ModeInput))
+	requireStructFieldError(t, err, "password", verrs.CodeStringMin, 8)
+
+	err = sv.ValidateStructWithOpts(Account{ID: "acc-1", Password: "long-enough"}, opts(core.ModeOutput))
+	assertStructCodes(t, err, []string{verrs.CodeFieldWriteOnly})
+	if err := sv.ValidateStructWithOpts(Account{ID: "acc-1"}, opts(core.ModeOutput)); err != nil {
+		t.Fatalf("output without writeonly field failed: %v", err)
+	}
+	err = sv.ValidateStructWithOpts(Account{ID: "a"}, opts(core.ModeOutput))
+	requireStructFieldError(t, err, "id", verrs.CodeStringMin, 3)
+
+	err = sv.ValidateStructWithOpts(Account{ID: "a", Password: "short"}, opts(core.ModeAny))
+	assertStructCodes(t, err, []string{verrs.CodeStringMin, verrs.CodeStringMin})
+}
@@ -0,0 +1,83 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/aatuh/validate/v3/types"
+)
+
+// fieldTag returns the rules tag of field ft of struct type t in this
+// package's syntax: the schema version override if one is registered, else
+// the engine's tag keys merged by mergeTags, each translated first when the
+// engine reads go-playground tags. On a translation error it returns the
+// raw tag with the error, so callers that only ask whether a field is
+// tagged may ignore the error.
+func (sv *StructValidator) fieldTag(t reflect.Type, ft reflect.StructField, version string) (string, error) {
+	if override, ok := sv.validator.SchemaFieldTag(t, version, ft.Name); ok {
+		return override, nil
+	}
+	names := sv.validator.TagNames()
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, ft.Tag.Get(name))
+	}
+	if !sv.validator.PlaygroundTags() {
+		return mergeTags(tags), nil
+	}
+	for i, tag := range tags {
+		if tag == "" {
+			continue
+		}
+		native, err := playgroundTag(tag, ft.Type)
+		if err != nil {
+			return mergeTags(tags), err
+		}
+		tags[i] = native
+	}
+	return mergeTags(tags), nil
+}
+
+// skipsField reports whether ft is excluded from validation, nested structs
+// included, by a go-playground `validate:"-"` tag under any tag key.
+func (sv *StructValidator) skipsField(ft reflect.StructField) bool {
+	if !sv.validator.PlaygroundTags() {
+		return false
+	}
+	for _, name := range sv.validator.TagNames() {
+		if ft.Tag.Get(name) == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeTags joins the non-empty tags in order. A later tag whose first
+// token repeats the first tag's base type drops it, so "string;min=3" and
+// "string;max=10" merge into "string;min=3;max=10". A single tag is
+// returned unchanged.
+func mergeTags(tags []string) string {
+	var kept []string
+	for _, tag := range tags {
+		if tag != "" {
+			kept = append(kept, tag)
+		}
+	}
+	switch len(kept) {
+	case 0:
+		return ""
+	case 1:
+		return kept[0]
+	}
+	merged := types.SplitTag(kept[0])
+	for _, tag := range kept[1:] {
+		tokens := types.SplitTag(tag)
+		if len(tokens) > 0 && len(merged) > 0 && strings.TrimSpace(tokens[0]) == strings.TrimSpace(merged[0]) {
+			tokens = tokens[1:]
+		}
+		merged = append(merged, tokens...)
+	}
+	return strings.Join(merged, ";")
+}
@@ -0,0 +1,69 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type thirdPartyProfile struct {
+	Handle string `binding:"string;min=3"`
+}
+
+type taggedSignup struct {
+	Name    string `validate:"string;min=3" binding:"string;max=5"`
+	Profile thirdPartyProfile
+}
+
+func TestWithTagNames_ReadsAndMergesKeys(t *testing.T) {
+	in := taggedSignup{Name: "Alexander", Profile: thirdPartyProfile{Handle: "al"}}
+	codes := func(err error) map[string]string {
+		t.Helper()
+		var es verrs.Errors
+		if !errors.As(err, &es) {
+			t.Fatalf("expected field errors, got %v", err)
+		}
+		out := map[string]string{}
+		for _, e := range es {
+			out[e.Path] = e.Code
+		}
+		return out
+	}
+
+	if err := NewStructValidator(core.New()).ValidateStruct(in); err != nil {
+		t.Fatalf("default tag key: %v", err)
+	}
+	got := codes(NewStructValidator(core.New().WithTagNames("binding")).ValidateStruct(in))
+	if len(got) != 2 || got["Name"] != "string.max" || got["Profile.Handle"] != "string.min" {
+		t.Fatalf("binding only: %v", got)
+	}
+	sv := NewStructValidator(core.NewEngine(core.WithTagNames("validate", "binding")))
+	if got := codes(sv.ValidateStruct(taggedSignup{Name: "Al"})); got["Name"] != "string.min" {
+		t.Fatalf("merged min: %v", got)
+	}
+	if got := codes(sv.ValidateStruct(in)); got["Name"] != "string.max" {
+		t.Fatalf("merged max: %v", got)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		tags []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"", "string;min=3;"}, "string;min=3;"},
+		{[]string{"string;min=3", "string;max=5"}, "string;min=3;max=5"},
+		{[]string{"string;min=3", "required"}, "string;min=3;required"},
+		{[]string{"slice;foreach=(string;min=1)", "slice;max=2"}, "slice;foreach=(string;min=1);max=2"},
+	}
+	for _, tt := range tests {
+		if got := mergeTags(tt.tags); got != tt.want {
+			t.Errorf("mergeTags(%q) = %q, want %q", tt.tags, got, tt.want)
+		}
+	}
+}
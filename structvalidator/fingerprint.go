@@ -0,0 +1,80 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+// Fingerprint returns the core.Fingerprint of the `validate` tags reachable
+// from the type of s, after schema version overrides, keyed by Go field
+// paths. Renaming a JSON field does not change it; changing a rule does.
+//
+// Parameters:
+//   - s: A struct value, pointer to struct, or reflect.Type of a struct.
+//   - opts: SchemaVersion is honored.
+//
+// Returns:
+//   - string: The fingerprint, e.g. "sha256:9f86...".
+//   - error: If s is not a struct type.
+func (sv *StructValidator) Fingerprint(s any, opts core.ValidateOpts) (string, error) {
+	typ, ok := s.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(s)
+	}
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return "", fmt.Errorf("Fingerprint: expected struct, got %T", s)
+	}
+
+	rules := map[string]string{}
+	onStack := map[reflect.Type]bool{}
+	var walk func(t reflect.Type, path string)
+	walk = func(t reflect.Type, path string) {
+		if onStack[t] {
+			return
+		}
+		onStack[t] = true
+		defer delete(onStack, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			ft := t.Field(i)
+			if ft.PkgPath != "" {
+				continue
+			}
+			fieldPath := fieldPathJoin(path, ft.Name, ".")
+			tag, _ := sv.fieldTag(t, ft, opts.SchemaVersion)
+			if tag == "" || dives(tag) {
+				if elem, elemPath, ok := nestedStructType(ft.Type, fieldPath); ok {
+					walk(elem, elemPath)
+				}
+			}
+			if tag != "" {
+				rules[fieldPath] = tag
+			}
+		}
+	}
+	walk(typ, "")
+	return core.Fingerprint(rules), nil
+}
+
+// ValidateStructResult validates s like ValidateStructContextWithOpts and
+// stamps the result with the Fingerprint of its type and
+// opts.SchemaVersion.
+func (sv *StructValidator) ValidateStructResult(ctx context.Context, s any, opts core.ValidateOpts) core.Result {
+	fp, err := sv.Fingerprint(s, opts)
+	if err != nil {
+		return core.Result{Version: opts.SchemaVersion, Err: err}
+	}
+	return core.Result{
+		Fingerprint: fp,
+		Version:     opts.SchemaVersion,
+		Err:         sv.ValidateStructContextWithOpts(ctx, s, opts),
+	}
+}
@@ -0,0 +1,59 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestStruct_FingerprintAndResult(t *testing.T) {
+	type Item struct {
+		SKU string `json:"sku" validate:"string;len=4"`
+	}
+	type Order struct {
+		ID    string `json:"id" validate:"string;required"`
+		Items []Item `json:"items"`
+	}
+	type RenamedOrder struct {
+		ID    string `json:"order_id" validate:"string;required"`
+		Items []Item `json:"lines"`
+	}
+	type StricterOrder struct {
+		ID    string `validate:"string;required;min=3"`
+		Items []Item
+	}
+
+	v := core.New().WithSchemaVersion(Item{}, "v2", map[string]string{"SKU": "string;len=6"})
+	sv := NewStructValidator(v)
+	fp, err := sv.Fingerprint(Order{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := core.Fingerprint(map[string]string{"ID": "string;required", "Items[].SKU": "string;len=4"}); fp != want {
+		t.Fatalf("Fingerprint = %q, want %q", fp, want)
+	}
+	same, _ := sv.Fingerprint(reflect.TypeOf(&RenamedOrder{}), core.ValidateOpts{UseJSONNames: true})
+	stricter, _ := sv.Fingerprint(StricterOrder{}, core.ValidateOpts{})
+	v2, _ := sv.Fingerprint(Order{}, core.ValidateOpts{SchemaVersion: "v2"})
+	if same != fp || stricter == fp || v2 == fp {
+		t.Fatalf("fingerprints: base %q, renamed %q, stricter %q, v2 %q", fp, same, stricter, v2)
+	}
+	if _, err := sv.Fingerprint(42, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for non-struct")
+	}
+
+	res := sv.ValidateStructResult(context.Background(), Order{ID: "1", Items: []Item{{SKU: "ABCDEF"}}}, core.ValidateOpts{SchemaVersion: "v2"})
+	if !res.Valid() || res.Fingerprint != v2 || res.Version != "v2" {
+		t.Fatalf("v2 result = %+v", res)
+	}
+	res = sv.ValidateStructResult(context.Background(), Order{}, core.ValidateOpts{})
+	assertStructCodes(t, res.Err, []string{verrs.CodeRequired})
+	if res.Fingerprint != fp {
+		t.Fatalf("result fingerprint = %q, want %q", res.Fingerprint, fp)
+	}
+}
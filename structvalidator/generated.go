@@ -0,0 +1,223 @@
+package structvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/internal/pathutil"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// GeneratedValidator is implemented by struct types with validators
+// generated by validategen. ValidateStruct prefers it over reflection when
+// the options and engine allow; see generatedValidator.
+type GeneratedValidator interface {
+	ValidateGenerated(ctx context.Context, e *core.Engine, opts core.ValidateOpts) error
+}
+
+// GeneratedRun collects the results of one generated validator call.
+// Generated code calls its methods; it is not meant for direct use.
+type GeneratedRun struct {
+	ctx      context.Context
+	engine   *core.Engine
+	opts     core.ValidateOpts
+	errs     verrs.Errors
+	terminal error
+	checked  int // Stop calls, one per validated field
+}
+
+// NewGeneratedRun starts a generated validator call.
+func NewGeneratedRun(ctx context.Context, e *core.Engine, opts core.ValidateOpts) *GeneratedRun {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &GeneratedRun{ctx: ctx, engine: e, opts: core.ApplyOpts(e, opts)}
+}
+
+// Fail records a built-in rule failure at path with the rule parameter
+// param, translated with the engine's translator; fallback is the English
+// message for args.
+func (g *GeneratedRun) Fail(path, code, fallback string, param any, args ...any) {
+	msg := fallback
+	if tr := g.engine.Translator(); tr != nil {
+		if translated := tr.T(code, args...); translated != "" {
+			msg = translated
+		}
+	}
+	g.errs = append(g.errs, verrs.FieldError{Path: path, Code: code, Msg: msg, Param: param})
+}
+
+// Rules validates value at path with the rules of tag, compiled by the
+// engine, for rules generated code does not inline.
+func (g *GeneratedRun) Rules(path string, value any, tag string) {
+	fn, err := g.engine.FromRulesContextWithOpts(types.SplitTag(tag), types.CompileOpts{})
+	if err != nil {
+		g.errs = append(g.errs, verrs.FieldError{Path: path, Code: verrs.CodeUnknown, Msg: err.Error()})
+		return
+	}
+	g.record(fn(g.ctx, value), path)
+}
+
+// Nested validates an untagged field at path the way the reflective walk
+// does: structs are validated, preferring their generated validators, and
+// struct elements of slices, arrays and maps are visited. In validate_lite
+// builds only structs with generated validators are validated.
+func (g *GeneratedRun) Nested(path string, value any) {
+	rv := derefPointer(reflect.ValueOf(value))
+	switch rv.Kind() {
+	case reflect.Struct:
+		g.nestedStruct(path, rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len() && !g.Stop(); i++ {
+			if ev := derefPointer(rv.Index(i)); ev.Kind() == reflect.Struct {
+				g.Nested(path+"["+strconv.Itoa(i)+"]", ev.Interface())
+			}
+		}
+	case reflect.Map:
+		for _, k := range sortedMapKeys(rv) {
+			if g.Stop() {
+				return
+			}
+			if ev := derefPointer(rv.MapIndex(k)); ev.Kind() == reflect.Struct {
+				g.Nested(path+pathutil.MapKeySegment(k.Interface()), ev.Interface())
+			}
+		}
+	}
+}
+
+// Index returns the path of element i of the collection at path.
+func (g *GeneratedRun) Index(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// Key returns the path of the map entry with key at path.
+func (g *GeneratedRun) Key(path string, key any) string {
+	return path + pathutil.MapKeySegment(key)
+}
+
+// Stop reports whether the generated validator must return: the context
+// is done, the time budget is spent, or StopOnFirst is set and a field
+// failed.
+func (g *GeneratedRun) Stop() bool {
+	g.checked++
+	if g.terminal == nil {
+		g.terminal = g.ctx.Err()
+	}
+	if g.terminal == nil && !g.opts.Deadline.IsZero() && !time.Now().Before(g.opts.Deadline) {
+		g.terminal = context.DeadlineExceeded
+	}
+	return g.terminal != nil || (g.opts.StopOnFirst && len(g.errs) > 0)
+}
+
+// Err returns the context error that stopped the call, the collected
+// field errors, or nil. A spent time budget adds a validation.timeout
+// error to the collected errors.
+func (g *GeneratedRun) Err() error {
+	if g.terminal != nil {
+		timeout, ok := g.engine.TimeoutError(g.ctx, g.terminal, "", g.checked)
+		if !ok {
+			return g.terminal
+		}
+		return append(g.errs, timeout)
+	}
+	if len(g.errs) > 0 {
+		return g.errs
+	}
+	return nil
+}
+
+func (g *GeneratedRun) record(err error, path string) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		g.terminal = err
+		return
+	}
+	g.errs = appendValidationErrors(g.errs, err, path, g.opts)
+}
+
+func appendValidationErrors(errs verrs.Errors, err error, fieldPath string, opts core.ValidateOpts) verrs.Errors {
+	if fieldErrors, ok := asFieldErrors(err); ok {
+		for _, fe := range fieldErrors {
+			fe.Path = fieldPathJoin(fieldPath, fe.Path, opts.PathSep)
+			errs = append(errs, fe)
+		}
+		return errs
+	}
+	return append(errs, verrs.FieldError{
+		Path: fieldPath, Code: verrs.CodeUnknown,
+		Msg: err.Error(),
+	})
+}
+
+// asFieldErrors is errors.As for verrs.Errors. Rule chains return
+// verrs.Errors unwrapped, so the type assertion spares errors.As its
+// heap-allocated target on the common path.
+func asFieldErrors(err error) (verrs.Errors, bool) {
+	if fieldErrors, ok := err.(verrs.Errors); ok {
+		return fieldErrors, true
+	}
+	var fieldErrors verrs.Errors
+	if errors.As(err, &fieldErrors) {
+		return fieldErrors, true
+	}
+	return nil, false
+}
+
+func fieldPathJoin(base, name, sep string) string {
+	if base == "" {
+		return name
+	}
+	if name == "" {
+		return base
+	}
+	if sep == "" {
+		sep = "."
+	}
+	// If the child path starts with a bracket, concatenate directly without separator
+	if len(name) > 0 && name[0] == '[' {
+		return base + name
+	}
+	return base + sep + name
+}
+
+func derefPointer(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	return v
+}
+
+func sortedMapKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		left := fmt.Sprint(keys[i].Interface())
+		right := fmt.Sprint(keys[j].Interface())
+		if left == right {
+			return keys[i].Type().String() < keys[j].Type().String()
+		}
+		return left < right
+	})
+	return keys
+}
+
+// SortedKeys returns the keys of m in the order the reflective walk visits
+// them, for generated code.
+func SortedKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
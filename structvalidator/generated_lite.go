@@ -0,0 +1,14 @@
+//go:build validate_lite
+
+package structvalidator
+
+import "reflect"
+
+// nestedStruct validates the struct rv at path with its generated
+// validator. validate_lite builds have no reflective walk, so structs
+// without one are not validated.
+func (g *GeneratedRun) nestedStruct(path string, rv reflect.Value) {
+	if gv, ok := rv.Interface().(GeneratedValidator); ok {
+		g.record(gv.ValidateGenerated(g.ctx, g.engine, g.opts), path)
+	}
+}
@@ -0,0 +1,31 @@
+//go:build validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type liteCity struct{ Name string }
+
+func (s liteCity) ValidateGenerated(ctx context.Context, e *core.Engine, opts core.ValidateOpts) error {
+	g := NewGeneratedRun(ctx, e, opts)
+	g.Rules("Name", s.Name, "string;min=3")
+	return g.Err()
+}
+
+type litePlain struct{ Name string }
+
+func TestGeneratedRun_LiteNested(t *testing.T) {
+	g := NewGeneratedRun(context.Background(), core.New(), core.ValidateOpts{})
+	g.Nested("Cities", []liteCity{{Name: "Oslo"}, {Name: "Ii"}})
+	g.Nested("Plain", litePlain{})
+	es, ok := g.Err().(verrs.Errors)
+	if !ok || len(es) != 1 || es[0].Path != "Cities[1].Name" || es[0].Code != verrs.CodeStringMin {
+		t.Fatalf("errors = %v", g.Err())
+	}
+}
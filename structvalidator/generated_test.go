@@ -0,0 +1,142 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type reflectedAddress struct {
+	City string `validate:"string;required;max=10"`
+	Zip  string `validate:"string;regex=^[0-9]{5}$"`
+}
+
+// generatedAddress mirrors reflectedAddress with the validator validategen
+// emits for it.
+type generatedAddress struct {
+	City string `validate:"string;required;max=10"`
+	Zip  string `validate:"string;regex=^[0-9]{5}$"`
+}
+
+func (s generatedAddress) ValidateGenerated(ctx context.Context, e *core.Engine, opts core.ValidateOpts) error {
+	g := NewGeneratedRun(ctx, e, opts)
+	switch v := s.City; {
+	case v == "":
+		g.Fail("City", "required", "value is required", nil)
+	case len(v) > 10:
+		g.Fail("City", "string.max", "maximum length is 10", 10, 10)
+	}
+	if g.Stop() {
+		return g.Err()
+	}
+	g.Rules("Zip", s.Zip, "string;regex=^[0-9]{5}$")
+	if g.Stop() {
+		return g.Err()
+	}
+	return g.Err()
+}
+
+type generatedOrder struct {
+	Home   generatedAddress
+	Others []reflectedAddress
+}
+
+func (s generatedOrder) ValidateGenerated(ctx context.Context, e *core.Engine, opts core.ValidateOpts) error {
+	g := NewGeneratedRun(ctx, e, opts)
+	g.Nested("Home", s.Home)
+	if g.Stop() {
+		return g.Err()
+	}
+	for i := range s.Others {
+		g.Nested(g.Index("Others", i), s.Others[i])
+		if g.Stop() {
+			break
+		}
+	}
+	return g.Err()
+}
+
+type reflectedOrder struct {
+	Home   reflectedAddress
+	Others []reflectedAddress
+}
+
+type countingGenerated struct {
+	Name  string `validate:"string;min=3"`
+	calls *int
+}
+
+func (s countingGenerated) ValidateGenerated(context.Context, *core.Engine, core.ValidateOpts) error {
+	*s.calls++
+	return nil
+}
+
+func TestGeneratedValidator_MatchesReflectiveWalk(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	cases := []struct{ city, zip string }{
+		{"Oulu", "90100"},
+		{"", "9010"},
+		{"Kaskinen-by-the-sea", "abcde"},
+	}
+	for _, c := range cases {
+		for _, stop := range []bool{false, true} {
+			opts := core.ValidateOpts{StopOnFirst: stop}
+			got := sv.ValidateStructWithOpts(generatedOrder{
+				Home:   generatedAddress{City: c.city, Zip: c.zip},
+				Others: []reflectedAddress{{City: c.city, Zip: c.zip}},
+			}, opts)
+			want := sv.ValidateStructWithOpts(reflectedOrder{
+				Home:   reflectedAddress{City: c.city, Zip: c.zip},
+				Others: []reflectedAddress{{City: c.city, Zip: c.zip}},
+			}, opts)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("%+v stop=%v: generated %v, reflective %v", c, stop, got, want)
+			}
+		}
+	}
+}
+
+func TestGeneratedValidator_PreferredWhenEquivalent(t *testing.T) {
+	calls := 0
+	s := countingGenerated{Name: "x", calls: &calls}
+	sv := NewStructValidator(core.NewEngine())
+	if err := sv.ValidateStruct(s); err != nil || calls != 1 {
+		t.Fatalf("ValidateStruct: err=%v calls=%d", err, calls)
+	}
+	if err := sv.ValidateStruct(&s); err != nil || calls != 2 {
+		t.Fatalf("pointer: err=%v calls=%d", err, calls)
+	}
+	if err := sv.ValidateStructWithOpts(s, core.ValidateOpts{CollectAllRules: true}); err == nil || calls != 2 {
+		t.Fatalf("CollectAllRules: err=%v calls=%d", err, calls)
+	}
+	if err := sv.ValidateStructWithOpts(s, core.ValidateOpts{UseJSONNames: true}); err == nil || calls != 2 {
+		t.Fatalf("UseJSONNames: err=%v calls=%d", err, calls)
+	}
+	if err := sv.ValidateStructWithOpts(s, core.ValidateOpts{Budget: time.Hour}); err == nil || calls != 2 {
+		t.Fatalf("Budget: err=%v calls=%d", err, calls)
+	}
+	shadowed := NewStructValidator(core.NewEngine().WithShadowRule(types.KString, 1))
+	if err := shadowed.ValidateStruct(s); err == nil || calls != 2 {
+		t.Fatalf("altered built-ins: err=%v calls=%d", err, calls)
+	}
+}
+
+func TestGeneratedRun_StopsWhenBudgetSpent(t *testing.T) {
+	g := NewGeneratedRun(context.Background(), core.NewEngine(), core.ValidateOpts{Deadline: time.Now()})
+	g.Fail("City", "required", "value is required", nil)
+	if !g.Stop() {
+		t.Fatal("Stop ignored the spent budget")
+	}
+	var es verrs.Errors
+	if err := g.Err(); !errors.As(err, &es) || len(es) != 2 || es[1].Code != verrs.CodeTimeout || es[1].Param != 1 {
+		t.Fatalf("Err() = %v", err)
+	}
+}
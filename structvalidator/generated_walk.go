@@ -0,0 +1,39 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+// generatedValidator returns the generated validator of s when it produces
+// the same result as the reflective walk: options that generated code does
+// not honor are unset, no quotas are registered, and the engine does not
+// alter built-in rules.
+func (sv *StructValidator) generatedValidator(s any, opts core.ValidateOpts) (GeneratedValidator, bool) {
+	g, ok := s.(GeneratedValidator)
+	if !ok {
+		return nil, false
+	}
+	if opts.FieldNameFunc != nil || opts.SchemaVersion != "" || opts.Mode != core.ModeAny ||
+		opts.IncludeValues || opts.CollectAllRules || !opts.Deadline.IsZero() {
+		return nil, false
+	}
+	if len(sv.validator.QuotaNames()) > 0 || sv.validator.AltersBuiltinRules() || !sv.validator.NativeTags() {
+		return nil, false
+	}
+	if sv.validator.Behavior().CallValidateSelf && selfCheckFor(reflect.TypeOf(s)) != selfNone {
+		// Generated code does not call ValidateSelf.
+		return nil, false
+	}
+	return g, true
+}
+
+// nestedStruct validates the struct rv at path with the reflective walk,
+// which prefers its generated validator.
+func (g *GeneratedRun) nestedStruct(path string, rv reflect.Value) {
+	sv := NewStructValidator(g.engine)
+	g.record(sv.validateStruct(g.ctx, rv.Interface(), g.opts), path)
+}
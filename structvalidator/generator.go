@@ -0,0 +1,115 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// generatorAttempts is how many random values Generate tries before it
+// falls back to the ExampleFor result.
+const generatorAttempts = 10
+
+// quickSize is the size hint Values passes to Generate, matching the size
+// testing/quick uses for its own arbitrary values.
+const quickSize = 50
+
+// Generator produces random values that satisfy a schema, so property
+// tests can constrain inputs to valid domains. It works with testing/quick
+// through Values, and with other libraries through Generate.
+type Generator struct {
+	sv      *StructValidator
+	opts    core.ValidateOpts
+	typ     reflect.Type
+	isPtr   bool
+	rules   []types.Rule
+	check   types.ContextValidatorFunc
+	example any
+}
+
+// Generator returns a Generator for schema. It fails when ExampleFor cannot
+// produce a valid value, since Generate falls back to that value.
+//
+// Parameters:
+//   - schema: A tag string, a struct value, a pointer to struct, or a
+//     reflect.Type of a struct.
+//   - opts: Options used to validate generated values.
+func (sv *StructValidator) Generator(schema any, opts core.ValidateOpts) (*Generator, error) {
+	example, err := sv.ExampleFor(schema, opts)
+	if err != nil {
+		return nil, err
+	}
+	g := &Generator{sv: sv, opts: opts, example: example}
+	if tag, ok := schema.(string); ok {
+		if g.rules, err = sv.validator.ParseRules(types.SplitTag(tag)); err != nil {
+			return nil, err
+		}
+		if g.check, err = sv.validator.CompileRulesContextE(g.rules); err != nil {
+			return nil, err
+		}
+		return g, nil
+	}
+	typ, ok := schema.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(schema)
+	}
+	g.isPtr = typ.Kind() == reflect.Ptr
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	g.typ = typ
+	return g, nil
+}
+
+// Generate returns a random value satisfying the schema. Values that do
+// not validate are retried, and after a few attempts the ExampleFor result
+// is returned, so every result is valid.
+//
+// Parameters:
+//   - rnd: Source of randomness.
+//   - size: Size hint bounding lengths, ranges and collection sizes.
+func (g *Generator) Generate(rnd *rand.Rand, size int) any {
+	for i := 0; i < generatorAttempts; i++ {
+		if v, err := g.random(rnd, size); err == nil {
+			return v
+		}
+	}
+	return g.example
+}
+
+// Values fills args with generated values. It has the signature of
+// quick.Config.Values, for properties whose arguments all share the schema.
+func (g *Generator) Values(args []reflect.Value, rnd *rand.Rand) {
+	for i := range args {
+		args[i] = reflect.ValueOf(g.Generate(rnd, quickSize))
+	}
+}
+
+// random returns one random value, or an error if it does not validate.
+func (g *Generator) random(rnd *rand.Rand, size int) (any, error) {
+	if g.typ == nil {
+		value, err := types.RandomValue(g.rules, nil, rnd, size)
+		if err != nil {
+			return nil, err
+		}
+		return value, g.check(context.Background(), value)
+	}
+	gen := func(rules []types.Rule, t reflect.Type) (any, error) {
+		return types.RandomValue(rules, t, rnd, size)
+	}
+	out := reflect.New(g.typ)
+	if err := g.sv.fillExample(out.Elem(), g.opts, gen, map[reflect.Type]bool{}); err != nil {
+		return nil, fmt.Errorf("generate: %w", err)
+	}
+	result := out.Interface()
+	if !g.isPtr {
+		result = out.Elem().Interface()
+	}
+	return result, g.sv.ValidateStructWithOpts(result, g.opts)
+}
@@ -0,0 +1,62 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+func TestGenerator_StructValuesValidate(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	gen, err := sv.Generator(exampleSignup{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	names := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		v, ok := gen.Generate(rnd, 20).(exampleSignup)
+		if !ok {
+			t.Fatalf("Generate returned %T, want exampleSignup", v)
+		}
+		if err := sv.ValidateStruct(v); err != nil {
+			t.Fatalf("generated value does not validate: %v", err)
+		}
+		names[v.Name] = true
+	}
+	if len(names) < 10 {
+		t.Fatalf("expected varied values, got %d distinct names", len(names))
+	}
+}
+
+func TestGenerator_QuickValues(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	gen, err := sv.Generator("int;min=5;max=9", core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	property := func(n int) bool { return n >= 5 && n <= 9 }
+	if err := quick.Check(property, &quick.Config{Values: gen.Values}); err != nil {
+		t.Fatal(err)
+	}
+
+	args := make([]reflect.Value, 2)
+	gen.Values(args, rand.New(rand.NewSource(2)))
+	for _, a := range args {
+		if a.Kind() != reflect.Int {
+			t.Fatalf("Values produced %v, want int", a.Kind())
+		}
+	}
+}
+
+func TestGenerator_RejectsUnsatisfiable(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	if _, err := sv.Generator("int;min=5;max=1", core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for unsatisfiable schema")
+	}
+}
@@ -0,0 +1,210 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Incremental validates one slice field of a struct that grows by appends,
+// such as a batch being assembled. Each call validates only the elements
+// appended since the previous call and reuses the earlier element errors,
+// so validating after every append costs O(n) in total instead of O(n²).
+//
+// Element rules are the field's foreach rules, or for untagged slices of
+// structs the elements' own tags, which tagged `dive` fields also run once
+// the foreach rules pass. Collection rules such as max and unique
+// still run over the whole slice on every call. Elements already
+// validated are assumed unchanged; call Reset after modifying one. A slice
+// shorter than the validated prefix resets automatically. An Incremental is
+// not safe for concurrent use.
+type Incremental struct {
+	sv         *StructValidator
+	typ        reflect.Type
+	field      reflect.StructField
+	opts       core.ValidateOpts
+	collection types.ContextValidatorFunc // nil for untagged fields
+	elem       types.ContextValidatorFunc // nil without foreach rules
+	dive       bool
+	checked    int
+	elemErrs   verrs.Errors
+}
+
+// CompileIncremental compiles the rules of the slice field named field of
+// struct type t for incremental validation.
+//
+// Parameters:
+//   - t: A struct type or pointer to struct type.
+//   - field: The Go name of an exported slice field of t.
+//   - opts: Options for every call; Mode, SchemaVersion and IncludeValues
+//     are ignored.
+//
+// Returns:
+//   - *Incremental: The validator, with nothing validated yet.
+//   - error: If field is not a slice field of t, its tag does not compile,
+//     or it uses struct rules or quotas, which need the whole struct.
+func (sv *StructValidator) CompileIncremental(t reflect.Type, field string, opts core.ValidateOpts) (*Incremental, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("CompileIncremental: expected struct type, got %v", t)
+	}
+	ft, ok := t.FieldByName(field)
+	if !ok || ft.PkgPath != "" || len(ft.Index) != 1 || ft.Type.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("CompileIncremental: %v has no exported slice field %q", t, field)
+	}
+	engine := sv.validator.ForTranslator(opts.Translator)
+	opts.Translator = nil
+	inc := &Incremental{sv: NewStructValidator(engine), typ: t, field: ft, opts: opts}
+
+	tag, err := sv.fieldTag(t, ft, "")
+	if err != nil {
+		return nil, fmt.Errorf("CompileIncremental: field %s: %w", field, err)
+	}
+	if tag == "" {
+		return inc, nil
+	}
+	tokens, _ := splitFieldAccess(types.SplitTag(tag))
+	tokens, _ = splitSensitive(tokens)
+	tokens, inc.dive = splitDive(tokens)
+	tokens, quotas := splitQuotaTokens(tokens)
+	tokens, structRules, err := splitStructRules(tokens)
+	if err != nil {
+		return nil, fmt.Errorf("CompileIncremental: field %s: %w", field, err)
+	}
+	if len(quotas) > 0 || len(structRules) > 0 {
+		return nil, fmt.Errorf("CompileIncremental: field %s: struct rules and quotas need the whole struct", field)
+	}
+	rules, err := engine.ParseRules(tokens)
+	if err != nil {
+		return nil, fmt.Errorf("CompileIncremental: field %s: %w", field, err)
+	}
+	compileOpts := types.CompileOpts{CollectAll: opts.CollectAllRules}
+	collection := make([]types.Rule, 0, len(rules))
+	var elemRules []types.Rule
+	for _, rule := range rules {
+		if rule.Kind != types.KForEach {
+			collection = append(collection, rule)
+			continue
+		}
+		nested, _ := rule.Args["rules"].([]types.Rule)
+		elemRules = append(elemRules, nested...)
+	}
+	if inc.collection, err = engine.CompileRulesContextWithOptsE(collection, compileOpts); err != nil {
+		return nil, fmt.Errorf("CompileIncremental: field %s: %w", field, err)
+	}
+	if len(elemRules) > 0 {
+		if inc.elem, err = engine.CompileRulesContextWithOptsE(elemRules, compileOpts); err != nil {
+			return nil, fmt.Errorf("CompileIncremental: field %s: %w", field, err)
+		}
+	}
+	return inc, nil
+}
+
+// Validate validates the elements of the field appended since the previous
+// call and returns the errors ValidateStruct would report for the field:
+// the collection rules' errors, or every element error found so far. When
+// ctx is done it returns the context error, and the next call resumes where
+// this one stopped.
+//
+// Parameters:
+//   - ctx: Passed to context-aware rules.
+//   - s: A value or pointer of the compiled struct type.
+//
+// Returns:
+//   - error: nil, errors.Errors, or the context error.
+func (inc *Incremental) Validate(ctx context.Context, s any) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	val := reflect.ValueOf(s)
+	if val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	if !val.IsValid() || val.Type() != inc.typ {
+		return fmt.Errorf("Incremental: expected %v, got %T", inc.typ, s)
+	}
+	opts := core.ApplyOpts(inc.sv.validator, inc.opts)
+	path := fieldDisplayName(inc.field, opts)
+	slice := val.FieldByIndex(inc.field.Index)
+	if slice.Len() < inc.checked {
+		inc.Reset()
+	}
+
+	var errs verrs.Errors
+	if inc.collection != nil {
+		if err := inc.collection(ctx, slice.Interface()); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			errs = appendValidationErrors(errs, err, path, opts)
+			if opts.StopOnFirst {
+				return errs
+			}
+		}
+	}
+	for ; inc.checked < slice.Len(); inc.checked++ {
+		if opts.StopOnFirst && len(inc.elemErrs) > 0 {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		elemPath := path + "[" + strconv.Itoa(inc.checked) + "]"
+		if err := inc.validateElem(ctx, slice.Index(inc.checked), opts); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			inc.elemErrs = appendValidationErrors(inc.elemErrs, err, elemPath, opts)
+		}
+	}
+	// Like the field's rule chain, a failing collection rule hides the
+	// element errors unless CollectAllRules is set.
+	if len(errs) > 0 && !opts.CollectAllRules {
+		return errs
+	}
+	errs = append(errs, inc.elemErrs...)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateElem validates one element with the foreach rules of a tagged
+// field, and walks it like the reflective walk does when the field is
+// untagged or tagged `dive`.
+func (inc *Incremental) validateElem(ctx context.Context, ev reflect.Value, opts core.ValidateOpts) error {
+	if inc.collection != nil {
+		if inc.elem != nil {
+			if err := inc.elem(ctx, valueForValidation(ev)); err != nil {
+				return err
+			}
+		}
+		if !inc.dive {
+			return nil
+		}
+	}
+	if ev = derefPointer(ev); ev.Kind() != reflect.Struct {
+		return nil
+	}
+	return inc.sv.ValidateStructContextWithOpts(ctx, ev.Interface(), opts)
+}
+
+// Validated returns the number of elements validated so far.
+func (inc *Incremental) Validated() int { return inc.checked }
+
+// Reset forgets the validated elements, so the next call validates the
+// whole slice again.
+func (inc *Incremental) Reset() {
+	inc.checked = 0
+	inc.elemErrs = nil
+}
@@ -0,0 +1,127 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type batchLine struct {
+	SKU string `validate:"string;min=3"`
+}
+
+type batch struct {
+	Name  string   `validate:"string;min=100"`
+	Tags  []string `validate:"slice;max=3;foreach=(string;min=2)"`
+	Lines []batchLine
+}
+
+func TestIncremental_MatchesFullValidation(t *testing.T) {
+	var calls int
+	e := core.New()
+	e.RegisterRule("counted", func(*types.Compiler, types.Rule) (func(any) error, error) {
+		return func(any) error { calls++; return nil }, nil
+	})
+	sv := NewStructValidator(e)
+	tags, err := sv.CompileIncremental(reflect.TypeOf(batch{}), "Tags", core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := sv.CompileIncremental(reflect.TypeOf(&batch{}), "Lines", core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fieldErrors := func(err error, prefix string) verrs.Errors {
+		var es, out verrs.Errors
+		errors.As(err, &es)
+		for _, fe := range es {
+			if len(fe.Path) >= len(prefix) && fe.Path[:len(prefix)] == prefix {
+				out = append(out, fe)
+			}
+		}
+		return out
+	}
+	b := &batch{}
+	for i, tag := range []string{"ok", "x", "ok", "y"} {
+		b.Tags = append(b.Tags, tag)
+		b.Lines = append(b.Lines, batchLine{SKU: tag})
+		full := sv.ValidateStruct(b)
+		got := fieldErrors(tags.Validate(context.Background(), b), "Tags")
+		if want := fieldErrors(full, "Tags"); !reflect.DeepEqual(got, want) {
+			t.Fatalf("append %d: Tags errors %v, want %v", i, got, want)
+		}
+		got = fieldErrors(lines.Validate(context.Background(), b), "Lines")
+		if want := fieldErrors(full, "Lines"); !reflect.DeepEqual(got, want) {
+			t.Fatalf("append %d: Lines errors %v, want %v", i, got, want)
+		}
+		if tags.Validated() != i+1 || lines.Validated() != i+1 {
+			t.Fatalf("append %d: validated %d and %d elements", i, tags.Validated(), lines.Validated())
+		}
+	}
+
+	// Earlier elements are not revalidated.
+	type counted struct {
+		Items []string `validate:"slice;foreach=(string;counted)"`
+	}
+	inc, err := sv.CompileIncremental(reflect.TypeOf(counted{}), "Items", core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := counted{}
+	for i := 0; i < 50; i++ {
+		c.Items = append(c.Items, "x")
+		if err := inc.Validate(context.Background(), c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 50 {
+		t.Fatalf("element rule ran %d times for 50 appends", calls)
+	}
+
+	// Shrinking resets the validated prefix.
+	b.Tags = []string{"z"}
+	if got := fieldErrors(tags.Validate(context.Background(), b), "Tags"); len(got) != 1 || got[0].Path != "Tags[0]" {
+		t.Fatalf("after shrink: %v", got)
+	}
+}
+
+func TestIncremental_ResumesAfterContextAndRejectsBadFields(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	inc, err := sv.CompileIncremental(reflect.TypeOf(batch{}), "Tags", core.ValidateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := batch{Tags: []string{"x", "ok"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := inc.Validate(ctx, b); !errors.Is(err, context.Canceled) || inc.Validated() != 0 {
+		t.Fatalf("canceled: err=%v validated=%d", err, inc.Validated())
+	}
+	var es verrs.Errors
+	if err := inc.Validate(context.Background(), b); !errors.As(err, &es) || len(es) != 1 || es[0].Path != "Tags[0]" {
+		t.Fatalf("resumed: %v", err)
+	}
+	if err := inc.Validate(context.Background(), struct{}{}); err == nil {
+		t.Fatal("accepted a value of another type")
+	}
+
+	for _, field := range []string{"Name", "Missing"} {
+		if _, err := sv.CompileIncremental(reflect.TypeOf(batch{}), field, core.ValidateOpts{}); err == nil {
+			t.Errorf("CompileIncremental(%q) accepted a non-slice field", field)
+		}
+	}
+	type quota struct {
+		Items []string `validate:"quota=items"`
+	}
+	if _, err := sv.CompileIncremental(reflect.TypeOf(quota{}), "Items", core.ValidateOpts{}); err == nil {
+		t.Error("CompileIncremental accepted a quota field")
+	}
+}
@@ -1,12 +1,17 @@
+//go:build !validate_lite
+
 package structvalidator
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/aatuh/validate/v3/core"
 	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
 )
 
 func TestStruct_MapKeyPathPreservesShortOrdinaryKeys(t *testing.T) {
@@ -78,3 +83,72 @@ func requireStructMapPrivacyErrors(t *testing.T, err error) verrs.Errors {
 	}
 	return es
 }
+
+func TestStruct_SensitiveFieldErrorsAreMarkedAndRedactable(t *testing.T) {
+	v := core.New().WithTranslator(dummyTr{}).WithRuleCompiler("echo", func(*types.Compiler, types.Rule) (func(any) error, error) {
+		return func(value any) error { return fmt.Errorf("bad value %v", value) }, nil
+	})
+	sv := NewStructValidator(v)
+
+	type Input struct {
+		SSN    string `validate:"string;sensitive;echo"`
+		Secret string `validate:"string;min=8;sensitive"`
+		Name   string `validate:"string;min=3"`
+	}
+	err := sv.ValidateStructWithOpts(Input{SSN: "123-45-6789", Secret: "short", Name: "x"}, core.ValidateOpts{CollectAllRules: true})
+	es := requireStructMapPrivacyErrors(t, err)
+	if len(es) != 3 {
+		t.Fatalf("errors = %#v, want three errors", es)
+	}
+	if strings.Contains(es.Error(), "123-45-6789") {
+		t.Fatalf("sensitive value leaked: %v", es)
+	}
+	for _, e := range es {
+		if want := e.Path != "Name"; e.Sensitive != want {
+			t.Fatalf("%s: Sensitive = %v, want %v", e.Path, e.Sensitive, want)
+		}
+	}
+	for _, e := range es.Redact() {
+		if e.Sensitive && (e.Msg != "" || e.Param != nil) {
+			t.Fatalf("%s: redacted error kept %q/%v", e.Path, e.Msg, e.Param)
+		}
+		if !e.Sensitive && e.Msg == "" {
+			t.Fatalf("%s: non-sensitive message stripped", e.Path)
+		}
+	}
+}
+
+func TestStruct_IncludeValuesAttachesTruncatedValues(t *testing.T) {
+	sv := NewStructValidator(core.New().WithTranslator(dummyTr{}))
+
+	type Input struct {
+		Age    int      `validate:"int;min=18"`
+		Bio    string   `validate:"string;max=3"`
+		Secret string   `validate:"string;sensitive;max=3"`
+		Tags   []string `validate:"slice;max=1"`
+	}
+	in := Input{Age: 12, Bio: strings.Repeat("é", 40), Secret: "hunter2", Tags: []string{"a", "b"}}
+
+	err := sv.ValidateStruct(in)
+	for _, e := range requireStructMapPrivacyErrors(t, err) {
+		if e.Value != nil {
+			t.Fatalf("%s: value attached without IncludeValues", e.Path)
+		}
+	}
+
+	err = sv.ValidateStructWithOpts(in, core.ValidateOpts{IncludeValues: true})
+	got := map[string]any{}
+	for _, e := range requireStructMapPrivacyErrors(t, err) {
+		got[e.Path] = e.Value
+	}
+	if got["Age"] != 12 || got["Tags"] != "[a b]" || got["Secret"] != nil {
+		t.Fatalf("values = %#v", got)
+	}
+	bio, _ := got["Bio"].(string)
+	if !strings.HasSuffix(bio, "...") || len(bio) > maxErrorValueLen+3 || !utf8.ValidString(bio) {
+		t.Fatalf("Bio value = %q, want valid truncated string", bio)
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("sensitive value leaked: %v", err)
+	}
+}
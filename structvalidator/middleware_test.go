@@ -0,0 +1,50 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type middlewareOrder struct {
+	ID    string `json:"id" validate:"string;min=1"`
+	Items []struct {
+		SKU string `json:"sku" validate:"string;len=3"`
+	} `json:"items" validate:"slice;max=2;dive"`
+}
+
+func TestValidateStruct_MiddlewareSeesFieldPaths(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	engine := core.NewEngine().Use(func(info types.RuleInfo, next types.ContextValidatorFunc) types.ContextValidatorFunc {
+		return func(ctx context.Context, v any) error {
+			mu.Lock()
+			seen[types.FieldPath(ctx)+" "+string(info.Kind)] = true
+			mu.Unlock()
+			return next(ctx, v)
+		}
+	})
+	o := middlewareOrder{ID: "o1"}
+	o.Items = append(o.Items, struct {
+		SKU string `json:"sku" validate:"string;len=3"`
+	}{SKU: "abc"})
+	if err := NewStructValidator(engine).ValidateStructWithOpts(o, core.ValidateOpts{UseJSONNames: true}); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for k := range seen {
+		got = append(got, k)
+	}
+	sort.Strings(got)
+	want := "id minLength,id string,items maxSliceLength,items slice,items[0].sku length,items[0].sku string"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("seen = %v", got)
+	}
+}
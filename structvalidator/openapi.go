@@ -0,0 +1,211 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// openAPIRefPrefix is where component schemas live in an OpenAPI document.
+const openAPIRefPrefix = "#/components/schemas/"
+
+// OpenAPIComponents returns OpenAPI 3.0 component schemas for schema and
+// the struct types it references, keyed by Go type name. Properties use
+// JSON field names, tag rules become schema constraints through
+// types.OpenAPISchemaFor, fields with `required` are listed as required,
+// and nested structs are $ref references to their own components.
+//
+// Parameters:
+//   - schema: A struct value, a pointer to struct, or a reflect.Type of a
+//     struct.
+//   - opts: Options selecting schema version tag overrides.
+//
+// Returns:
+//   - map[string]*types.OpenAPISchema: Schemas for components.schemas.
+//   - error: If schema is not a struct or a tag does not parse.
+func (sv *StructValidator) OpenAPIComponents(schema any, opts core.ValidateOpts) (map[string]*types.OpenAPISchema, error) {
+	typ, ok := schema.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(schema)
+	}
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("OpenAPIComponents: expected struct, got %T", schema)
+	}
+	components := map[string]*types.OpenAPISchema{}
+	if _, err := sv.openAPIStruct(typ, opts, components); err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+// openAPIStruct adds the component for t and returns a reference to it.
+func (sv *StructValidator) openAPIStruct(t reflect.Type, opts core.ValidateOpts, components map[string]*types.OpenAPISchema) (*types.OpenAPISchema, error) {
+	name := t.Name()
+	if name == "" {
+		name = "Anonymous"
+	}
+	ref := &types.OpenAPISchema{Ref: openAPIRefPrefix + name}
+	if _, ok := components[name]; ok {
+		return ref, nil
+	}
+	s := &types.OpenAPISchema{Type: "object", Properties: map[string]*types.OpenAPISchema{}}
+	components[name] = s
+	if err := sv.openAPIFields(t, s, opts, components); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+// openAPIFields adds the properties of t to s, flattening untagged embedded
+// structs as encoding/json does.
+func (sv *StructValidator) openAPIFields(t reflect.Type, s *types.OpenAPISchema, opts core.ValidateOpts, components map[string]*types.OpenAPISchema) error {
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		jsonName, _, _ := strings.Cut(ft.Tag.Get("json"), ",")
+		embedded := ft.Anonymous && ft.Type.Kind() == reflect.Struct
+		if (ft.PkgPath != "" && !embedded) || jsonName == "-" {
+			continue
+		}
+		tag, err := sv.fieldTag(t, ft, opts.SchemaVersion)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+		if embedded && tag == "" && jsonName == "" {
+			if err := sv.openAPIFields(ft.Type, s, opts, components); err != nil {
+				return err
+			}
+			continue
+		}
+		if ft.PkgPath != "" {
+			continue
+		}
+
+		tokens, access := splitFieldAccess(types.SplitTag(tag))
+		tokens, _ = splitSensitive(tokens)
+		tokens, _ = splitDive(tokens)
+		tokens, _ = splitQuotaTokens(tokens)
+		tokens, _, err = splitStructRules(tokens)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ft.Name, err)
+		}
+		var rules []types.Rule
+		if len(tokens) > 0 {
+			if rules, err = sv.validator.ParseRules(tokens); err != nil {
+				return fmt.Errorf("%s: %w", ft.Name, err)
+			}
+		}
+		prop, err := sv.openAPIField(rules, ft.Type, opts, components)
+		if err != nil {
+			return err
+		}
+		prop.ReadOnly = access == fieldReadOnly
+		prop.WriteOnly = access == fieldWriteOnly
+		if ft.Type.Kind() == reflect.Ptr && hasRuleKind(rules, types.KOmitempty) {
+			prop.Nullable = true
+		}
+		name := JSONFieldName(ft)
+		s.Properties[name] = prop
+		if hasRuleKind(rules, types.KRequired) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return nil
+}
+
+// openAPIField returns the schema of a field from its rules, completed from
+// its Go type where the rules say nothing, such as untagged fields, custom
+// base types and struct elements.
+func (sv *StructValidator) openAPIField(rules []types.Rule, t reflect.Type, opts core.ValidateOpts, components map[string]*types.OpenAPISchema) (*types.OpenAPISchema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s := &types.OpenAPISchema{}
+	if len(rules) > 0 {
+		s = types.OpenAPISchemaFor(rules)
+	}
+	if s.Type != "" && t.Kind() != reflect.Slice && t.Kind() != reflect.Array && t.Kind() != reflect.Map {
+		return s, nil
+	}
+	switch {
+	case t == timeType:
+		if s.Type == "" {
+			s.Type, s.Format = "string", "date-time"
+		}
+	case t.Kind() == reflect.Struct:
+		if s.Type != "" {
+			return s, nil
+		}
+		return sv.openAPIStruct(t, opts, components)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && s.Type == "" {
+			s.Type, s.Format = "string", "byte"
+			break
+		}
+		s.Type = "array"
+		elem, err := sv.openAPIField(nil, t.Elem(), opts, components)
+		if err != nil {
+			return nil, err
+		}
+		s.Items = mergeOpenAPISchema(s.Items, elem)
+	case t.Kind() == reflect.Map:
+		s.Type = "object"
+		elem, err := sv.openAPIField(nil, t.Elem(), opts, components)
+		if err != nil {
+			return nil, err
+		}
+		s.AdditionalProperties = mergeOpenAPISchema(s.AdditionalProperties, elem)
+	default:
+		if s.Type == "" {
+			s.Type = openAPIKindType(t.Kind())
+		}
+	}
+	return s, nil
+}
+
+// mergeOpenAPISchema fills the type of a rule-derived element schema from
+// the Go element type, or returns the Go-derived schema when there is none.
+func mergeOpenAPISchema(fromRules, fromType *types.OpenAPISchema) *types.OpenAPISchema {
+	switch {
+	case fromRules == nil:
+		return fromType
+	case fromRules.Type == "" && fromType.Ref != "":
+		return fromType
+	case fromRules.Type == "":
+		fromRules.Type = fromType.Type
+		if fromRules.Format == "" {
+			fromRules.Format = fromType.Format
+		}
+	}
+	return fromRules
+}
+
+func openAPIKindType(k reflect.Kind) string {
+	switch {
+	case k == reflect.String:
+		return "string"
+	case k == reflect.Bool:
+		return "boolean"
+	case k >= reflect.Int && k <= reflect.Uintptr:
+		return "integer"
+	case k == reflect.Float32 || k == reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+func hasRuleKind(rules []types.Rule, kind types.Kind) bool {
+	for _, r := range rules {
+		if r.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
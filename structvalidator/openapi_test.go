@@ -0,0 +1,91 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+type openAPIAddress struct {
+	City string `json:"city" validate:"string;required;max=40"`
+	Zip  string `json:"zip" validate:"string;regex=^[0-9]{5}$"`
+}
+
+type openAPIAudit struct {
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type openAPINode struct {
+	Name     string         `json:"name" validate:"string;min=1"`
+	Children []*openAPINode `json:"children"`
+}
+
+type openAPIUser struct {
+	openAPIAudit
+	ID       string            `json:"id" validate:"string;readonly"`
+	Name     string            `json:"name" validate:"string;required;min=3;max=20"`
+	Password string            `json:"password" validate:"string;writeonly;min=12"`
+	Age      int               `json:"age" validate:"int;between=18,120"`
+	Role     string            `json:"role" validate:"string;oneof=admin,member"`
+	Nickname *string           `json:"nickname" validate:"string;omitempty;max=10"`
+	Tags     []string          `json:"tags" validate:"slice;max=5;foreach=(string;min=2)"`
+	Home     openAPIAddress    `json:"home"`
+	Others   []openAPIAddress  `json:"others" validate:"slice;max=3"`
+	Labels   map[string]string `json:"labels"`
+	Tree     *openAPINode      `json:"tree"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+func TestOpenAPIComponents_Struct(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	components, err := sv.OpenAPIComponents(openAPIUser{}, core.ValidateOpts{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"openAPIUser": `{"type":"object","properties":{` +
+			`"age":{"type":"integer","minimum":18,"maximum":120},` +
+			`"createdAt":{"type":"string","format":"date-time"},` +
+			`"home":{"$ref":"#/components/schemas/openAPIAddress"},` +
+			`"id":{"type":"string","readOnly":true},` +
+			`"labels":{"type":"object","additionalProperties":{"type":"string"}},` +
+			`"name":{"type":"string","minLength":3,"maxLength":20},` +
+			`"nickname":{"type":"string","nullable":true,"maxLength":10},` +
+			`"others":{"type":"array","maxItems":3,"items":{"$ref":"#/components/schemas/openAPIAddress"}},` +
+			`"password":{"type":"string","writeOnly":true,"minLength":12},` +
+			`"role":{"type":"string","enum":["admin","member"]},` +
+			`"tags":{"type":"array","maxItems":5,"items":{"type":"string","minLength":2}},` +
+			`"tree":{"$ref":"#/components/schemas/openAPINode"}},` +
+			`"required":["name"]}`,
+		"openAPIAddress": `{"type":"object","properties":{` +
+			`"city":{"type":"string","maxLength":40},` +
+			`"zip":{"type":"string","pattern":"^[0-9]{5}$"}},"required":["city"]}`,
+		"openAPINode": `{"type":"object","properties":{` +
+			`"children":{"type":"array","items":{"$ref":"#/components/schemas/openAPINode"}},` +
+			`"name":{"type":"string","minLength":1}}}`,
+	}
+	if len(components) != len(want) {
+		t.Fatalf("got %d components, want %d: %v", len(components), len(want), components)
+	}
+	for name, w := range want {
+		got, err := json.Marshal(components[name])
+		if err != nil {
+			t.Fatalf("marshal %s: %v", name, err)
+		}
+		if string(got) != w {
+			t.Errorf("%s:\n got %s\nwant %s", name, got, w)
+		}
+	}
+}
+
+func TestOpenAPIComponents_RejectsNonStruct(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	if _, err := sv.OpenAPIComponents("string;min=1", core.ValidateOpts{}); err == nil {
+		t.Fatal("expected error for non-struct schema")
+	}
+}
@@ -0,0 +1,266 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// nestsTagged reports whether the walk also recurses into the structs held
+// by ft when it is tagged. go-playground/validator always does, while native
+// tags recurse only into untagged fields.
+func (sv *StructValidator) nestsTagged(ft reflect.StructField) bool {
+	if !sv.validator.PlaygroundTags() {
+		return false
+	}
+	_, _, ok := nestedStructType(ft.Type, "")
+	return ok
+}
+
+// playgroundTag translates tag, written in go-playground/validator syntax,
+// into this package's syntax for a field of type t. The base type token is
+// derived from t, and rules after `dive` apply to the elements of slices
+// and arrays or, split by `keys` and `endkeys`, to the keys and values of
+// maps. Tags without rules translate to "".
+func playgroundTag(tag string, t reflect.Type) (string, error) {
+	if tag == "-" {
+		return "", nil
+	}
+	tokens := strings.Split(tag, ",")
+	for i, token := range tokens {
+		if strings.Contains(token, "|") {
+			return "", fmt.Errorf("go-playground tag %q: or-operator | is not supported", tag)
+		}
+		token = strings.ReplaceAll(token, "0x2C", ",")
+		tokens[i] = strings.ReplaceAll(strings.TrimSpace(token), "0x7C", "|")
+	}
+	native, err := playgroundRules(tokens, t)
+	if err != nil {
+		return "", fmt.Errorf("go-playground tag %q: %w", tag, err)
+	}
+	return native, nil
+}
+
+// playgroundRules translates go-playground tokens for a value of type t.
+func playgroundRules(tokens []string, t reflect.Type) (string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	base := playgroundBase(t)
+	var rules []string
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "" {
+			continue
+		}
+		if token != "dive" {
+			translated, err := playgroundRule(token, base)
+			if err != nil {
+				return "", err
+			}
+			rules = append(rules, translated...)
+			continue
+		}
+		nested, err := playgroundDive(tokens[i+1:], t)
+		if err != nil {
+			return "", err
+		}
+		rules = append(rules, nested...)
+		break
+	}
+	if len(rules) == 0 {
+		return "", nil
+	}
+	if base == "" {
+		return strings.Join(rules, ";"), nil
+	}
+	return base + ";" + strings.Join(rules, ";"), nil
+}
+
+// playgroundDive translates the tokens after `dive` into foreach, keys and
+// values rules for t.
+func playgroundDive(tokens []string, t reflect.Type) ([]string, error) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		elem, err := playgroundRules(tokens, t.Elem())
+		if err != nil || elem == "" {
+			return nil, err
+		}
+		return []string{"foreach=(" + elem + ")"}, nil
+	case reflect.Map:
+		var out []string
+		if len(tokens) > 0 && tokens[0] == "keys" {
+			end := -1
+			for i, token := range tokens {
+				if token == "endkeys" {
+					end = i
+					break
+				}
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("keys without endkeys")
+			}
+			keys, err := playgroundRules(tokens[1:end], t.Key())
+			if err != nil {
+				return nil, err
+			}
+			if keys != "" {
+				out = append(out, "keys=("+keys+")")
+			}
+			tokens = tokens[end+1:]
+		}
+		values, err := playgroundRules(tokens, t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if values != "" {
+			out = append(out, "values=("+values+")")
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("dive is not supported on %s fields", t)
+	}
+}
+
+// playgroundBase returns the base type token for values of type t, or ""
+// for structs and other types without one.
+func playgroundBase(t reflect.Type) string {
+	if t == timeType {
+		return "time"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice:
+		return "slice"
+	case reflect.Array:
+		return "array"
+	case reflect.Map:
+		return "map"
+	default:
+		return ""
+	}
+}
+
+// playgroundRule translates one go-playground token for base. Tokens with
+// the same meaning in both syntaxes, such as email or startswith=, pass
+// through unchanged.
+func playgroundRule(token, base string) ([]string, error) {
+	name, param, _ := strings.Cut(token, "=")
+	sized := base == "string" || base == "slice" || base == "array" || base == "map"
+	numeric := base == "int" || base == "uint" || base == "float"
+	switch name {
+	case "required", "omitempty":
+		return []string{token}, nil
+	case "eqfield":
+		return []string{"eqField=" + param}, nil
+	case "nefield":
+		return []string{"neField=" + param}, nil
+	case "required_with":
+		if strings.Contains(param, " ") {
+			return nil, fmt.Errorf("required_with supports a single field")
+		}
+		return []string{"requiredWith=" + param}, nil
+	case "required_if", "required_unless":
+		fields := strings.Fields(param)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s supports a single field and value", name)
+		}
+		kind := "requiredIf="
+		if name == "required_unless" {
+			kind = "requiredUnless="
+		}
+		return []string{kind + fields[0] + "," + fields[1]}, nil
+	}
+	if base == "" {
+		return nil, fmt.Errorf("%s is not supported on fields without a base type", name)
+	}
+	switch name {
+	case "min", "max":
+		if numeric {
+			return []string{token}, nil
+		}
+		if sized {
+			return playgroundLength(name, param, base)
+		}
+	case "eq":
+		if base == "string" && !strings.ContainsAny(param, " ,") {
+			return []string{"oneof=" + param}, nil
+		}
+		if base != "string" {
+			return playgroundRule("len="+param, base)
+		}
+	case "len":
+		if numeric {
+			return []string{"min=" + param, "max=" + param}, nil
+		}
+		if sized {
+			return playgroundLength(name, param, base)
+		}
+	case "gt", "gte", "lt", "lte":
+		if numeric {
+			return []string{token}, nil
+		}
+		if sized {
+			return playgroundLength(name, param, base)
+		}
+	case "oneof":
+		if base == "string" {
+			return []string{token}, nil
+		}
+	default:
+		return []string{token}, nil
+	}
+	return nil, fmt.Errorf("%s is not supported on %s fields", name, base)
+}
+
+// playgroundLength translates min, max, len, gt, gte, lt and lte, which
+// bound the length of strings and collections. Like go-playground/validator,
+// string lengths count runes.
+func playgroundLength(name, param, base string) ([]string, error) {
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%s=%s must be a non-negative integer", name, param)
+	}
+	lo, hi := -1, -1
+	switch name {
+	case "min", "gte":
+		lo = n
+	case "max", "lte":
+		hi = n
+	case "len":
+		lo, hi = n, n
+	case "gt":
+		lo = n + 1
+	case "lt":
+		if n == 0 {
+			return nil, fmt.Errorf("lt=0 never matches")
+		}
+		hi = n - 1
+	}
+	minToken, maxToken := "min=", "max="
+	if base == "string" {
+		minToken, maxToken = "minRunes=", "maxRunes="
+	} else if name == "len" {
+		return []string{"len=" + param}, nil
+	}
+	var out []string
+	if lo >= 0 {
+		out = append(out, minToken+strconv.Itoa(lo))
+	}
+	if hi >= 0 {
+		out = append(out, maxToken+strconv.Itoa(hi))
+	}
+	return out, nil
+}
@@ -0,0 +1,123 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type playgroundAddress struct {
+	City string `validate:"required,max=5"`
+}
+
+type playgroundUser struct {
+	Name     string             `validate:"required,min=3,max=10"`
+	Role     string             `validate:"oneof=admin user guest"`
+	Age      int                `validate:"gte=18,lte=130"`
+	Tags     []string           `validate:"max=3,dive,required,min=2"`
+	Labels   map[string]int     `validate:"dive,keys,min=2,endkeys,gt=0"`
+	Password string             `validate:"required"`
+	Confirm  string             `validate:"eqfield=Password"`
+	Address  *playgroundAddress `validate:"required"`
+	Internal playgroundAddress  `validate:"-"`
+}
+
+func validPlaygroundUser() playgroundUser {
+	return playgroundUser{
+		Name: "Ada", Role: "admin", Age: 36,
+		Tags:     []string{"go", "db"},
+		Labels:   map[string]int{"team": 1},
+		Password: "secret", Confirm: "secret",
+		Address:  &playgroundAddress{City: "Oslo"},
+		Internal: playgroundAddress{City: "too long to pass"},
+	}
+}
+
+func TestPlaygroundTags_Validate(t *testing.T) {
+	sv := NewStructValidator(core.New().WithPlaygroundTags(true))
+	if err := sv.ValidateStruct(validPlaygroundUser()); err != nil {
+		t.Fatalf("valid user: %v", err)
+	}
+
+	u := validPlaygroundUser()
+	u.Name = "Al"
+	u.Role = "root"
+	u.Age = 17
+	u.Tags = []string{"go", "x"}
+	u.Labels = map[string]int{"team": 0}
+	u.Confirm = "other"
+	u.Address = &playgroundAddress{City: "Helsinki"}
+	err := sv.ValidateStruct(u)
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("expected field errors, got %v", err)
+	}
+	got := map[string]string{}
+	for _, e := range es {
+		got[e.Path] = e.Code
+	}
+	want := map[string]string{
+		"Name":         "string.minRunes",
+		"Role":         "string.oneof",
+		"Age":          "number.gte",
+		"Tags[1]":      "string.minRunes",
+		"Labels[team]": "number.gt",
+		"Confirm":      "field.eq",
+		"Address.City": "string.maxRunes",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("codes = %v, want %v", got, want)
+	}
+
+	u = validPlaygroundUser()
+	u.Address = nil
+	if err := sv.ValidateStruct(u); err == nil {
+		t.Fatal("nil Address with required passed")
+	}
+}
+
+func TestPlaygroundTags_OptIn(t *testing.T) {
+	if err := NewStructValidator(core.New()).CompileStruct(playgroundUser{}, core.ValidateOpts{}); err == nil {
+		t.Fatal("go-playground tags compiled without WithPlaygroundTags")
+	}
+	if err := NewStructValidator(core.New().WithPlaygroundTags(true)).CompileStruct(playgroundUser{}, core.ValidateOpts{}); err != nil {
+		t.Fatalf("CompileStruct: %v", err)
+	}
+}
+
+func TestPlaygroundTag_Translation(t *testing.T) {
+	tests := []struct {
+		tag  string
+		typ  any
+		want string
+		err  bool
+	}{
+		{tag: "required,min=3,max=10", typ: "", want: "string;required;minRunes=3;maxRunes=10"},
+		{tag: "len=4", typ: []int{}, want: "slice;len=4"},
+		{tag: "len=4", typ: 0, want: "int;min=4;max=4"},
+		{tag: "gt=0,lt=10", typ: "", want: "string;minRunes=1;maxRunes=9"},
+		{tag: "gt=0.5", typ: 0.0, want: "float;gt=0.5"},
+		{tag: "eq=on", typ: "", want: "string;oneof=on"},
+		{tag: "omitempty,startswith=ab0x2Ccd", typ: "", want: "string;omitempty;startswith=ab,cd"},
+		{tag: "dive,dive,email", typ: [][]string{}, want: "slice;foreach=(slice;foreach=(string;email))"},
+		{tag: "dive,keys,min=2,endkeys,gt=0", typ: map[string]int{}, want: "map;keys=(string;minRunes=2);values=(int;gt=0)"},
+		{tag: "dive", typ: []playgroundAddress{}, want: ""},
+		{tag: "required_if=Role admin", typ: "", want: "string;requiredIf=Role,admin"},
+		{tag: "-", typ: "", want: ""},
+		{tag: "rgb|rgba", typ: "", err: true},
+		{tag: "oneof=1 2", typ: 0, err: true},
+		{tag: "dive,required", typ: "", err: true},
+		{tag: "min=1", typ: playgroundAddress{}, err: true},
+	}
+	for _, tt := range tests {
+		got, err := playgroundTag(tt.tag, reflect.TypeOf(tt.typ))
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("playgroundTag(%q, %T) = %q, %v; want %q, error %v", tt.tag, tt.typ, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// PolicyRule is one constraint that platform teams place on the rules of
+// struct fields, such as "every string field must have a max length".
+//
+// Fields:
+//   - Name: Identifies the rule in violations.
+//   - Paths: path.Match patterns matched against field paths, named as in
+//     validation errors; "[]" marks elements of slices, arrays and maps. No
+//     patterns selects every field.
+//   - Types: Go kinds of the fields the rule applies to, with pointers
+//     dereferenced. No kinds selects every field.
+//   - Require: Groups of rule kinds; a selected field must use at least one
+//     kind of every group.
+//   - Forbid: Rule kinds a selected field must not use.
+type PolicyRule struct {
+	Name    string
+	Paths   []string
+	Types   []reflect.Kind
+	Require [][]types.Kind
+	Forbid  []types.Kind
+}
+
+// Policy is a set of rules checked together by CheckPolicy.
+type Policy []PolicyRule
+
+// PolicyViolation reports a field that breaks a policy rule.
+//
+// Fields:
+//   - Type: Name of the checked struct type.
+//   - Path: Field path, named as in validation errors.
+//   - Rule: Name of the broken PolicyRule.
+//   - Msg: What the field lacks or uses.
+type PolicyViolation struct {
+	Type string
+	Path string
+	Rule string
+	Msg  string
+}
+
+func (v PolicyViolation) String() string {
+	return fmt.Sprintf("%s.%s: %s: %s", v.Type, v.Path, v.Rule, v.Msg)
+}
+
+// RequireMaxLength returns a policy rule that every string, slice and map
+// field matching paths, or every such field when paths is empty, bounds its
+// length or size with max, len or maxRunes. Arrays have a fixed length and
+// are not selected.
+func RequireMaxLength(paths ...string) PolicyRule {
+	return PolicyRule{
+		Name:  "max-length",
+		Paths: paths,
+		Types: []reflect.Kind{reflect.String, reflect.Slice, reflect.Map},
+		Require: [][]types.Kind{{
+			types.KMaxLength, types.KLength, types.KMaxRunes,
+			types.KMaxSliceLength, types.KSliceLength,
+			types.KMaxMapKeys, types.KMapLength,
+		}},
+	}
+}
+
+// CheckPolicy checks the rules of every exported field reachable from the
+// given struct types against p, turning the tags of request models into an
+// enforceable input-hardening contract. Fields are reached as in Coverage:
+// untagged nested structs and structs of fields tagged with `dive` are
+// walked. Elements of slices, arrays and maps of non-struct types are
+// checked too, at the field path with "[]" appended, against their
+// foreach and values rules.
+//
+// Parameters:
+//   - p: The policy to enforce.
+//   - opts: PathSep, FieldNameFunc, UseJSONNames and SchemaVersion are
+//     honored.
+//   - schemas: Struct values, pointers to struct, or reflect.Types of
+//     structs.
+//
+// Returns:
+//   - []PolicyViolation: Violations in type, field and policy rule order.
+//   - error: If a schema is not a struct, a pattern is malformed, or a tag
+//     does not parse.
+func (sv *StructValidator) CheckPolicy(p Policy, opts core.ValidateOpts, schemas ...any) ([]PolicyViolation, error) {
+	opts = core.ApplyOpts(sv.validator, opts)
+	for _, rule := range p {
+		for _, pattern := range rule.Paths {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("CheckPolicy: rule %q: pattern %q: %w", rule.Name, pattern, err)
+			}
+		}
+	}
+
+	var out []PolicyViolation
+	for _, s := range schemas {
+		typ, ok := s.(reflect.Type)
+		if !ok {
+			typ = reflect.TypeOf(s)
+		}
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ == nil || typ.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("CheckPolicy: expected struct, got %T", s)
+		}
+		typeName := typ.Name()
+		if typeName == "" {
+			typeName = "Anonymous"
+		}
+		check := func(fieldPath string, t reflect.Type, rules []types.Rule) {
+			for _, rule := range p {
+				if msg := rule.violation(fieldPath, t, rules); msg != "" {
+					out = append(out, PolicyViolation{Type: typeName, Path: fieldPath, Rule: rule.Name, Msg: msg})
+				}
+			}
+		}
+
+		onStack := map[reflect.Type]bool{}
+		var walk func(t reflect.Type, prefix string) error
+		walk = func(t reflect.Type, prefix string) error {
+			if onStack[t] {
+				return nil
+			}
+			onStack[t] = true
+			defer delete(onStack, t)
+
+			for i := 0; i < t.NumField(); i++ {
+				ft := t.Field(i)
+				if ft.PkgPath != "" {
+					continue
+				}
+				fieldPath := fieldPathJoin(prefix, fieldDisplayName(ft, opts), opts.PathSep)
+				tag, err := sv.fieldTag(t, ft, opts.SchemaVersion)
+				if err != nil {
+					return fmt.Errorf("CheckPolicy: %s.%s: %w", typeName, fieldPath, err)
+				}
+				elem, elemPath, nested := nestedStructType(ft.Type, fieldPath)
+				if tag == "" && nested && hasExportedField(elem) {
+					if err := walk(elem, elemPath); err != nil {
+						return err
+					}
+					continue
+				}
+				rules, err := sv.policyRules(tag)
+				if err != nil {
+					return fmt.Errorf("CheckPolicy: %s.%s: %w", typeName, fieldPath, err)
+				}
+				check(fieldPath, ft.Type, rules)
+				if nested {
+					if dives(tag) {
+						if err := walk(elem, elemPath); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				if et, ok := policyElemType(ft.Type); ok {
+					check(fieldPath+"[]", et, elemRules(rules))
+				}
+			}
+			return nil
+		}
+		if err := walk(typ, ""); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// policyRules parses the field rules of tag, leaving out struct-level,
+// access, sensitivity, dive and quota tokens.
+func (sv *StructValidator) policyRules(tag string) ([]types.Rule, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	tokens, _ := splitFieldAccess(types.SplitTag(tag))
+	tokens, _ = splitSensitive(tokens)
+	tokens, _ = splitDive(tokens)
+	tokens, _ = splitQuotaTokens(tokens)
+	tokens, _, err := splitStructRules(tokens)
+	if err != nil || len(tokens) == 0 {
+		return nil, err
+	}
+	return sv.validator.ParseRules(tokens)
+}
+
+// violation returns why a field of type t at fieldPath with rules breaks r,
+// or "" when r does not select the field or the field complies.
+func (r PolicyRule) violation(fieldPath string, t reflect.Type, rules []types.Rule) string {
+	if !r.selects(fieldPath, t) {
+		return ""
+	}
+	for _, group := range r.Require {
+		if !hasAnyRuleKind(rules, group) {
+			return "missing one of " + joinKinds(group)
+		}
+	}
+	for _, kind := range r.Forbid {
+		if hasRuleKind(rules, kind) {
+			return "uses forbidden rule " + string(kind)
+		}
+	}
+	return ""
+}
+
+func (r PolicyRule) selects(fieldPath string, t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if len(r.Types) > 0 {
+		found := false
+		for _, k := range r.Types {
+			found = found || k == t.Kind()
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.Paths) == 0 {
+		return true
+	}
+	for _, pattern := range r.Paths {
+		if ok, _ := path.Match(pattern, fieldPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// policyElemType returns the element type of slices, arrays and maps of
+// non-struct elements.
+func policyElemType(t reflect.Type) (reflect.Type, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem(), true
+	}
+	return nil, false
+}
+
+// elemRules returns the rules applied to each element by foreach or values.
+func elemRules(rules []types.Rule) []types.Rule {
+	for _, r := range rules {
+		switch r.Kind {
+		case types.KForEach, types.KArrayForEach, types.KMapValues:
+			inner, _ := r.Args["rules"].([]types.Rule)
+			return inner
+		}
+	}
+	return nil
+}
+
+func hasAnyRuleKind(rules []types.Rule, kinds []types.Kind) bool {
+	for _, kind := range kinds {
+		if hasRuleKind(rules, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinKinds(kinds []types.Kind) string {
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = string(k)
+	}
+	return strings.Join(names, ", ")
+}
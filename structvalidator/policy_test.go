@@ -0,0 +1,71 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type policyAddress struct {
+	Street string `json:"street" validate:"string;max=100"`
+	City   string `json:"city"`
+}
+
+type policyRequest struct {
+	Name    string            `json:"name" validate:"string;min=1;max=50"`
+	Bio     *string           `json:"bio" validate:"string;omitempty;regex=^[a-z]+$"`
+	Tags    []string          `json:"tags" validate:"slice;max=10;foreach=(string;max=20)"`
+	Labels  map[string]string `json:"labels" validate:"map;max=5"`
+	Count   int               `json:"count" validate:"int;min=0"`
+	Address policyAddress     `json:"address"`
+	Items   []policyAddress   `json:"items" validate:"slice;max=3;dive"`
+}
+
+func TestCheckPolicy_RequireMaxLength(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	got, err := sv.CheckPolicy(Policy{RequireMaxLength()}, core.ValidateOpts{UseJSONNames: true}, policyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, v := range got {
+		if v.Type != "policyRequest" || v.Rule != "max-length" {
+			t.Fatalf("violation = %+v", v)
+		}
+		paths = append(paths, v.Path)
+	}
+	want := []string{"bio", "labels[]", "address.city", "items[].city"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestCheckPolicy_ForbidAndPaths(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	p := Policy{
+		{Name: "no-regex", Forbid: []types.Kind{types.KRegex}},
+		{Name: "bounded-count", Paths: []string{"Count"}, Require: [][]types.Kind{{types.KMaxInt}}},
+	}
+	got, err := sv.CheckPolicy(p, core.ValidateOpts{}, reflect.TypeOf(policyRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PolicyViolation{
+		{Type: "policyRequest", Path: "Bio", Rule: "no-regex", Msg: "uses forbidden rule regex"},
+		{Type: "policyRequest", Path: "Count", Rule: "bounded-count", Msg: "missing one of maxInt"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("violations = %+v, want %+v", got, want)
+	}
+
+	if _, err := sv.CheckPolicy(Policy{{Name: "bad", Paths: []string{"["}}}, core.ValidateOpts{}, policyRequest{}); err == nil {
+		t.Fatal("malformed pattern accepted")
+	}
+	if _, err := sv.CheckPolicy(p, core.ValidateOpts{}, "x"); err == nil {
+		t.Fatal("non-struct accepted")
+	}
+}
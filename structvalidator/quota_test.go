@@ -0,0 +1,64 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type quotaMessage struct {
+	Attachments []string `validate:"slice;quota=attachments"`
+}
+
+type quotaThread struct {
+	Extra    int `validate:"int;quota=attachments"`
+	Messages []quotaMessage
+}
+
+func TestQuotaAccumulatesAcrossNestedStructs(t *testing.T) {
+	v := core.NewEngine().WithTranslator(dummyTr{}).WithQuota("attachments", 4)
+	sv := NewStructValidator(v)
+
+	ok := quotaThread{Extra: 1, Messages: []quotaMessage{
+		{Attachments: []string{"a"}},
+		{Attachments: []string{"b", "c"}},
+	}}
+	if err := sv.ValidateStruct(ok); err != nil {
+		t.Fatalf("expected quota within limit, got %v", err)
+	}
+
+	over := quotaThread{Extra: 2, Messages: []quotaMessage{
+		{Attachments: []string{"a"}},
+		{Attachments: []string{"b", "c"}},
+	}}
+	err := sv.ValidateStruct(over)
+	requireStructFieldError(t, err, "", verrs.CodeQuotaExceeded, int64(4))
+}
+
+func TestQuotaWithoutLimitIsIgnored(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	s := quotaMessage{Attachments: make([]string, 100)}
+	if err := sv.ValidateStruct(s); err != nil {
+		t.Fatalf("expected no error without registered quota, got %v", err)
+	}
+}
+
+func TestQuotaAccumulatorSharedWithStructRules(t *testing.T) {
+	type payload struct {
+		A string `validate:"struct:count"`
+		B string `validate:"struct:count"`
+	}
+	v := core.NewEngine().WithTranslator(dummyTr{}).WithQuota("calls", 1).
+		WithStructRuleCompiler("count", func(types.Rule) (core.StructRuleFunc, error) {
+			return func(ctx core.StructRuleContext) error {
+				ctx.Accumulator.Add("calls", 1)
+				return nil
+			}, nil
+		})
+	err := NewStructValidator(v).ValidateStruct(payload{A: "x", B: "y"})
+	assertStructCodes(t, err, []string{verrs.CodeQuotaExceeded})
+}
@@ -0,0 +1,27 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+func TestRecorder_SkipsSensitiveFields(t *testing.T) {
+	type account struct {
+		Email string `validate:"string;min=5"`
+		Token string `validate:"string;sensitive;len=8"`
+	}
+	var corpus bytes.Buffer
+	sv := NewStructValidator(core.NewEngine().WithRecorder(core.NewRecorder(&corpus)))
+	if err := sv.ValidateStruct(account{Email: "Bob", Token: "abc"}); err == nil {
+		t.Fatal("expected errors")
+	}
+	got := strings.TrimSpace(corpus.String())
+	if got != `{"tag":"string;min=5","type":"string","value":"Xxx","codes":["string.min"]}` {
+		t.Fatalf("corpus = %s", got)
+	}
+}
@@ -0,0 +1,47 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestStruct_SchemaReferenceValidatesFieldValue(t *testing.T) {
+	core.RegisterSchema("svtest.Address", map[string]string{
+		"City": "string;required",
+		"Zip":  "string;len=5",
+	})
+	type Address struct {
+		City string
+		Zip  string
+	}
+	type Order struct {
+		Billing  Address        `validate:"schema=svtest.Address"`
+		Shipping *Address       `validate:"schema=svtest.Address"`
+		Extra    map[string]any `validate:"map;schema=svtest.Address"`
+	}
+	sv := NewStructValidator(core.New())
+
+	err := sv.ValidateStruct(Order{
+		Billing: Address{Zip: "90100"},
+		Extra:   map[string]any{"City": "Oulu", "Zip": "1"},
+	})
+	requireStructFieldError(t, err, "Billing.City", verrs.CodeRequired, nil)
+	requireStructFieldError(t, err, "Extra.Zip", verrs.CodeStringLength, 5)
+	assertStructCodes(t, err, []string{verrs.CodeRequired, verrs.CodeStringLength})
+
+	valid := Order{Billing: Address{City: "Oulu", Zip: "90100"}, Shipping: &Address{City: "Vaasa", Zip: "65100"}}
+	if err := sv.ValidateStruct(valid); err != nil {
+		t.Fatalf("valid order: %v", err)
+	}
+
+	type Broken struct {
+		A Address `validate:"schema=svtest.Missing"`
+	}
+	if err := sv.CompileStruct(Broken{}, core.ValidateOpts{}); err == nil {
+		t.Fatal("expected compile error for unknown schema")
+	}
+}
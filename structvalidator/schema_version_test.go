@@ -0,0 +1,48 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestStruct_SchemaVersionOverridesTags(t *testing.T) {
+	type Address struct {
+		Zip string `validate:"string;min=3"`
+	}
+	type Signup struct {
+		Name    string `validate:"string;min=2"`
+		Bio     string `validate:"string;max=100"`
+		Address Address
+	}
+
+	v := core.New().WithTranslator(dummyTr{}).
+		WithSchemaVersion(Signup{}, "v2", map[string]string{"Name": "string;min=4", "Bio": ""}).
+		WithSchemaVersion(&Address{}, "v2", map[string]string{"Zip": "string;len=5"})
+	sv := NewStructValidator(v)
+
+	in := Signup{Name: "Bob", Bio: string(make([]byte, 150)), Address: Address{Zip: "1234"}}
+
+	err := sv.ValidateStruct(in)
+	assertStructCodes(t, err, []string{verrs.CodeStringMax})
+
+	err = sv.ValidateStructWithOpts(in, core.ValidateOpts{SchemaVersion: "v2"})
+	requireStructFieldError(t, err, "Name", verrs.CodeStringMin, 4)
+	requireStructFieldError(t, err, "Address.Zip", verrs.CodeStringLength, 5)
+	assertStructCodes(t, err, []string{verrs.CodeStringMin, verrs.CodeStringLength})
+
+	// Unknown versions fall back to the declared struct tags.
+	err = sv.ValidateStructWithOpts(in, core.ValidateOpts{SchemaVersion: "v9"})
+	assertStructCodes(t, err, []string{verrs.CodeStringMax})
+
+	if got := v.SchemaVersions(reflect.TypeOf(Signup{})); !reflect.DeepEqual(got, []string{"v2"}) {
+		t.Fatalf("SchemaVersions = %v", got)
+	}
+	if _, ok := core.New().SchemaFieldTag(reflect.TypeOf(Signup{}), "v2", "Name"); ok {
+		t.Fatalf("fresh engine must not share schema versions")
+	}
+}
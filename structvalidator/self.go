@@ -0,0 +1,53 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import "reflect"
+
+// Validatable is implemented by structs with invariants that span several
+// fields. Under types.Behavior.CallValidateSelf, ValidateStruct calls
+// ValidateSelf after the struct's field rules, for the root and every nested
+// struct it walks. Paths of returned Errors
+// are relative to the struct and are prefixed with its path; other errors
+// are reported at the struct's path with code unknown.
+type Validatable interface {
+	ValidateSelf() error
+}
+
+var validatableType = reflect.TypeOf((*Validatable)(nil)).Elem()
+
+// selfCheck records how a struct type implements Validatable.
+type selfCheck uint8
+
+const (
+	selfNone selfCheck = iota
+	selfValue
+	selfPointer
+)
+
+func selfCheckFor(t reflect.Type) selfCheck {
+	switch {
+	case t.Implements(validatableType):
+		return selfValue
+	case reflect.PointerTo(t).Implements(validatableType):
+		return selfPointer
+	}
+	return selfNone
+}
+
+// run calls ValidateSelf on the struct v. Pointer receivers get v's address,
+// or the address of a copy when v is not addressable.
+func (s selfCheck) run(v reflect.Value) error {
+	switch s {
+	case selfValue:
+		return v.Interface().(Validatable).ValidateSelf()
+	case selfPointer:
+		if !v.CanAddr() {
+			cp := reflect.New(v.Type())
+			cp.Elem().Set(v)
+			v = cp.Elem()
+		}
+		return v.Addr().Interface().(Validatable).ValidateSelf()
+	}
+	return nil
+}
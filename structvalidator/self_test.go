@@ -0,0 +1,81 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type selfRange struct {
+	Min int `json:"min" validate:"int;min=0"`
+	Max int `json:"max" validate:"int;min=0"`
+}
+
+func (r selfRange) ValidateSelf() error {
+	if r.Min > r.Max {
+		return verrs.Errors{{Path: "Max", Code: "range.order", Msg: "max must not be below min"}}
+	}
+	return nil
+}
+
+type selfBooking struct {
+	Guests int `validate:"int;min=1"`
+	Range  selfRange
+	Ranges []selfRange `validate:"slice;dive"`
+}
+
+func (b *selfBooking) ValidateSelf() error {
+	if b.Guests > 10 {
+		return errors.New("too many guests")
+	}
+	return nil
+}
+
+func TestValidateStruct_CallsValidateSelf(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine().WithBehavior(types.Behavior{CallValidateSelf: true}))
+	b := selfBooking{
+		Guests: 11,
+		Range:  selfRange{Min: 5, Max: 1},
+		Ranges: []selfRange{{Min: 1, Max: 2}, {Min: -1, Max: -2}},
+	}
+	// Pointer receivers run for values too.
+	err := sv.ValidateStruct(b)
+	var es verrs.Errors
+	if !errors.As(err, &es) {
+		t.Fatalf("err = %v", err)
+	}
+	var got []string
+	for _, e := range es {
+		got = append(got, e.Path+" "+e.Code)
+	}
+	want := []string{
+		"Range.Max range.order",
+		"Ranges[1].Min int.min",
+		"Ranges[1].Max int.min",
+		"Ranges[1].Max range.order",
+		" unknown",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors = %q, want %q", got, want)
+	}
+
+	if err := sv.ValidateStructWithOpts(&b, core.ValidateOpts{StopOnFirst: true}); err == nil {
+		t.Fatal("StopOnFirst lost errors")
+	} else if es := err.(verrs.Errors); len(es) != 1 || es[0].Path != "Range.Max" {
+		t.Fatalf("StopOnFirst errors = %v", es)
+	}
+
+	if err := sv.ValidateStruct(&selfBooking{Guests: 2, Range: selfRange{Min: 1, Max: 2}}); err != nil {
+		t.Fatalf("valid booking: %v", err)
+	}
+
+	if err := NewStructValidator(core.NewEngine()).ValidateStruct(selfBooking{Guests: 11, Range: selfRange{Min: 5, Max: 1}}); err != nil {
+		t.Fatalf("ValidateSelf ran without CallValidateSelf: %v", err)
+	}
+}
@@ -1,13 +1,18 @@
+//go:build !validate_lite
+
 package structvalidator
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
-	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/aatuh/validate/v3/core"
 	verrs "github.com/aatuh/validate/v3/errors"
@@ -69,10 +74,25 @@ func (sv *StructValidator) ValidateStructContextWithOpts(
 	s any,
 	opts core.ValidateOpts,
 ) error {
+	return sv.validator.Observe(func() error { return sv.validateStruct(ctx, s, opts) })
+}
+
+// validateStruct is ValidateStructContextWithOpts without reporting the
+// call to the engine's observer.
+func (sv *StructValidator) validateStruct(ctx context.Context, s any, opts core.ValidateOpts) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	if e := sv.validator.ForTranslator(opts.Translator); e != sv.validator {
+		// e already renders with the translator; clearing it keeps
+		// engines that ForTranslator cannot keep from recursing.
+		opts.Translator = nil
+		return NewStructValidator(e).validateStruct(ctx, s, opts)
+	}
 	opts = core.ApplyOpts(sv.validator, opts)
+	callerCtx := ctx
+	ctx, cancel := core.BudgetContext(ctx, opts)
+	defer cancel()
 
 	val := reflect.ValueOf(s)
 	typ := reflect.TypeOf(s)
@@ -92,106 +112,131 @@ func (sv *StructValidator) ValidateStructContextWithOpts(
 	if val.Kind() != reflect.Struct {
 		return fmt.Errorf("ValidateStruct: expected struct, got %T", s)
 	}
+	if g, ok := sv.generatedValidator(val.Interface(), opts); ok {
+		return g.ValidateGenerated(ctx, sv.validator, opts)
+	}
 
-	var errs verrs.Errors
+	// Errors collect in a stack buffer and are copied out only when there
+	// are any, so a valid struct with few fields allocates no error slice.
+	var small [smallStructErrors]verrs.FieldError
+	errs := verrs.Errors(small[:0])
 	var terminalErr error
+	var terminalPath string
+	checked := 0
+	var sensitivePaths []string
+	var fieldValues map[string]any
+	if opts.IncludeValues {
+		fieldValues = map[string]any{}
+	}
+	// The accumulator is created on first use by a quota or struct rule.
+	var acc *core.Accumulator
+	accumulator := func() *core.Accumulator {
+		if acc == nil {
+			acc = core.NewAccumulator()
+		}
+		return acc
+	}
+
+	// Middleware reads field paths from the context; see types.FieldPath.
+	withPaths := sv.validator.HasMiddleware()
 
 	// walkStruct returns true to continue, false to stop early.
 	var walkStruct func(v reflect.Value, t reflect.Type, path string) bool
-	walkStruct = func(v reflect.Value, t reflect.Type, path string) bool {
-		for i := 0; i < v.NumField(); i++ {
-			if err := ctx.Err(); err != nil {
-				terminalErr = err
+	// walkNested walks the structs held by fv, directly or as collection
+	// elements. It returns false to stop early.
+	walkNested := func(fv reflect.Value, fieldPath string) bool {
+		// Dereference pointer before checking kind
+		derefFv := derefPointer(fv)
+		switch derefFv.Kind() {
+		case reflect.Struct:
+			if !walkStruct(derefFv, derefFv.Type(), fieldPath) &&
+				opts.StopOnFirst {
 				return false
 			}
-			ft := t.Field(i)
-			fv := v.Field(i)
-
-			// Skip unexported fields.
-			if ft.PkgPath != "" {
-				continue
-			}
-
-			displayName := fieldDisplayName(ft, opts)
-			fieldPath := fieldPathJoin(path, displayName, opts.PathSep)
-
-			// Recurse into structs/slices/maps when no tag is present.
-			tag := ft.Tag.Get("validate")
-			if tag == "" {
-				// Dereference pointer before checking kind
-				derefFv := derefPointer(fv)
-				switch derefFv.Kind() {
-				case reflect.Struct:
-					if !walkStruct(derefFv, derefFv.Type(), fieldPath) &&
+		case reflect.Slice, reflect.Array:
+			for j := 0; j < derefFv.Len(); j++ {
+				ep := fieldPath + "[" + strconv.Itoa(j) + "]"
+				ev := derefFv.Index(j)
+				// Dereference pointer in slice elements
+				derefEv := derefPointer(ev)
+				if derefEv.Kind() == reflect.Struct {
+					if !walkStruct(derefEv, derefEv.Type(), ep) &&
 						opts.StopOnFirst {
 						return false
 					}
-					continue
-				case reflect.Slice, reflect.Array:
-					for j := 0; j < derefFv.Len(); j++ {
-						ep := fieldPath + "[" + strconv.Itoa(j) + "]"
-						ev := derefFv.Index(j)
-						// Dereference pointer in slice elements
-						derefEv := derefPointer(ev)
-						if derefEv.Kind() == reflect.Struct {
-							if !walkStruct(derefEv, derefEv.Type(), ep) &&
-								opts.StopOnFirst {
-								return false
-							}
-						}
-					}
-					continue
-				case reflect.Map:
-					for _, mk := range sortedMapKeys(derefFv) {
-						ev := derefFv.MapIndex(mk)
-						ep := fieldPath + pathutil.MapKeySegment(mk.Interface())
-						// Dereference pointer in map values
-						derefEv := derefPointer(ev)
-						if derefEv.Kind() == reflect.Struct {
-							if !walkStruct(derefEv, derefEv.Type(), ep) &&
-								opts.StopOnFirst {
-								return false
-							}
-						}
+				}
+			}
+		case reflect.Map:
+			for _, mk := range sortedMapKeys(derefFv) {
+				ev := derefFv.MapIndex(mk)
+				ep := fieldPath + pathutil.MapKeySegment(mk.Interface())
+				// Dereference pointer in map values
+				derefEv := derefPointer(ev)
+				if derefEv.Kind() == reflect.Struct {
+					if !walkStruct(derefEv, derefEv.Type(), ep) &&
+						opts.StopOnFirst {
+						return false
 					}
-					continue
-				default:
-					continue
 				}
 			}
+		}
+		return true
+	}
+	walkStruct = func(v reflect.Value, t reflect.Type, path string) bool {
+		plan := sv.structPlanFor(t, opts)
+		for _, fp := range plan.fields {
+			ft := fp.field
+			fv := v.Field(fp.index)
 
-			// Validate with rules from tag.
-			tokens := types.SplitTag(tag)
-			rules, structRules, err := splitStructRules(tokens)
-			if err != nil {
-				errs = append(errs, verrs.FieldError{Path: fieldPath, Code: verrs.CodeUnknown, Msg: err.Error()})
-				if opts.StopOnFirst {
+			displayName := fieldDisplayName(ft, opts)
+			fieldPath := fieldPathJoin(path, displayName, opts.PathSep)
+			if err := ctx.Err(); err != nil {
+				terminalErr, terminalPath = err, fieldPath
+				return false
+			}
+
+			// Recurse into structs/slices/maps when no tag is present.
+			if !fp.tagged {
+				if !walkNested(fv, fieldPath) {
 					return false
 				}
 				continue
 			}
-			ctxFn := func(context.Context, any) error { return nil }
-			if len(rules) > 0 {
-				ctxFn, err = sv.validator.FromRulesContextWithOpts(rules, types.CompileOpts{CollectAll: opts.CollectAllRules})
-				if err != nil {
-					errs = append(errs, verrs.FieldError{
-						Path: fieldPath, Code: verrs.CodeUnknown,
-						Msg: err.Error(),
-					})
+
+			// Validate with the field's compiled rules.
+			if fp.sensitive {
+				sensitivePaths = append(sensitivePaths, fieldPath)
+			}
+			fieldValue := valueForValidation(fv)
+			if opts.IncludeValues && !fp.sensitive {
+				fieldValues[fieldPath] = fieldValue
+			}
+			if code := fieldAccessViolation(fp.access, opts.Mode); code != "" {
+				if !isZeroValue(fieldValue) {
+					errs = append(errs, verrs.FieldError{Path: fieldPath, Code: code, Msg: translate(sv.validator.Translator(), code, fieldAccessMessage(code))})
 					if opts.StopOnFirst {
 						return false
 					}
-					continue
 				}
+				continue
 			}
-			fieldValue := valueForValidation(fv)
-			if err := validateStructRules(ctx, fieldValue, v, ft, structRules, fieldPath, opts, sv.validator); err != nil {
+			for _, name := range fp.quotas {
+				accumulator().Add(name, quotaAmount(fv))
+			}
+			if fp.err != nil {
+				errs = append(errs, verrs.FieldError{Path: fieldPath, Code: verrs.CodeUnknown, Msg: fp.err.Error()})
+				if opts.StopOnFirst {
+					return false
+				}
+				continue
+			}
+			checked++
+			if err := validateStructRules(ctx, fieldValue, v, ft, fp.structRules, fieldPath, opts, sv.validator, accumulator); err != nil {
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					terminalErr = err
+					terminalErr, terminalPath = err, fieldPath
 					return false
 				}
-				var fieldErrors verrs.Errors
-				if errors.As(err, &fieldErrors) {
+				if fieldErrors, ok := asFieldErrors(err); ok {
 					errs = append(errs, fieldErrors...)
 				} else {
 					errs = append(errs, verrs.FieldError{Path: fieldPath, Code: verrs.CodeUnknown, Msg: err.Error()})
@@ -203,70 +248,66 @@ func (sv *StructValidator) ValidateStructContextWithOpts(
 					continue
 				}
 			}
-			if err := ctxFn(ctx, fieldValue); err != nil {
-				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					terminalErr = err
-					return false
+			if fp.validate != nil {
+				fieldCtx := ctx
+				if withPaths {
+					fieldCtx = types.WithFieldPath(ctx, fieldPath)
 				}
-				appendValidationErrors(&errs, err, fieldPath, opts)
-				if opts.StopOnFirst {
-					return false
+				if err := fp.validate(fieldCtx, fieldValue); err != nil {
+					if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+						terminalErr, terminalPath = err, fieldPath
+						return false
+					}
+					errs = appendValidationErrors(errs, err, fieldPath, opts)
+					if opts.StopOnFirst {
+						return false
+					}
+					continue
 				}
 			}
+			if fp.nested && !walkNested(fv, fieldPath) {
+				return false
+			}
+		}
+		if err := plan.self.run(v); err != nil {
+			errs = appendValidationErrors(errs, err, path, opts)
+			if opts.StopOnFirst {
+				return false
+			}
 		}
 		return true
 	}
 
 	// Start the walk from the root.
-	walkStruct(val, typ, "")
+	completed := walkStruct(val, typ, "")
 
 	if terminalErr != nil {
-		return terminalErr
+		// A spent budget keeps the errors found so far; quotas are skipped
+		// because not every field contributed.
+		timeout, ok := sv.validator.TimeoutError(callerCtx, terminalErr, terminalPath, checked)
+		if !ok {
+			return terminalErr
+		}
+		errs = append(errs, timeout)
+	} else if completed || !opts.StopOnFirst {
+		// Final phase: check request-wide quotas once every field contributed.
+		errs = append(errs, quotaErrors(acc, sv.validator, opts)...)
+	}
+	markSensitive(errs, sensitivePaths, opts, sv.validator.Translator())
+	for i := range errs {
+		if value, ok := fieldValues[errs[i].Path]; ok && !errs[i].Sensitive {
+			errs[i].Value = errorValue(value)
+		}
 	}
 	if len(errs) > 0 {
-		return errs
+		return append(verrs.Errors(nil), errs...)
 	}
 	return nil
 }
 
-// derefPointer dereferences a pointer value recursively until it reaches a non-pointer type.
-func derefPointer(v reflect.Value) reflect.Value {
-	for v.IsValid() && v.Kind() == reflect.Ptr && !v.IsNil() {
-		v = v.Elem()
-	}
-	return v
-}
-
-// fieldPathJoin joins path parts with a custom separator.
-// Handles bracket-prefixed paths (e.g., "[0]", "[key]") by concatenating without separator.
-func fieldPathJoin(base, name, sep string) string {
-	if base == "" {
-		return name
-	}
-	if name == "" {
-		return base
-	}
-	if sep == "" {
-		sep = "."
-	}
-	// If the child path starts with a bracket, concatenate directly without separator
-	if len(name) > 0 && name[0] == '[' {
-		return base + name
-	}
-	return base + sep + name
-}
-
 // JSONFieldName returns a field's JSON tag name, falling back to the Go name.
 func JSONFieldName(field reflect.StructField) string {
-	tag := field.Tag.Get("json")
-	if tag == "" {
-		return field.Name
-	}
-	name, _, _ := strings.Cut(tag, ",")
-	if name == "" || name == "-" {
-		return field.Name
-	}
-	return name
+	return core.JSONFieldName(field)
 }
 
 func fieldDisplayName(field reflect.StructField, opts core.ValidateOpts) string {
@@ -296,12 +337,220 @@ func valueForValidation(v reflect.Value) any {
 	return v.Interface()
 }
 
+// fieldAccess records the readonly/writeonly marker of a tagged field.
+type fieldAccess int
+
+const (
+	fieldReadWrite fieldAccess = iota
+	fieldReadOnly
+	fieldWriteOnly
+)
+
+// splitFieldAccess removes readonly/writeonly markers from tag tokens.
+func splitFieldAccess(tokens []string) ([]string, fieldAccess) {
+	access := fieldReadWrite
+	out := tokens[:0:0]
+	for _, token := range tokens {
+		switch strings.TrimSpace(token) {
+		case "readonly":
+			access = fieldReadOnly
+		case "writeonly":
+			access = fieldWriteOnly
+		default:
+			out = append(out, token)
+		}
+	}
+	return out, access
+}
+
+// fieldAccessViolation returns the error code for a field that must not be
+// present in the current mode, or "" when the field validates normally.
+func fieldAccessViolation(access fieldAccess, mode core.FieldMode) string {
+	switch {
+	case access == fieldReadOnly && mode == core.ModeInput:
+		return verrs.CodeFieldReadOnly
+	case access == fieldWriteOnly && mode == core.ModeOutput:
+		return verrs.CodeFieldWriteOnly
+	default:
+		return ""
+	}
+}
+
+func fieldAccessMessage(code string) string {
+	if code == verrs.CodeFieldWriteOnly {
+		return "field is write-only"
+	}
+	return "field is read-only"
+}
+
+// splitSensitive removes the `sensitive` marker from tag tokens and reports
+// whether it was present.
+func splitSensitive(tokens []string) ([]string, bool) {
+	sensitive := false
+	out := tokens[:0:0]
+	for _, token := range tokens {
+		if strings.TrimSpace(token) == "sensitive" {
+			sensitive = true
+			continue
+		}
+		out = append(out, token)
+	}
+	return out, sensitive
+}
+
+// splitDive removes the `dive` marker from tag tokens and reports whether it
+// was present. A tagged field marked with it is walked for nested structs,
+// directly or as collection elements, once its own rules pass.
+func splitDive(tokens []string) ([]string, bool) {
+	dive := false
+	out := tokens[:0:0]
+	for _, token := range tokens {
+		if strings.TrimSpace(token) == "dive" {
+			dive = true
+			continue
+		}
+		out = append(out, token)
+	}
+	return out, dive
+}
+
+// dives reports whether tag carries the `dive` marker.
+func dives(tag string) bool {
+	_, dive := splitDive(types.SplitTag(tag))
+	return dive
+}
+
+// markSensitive flags errors at or below the sensitive field paths. Free-form
+// messages, which may come from custom rules echoing the value, are replaced
+// with a generic one.
+func markSensitive(errs verrs.Errors, paths []string, opts core.ValidateOpts, tr interface {
+	T(string, ...any) string
+}) {
+	sep := opts.PathSep
+	if sep == "" {
+		sep = "."
+	}
+	for i := range errs {
+		for _, p := range paths {
+			if path := errs[i].Path; path == p || strings.HasPrefix(path, p+"[") || strings.HasPrefix(path, p+sep) {
+				errs[i].Sensitive = true
+				if errs[i].Code == verrs.CodeUnknown {
+					errs[i].Msg = translate(tr, verrs.CodeUnknown, "value is invalid")
+				}
+				break
+			}
+		}
+	}
+}
+
+// smallStructErrors is how many field errors a struct walk holds on the
+// stack before spilling to the heap. BenchmarkStruct_Small covers it.
+const smallStructErrors = 4
+
+// maxErrorValueLen caps the length of values attached to errors.
+const maxErrorValueLen = 64
+
+// errorValue returns value for FieldError.Value: numbers, bools and times
+// as they are, strings truncated to maxErrorValueLen bytes, and other values
+// formatted with %v and truncated.
+func errorValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return truncateErrorValue(v)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, time.Time:
+		return v
+	}
+	return truncateErrorValue(fmt.Sprintf("%v", value))
+}
+
+func truncateErrorValue(s string) string {
+	if len(s) <= maxErrorValueLen {
+		return s
+	}
+	cut := maxErrorValueLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
+
+// splitQuotaTokens removes `quota=name` tokens from tag tokens and returns the
+// quota names the field contributes to.
+func splitQuotaTokens(tokens []string) ([]string, []string) {
+	var quotas []string
+	out := tokens[:0:0]
+	for _, token := range tokens {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(token), "quota="); ok {
+			quotas = append(quotas, name)
+			continue
+		}
+		out = append(out, token)
+	}
+	return out, quotas
+}
+
+// quotaAmount returns how much a field adds to a quota: the length of
+// strings and collections, the value of non-negative integers, and 1 for
+// any other non-zero value.
+func quotaAmount(fv reflect.Value) int64 {
+	fv = derefPointer(fv)
+	if !fv.IsValid() {
+		return 0
+	}
+	switch fv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return int64(fv.Len())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n := fv.Int(); n > 0 {
+			return n
+		}
+		return 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n := fv.Uint(); n <= math.MaxInt64 {
+			return int64(n)
+		}
+		return math.MaxInt64
+	case reflect.Ptr:
+		return 0
+	}
+	if fv.IsZero() {
+		return 0
+	}
+	return 1
+}
+
+// quotaErrors reports every registered quota whose accumulated total exceeds
+// its limit. Errors are attached to the root path.
+func quotaErrors(acc *core.Accumulator, v *core.Validate, opts core.ValidateOpts) verrs.Errors {
+	var errs verrs.Errors
+	for _, name := range v.QuotaNames() {
+		max, _ := v.Quota(name)
+		if acc.Value(name) <= max {
+			continue
+		}
+		msg := fmt.Sprintf("quota %s exceeded: maximum %d", name, max)
+		if tr := v.Translator(); tr != nil {
+			if translated := tr.T(verrs.CodeQuotaExceeded, name, max); translated != "" {
+				msg = translated
+			}
+		}
+		errs = append(errs, verrs.FieldError{Path: "", Code: verrs.CodeQuotaExceeded, Param: max, Msg: msg})
+		if opts.StopOnFirst {
+			break
+		}
+	}
+	return errs
+}
+
 const (
 	structRuleEqual          types.Kind = "eqField"
 	structRuleNotEqual       types.Kind = "neField"
 	structRuleRequiredWith   types.Kind = "requiredWith"
 	structRuleRequiredIf     types.Kind = "requiredIf"
 	structRuleRequiredUnless types.Kind = "requiredUnless"
+	structRuleSchema         types.Kind = "schema"
 )
 
 func splitStructRules(tokens []string) ([]string, []types.Rule, error) {
@@ -310,8 +559,11 @@ func splitStructRules(tokens []string) ([]string, []types.Rule, error) {
 	}
 	out := make([]string, 0, len(tokens))
 	structRules := make([]types.Rule, 0, 2)
+	constantTime := false
 	for _, token := range tokens {
 		switch {
+		case token == "constantTime":
+			constantTime = true
 		case strings.HasPrefix(token, "eqField="):
 			structRules = append(structRules, types.NewRule(structRuleEqual, map[string]any{"field": strings.TrimPrefix(token, "eqField=")}))
 		case strings.HasPrefix(token, "neField="):
@@ -330,6 +582,8 @@ func splitStructRules(tokens []string) ([]string, []types.Rule, error) {
 				return nil, nil, err
 			}
 			structRules = append(structRules, rule)
+		case strings.HasPrefix(token, "schema="):
+			structRules = append(structRules, types.NewRule(structRuleSchema, map[string]any{"name": strings.TrimPrefix(token, "schema=")}))
 		case strings.HasPrefix(token, "struct:"):
 			rule, err := parseStructCustomRule(token)
 			if err != nil {
@@ -340,9 +594,45 @@ func splitStructRules(tokens []string) ([]string, []types.Rule, error) {
 			out = append(out, token)
 		}
 	}
+	if constantTime {
+		found := false
+		for i, rule := range structRules {
+			if rule.Kind == structRuleEqual || rule.Kind == structRuleNotEqual {
+				structRules[i].Args["constantTime"] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("constantTime requires eqField or neField")
+		}
+	}
 	return out, structRules, nil
 }
 
+// fieldValuesEqual compares two field values. With constantTime set, string
+// and []byte values are compared with crypto/subtle so the time taken does
+// not reveal how much of a secret matched; only the length can leak.
+func fieldValuesEqual(a, b any, constantTime bool) bool {
+	if constantTime {
+		if x, ok := secretBytes(a); ok {
+			if y, ok := secretBytes(b); ok {
+				return subtle.ConstantTimeCompare(x, y) == 1
+			}
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func secretBytes(v any) ([]byte, bool) {
+	switch s := v.(type) {
+	case string:
+		return []byte(s), true
+	case []byte:
+		return s, true
+	}
+	return nil, false
+}
+
 func parseConditionalRequiredRule(kind types.Kind, token, prefix string) (types.Rule, error) {
 	raw := strings.TrimPrefix(token, prefix)
 	field, value, ok := strings.Cut(raw, ",")
@@ -370,38 +660,41 @@ func validateStructRules(
 	value any,
 	owner reflect.Value,
 	field reflect.StructField,
-	rules []types.Rule,
+	rules []structRulePlan,
 	path string,
 	opts core.ValidateOpts,
 	v *core.Validate,
+	accumulator func() *core.Accumulator,
 ) error {
 	if len(rules) == 0 {
 		return nil
 	}
+	acc := accumulator()
 	var errs verrs.Errors
 	for _, rule := range rules {
 		if err := runtimeCtx.Err(); err != nil {
 			return err
 		}
-		fn, err := compileStructRule(rule, v)
-		if err != nil {
-			errs = append(errs, verrs.FieldError{Path: path, Code: verrs.CodeUnknown, Msg: err.Error()})
+		if rule.err != nil {
+			errs = append(errs, verrs.FieldError{Path: path, Code: verrs.CodeUnknown, Msg: rule.err.Error()})
 			if !opts.CollectAllRules {
 				return errs
 			}
 			continue
 		}
+		fn := rule.fn
 		ctx := core.StructRuleContext{
-			Path:       path,
-			Field:      field,
-			Value:      value,
-			Owner:      owner,
-			Rule:       rule,
-			Context:    runtimeCtx,
-			Translator: v.Translator(),
+			Path:        path,
+			Field:       field,
+			Value:       value,
+			Owner:       owner,
+			Rule:        rule.rule,
+			Context:     runtimeCtx,
+			Translator:  v.Translator(),
+			Accumulator: acc,
 		}
 		if err := fn(ctx); err != nil {
-			appendValidationErrors(&errs, err, path, opts)
+			errs = appendValidationErrors(errs, err, path, opts)
 			if !opts.CollectAllRules || hasRequiredFailure(err) {
 				return errs
 			}
@@ -430,13 +723,14 @@ func compileStructRule(rule types.Rule, v *core.Validate) (core.StructRuleFunc,
 		if err != nil {
 			return nil, err
 		}
+		constantTime, _ := rule.Args["constantTime"].(bool)
 		return func(ctx core.StructRuleContext) error {
 			other, ok := ctx.FieldValue(field)
 			if !ok {
 				return fieldReferenceError(ctx, field)
 			}
-			if !reflect.DeepEqual(ctx.Value, other) {
-				return verrs.Errors{verrs.FieldError{Code: verrs.CodeFieldEqual, Msg: translate(ctx.Translator, verrs.CodeFieldEqual, "must match the referenced field")}}
+			if !fieldValuesEqual(ctx.Value, other, constantTime) {
+				return verrs.Errors{verrs.FieldError{Code: verrs.CodeFieldEqual, Msg: translate(ctx.Translator, verrs.CodeFieldEqual, "must match the referenced field"), Param: field}}
 			}
 			return nil
 		}, nil
@@ -445,13 +739,14 @@ func compileStructRule(rule types.Rule, v *core.Validate) (core.StructRuleFunc,
 		if err != nil {
 			return nil, err
 		}
+		constantTime, _ := rule.Args["constantTime"].(bool)
 		return func(ctx core.StructRuleContext) error {
 			other, ok := ctx.FieldValue(field)
 			if !ok {
 				return fieldReferenceError(ctx, field)
 			}
-			if reflect.DeepEqual(ctx.Value, other) {
-				return verrs.Errors{verrs.FieldError{Code: verrs.CodeFieldNotEqual, Msg: translate(ctx.Translator, verrs.CodeFieldNotEqual, "must differ from the referenced field")}}
+			if fieldValuesEqual(ctx.Value, other, constantTime) {
+				return verrs.Errors{verrs.FieldError{Code: verrs.CodeFieldNotEqual, Msg: translate(ctx.Translator, verrs.CodeFieldNotEqual, "must differ from the referenced field"), Param: field}}
 			}
 			return nil
 		}, nil
@@ -466,7 +761,7 @@ func compileStructRule(rule types.Rule, v *core.Validate) (core.StructRuleFunc,
 				return fieldReferenceError(ctx, field)
 			}
 			if !isZeroValue(other) && isZeroValue(ctx.Value) {
-				return verrs.Errors{verrs.FieldError{Code: verrs.CodeRequiredWith, Msg: translate(ctx.Translator, verrs.CodeRequiredWith, "value is required")}}
+				return verrs.Errors{verrs.FieldError{Code: verrs.CodeRequiredWith, Msg: translate(ctx.Translator, verrs.CodeRequiredWith, "value is required"), Param: field}}
 			}
 			return nil
 		}, nil
@@ -481,7 +776,7 @@ func compileStructRule(rule types.Rule, v *core.Validate) (core.StructRuleFunc,
 				return fieldReferenceError(ctx, field)
 			}
 			if fmt.Sprint(other) == want && isZeroValue(ctx.Value) {
-				return verrs.Errors{verrs.FieldError{Code: verrs.CodeRequiredIf, Msg: translate(ctx.Translator, verrs.CodeRequiredIf, "value is required")}}
+				return verrs.Errors{verrs.FieldError{Code: verrs.CodeRequiredIf, Msg: translate(ctx.Translator, verrs.CodeRequiredIf, "value is required"), Param: field}}
 			}
 			return nil
 		}, nil
@@ -496,10 +791,22 @@ func compileStructRule(rule types.Rule, v *core.Validate) (core.StructRuleFunc,
 				return fieldReferenceError(ctx, field)
 			}
 			if fmt.Sprint(other) != want && isZeroValue(ctx.Value) {
-				return verrs.Errors{verrs.FieldError{Code: verrs.CodeRequiredUnless, Msg: translate(ctx.Translator, verrs.CodeRequiredUnless, "value is required")}}
+				return verrs.Errors{verrs.FieldError{Code: verrs.CodeRequiredUnless, Msg: translate(ctx.Translator, verrs.CodeRequiredUnless, "value is required"), Param: field}}
 			}
 			return nil
 		}, nil
+	case structRuleSchema:
+		name, _ := rule.Args["name"].(string)
+		schema, err := v.NamedSchema(name)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx core.StructRuleContext) error {
+			if rv := reflect.ValueOf(ctx.Value); !rv.IsValid() || (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Map) && rv.IsNil() {
+				return nil
+			}
+			return schema.ValidateValueContextWithOpts(ctx.Context, ctx.Value, core.ValidateOpts{})
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown struct rule kind: %s", rule.Kind)
 	}
@@ -530,21 +837,6 @@ func fieldReferenceError(ctx core.StructRuleContext, field string) error {
 	}}
 }
 
-func appendValidationErrors(errs *verrs.Errors, err error, fieldPath string, opts core.ValidateOpts) {
-	var fieldErrors verrs.Errors
-	if errors.As(err, &fieldErrors) {
-		for _, fe := range fieldErrors {
-			fe.Path = fieldPathJoin(fieldPath, fe.Path, opts.PathSep)
-			*errs = append(*errs, fe)
-		}
-		return
-	}
-	*errs = append(*errs, verrs.FieldError{
-		Path: fieldPath, Code: verrs.CodeUnknown,
-		Msg: err.Error(),
-	})
-}
-
 func hasRequiredFailure(err error) bool {
 	var fieldErrors verrs.Errors
 	if !errors.As(err, &fieldErrors) {
@@ -584,16 +876,3 @@ func isZeroValue(v any) bool {
 	}
 	return reflect.DeepEqual(v, reflect.Zero(rv.Type()).Interface())
 }
-
-func sortedMapKeys(rv reflect.Value) []reflect.Value {
-	keys := rv.MapKeys()
-	sort.Slice(keys, func(i, j int) bool {
-		left := fmt.Sprint(keys[i].Interface())
-		right := fmt.Sprint(keys[j].Interface())
-		if left == right {
-			return keys[i].Type().String() < keys[j].Type().String()
-		}
-		return left < right
-	})
-	return keys
-}
@@ -1,11 +1,18 @@
+//go:build !validate_lite
+
 package structvalidator
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
 )
 
 type dummyTr struct{}
@@ -211,3 +218,81 @@ func TestStruct_OK(t *testing.T) {
 
 // guard unused import errors for "errors" on some Go versions.
 var _ = errors.New
+
+func TestStruct_TranslatorOption(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	opts := core.ValidateOpts{Translator: dummyTr{}}
+	for i := 0; i < 2; i++ {
+		err := sv.ValidateStructWithOpts(Profile{Website: "x"}, opts)
+		if err == nil || !strings.HasSuffix(err.Error(), ": string.min") {
+			t.Fatalf("want message from opts translator, got %v", err)
+		}
+	}
+	if err := sv.ValidateStruct(Profile{Website: "x"}); err == nil || !strings.HasSuffix(err.Error(), ": minimum length is 5") {
+		t.Fatalf("engine translator changed: %v", err)
+	}
+}
+
+type funcTr func(key string, params ...any) string
+
+func (f funcTr) T(key string, params ...any) string { return f(key, params...) }
+
+type mapTr map[string]string
+
+func (m mapTr) T(key string, _ ...any) string { return m[key] }
+
+func TestStruct_NonComparableTranslatorOption(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	for _, tr := range []translator.Translator{
+		funcTr(func(key string, _ ...any) string { return "func " + key }),
+		mapTr{"string.min": "map string.min"},
+	} {
+		opts := core.ValidateOpts{Translator: tr}
+		for i := 0; i < 2; i++ {
+			err := sv.ValidateStructWithOpts(Profile{Website: "x"}, opts)
+			if err == nil || !strings.HasSuffix(err.Error(), " string.min") {
+				t.Fatalf("%T: want message from opts translator, got %v", tr, err)
+			}
+		}
+	}
+}
+
+type slowFields struct {
+	A string `validate:"string;slow"`
+	B string `validate:"string;slow"`
+	C string `validate:"string;slow"`
+}
+
+func TestStruct_BudgetStopsWithTimeout(t *testing.T) {
+	e := core.New()
+	e.RegisterRule("slow", func(*types.Compiler, types.Rule) (func(any) error, error) {
+		return func(any) error {
+			time.Sleep(20 * time.Millisecond)
+			return verrs.Errors{verrs.FieldError{Code: "slow", Msg: "slow"}}
+		}, nil
+	})
+	sv := NewStructValidator(e)
+
+	err := sv.ValidateStructWithOpts(slowFields{}, core.ValidateOpts{Budget: 5 * time.Millisecond})
+	var es verrs.Errors
+	if !errors.As(err, &es) || len(es) != 2 {
+		t.Fatalf("budgeted errors = %v", err)
+	}
+	if es[0].Path != "A" || es[1].Path != "B" || es[1].Code != verrs.CodeTimeout || es[1].Param != 1 {
+		t.Fatalf("budgeted errors = %+v", es)
+	}
+	if es[1].Msg != "validation time budget exceeded after 1 fields" {
+		t.Fatalf("timeout message = %q", es[1].Msg)
+	}
+
+	err = sv.ValidateStructWithOpts(slowFields{}, core.ValidateOpts{Deadline: time.Now()})
+	if !errors.As(err, &es) || len(es) != 1 || es[0].Path != "A" || es[0].Code != verrs.CodeTimeout {
+		t.Fatalf("spent deadline = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sv.ValidateStructContextWithOpts(ctx, slowFields{}, core.ValidateOpts{Budget: time.Hour}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled context: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import "github.com/aatuh/validate/v3/types"
+
+// TagTokens returns the tokens handled by struct validation before the
+// remaining tag is compiled as field rules. It mirrors splitStructRules,
+// splitFieldAccess, splitSensitive, splitDive and splitQuotaTokens.
+func TagTokens() []types.TagToken {
+	return []types.TagToken{
+		{Token: "eqField", Param: "field", Kind: structRuleEqual, Summary: "Value must equal another field"},
+		{Token: "neField", Param: "field", Kind: structRuleNotEqual, Summary: "Value must differ from another field"},
+		{Token: "constantTime", Summary: "Compare eqField/neField string values in constant time"},
+		{Token: "requiredWith", Param: "field", Kind: structRuleRequiredWith, Summary: "Required when another field is non-zero"},
+		{Token: "requiredIf", Param: "field,value", Kind: structRuleRequiredIf, Summary: "Required when another field equals a value"},
+		{Token: "requiredUnless", Param: "field,value", Kind: structRuleRequiredUnless, Summary: "Required unless another field equals a value"},
+		{Token: "schema", Param: "name", Kind: structRuleSchema, Summary: "Validate the value with a schema registered by RegisterSchema"},
+		{Token: "struct:", Param: types.ParamRuleName, Summary: "Apply a registered struct rule"},
+		{Token: "readonly", Summary: "Field must be zero in input mode"},
+		{Token: "writeonly", Summary: "Field must be zero in output mode"},
+		{Token: "sensitive", Summary: "Mark the field's errors sensitive; Errors.Redact strips their message and param"},
+		{Token: "quota", Param: "name", Summary: "Charge the field against a named quota"},
+		{Token: "dive", Summary: "Also validate nested structs, directly or as collection elements, once the field's rules pass"},
+	}
+}
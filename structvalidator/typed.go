@@ -0,0 +1,181 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// structPlanKey identifies a compiled struct plan in the engine type cache.
+// Only options that change how tags compile are part of the key.
+type structPlanKey struct {
+	typ           reflect.Type
+	schemaVersion string
+	collectAll    bool
+}
+
+// structPlan is the precompiled validation of one struct type's fields.
+type structPlan struct {
+	fields []fieldPlan
+	self   selfCheck
+}
+
+// fieldPlan is the precompiled validation of one exported struct field.
+//
+// Fields:
+//   - index: Field index in the struct.
+//   - field: The struct field.
+//   - tagged: Whether the field has an effective `validate` tag; untagged
+//     fields are walked for nested structs instead.
+//   - nested: Whether a tagged field is also walked for nested structs once
+//     its rules pass: tagged `dive` or, in go-playground mode, always.
+//   - err: Tag error, reported as a field error at validation time.
+//   - validate: Compiled rule chain; nil when the tag has no value rules.
+type fieldPlan struct {
+	index       int
+	field       reflect.StructField
+	tagged      bool
+	nested      bool
+	access      fieldAccess
+	sensitive   bool
+	quotas      []string
+	err         error
+	structRules []structRulePlan
+	validate    types.ContextValidatorFunc
+}
+
+// structRulePlan is a compiled struct-level rule, or the error compiling it.
+type structRulePlan struct {
+	rule types.Rule
+	fn   core.StructRuleFunc
+	err  error
+}
+
+// structPlanFor returns the plan for struct type t, compiling and caching it
+// on the engine on first use.
+func (sv *StructValidator) structPlanFor(t reflect.Type, opts core.ValidateOpts) *structPlan {
+	key := structPlanKey{typ: t, schemaVersion: opts.SchemaVersion, collectAll: opts.CollectAllRules}
+	if plan, ok := sv.validator.CachedType(key); ok {
+		return plan.(*structPlan)
+	}
+	return sv.validator.CacheType(key, sv.compileStructPlan(t, opts)).(*structPlan)
+}
+
+func (sv *StructValidator) compileStructPlan(t reflect.Type, opts core.ValidateOpts) *structPlan {
+	plan := &structPlan{fields: make([]fieldPlan, 0, t.NumField())}
+	if sv.validator.Behavior().CallValidateSelf {
+		plan.self = selfCheckFor(t)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if ft.PkgPath != "" {
+			continue
+		}
+		if sv.skipsField(ft) {
+			continue
+		}
+		fp := fieldPlan{index: i, field: ft}
+		tag, err := sv.fieldTag(t, ft, opts.SchemaVersion)
+		if err != nil {
+			fp.tagged, fp.err = true, err
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+		if tag == "" {
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+		fp.tagged = true
+		tokens, access := splitFieldAccess(types.SplitTag(tag))
+		tokens, fp.sensitive = splitSensitive(tokens)
+		tokens, fp.nested = splitDive(tokens)
+		fp.nested = fp.nested || sv.nestsTagged(ft)
+		fp.access = access
+		tokens, fp.quotas = splitQuotaTokens(tokens)
+		rules, structRules, err := splitStructRules(tokens)
+		if err == nil && len(rules) > 0 {
+			fp.validate, err = sv.validator.FromRulesContextWithOpts(rules, types.CompileOpts{CollectAll: opts.CollectAllRules, Sensitive: fp.sensitive})
+		}
+		if err != nil {
+			fp.err = err
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+		for _, rule := range structRules {
+			fn, err := compileStructRule(rule, sv.validator)
+			fp.structRules = append(fp.structRules, structRulePlan{rule: rule, fn: fn, err: err})
+		}
+		plan.fields = append(plan.fields, fp)
+	}
+	return plan
+}
+
+// TypedValidator validates values of one struct type with field validators
+// compiled once by CompileType. It is safe for concurrent use.
+type TypedValidator struct {
+	sv   *StructValidator
+	typ  reflect.Type
+	opts core.ValidateOpts
+}
+
+// CompileType walks the struct type t once and compiles the validators of
+// all its fields, returning a reusable TypedValidator for hot paths.
+// Compiled fields are cached per engine by type, so ValidateStruct shares
+// them too, and nested struct types are compiled on first use.
+//
+// Parameters:
+//   - t: A struct type or pointer to struct type.
+//
+// Returns:
+//   - *TypedValidator: The compiled validator.
+//   - error: If t is not a struct type, or TagCompileErrors for broken tags.
+func (sv *StructValidator) CompileType(t reflect.Type) (*TypedValidator, error) {
+	return sv.CompileTypeWithOpts(t, core.ValidateOpts{})
+}
+
+// CompileTypeWithOpts is CompileType with default options for every
+// validation call of the returned TypedValidator.
+func (sv *StructValidator) CompileTypeWithOpts(t reflect.Type, opts core.ValidateOpts) (*TypedValidator, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("CompileType: expected struct type, got %v", t)
+	}
+	if err := sv.CompileStruct(t, opts); err != nil {
+		return nil, err
+	}
+	sv.structPlanFor(t, opts)
+	return &TypedValidator{sv: sv, typ: t, opts: opts}, nil
+}
+
+// Type returns the struct type the validator was compiled for.
+func (tv *TypedValidator) Type() reflect.Type { return tv.typ }
+
+// Validate validates s, a value or pointer of the compiled type.
+func (tv *TypedValidator) Validate(s any) error {
+	return tv.ValidateContextWithOpts(context.Background(), s, tv.opts)
+}
+
+// ValidateContext validates s with context.
+func (tv *TypedValidator) ValidateContext(ctx context.Context, s any) error {
+	return tv.ValidateContextWithOpts(ctx, s, tv.opts)
+}
+
+// ValidateContextWithOpts validates s with context and options that replace
+// the compiled defaults.
+func (tv *TypedValidator) ValidateContextWithOpts(ctx context.Context, s any, opts core.ValidateOpts) error {
+	typ := reflect.TypeOf(s)
+	if typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ != tv.typ {
+		return fmt.Errorf("TypedValidator: expected %v, got %T", tv.typ, s)
+	}
+	return tv.sv.ValidateStructContextWithOpts(ctx, s, opts)
+}
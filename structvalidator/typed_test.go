@@ -0,0 +1,74 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestCompileType_ValidatesLikeValidateStruct(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	tv, err := sv.CompileType(reflect.TypeOf(benchOrder{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tv.Type() != reflect.TypeOf(benchOrder{}) {
+		t.Fatalf("Type() = %v", tv.Type())
+	}
+	valid := benchOrder{ID: "ORDER001", Lines: []benchItem{{Name: "Alpha", Price: 1}}}
+	if err := tv.Validate(valid); err != nil {
+		t.Fatalf("valid order: %v", err)
+	}
+	if err := tv.Validate(&valid); err != nil {
+		t.Fatalf("valid order pointer: %v", err)
+	}
+	invalid := benchOrder{ID: "short", Lines: []benchItem{{Name: "Al", Price: -1}}}
+	got := tv.Validate(invalid)
+	want := sv.ValidateStruct(invalid)
+	var es verrs.Errors
+	if !errors.As(got, &es) || len(es) != 3 {
+		t.Fatalf("expected 3 field errors, got %v", got)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TypedValidator and ValidateStruct differ:\n%v\n%v", got, want)
+	}
+}
+
+func TestCompileType_Errors(t *testing.T) {
+	sv := NewStructValidator(core.NewEngine())
+	if _, err := sv.CompileType(reflect.TypeOf("")); err == nil {
+		t.Fatal("expected error for non-struct type")
+	}
+	var report TagCompileErrors
+	if _, err := sv.CompileType(reflect.TypeOf(compileOrder{})); !errors.As(err, &report) {
+		t.Fatalf("expected TagCompileErrors, got %v", err)
+	}
+	tv, err := sv.CompileType(reflect.TypeOf(&benchItem{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tv.Validate(benchOrder{}); err == nil {
+		t.Fatal("expected error for value of another type")
+	}
+}
+
+func TestStructPlan_CachedPerEngine(t *testing.T) {
+	engine := core.NewEngine()
+	sv := NewStructValidator(engine)
+	typ := reflect.TypeOf(benchItem{})
+	first := sv.structPlanFor(typ, core.ValidateOpts{})
+	if again := NewStructValidator(engine).structPlanFor(typ, core.ValidateOpts{}); again != first {
+		t.Fatal("expected the plan to be cached on the engine")
+	}
+	if other := sv.structPlanFor(typ, core.ValidateOpts{CollectAllRules: true}); other == first {
+		t.Fatal("expected a separate plan for CollectAllRules")
+	}
+	if copied := NewStructValidator(engine.Copy()).structPlanFor(typ, core.ValidateOpts{}); copied == first {
+		t.Fatal("expected engine copies not to share plans")
+	}
+}
@@ -0,0 +1,40 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/aatuh/validate/v3/core"
+)
+
+// XMLFieldName returns a field's XML element or attribute name, falling
+// back to the Go name. See core.XMLFieldName.
+func XMLFieldName(field reflect.StructField) string {
+	return core.XMLFieldName(field)
+}
+
+// DecodeXMLContextWithOpts decodes one XML document from r into dst, a
+// pointer to struct, and validates the result. Error paths use xml tag
+// names, such as "@id" or "items.item[0].sku", unless opts selects other
+// names.
+//
+// Returns:
+//   - error: nil, a decode error wrapped as "decode XML: ...",
+//     errors.Errors, or the context error.
+func (sv *StructValidator) DecodeXMLContextWithOpts(ctx context.Context, r io.Reader, dst any, opts core.ValidateOpts) error {
+	if rv := reflect.ValueOf(dst); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("DecodeXML: expected non-nil pointer, got %T", dst)
+	}
+	if err := xml.NewDecoder(r).Decode(dst); err != nil {
+		return fmt.Errorf("decode XML: %w", err)
+	}
+	if opts.FieldNameFunc == nil && !opts.UseJSONNames {
+		opts.UseXMLNames = true
+	}
+	return sv.ValidateStructContextWithOpts(ctx, dst, opts)
+}
@@ -0,0 +1,69 @@
+//go:build !validate_lite
+
+package structvalidator
+
+import (
+	"context"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aatuh/validate/v3/core"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type xmlLine struct {
+	SKU string `xml:"sku,attr" validate:"string;len=4"`
+	Qty int    `xml:"qty" validate:"int;min=1"`
+}
+
+type xmlOrder struct {
+	XMLName xml.Name  `xml:"urn:orders order"`
+	ID      string    `xml:"id,attr" validate:"string;required"`
+	Note    string    `xml:",chardata" validate:"string;max=5"`
+	Email   string    `xml:"urn:contact customer>email" validate:"string;required"`
+	Lines   []xmlLine `xml:"lines>line"`
+}
+
+func TestDecodeXML_UsesXMLNames(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	doc := `<order xmlns="urn:orders">too long note<lines><line sku="AB"><qty>0</qty></line></lines></order>`
+	var order xmlOrder
+	err := sv.DecodeXMLContextWithOpts(context.Background(), strings.NewReader(doc), &order, core.ValidateOpts{})
+	es, ok := err.(verrs.Errors)
+	if !ok {
+		t.Fatalf("expected errors.Errors, got %T %v", err, err)
+	}
+	var got []string
+	for _, fe := range es {
+		got = append(got, fe.Path+" "+fe.Code)
+	}
+	want := []string{
+		"@id " + verrs.CodeRequired,
+		"Note " + verrs.CodeStringMax,
+		"customer.email " + verrs.CodeRequired,
+		"lines.line[0].@sku " + verrs.CodeStringLength,
+		"lines.line[0].qty " + verrs.CodeIntMin,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("paths = %#v, want %#v", got, want)
+	}
+
+	err = sv.DecodeXMLContextWithOpts(context.Background(), strings.NewReader(doc), &order, core.ValidateOpts{UseJSONNames: true})
+	if es, ok := err.(verrs.Errors); !ok || es[0].Path != "ID" {
+		t.Fatalf("UseJSONNames should override XML names, got %v", err)
+	}
+}
+
+func TestDecodeXML_Errors(t *testing.T) {
+	sv := NewStructValidator(core.New())
+	var order xmlOrder
+	err := sv.DecodeXMLContextWithOpts(context.Background(), strings.NewReader("<order>"), &order, core.ValidateOpts{})
+	if err == nil || !strings.HasPrefix(err.Error(), "decode XML: ") {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+	if err := sv.DecodeXMLContextWithOpts(context.Background(), strings.NewReader(""), order, core.ValidateOpts{}); err == nil {
+		t.Fatalf("expected error for non-pointer destination")
+	}
+}
@@ -0,0 +1,257 @@
+// Package tagcheck statically checks `validate` struct tags in Go source.
+//
+// It reports tags that fail to parse or compile (unknown rules, bad
+// parameters, cross-field references to missing fields) and, when type
+// information is available, base types that do not match the field type.
+// String literals passed to types.ParseRules and types.MustRules are checked
+// the same way.
+//
+// The package only uses the standard library so the module stays
+// dependency-free. The golang.org/x/tools/go/analysis Analyzer wrapping it,
+// for go vet -vettool and gopls, is in the separate module
+// github.com/aatuh/validate/v3/analysis.
+package tagcheck
+
+import (
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	validate "github.com/aatuh/validate/v3"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Diagnostic is one tag problem found in source.
+//
+// Fields:
+//   - Pos: Position of the field's tag literal.
+//   - Field: Field name; empty for rule expressions.
+//   - Tag: The `validate` tag value or rule expression.
+//   - Message: Description of the problem.
+type Diagnostic struct {
+	Pos     token.Pos
+	Field   string
+	Tag     string
+	Message string
+}
+
+// Checker checks `validate` tags against a Validate instance.
+//
+// Fields:
+//   - Validate: Instance whose rules, struct rules and plugins tags are
+//     compiled with; nil uses validate.New().
+type Checker struct {
+	Validate *validate.Validate
+}
+
+// Check inspects every struct type in files. info may be nil, in which case
+// field type mismatches are not reported and cross-field references only
+// resolve to fields declared directly in the struct.
+//
+// Converters registered with WithConverter are not taken into account, so a
+// field whose type is converted at runtime may be reported as a mismatch.
+func (c Checker) Check(files []*ast.File, info *gotypes.Info) []Diagnostic {
+	v := c.Validate
+	if v == nil {
+		v = validate.New()
+	}
+	var diags []Diagnostic
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.StructType:
+				diags = append(diags, checkStruct(v, n, info)...)
+			case *ast.CallExpr:
+				if d, ok := checkRulesCall(v, n, info); ok {
+					diags = append(diags, d)
+				}
+			}
+			return true
+		})
+	}
+	return diags
+}
+
+func checkStruct(v *validate.Validate, st *ast.StructType, info *gotypes.Info) []Diagnostic {
+	hasField := directFields(st)
+	if info != nil {
+		if s, ok := info.TypeOf(st).(*gotypes.Struct); ok {
+			hasField = func(name string) bool {
+				obj, _, _ := gotypes.LookupFieldOrMethod(s, false, nil, name)
+				_, isVar := obj.(*gotypes.Var)
+				return isVar
+			}
+		}
+	}
+
+	var diags []Diagnostic
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		raw, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tag, ok := reflect.StructTag(raw).Lookup("validate")
+		if !ok || tag == "" {
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			report := func(msg string) {
+				diags = append(diags, Diagnostic{Pos: field.Tag.Pos(), Field: name.Name, Tag: tag, Message: msg})
+			}
+			if err := v.CompileFieldTag(tag, hasField); err != nil {
+				report(err.Error())
+				continue
+			}
+			if info != nil {
+				if msg := typeMismatch(tag, info.TypeOf(field.Type)); msg != "" {
+					report(msg)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// checkRulesCall checks the literal expression of a ParseRules or MustRules
+// call. Without type information any selector with those names matches.
+func checkRulesCall(v *validate.Validate, call *ast.CallExpr, info *gotypes.Info) (Diagnostic, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "ParseRules" && sel.Sel.Name != "MustRules") || len(call.Args) != 1 {
+		return Diagnostic{}, false
+	}
+	if info != nil {
+		fn, ok := info.Uses[sel.Sel].(*gotypes.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != rulesPkgPath {
+			return Diagnostic{}, false
+		}
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return Diagnostic{}, false
+	}
+	expr, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return Diagnostic{}, false
+	}
+	rules, err := types.ParseRules(expr)
+	if err == nil {
+		_, err = v.CompileRulesE(rules)
+	}
+	if err == nil {
+		return Diagnostic{}, false
+	}
+	return Diagnostic{Pos: lit.Pos(), Tag: expr, Message: err.Error()}, true
+}
+
+// rulesPkgPath is the import path of ParseRules and MustRules.
+var rulesPkgPath = reflect.TypeOf(types.Rule{}).PkgPath()
+
+// directFields resolves cross-field references without type information.
+func directFields(st *ast.StructType) func(string) bool {
+	names := map[string]bool{}
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			if name.IsExported() {
+				names[name.Name] = true
+			}
+		}
+	}
+	return func(name string) bool { return names[name] }
+}
+
+// typeMismatch compares the tag's base type with the field's underlying
+// type after dereferencing pointers, as the runtime walk does.
+func typeMismatch(tag string, t gotypes.Type) string {
+	if t == nil {
+		return ""
+	}
+	for {
+		ptr, ok := t.Underlying().(*gotypes.Pointer)
+		if !ok {
+			break
+		}
+		t = ptr.Elem()
+	}
+	base := types.Kind(strings.TrimSpace(firstToken(tag)))
+	if base == types.KTime {
+		if named, ok := t.(*gotypes.Named); ok {
+			obj := named.Obj()
+			if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+				return ""
+			}
+		}
+		// String fields holding dates are parsed with the tag's layout.
+		if basic, ok := t.Underlying().(*gotypes.Basic); ok && basic.Info()&gotypes.IsString != 0 {
+			return ""
+		}
+		return "time rules on field of type " + t.String()
+	}
+
+	var ok bool
+	switch u := t.Underlying().(type) {
+	case *gotypes.Interface:
+		return ""
+	case *gotypes.Basic:
+		info := u.Info()
+		switch base {
+		case types.KString:
+			ok = info&gotypes.IsString != 0
+		case types.KInt:
+			ok = info&gotypes.IsInteger != 0
+		case types.KInt64:
+			ok = u.Kind() == gotypes.Int64
+		case types.KUint:
+			ok = info&gotypes.IsUnsigned != 0
+		case types.KUint64:
+			ok = u.Kind() == gotypes.Uint64
+		case types.KFloat:
+			ok = info&gotypes.IsFloat != 0
+		case types.KBool:
+			ok = info&gotypes.IsBoolean != 0
+		default:
+			ok = !isBaseKind(base)
+		}
+	case *gotypes.Slice:
+		ok = base == types.KSlice || !isBaseKind(base)
+	case *gotypes.Array:
+		ok = base == types.KArray || !isBaseKind(base)
+	case *gotypes.Map:
+		ok = base == types.KMap || !isBaseKind(base)
+	default:
+		ok = !isBaseKind(base)
+	}
+	if ok {
+		return ""
+	}
+	return string(base) + " rules on field of type " + t.String()
+}
+
+// firstToken returns the first tag token that is not a struct-only marker.
+func firstToken(tag string) string {
+	for _, token := range types.SplitTag(tag) {
+		token = strings.TrimSpace(token)
+		if token == "readonly" || token == "writeonly" || strings.HasPrefix(token, "quota=") {
+			continue
+		}
+		return token
+	}
+	return ""
+}
+
+func isBaseKind(kind types.Kind) bool {
+	switch kind {
+	case types.KString, types.KInt, types.KInt64, types.KUint, types.KUint64,
+		types.KFloat, types.KBool, types.KSlice, types.KArray, types.KMap, types.KTime:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package tagcheck
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"strings"
+	"testing"
+)
+
+const src = `package p
+
+import "time"
+
+type Request struct {
+	Name     string            ` + "`validate:\"string;min=1\"`" + `
+	Age      int               ` + "`validate:\"int;min=x\"`" + `
+	Email    string            ` + "`validate:\"string;nosuchrule\"`" + `
+	Count    string            ` + "`validate:\"int;positive\"`" + `
+	Confirm  string            ` + "`validate:\"string;eqField=Missing\"`" + `
+	Password string            ` + "`validate:\"string;eqField=Name\"`" + `
+	At       *time.Time        ` + "`validate:\"time;notzero\"`" + `
+	Tags     []string          ` + "`validate:\"slice;foreach=(string;min=1)\"`" + `
+	Labels   map[string]string ` + "`validate:\"slice;min=1\"`" + `
+	ID       string            ` + "`validate:\"string;readonly;uuid\"`" + `
+	hidden   string            ` + "`validate:\"string;nosuchrule\"`" + `
+}
+`
+
+func checkSource(t *testing.T, withTypes bool) map[string]string {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var info *gotypes.Info
+	if withTypes {
+		info = &gotypes.Info{Types: map[ast.Expr]gotypes.TypeAndValue{}}
+		conf := gotypes.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+		if _, err := conf.Check("p", fset, []*ast.File{file}, info); err != nil {
+			t.Fatalf("type check: %v", err)
+		}
+	}
+	got := map[string]string{}
+	for _, d := range (Checker{}).Check([]*ast.File{file}, info) {
+		got[d.Field] = d.Message
+	}
+	return got
+}
+
+func TestCheckReportsTagErrors(t *testing.T) {
+	got := checkSource(t, false)
+	for _, field := range []string{"Age", "Email", "Confirm"} {
+		if _, ok := got[field]; !ok {
+			t.Fatalf("expected diagnostic for %s, got %v", field, got)
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("diagnostics = %v, want only Age, Email and Confirm", got)
+	}
+}
+
+func TestCheckReportsTypeMismatches(t *testing.T) {
+	got := checkSource(t, true)
+	if msg := got["Count"]; !strings.Contains(msg, "int rules on field of type string") {
+		t.Fatalf("Count diagnostic = %q", msg)
+	}
+	if msg := got["Labels"]; !strings.Contains(msg, "slice rules on field of type map[string]string") {
+		t.Fatalf("Labels diagnostic = %q", msg)
+	}
+	for _, field := range []string{"Name", "Password", "At", "Tags", "ID"} {
+		if msg, ok := got[field]; ok {
+			t.Fatalf("unexpected diagnostic for %s: %s", field, msg)
+		}
+	}
+}
+
+func TestCheckReportsRuleExpressions(t *testing.T) {
+	const src = `package p
+
+var (
+	name  = types.MustRules("string min=3 max=50")
+	age   = types.MustRules("int min=x")
+	email = types.MustRules("string nosuchrule")
+	tags, _ = types.ParseRules("slice foreach=(string min=)")
+	other = types.MustRules(name)
+)
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := map[string]bool{}
+	for _, d := range (Checker{}).Check([]*ast.File{file}, nil) {
+		if d.Field != "" || d.Message == "" {
+			t.Fatalf("unexpected diagnostic %#v", d)
+		}
+		got[d.Tag] = true
+	}
+	want := map[string]bool{"int min=x": true, "string nosuchrule": true, "slice foreach=(string min=)": true}
+	if len(got) != len(want) {
+		t.Fatalf("diagnostics for %v, want %v", got, want)
+	}
+	for expr := range want {
+		if !got[expr] {
+			t.Fatalf("missing diagnostic for %q in %v", expr, got)
+		}
+	}
+}
@@ -102,6 +102,8 @@ func DefaultEnglishTranslations() map[string]string {
 		"bool.type":   "expected boolean",
 		"int.type":    "expected integer",
 		"int64.type":  "expected int64",
+		"uint.type":   "expected unsigned integer",
+		"uint64.type": "expected uint64",
 		"float.type":  "expected finite floating-point number",
 		"number.type": "expected number",
 		"string.type": "expected string",
@@ -110,13 +112,24 @@ func DefaultEnglishTranslations() map[string]string {
 		"time.type":   "expected time.Time",
 
 		// Generic validation
-		"required":        "value is required",
-		"required.with":   "value is required",
-		"required.if":     "value is required",
-		"required.unless": "value is required",
-		"field.eq":        "must match the referenced field",
-		"field.ne":        "must differ from the referenced field",
-		"field.reference": "invalid referenced field",
+		"required":           "value is required",
+		"required.with":      "value is required",
+		"required.if":        "value is required",
+		"required.unless":    "value is required",
+		"value.nil":          "value must not be nil",
+		"field.eq":           "must match the referenced field",
+		"field.ne":           "must differ from the referenced field",
+		"field.reference":    "invalid referenced field",
+		"field.readonly":     "field is read-only",
+		"field.writeonly":    "field is write-only",
+		"quota.exceeded":     "quota %s exceeded: maximum %d",
+		"rule.unavailable":   "validation rule %s is temporarily unavailable",
+		"validation.timeout": "validation time budget exceeded after %d fields",
+		"body.size":          "request body exceeds %d bytes",
+		"body.depth":         "JSON nesting exceeds depth %d",
+		"body.values":        "JSON body exceeds %d values",
+		"body.syntax":        "malformed JSON body",
+		"body.type":          "expected %s value",
 
 		// String validation
 		"string.length":               "must be exactly %d characters long",
@@ -125,6 +138,7 @@ func DefaultEnglishTranslations() map[string]string {
 		"string.nonempty":             "must not be empty",
 		"string.contains":             "must contain required text",
 		"string.notContains":          "must not contain prohibited text",
+		"string.excludesAll":          "must not contain any of: %s",
 		"string.prefix":               "must have required prefix",
 		"string.suffix":               "must have required suffix",
 		"string.url":                  "must be a valid absolute URL",
@@ -134,6 +148,13 @@ func DefaultEnglishTranslations() map[string]string {
 		"string.ascii":                "must contain only ASCII characters",
 		"string.alpha":                "must contain only letters",
 		"string.alnum":                "must contain only letters and digits",
+		"string.alphaUnicode":         "must contain only letters",
+		"string.alnumUnicode":         "must contain only letters and digits",
+		"string.numeric":              "must contain only digits",
+		"string.printAscii":           "must contain only printable ASCII characters",
+		"string.numStr":               "must be a decimal number",
+		"string.numStr.min":           "must be at least %s",
+		"string.numStr.max":           "must be at most %s",
 		"string.minLength":            "must be at least %d characters long",
 		"string.maxLength":            "must be at most %d characters long",
 		"string.minRunes":             "minimum rune count is %d",
@@ -146,6 +167,9 @@ func DefaultEnglishTranslations() map[string]string {
 		// Integer validation
 		"int.min":                   "minimum value is %d",
 		"int.max":                   "maximum value is %d",
+		"uint.min":                  "minimum value is %d",
+		"uint.max":                  "maximum value is %d",
+		"int.multipleOf":            "must be a multiple of %d",
 		"number.min":                "minimum value is %g",
 		"number.max":                "maximum value is %g",
 		"number.gt":                 "must be greater than %g",
@@ -153,8 +177,11 @@ func DefaultEnglishTranslations() map[string]string {
 		"number.lt":                 "must be less than %g",
 		"number.lte":                "must be less than or equal to %g",
 		"number.between":            "must be between %g and %g",
+		"number.multipleOf":         "must be a multiple of %g",
 		"number.positive":           "must be positive",
 		"number.nonnegative":        "must be nonnegative",
+		"number.negative":           "must be negative",
+		"number.nonpositive":        "must be nonpositive",
 		"number.finite":             "must be finite",
 		"int.invalidMinParameter":   "invalid parameter for min",
 		"int.invalidMaxParameter":   "invalid parameter for max",
@@ -168,6 +195,7 @@ func DefaultEnglishTranslations() map[string]string {
 		"slice.min":                 "minimum length is %d",
 		"slice.max":                 "maximum length is %d",
 		"slice.unique":              "must contain unique elements",
+		"slice.unique.field":        "expected elements with field %s",
 		"slice.contains":            "must contain required element",
 		"slice.forEach":             "element validation failed",
 		"slice.element":             "element %d: %s",
@@ -178,13 +206,14 @@ func DefaultEnglishTranslations() map[string]string {
 		"slice.notSlice":            "value is not a slice",
 
 		// Array validation
-		"array.type":     "expected array",
-		"array.length":   "must have exactly %d elements",
-		"array.min":      "minimum length is %d",
-		"array.max":      "maximum length is %d",
-		"array.unique":   "must contain unique elements",
-		"array.contains": "must contain required element",
-		"array.forEach":  "element validation failed",
+		"array.type":         "expected array",
+		"array.length":       "must have exactly %d elements",
+		"array.min":          "minimum length is %d",
+		"array.max":          "maximum length is %d",
+		"array.unique":       "must contain unique elements",
+		"array.unique.field": "expected elements with field %s",
+		"array.contains":     "must contain required element",
+		"array.forEach":      "element validation failed",
 
 		// Map validation
 		"map.length":  "must have exactly %d keys",
@@ -196,12 +225,20 @@ func DefaultEnglishTranslations() map[string]string {
 		// Bool validation
 		"bool.true":  "must be true",
 		"bool.false": "must be false",
+		"bool.parse": "expected true, false, 1 or 0",
 
 		// Time validation
 		"time.notzero": "must not be zero",
 		"time.before":  "must be before %s",
 		"time.after":   "must be after %s",
 		"time.between": "must be between %s and %s",
+		"time.min":     "must not be before %s",
+		"time.max":     "must not be after %s",
+		"time.parse":   "expected time in layout %s",
+
+		// Enum validation
+		"enum.value": "must be a valid %s",
+		"enum.name":  "must be a %s name",
 
 		// Legacy compatibility
 		"bool.notBool": "value is not a boolean",
@@ -0,0 +1,64 @@
+package types
+
+// Behavior selects fixes to built-in rules that change validation outcomes.
+// Each fix is a field, off by default, so the zero Behavior keeps the
+// outcomes these rules had before each fix; enable a fix once inputs have
+// been checked against it, for example by replaying a recorded corpus.
+//
+// Fields:
+//   - OneOfCaseFold: oneof matches values under Unicode case folding, so
+//     "RED" passes oneof=red,green.
+//   - AnchorRegex: regex patterns must match the whole value. Without it,
+//     "^" and "$" are only added to the pattern's ends, so in a pattern
+//     such as "a|b" each anchor binds to one branch and "ax" passes.
+//   - DerefPointers: validators for built-in base types dereference
+//     pointers, so a *string validates as a string. Without it, pointers
+//     fail with the base type's code, such as string.type. Struct fields
+//     are dereferenced either way.
+//   - NilValueCode: nil values and, with DerefPointers, nil pointers of
+//     built-in base types without omitempty or required fail with value.nil.
+//     Without it, they fail with the base type's code.
+//   - ExactIntBounds: integer values compare exactly with gt, gte, lt, lte
+//     and between bounds. Without it, they are converted to float64 first,
+//     so beyond 2^53 a value may round past a bound.
+//   - RejectNaNParams: numeric bounds of NaN, as in float;min=NaN, fail to
+//     compile. Without it they compile, and every comparison with them is
+//     false.
+//   - CallValidateSelf: struct validation calls ValidateSelf on structs that
+//     implement structvalidator.Validatable, after their field rules.
+//     Without it, such methods are not called.
+type Behavior struct {
+	OneOfCaseFold    bool
+	AnchorRegex      bool
+	DerefPointers    bool
+	NilValueCode     bool
+	ExactIntBounds   bool
+	RejectNaNParams  bool
+	CallValidateSelf bool
+}
+
+// LatestBehavior returns a Behavior with every fix enabled.
+func LatestBehavior() Behavior {
+	return Behavior{
+		OneOfCaseFold:    true,
+		AnchorRegex:      true,
+		DerefPointers:    true,
+		NilValueCode:     true,
+		ExactIntBounds:   true,
+		RejectNaNParams:  true,
+		CallValidateSelf: true,
+	}
+}
+
+// SetBehavior applies b to rules compiled afterwards.
+func (c *Compiler) SetBehavior(b Behavior) {
+	c.behavior = b
+}
+
+// regexPattern returns pattern as the regex rule compiles it.
+func (c *Compiler) regexPattern(pattern string) string {
+	if c.behavior.AnchorRegex {
+		return "^(?:" + pattern + ")$"
+	}
+	return normalizeRegexPattern(pattern)
+}
@@ -0,0 +1,43 @@
+package types
+
+import "testing"
+
+func TestBehavior(t *testing.T) {
+	cases := []struct {
+		tag    string
+		value  string
+		legacy bool
+		latest bool
+	}{
+		{"string;oneof=red,green", "red", true, true},
+		{"string;oneof=red,green", "RED", false, true},
+		{"string;oneof=red,green", "blue", false, false},
+		{"string;regex=a|b", "a", true, true},
+		{"string;regex=a|b", "ax", true, false},
+		{"string;regex=a|b", "xb", true, false},
+		{"string;regex=^[0-9]+$", "123", true, true},
+		{"string;regex=^[0-9]+$", "12a", false, false},
+	}
+	legacy := NewCompiler(nil)
+	latest := NewCompiler(nil)
+	latest.SetBehavior(LatestBehavior())
+	for _, tc := range cases {
+		rules, err := ParseTag(tc.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tc.tag, err)
+		}
+		for _, c := range []struct {
+			name string
+			c    *Compiler
+			want bool
+		}{{"legacy", legacy, tc.legacy}, {"latest", latest, tc.latest}} {
+			fn, err := c.c.CompileE(rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fn(tc.value) == nil; got != c.want {
+				t.Errorf("%s %s %q: valid = %v, want %v", c.name, tc.tag, tc.value, got, c.want)
+			}
+		}
+	}
+}
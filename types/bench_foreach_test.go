@@ -0,0 +1,43 @@
+package types
+
+import (
+	"strconv"
+	"testing"
+)
+
+// BenchmarkForEach compares the direct paths for common slice types with
+// the reflection path taken by named slice types.
+func BenchmarkForEach(b *testing.B) {
+	strs := make([]string, 100)
+	ints := make([]int, 100)
+	for i := range strs {
+		strs[i] = "item-" + strconv.Itoa(i)
+		ints[i] = i
+	}
+	type namedInts []int
+	cases := []struct {
+		name string
+		tag  string
+		v    any
+	}{
+		{"strings", "slice;foreach=(string;min=1)", strs},
+		{"strings/reflect", "slice;foreach=(string;min=1)", namedStrings(strs)},
+		{"ints", "slice;foreach=(int;min=0)", ints},
+		{"ints/reflect", "slice;foreach=(int;min=0)", namedInts(ints)},
+	}
+	for _, tc := range cases {
+		rules, err := ParseTag(tc.tag)
+		if err != nil {
+			b.Fatal(err)
+		}
+		fn := NewCompiler(nil).Compile(rules)
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := fn(tc.v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
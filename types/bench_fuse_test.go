@@ -0,0 +1,41 @@
+package types
+
+import "testing"
+
+// BenchmarkRuleFusion compares fused and unfused chains of length and bound
+// rules on passing values.
+func BenchmarkRuleFusion(b *testing.B) {
+	cases := []struct {
+		name string
+		tag  string
+		v    any
+	}{
+		{"string", "string;min=3;max=20;minRunes=3;maxRunes=20", "validation"},
+		{"int", "int;min=0;max=150", 42},
+	}
+	for _, tc := range cases {
+		rules, err := ParseTag(tc.tag)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, fusion := range []bool{true, false} {
+			c := NewCompiler(nil)
+			c.SetRuleFusion(fusion)
+			fn, err := c.CompileE(rules)
+			if err != nil {
+				b.Fatal(err)
+			}
+			name := tc.name + "/fused"
+			if !fusion {
+				name = tc.name + "/unfused"
+			}
+			b.Run(name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if err := fn(tc.v); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
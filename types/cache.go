@@ -0,0 +1,245 @@
+package types
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// ResultCache caches the results of expensive context-aware custom rules,
+// such as MX lookups or uniqueness checks, keyed by rule and value. Only
+// passes and validation failures (errors.Errors) are cached; outages and
+// context errors are not. Values that are not comparable bypass the cache.
+//
+// Entries expire after the cache's TTL, and the least recently used entry
+// is evicted when the cache is full. A ResultCache is safe for concurrent
+// use and may be shared by several rule kinds and engines; cached messages
+// come from the translator of the engine that stored them.
+type ResultCache struct {
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[resultCacheKey]*list.Element
+	order   *list.List // front is most recently used
+	stats   ResultCacheStats
+}
+
+// ResultCacheStats reports the activity of a ResultCache.
+//
+// Fields:
+//   - Hits: Lookups answered from the cache.
+//   - Misses: Lookups that called the rule, including expired entries.
+//   - Evictions: Entries dropped to respect the size bound.
+//   - Expirations: Entries dropped because their TTL passed.
+//   - Entries: Current number of entries.
+type ResultCacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Entries     int
+}
+
+type resultCacheKey struct {
+	kind  Kind
+	args  string
+	value any
+}
+
+type resultCacheEntry struct {
+	key     resultCacheKey
+	err     verrs.Errors
+	expires time.Time
+}
+
+// NewResultCache returns an empty ResultCache.
+//
+// Parameters:
+//   - maxEntries: Size bound; values below 1 mean 1.
+//   - ttl: Lifetime of an entry; values <= 0 disable expiry.
+func NewResultCache(maxEntries int, ttl time.Duration) *ResultCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &ResultCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		entries:    make(map[resultCacheKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Stats returns a snapshot of the cache's metrics.
+func (rc *ResultCache) Stats() ResultCacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	stats := rc.stats
+	stats.Entries = rc.order.Len()
+	return stats
+}
+
+// Purge removes every entry, keeping the metrics.
+func (rc *ResultCache) Purge() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = make(map[resultCacheKey]*list.Element)
+	rc.order.Init()
+}
+
+// get returns the cached result for key and whether one was found.
+func (rc *ResultCache) get(key resultCacheKey) (verrs.Errors, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	elem, ok := rc.entries[key]
+	if !ok {
+		rc.stats.Misses++
+		return nil, false
+	}
+	entry := elem.Value.(*resultCacheEntry)
+	if rc.ttl > 0 && !rc.now().Before(entry.expires) {
+		rc.order.Remove(elem)
+		delete(rc.entries, key)
+		rc.stats.Expirations++
+		rc.stats.Misses++
+		return nil, false
+	}
+	rc.order.MoveToFront(elem)
+	rc.stats.Hits++
+	return entry.err, true
+}
+
+// put stores the result of a rule call, evicting the least recently used
+// entry when the cache is full.
+func (rc *ResultCache) put(key resultCacheKey, err verrs.Errors) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry := &resultCacheEntry{key: key, err: err, expires: rc.now().Add(rc.ttl)}
+	if elem, ok := rc.entries[key]; ok {
+		elem.Value = entry
+		rc.order.MoveToFront(elem)
+		return
+	}
+	rc.entries[key] = rc.order.PushFront(entry)
+	for rc.order.Len() > rc.maxEntries {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*resultCacheEntry).key)
+		rc.stats.Evictions++
+	}
+}
+
+// SetResultCache caches the results of context-aware custom rules of kind
+// in rc.
+func (c *Compiler) SetResultCache(kind Kind, rc *ResultCache) {
+	if c.resultCaches == nil {
+		c.resultCaches = map[Kind]*ResultCache{}
+	}
+	c.resultCaches[kind] = rc
+}
+
+func (c *Compiler) cacheContextRule(rule Rule, compiled compiledContextRule) compiledContextRule {
+	rc := c.resultCaches[rule.Kind]
+	if rc == nil || compiled.err != nil {
+		return compiled
+	}
+	args := ruleArgsKey(rule)
+	return compiledContextRule{validate: func(ctx context.Context, v any) error {
+		if v == nil || !reflect.ValueOf(v).Comparable() {
+			return compiled.validate(ctx, v)
+		}
+		key := resultCacheKey{kind: rule.Kind, args: args, value: v}
+		return rc.do(key, func() error { return compiled.validate(ctx, v) })
+	}}
+}
+
+// do returns the cached result for key, or calls validate and caches its
+// result if it passed or failed validation.
+func (rc *ResultCache) do(key resultCacheKey, validate func() error) error {
+	if cached, ok := rc.get(key); ok {
+		if cached == nil {
+			return nil
+		}
+		return append(verrs.Errors(nil), cached...)
+	}
+	err := validate()
+	if err == nil {
+		rc.put(key, nil)
+		return nil
+	}
+	var fieldErrors verrs.Errors
+	if errors.As(err, &fieldErrors) {
+		rc.put(key, append(verrs.Errors(nil), fieldErrors...))
+	}
+	return err
+}
+
+// ruleArgsKey renders the arguments of rule in a stable order, so rules
+// with equal arguments share cache entries.
+func ruleArgsKey(rule Rule) string {
+	names := make([]string, 0, len(rule.Args))
+	for name := range rule.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%#v;", name, rule.Args[name])
+	}
+	if rule.Elem != nil {
+		fmt.Fprintf(&b, "elem=%s(%s)", rule.Elem.Kind, ruleArgsKey(*rule.Elem))
+	}
+	return b.String()
+}
+
+// memoIDs numbers memoized validators, so validators sharing a ResultCache
+// keep separate entries.
+var memoIDs atomic.Uint64
+
+// Memoize wraps a pure, deterministic validator so repeated values, such as
+// enum-like strings in a batch, reuse the first result instead of running
+// the rule chain again. Results are keyed by value with ==, so values of
+// different types are separate entries; nil, pointers and values that are
+// not comparable bypass the cache. Passes and validation failures
+// (errors.Errors) are cached; other errors are not.
+//
+// Parameters:
+//   - fn: The validator; it must not depend on anything but the value.
+//   - rc: Bounds the entries and their lifetime; it may be shared by
+//     several memoized validators.
+//
+// Returns:
+//   - func(any) error: The memoized validator, safe for concurrent use if
+//     fn is.
+func Memoize(fn func(any) error, rc *ResultCache) func(any) error {
+	memo := MemoizeContext(func(_ context.Context, v any) error { return fn(v) }, rc)
+	return func(v any) error { return memo(context.Background(), v) }
+}
+
+// MemoizeContext is Memoize for context-aware validators. Context errors
+// are not cached.
+func MemoizeContext(fn ContextValidatorFunc, rc *ResultCache) ContextValidatorFunc {
+	args := "memo#" + strconv.FormatUint(memoIDs.Add(1), 10)
+	return func(ctx context.Context, v any) error {
+		if v == nil {
+			return fn(ctx, v)
+		}
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr || !rv.Comparable() {
+			return fn(ctx, v)
+		}
+		key := resultCacheKey{args: args, value: v}
+		return rc.do(key, func() error { return fn(ctx, v) })
+	}
+}
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func compileCached(t *testing.T, failures int, calls *int, rc *ResultCache) ContextValidatorFunc {
+	t.Helper()
+	c := NewCompiler(nil)
+	c.RegisterContextRule("mx", flakyRule(failures, calls))
+	c.SetResultCache("mx", rc)
+	fn, err := c.CompileContextWithOptsE([]Rule{NewRule("mx", nil)}, CompileOpts{})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return fn
+}
+
+func TestResultCache_CachesPassesAndFailures(t *testing.T) {
+	calls := 0
+	rc := NewResultCache(10, time.Minute)
+	fn := compileCached(t, 0, &calls, rc)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := fn(ctx, "good"); err != nil {
+			t.Fatalf("good: %v", err)
+		}
+		var es verrs.Errors
+		if err := fn(ctx, "bad"); !errors.As(err, &es) || es[0].Code != "mx.missing" {
+			t.Fatalf("bad: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+	stats := rc.Stats()
+	if stats.Hits != 4 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+func TestResultCache_DoesNotCacheOutages(t *testing.T) {
+	calls := 0
+	rc := NewResultCache(10, time.Minute)
+	fn := compileCached(t, 1, &calls, rc)
+	if err := fn(context.Background(), "good"); err == nil {
+		t.Fatal("expected outage error")
+	}
+	if err := fn(context.Background(), "good"); err != nil {
+		t.Fatalf("expected the rule to be called again: %v", err)
+	}
+	if calls != 2 || rc.Stats().Entries != 1 {
+		t.Fatalf("calls = %d, stats = %+v", calls, rc.Stats())
+	}
+}
+
+func TestResultCache_ExpiresAndEvicts(t *testing.T) {
+	calls := 0
+	now := time.Unix(0, 0)
+	rc := NewResultCache(2, time.Minute)
+	rc.now = func() time.Time { return now }
+	fn := compileCached(t, 0, &calls, rc)
+	ctx := context.Background()
+
+	_ = fn(ctx, "a")
+	_ = fn(ctx, "b")
+	_ = fn(ctx, "a") // hit; "b" becomes least recently used
+	_ = fn(ctx, "c") // evicts "b"
+	_ = fn(ctx, "a")
+	if calls != 3 || rc.Stats().Evictions != 1 {
+		t.Fatalf("calls = %d, stats = %+v", calls, rc.Stats())
+	}
+
+	now = now.Add(time.Minute)
+	_ = fn(ctx, "a")
+	if calls != 4 || rc.Stats().Expirations != 1 {
+		t.Fatalf("after TTL: calls = %d, stats = %+v", calls, rc.Stats())
+	}
+}
+
+func TestResultCache_KeysByArgsAndSkipsUncomparableValues(t *testing.T) {
+	calls := 0
+	rc := NewResultCache(10, 0)
+	c := NewCompiler(nil)
+	c.RegisterContextRule("mx", flakyRule(0, &calls))
+	c.SetResultCache("mx", rc)
+	strict, err := c.CompileContextWithOptsE([]Rule{NewRule("mx", map[string]any{"strict": true})}, CompileOpts{})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	loose, err := c.CompileContextWithOptsE([]Rule{NewRule("mx", nil)}, CompileOpts{})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	ctx := context.Background()
+	_ = strict(ctx, "a")
+	_ = loose(ctx, "a")
+	_ = strict(ctx, "a")
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+
+	_ = loose(ctx, []string{"a"})
+	_ = loose(ctx, []string{"a"})
+	if calls != 4 {
+		t.Fatalf("uncomparable values: calls = %d, want 4", calls)
+	}
+
+	rc.Purge()
+	_ = loose(ctx, "a")
+	if calls != 5 {
+		t.Fatalf("after Purge: calls = %d, want 5", calls)
+	}
+}
+
+func TestMemoize_RunsEachDistinctValueOnce(t *testing.T) {
+	calls := 0
+	chain := NewCompiler(nil).Compile([]Rule{NewRule(KString, nil), NewRule(KOneOf, map[string]any{"values": []string{"open", "closed"}})})
+	rc := NewResultCache(2, 0)
+	fn := Memoize(func(v any) error { calls++; return chain(v) }, rc)
+	other := Memoize(func(any) error { calls++; return nil }, rc)
+
+	for i := 0; i < 3; i++ {
+		if err := fn("open"); err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		var es verrs.Errors
+		if err := fn("draft"); !errors.As(err, &es) || es[0].Code != verrs.CodeStringOneOf {
+			t.Fatalf("draft: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+	// Validators sharing a cache keep separate entries.
+	if err := other("draft"); err != nil || calls != 3 {
+		t.Fatalf("shared cache: err = %v, calls = %d", err, calls)
+	}
+	if rc.Stats().Evictions != 1 {
+		t.Fatalf("stats = %+v", rc.Stats())
+	}
+
+	s := "open"
+	_ = fn(&s)
+	_ = fn(&s)
+	_ = fn(nil)
+	_ = fn(nil)
+	if calls != 7 {
+		t.Fatalf("pointers and nil: calls = %d, want 7", calls)
+	}
+
+	ctxFn := MemoizeContext(func(ctx context.Context, v any) error { calls++; return ctx.Err() }, rc)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = ctxFn(canceled, "x")
+	if err := ctxFn(context.Background(), "x"); err != nil || calls != 9 {
+		t.Fatalf("context error was cached: err = %v, calls = %d", err, calls)
+	}
+}
@@ -0,0 +1,67 @@
+package types
+
+// asciiClass is an ASCII character class checked eight bytes at a time.
+// word returns 0x80 in each byte lane of w that is in the class; it is only
+// called on words whose bytes are all ASCII. ok checks single bytes of the
+// tail that does not fill a word.
+type asciiClass struct {
+	word func(w uint64) uint64
+	ok   func(b byte) bool
+}
+
+const (
+	lanes01 = 0x0101010101010101
+	lanes80 = 0x80 * lanes01
+)
+
+// lanesBetween returns 0x80 in each byte lane of w holding a value x with
+// lo < x < hi. Every byte of w must be below 0x80, lo at most 0x7F and hi at
+// most 0x80; the sums then stay within their lanes.
+func lanesBetween(w uint64, lo, hi uint64) uint64 {
+	return (lanes01*(0x7F+hi) - w) & ^w & (w + lanes01*(0x7F-lo)) & lanes80
+}
+
+var (
+	asciiAny = asciiClass{
+		word: func(uint64) uint64 { return lanes80 },
+		ok:   func(b byte) bool { return b <= 127 },
+	}
+	asciiAlpha = asciiClass{
+		// Setting 0x20 folds A-Z onto a-z and maps no other byte there.
+		word: func(w uint64) uint64 { return lanesBetween(w|0x20*lanes01, 'a'-1, 'z'+1) },
+		ok:   isASCIILetter,
+	}
+	asciiAlnum = asciiClass{
+		word: func(w uint64) uint64 {
+			return lanesBetween(w|0x20*lanes01, 'a'-1, 'z'+1) | lanesBetween(w, '0'-1, '9'+1)
+		},
+		ok: func(b byte) bool { return isASCIILetter(b) || isASCIIDigit(b) },
+	}
+	asciiDigits = asciiClass{
+		word: func(w uint64) uint64 { return lanesBetween(w, '0'-1, '9'+1) },
+		ok:   isASCIIDigit,
+	}
+	asciiPrint = asciiClass{
+		word: func(w uint64) uint64 { return lanesBetween(w, ' '-1, '~'+1) },
+		ok:   func(b byte) bool { return b >= ' ' && b <= '~' },
+	}
+)
+
+// all reports whether every byte of s is in the class. Bytes of multi-byte
+// UTF-8 sequences are never ASCII and so always fail.
+func (c asciiClass) all(s string) bool {
+	i := 0
+	for ; i+8 <= len(s); i += 8 {
+		w := uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+			uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+		if w&lanes80 != 0 || c.word(w) != lanes80 {
+			return false
+		}
+	}
+	for ; i < len(s); i++ {
+		if !c.ok(s[i]) {
+			return false
+		}
+	}
+	return true
+}
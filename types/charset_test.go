@@ -0,0 +1,82 @@
+package types
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+var asciiClasses = map[string]asciiClass{
+	"ascii":      asciiAny,
+	"alpha":      asciiAlpha,
+	"alnum":      asciiAlnum,
+	"numeric":    asciiDigits,
+	"printascii": asciiPrint,
+}
+
+// allBytes is the byte-at-a-time reference the word scan must match.
+func allBytes(s string, ok func(byte) bool) bool {
+	for i := 0; i < len(s); i++ {
+		if !ok(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestASCIIClass_MatchesByteScan(t *testing.T) {
+	for name, class := range asciiClasses {
+		// Every byte value at every position of a word and of the tail.
+		for pos := 0; pos < 11; pos++ {
+			for b := 0; b < 256; b++ {
+				s := []byte(strings.Repeat("a", 11))
+				if name == "numeric" {
+					s = []byte(strings.Repeat("7", 11))
+				}
+				s[pos] = byte(b)
+				if got, want := class.all(string(s)), class.ok(byte(b)); got != want {
+					t.Fatalf("%s: byte %#x at %d: got %v, want %v", name, b, pos, got, want)
+				}
+			}
+		}
+		rnd := rand.New(rand.NewPCG(1, 2))
+		alphabet := "aZ09 ~\x7f\x80é_-@[`{/:"
+		for i := 0; i < 2000; i++ {
+			var b strings.Builder
+			for n := rnd.IntN(24); n > 0; n-- {
+				b.WriteByte(alphabet[rnd.IntN(len(alphabet))])
+			}
+			s := b.String()
+			if got, want := class.all(s), allBytes(s, class.ok); got != want {
+				t.Fatalf("%s: %q: got %v, want %v", name, s, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkASCIIClass(b *testing.B) {
+	s := strings.Repeat("Sphinx0fBlackQuartzJudgeMyVow", 4)
+	for _, name := range []string{"ascii", "alpha", "alnum"} {
+		class := asciiClasses[name]
+		input := s
+		if name == "alpha" {
+			input = strings.ReplaceAll(s, "0", "o")
+		}
+		b.Run(name+"/word", func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				if !class.all(input) {
+					b.Fatal("rejected")
+				}
+			}
+		})
+		b.Run(name+"/byte", func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				if !allBytes(input, class.ok) {
+					b.Fatal("rejected")
+				}
+			}
+		})
+	}
+}
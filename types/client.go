@@ -0,0 +1,210 @@
+package types
+
+import (
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// ClientRules describes a rule chain for evaluation outside Go, such as in
+// a browser, and serializes to JSON. A client evaluates a value as follows:
+//
+//  1. A value that is absent, null or the zero value of Type ("", 0,
+//     false, an empty list or object) fails with code "required" when
+//     Required is set, and otherwise passes when Optional is set.
+//  2. Checks run in order; the first failing check yields its Code, as
+//     the server would report it in FieldError.Code.
+//  3. When all checks pass, Items applies to every element of a list and
+//     Values to every value of an object, with paths like "tags[0]" and
+//     "labels[key]".
+//
+// Rules a client cannot mirror, such as url, plugin or custom rules, are
+// listed in ServerOnly and left to the server; a passing client result is
+// therefore advisory.
+//
+// Fields:
+//   - Type: The base kind, e.g. "string", "int", "float" or "slice";
+//     empty for chains of only required and omitempty.
+//   - Required: The value must be present and non-zero.
+//   - Optional: Zero values skip the remaining rules (omitempty).
+//   - Checks: Client-evaluable checks, see ClientCheck.
+//   - Items: Rules of list elements (foreach).
+//   - Values: Rules of object values (map values).
+//   - ServerOnly: Kinds that only the server evaluates.
+type ClientRules struct {
+	Type       string        `json:"type,omitempty"`
+	Required   bool          `json:"required,omitempty"`
+	Optional   bool          `json:"optional,omitempty"`
+	Checks     []ClientCheck `json:"checks,omitempty"`
+	Items      *ClientRules  `json:"items,omitempty"`
+	Values     *ClientRules  `json:"values,omitempty"`
+	ServerOnly []Kind        `json:"serverOnly,omitempty"`
+}
+
+// ClientCheck is one client-evaluable rule. Rule selects the comparison
+// and Arg is its operand:
+//
+//   - length, minLength, maxLength: string length in UTF-8 bytes vs Arg.
+//   - minRunes, maxRunes: string length in code points vs Arg.
+//   - nonEmpty: string is not empty.
+//   - contains, notContains, prefix, suffix: substring test with Arg.
+//   - regex: Arg is an anchored RE2 pattern; the common subset matches
+//     JavaScript RegExp with the "u" flag.
+//   - oneOf: string is one of the Arg strings.
+//   - ascii, numeric, printAscii: every byte is ASCII, an ASCII digit, or
+//     in the printable range 0x20-0x7E.
+//   - alpha, alnum, alphaUnicode, alnumUnicode: every code point is a
+//     Unicode letter, or a Unicode letter or digit.
+//   - numStr: string is a plain decimal number, /^[+-]?\d+(\.\d+)?$/.
+//   - numStrMin, numStrMax: such a string compared with Arg, a decimal
+//     string, inclusively; the server compares exactly.
+//   - minInt, maxInt, minUint, maxUint, minNumber, maxNumber, greaterThan,
+//     greaterThanEqual, lessThan, lessThanEqual: numeric comparison with Arg (inclusive for
+//     min and max).
+//   - between: Arg is [min, max], both inclusive.
+//   - positive, nonNegative, negative, nonPositive, finite: numeric sign
+//     and finiteness tests.
+//   - multipleOf: the number divided by Arg is a whole number; Code is
+//     number.multipleOf for float Args, which allow a relative error of
+//     1e-9.
+//   - sliceLength, minSliceLength, maxSliceLength, arrayLength,
+//     minArrayLength, maxArrayLength: list length vs Arg.
+//   - sliceUnique, arrayUnique: list elements are distinct.
+//   - mapLength, minMapKeys, maxMapKeys: object key count vs Arg.
+//   - boolTrue, boolFalse: the boolean equals true or false.
+//   - enum: Arg is {"names": [...], "values": [...]}; strings must be one
+//     of the names and numbers one of the values.
+type ClientCheck struct {
+	Rule Kind   `json:"rule"`
+	Code string `json:"code"`
+	Arg  any    `json:"arg,omitempty"`
+}
+
+// clientCodes maps the kinds a client can evaluate to the error codes the
+// compiler reports for them.
+var clientCodes = map[Kind]string{
+	KLength:           verrs.CodeStringLength,
+	KMinLength:        verrs.CodeStringMin,
+	KMaxLength:        verrs.CodeStringMax,
+	KMinRunes:         verrs.CodeStringMinRunes,
+	KMaxRunes:         verrs.CodeStringMaxRunes,
+	KNonEmpty:         verrs.CodeStringNonEmpty,
+	KContains:         verrs.CodeStringContains,
+	KNotContains:      verrs.CodeStringNotContains,
+	KPrefix:           verrs.CodeStringPrefix,
+	KSuffix:           verrs.CodeStringSuffix,
+	KExcludesAll:      verrs.CodeStringExcludesAll,
+	KRegex:            verrs.CodeStringRegexNoMatch,
+	KOneOf:            verrs.CodeStringOneOf,
+	KASCII:            verrs.CodeStringASCII,
+	KAlpha:            verrs.CodeStringAlpha,
+	KAlnum:            verrs.CodeStringAlnum,
+	KAlphaUni:         verrs.CodeStringAlphaUnicode,
+	KAlnumUni:         verrs.CodeStringAlnumUnicode,
+	KNumeric:          verrs.CodeStringNumeric,
+	KPrintASCII:       verrs.CodeStringPrintASCII,
+	KNumStr:           verrs.CodeStringNumStr,
+	KNumStrMin:        verrs.CodeStringNumStrMin,
+	KNumStrMax:        verrs.CodeStringNumStrMax,
+	KMinInt:           verrs.CodeIntMin,
+	KMaxInt:           verrs.CodeIntMax,
+	KMinUint:          verrs.CodeUintMin,
+	KMaxUint:          verrs.CodeUintMax,
+	KMinNumber:        verrs.CodeNumberMin,
+	KMaxNumber:        verrs.CodeNumberMax,
+	KGreaterThan:      verrs.CodeNumberGreaterThan,
+	KGreaterThanEqual: verrs.CodeNumberGreaterThanEqual,
+	KLessThan:         verrs.CodeNumberLessThan,
+	KLessThanEqual:    verrs.CodeNumberLessThanEqual,
+	KBetween:          verrs.CodeNumberBetween,
+	KPositive:         verrs.CodeNumberPositive,
+	KNonNegative:      verrs.CodeNumberNonNeg,
+	KNegative:         verrs.CodeNumberNegative,
+	KNonPositive:      verrs.CodeNumberNonPos,
+	KFinite:           verrs.CodeNumberFinite,
+	KMultipleOf:       verrs.CodeIntMultipleOf,
+	KSliceLength:      verrs.CodeSliceLength,
+	KMinSliceLength:   verrs.CodeSliceMin,
+	KMaxSliceLength:   verrs.CodeSliceMax,
+	KSliceUnique:      verrs.CodeSliceUnique,
+	KArrayLength:      verrs.CodeArrayLength,
+	KMinArrayLength:   verrs.CodeArrayMin,
+	KMaxArrayLength:   verrs.CodeArrayMax,
+	KArrayUnique:      verrs.CodeArrayUnique,
+	KMapLength:        verrs.CodeMapLength,
+	KMinMapKeys:       verrs.CodeMapMinKeys,
+	KMaxMapKeys:       verrs.CodeMapMaxKeys,
+	KBoolTrue:         verrs.CodeBoolTrue,
+	KBoolFalse:        verrs.CodeBoolFalse,
+	KEnum:             verrs.CodeEnumValue,
+}
+
+// ClientRulesFor returns the client description of a parsed rule chain.
+// Built-in base type rules and rules that only adapt Go values, such as
+// bool;parse or stringer, produce no checks.
+func ClientRulesFor(rules []Rule) *ClientRules {
+	cr := &ClientRules{}
+	for _, r := range rules {
+		if r.Kind != KRequired && r.Kind != KOmitempty && r.Kind != KUnbounded {
+			cr.Type = string(r.Kind)
+			break
+		}
+	}
+	builtinBase := derefsPointers(rules)
+	for _, r := range rules {
+		switch r.Kind {
+		case KRequired:
+			cr.Required = true
+			continue
+		case KOmitempty:
+			cr.Optional = true
+			continue
+		case KBoolParse, KStringer, KTimeLayout, KUnbounded:
+			continue
+		case KForEach, KArrayForEach:
+			if elem, ok := r.Args["rules"].([]Rule); ok {
+				cr.Items = ClientRulesFor(elem)
+			}
+			continue
+		case KMapValues:
+			if elem, ok := r.Args["rules"].([]Rule); ok {
+				cr.Values = ClientRulesFor(elem)
+			}
+			continue
+		}
+		if builtinBase && r.Kind == Kind(cr.Type) {
+			continue
+		}
+		code, ok := clientCodes[r.Kind]
+		if !ok {
+			cr.ServerOnly = append(cr.ServerOnly, r.Kind)
+			continue
+		}
+		if _, isFloat := r.Args["n"].(float64); r.Kind == KMultipleOf && isFloat {
+			code = verrs.CodeNumberMultipleOf
+		}
+		cr.Checks = append(cr.Checks, ClientCheck{Rule: r.Kind, Code: code, Arg: clientArg(r)})
+	}
+	return cr
+}
+
+func clientArg(r Rule) any {
+	switch r.Kind {
+	case KRegex:
+		return normalizeRegexPattern(argString(r, "pattern"))
+	case KOneOf:
+		values, _ := r.Args["values"].([]string)
+		return append([]string(nil), values...)
+	case KContains, KNotContains, KPrefix, KSuffix, KExcludesAll, KNumStrMin, KNumStrMax:
+		return argString(r, "value")
+	case KBetween:
+		lo, _ := argNumber(r, "min")
+		hi, _ := argNumber(r, "max")
+		return []float64{lo, hi}
+	case KEnum:
+		values, names := enumRuleValues(r)
+		return map[string]any{"names": names, "values": values}
+	}
+	if n, ok := r.Args["n"]; ok {
+		return n
+	}
+	return nil
+}
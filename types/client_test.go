@@ -0,0 +1,37 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestClientRulesForRules(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"string;required;min=3;max=20", `{"type":"string","required":true,"checks":[{"rule":"minLength","code":"string.min","arg":3},{"rule":"maxLength","code":"string.max","arg":20}]}`},
+		{"string;omitempty;regex=[a-z]+", `{"type":"string","optional":true,"checks":[{"rule":"regex","code":"string.regex.noMatch","arg":"^[a-z]+$"}]}`},
+		{"string;oneof=red,green;prefix=r", `{"type":"string","checks":[{"rule":"oneOf","code":"string.oneof","arg":["red","green"]},{"rule":"prefix","code":"string.prefix","arg":"r"}]}`},
+		{"string;url", `{"type":"string","serverOnly":["url"]}`},
+		{"int;min=0;max=10", `{"type":"int","checks":[{"rule":"minInt","code":"int.min","arg":0},{"rule":"maxInt","code":"int.max","arg":10}]}`},
+		{"float;between=0,1", `{"type":"float","checks":[{"rule":"between","code":"number.between","arg":[0,1]}]}`},
+		{"bool;parse;true", `{"type":"bool","checks":[{"rule":"boolTrue","code":"bool.true"}]}`},
+		{"time;after=2020-01-01T00:00:00Z", `{"type":"time","serverOnly":["timeAfter"]}`},
+		{"slice;max=5;unique;foreach=(string;min=2)", `{"type":"slice","checks":[{"rule":"maxSliceLength","code":"slice.max","arg":5},{"rule":"sliceUnique","code":"slice.unique"}],"items":{"type":"string","checks":[{"rule":"minLength","code":"string.min","arg":2}]}}`},
+		{"map;minKeys=1;values=(int;positive)", `{"type":"map","checks":[{"rule":"minMapKeys","code":"map.minkeys","arg":1}],"values":{"type":"int","checks":[{"rule":"positive","code":"number.positive"}]}}`},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		got, err := json.Marshal(ClientRulesFor(rules))
+		if err != nil {
+			t.Fatalf("marshal %q: %v", tt.tag, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%q:\n got %s\nwant %s", tt.tag, got, tt.want)
+		}
+	}
+}
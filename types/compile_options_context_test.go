@@ -107,3 +107,41 @@ func assertCodes(t *testing.T, err error, want []string) {
 		}
 	}
 }
+
+func TestCompiler_OmitEmptyShortCircuitsZeroValues(t *testing.T) {
+	c := NewCompiler(nil)
+	var nilPtr *int
+	tests := []struct {
+		tag  string
+		zero any
+		bad  any
+	}{
+		{"string;omitempty;min=3", "", "ab"},
+		{"int;omitempty;min=5", 0, 3},
+		{"int;omitempty;min=5", nilPtr, 3},
+		{"slice;omitempty;min=2", []int(nil), []int{1}},
+		{"map;omitempty;max=1", map[string]int(nil), map[string]int{"a": 1, "b": 2}},
+		{"slice;foreach=(string;omitempty;min=3)", []string{"", "abc"}, []string{"ab"}},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tag, err)
+		}
+		fn, err := c.CompileE(rules)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tag, err)
+		}
+		if err := fn(tt.zero); err != nil {
+			t.Errorf("%s: zero value %#v failed: %v", tt.tag, tt.zero, err)
+		}
+		if err := fn(tt.bad); err == nil {
+			t.Errorf("%s: %#v passed", tt.tag, tt.bad)
+		}
+	}
+
+	// A lone omitempty rule compiles and accepts every value.
+	if compiled := c.compileRule(NewRule(KOmitempty, nil)); compiled.err != nil || compiled.validate(42) != nil {
+		t.Fatalf("lone omitempty = %+v", compiled)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"net"
 	"net/netip"
 	"net/url"
@@ -31,13 +32,35 @@ type RuleCompiler func(c *Compiler, rule Rule) (func(any) error, error)
 var (
 	globalRegistry   = map[Kind]RuleCompiler{}
 	globalRegistryMu sync.RWMutex
+	// globalRegistryVersion counts changes to globalRegistry.
+	globalRegistryVersion uint64
 )
 
+// RegistryVersion returns a counter that changes whenever a global rule
+// compiler is registered. Callers that keep a Compiler across compiles use
+// it to notice rules registered after the Compiler was created.
+func RegistryVersion() uint64 {
+	globalRegistryMu.RLock()
+	defer globalRegistryMu.RUnlock()
+	return globalRegistryVersion
+}
+
 // RegisterRule registers a global custom Rule compiler. Call this at init.
+// A later registration for the same kind replaces the earlier one; plugins
+// that need conflict detection use RegisterNamespacedRule.
 func RegisterRule(kind Kind, rc RuleCompiler) {
 	globalRegistryMu.Lock()
 	defer globalRegistryMu.Unlock()
 	globalRegistry[kind] = rc
+	globalRegistryVersion++
+}
+
+// isGlobalRule reports whether kind has a globally registered compiler.
+func isGlobalRule(kind Kind) bool {
+	globalRegistryMu.RLock()
+	defer globalRegistryMu.RUnlock()
+	_, ok := globalRegistry[kind]
+	return ok
 }
 
 // Compiler compiles rules into validator functions.
@@ -46,6 +69,19 @@ type Compiler struct {
 	custom        map[Kind]RuleCompiler
 	contextCustom map[Kind]ContextRuleCompiler
 	types         *TypeRegistry
+	shadow        map[Kind]float64
+	shadowHook    ShadowHook
+	retry         map[Kind]RetryPolicy
+	outage        OutagePolicy
+	resultCaches  map[Kind]*ResultCache
+	converters    map[Kind]map[reflect.Type]ConverterFunc
+	nilAsEmpty    bool
+	nilPolicy     NilPolicy
+	noFusion      bool
+	compileHooks  map[Kind][]CompileHook
+	limits        DefaultLimits
+	middleware    []Middleware
+	behavior      Behavior
 }
 
 // NewCompiler creates a new compiler with the given translator.
@@ -108,6 +144,106 @@ func (c *Compiler) RegisterType(name string, factory TypeValidatorFactory) {
 	c.types.RegisterType(name, factory)
 }
 
+// SetNilCollectionsAsEmpty makes slice and map rules treat an untyped nil
+// value (for example a nil pointer to a slice) as an empty collection
+// instead of failing with a type error.
+func (c *Compiler) SetNilCollectionsAsEmpty(enabled bool) {
+	c.nilAsEmpty = enabled
+}
+
+// NilPolicy selects how validators for built-in base types treat nil values,
+// including nil pointers, when the rules have neither omitempty nor required.
+type NilPolicy int
+
+const (
+	// NilReject leaves nil values to the rules, which fail them with the
+	// base type's code, or with value.nil under Behavior.NilValueCode
+	// (default).
+	NilReject NilPolicy = iota
+	// NilAllow lets nil values pass, as if omitempty were present.
+	NilAllow
+)
+
+// SetNilPolicy sets how nil values are treated; see NilPolicy.
+func (c *Compiler) SetNilPolicy(p NilPolicy) {
+	c.nilPolicy = p
+}
+
+// derefsPointers reports whether validators for rules dereference pointers:
+// true for built-in base types, false for custom types, which may expect
+// pointer values.
+func derefsPointers(rules []Rule) bool {
+	switch baseKind(rules) {
+	case KString, KInt, KInt64, KUint, KUint64, KFloat, KBool, KTime, KSlice, KArray, KMap:
+		return true
+	}
+	return false
+}
+
+// coercionFor returns the step that adapts values after dereferencing and
+// before a rule chain runs, or nil when none applies: strings for bool;parse
+// and time chains, named integer types for int chains with enum, and
+// fmt.Stringer values for string;stringer.
+func (c *Compiler) coercionFor(rules []Rule) func(any) (any, error) {
+	base := baseKind(rules)
+	switch {
+	case base == KBool && hasKind(rules, KBoolParse):
+		return c.parseBoolString
+	case base == KTime:
+		layouts := timeParseLayouts(rules)
+		return func(v any) (any, error) { return c.parseTimeString(v, layouts) }
+	case (base == KInt || base == KInt64) && hasKind(rules, KEnum):
+		return enumIntValue
+	case base == KString && hasKind(rules, KStringer):
+		return stringerValue
+	}
+	return nil
+}
+
+// derefValue dereferences pointers, so a *string validates as a string.
+// Nil pointers become an untyped nil.
+func derefValue(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr {
+		return v
+	}
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	return rv.Interface()
+}
+
+// checkNil handles a nil value before rules run: it passes with omitempty
+// or NilAllow, fails with required, and fails with value.nil under
+// Behavior.NilValueCode. It reports whether v was handled.
+func (c *Compiler) checkNil(v any, hasOmitEmpty bool, required func(any) error) (bool, error) {
+	if v != nil {
+		return false, nil
+	}
+	switch {
+	case hasOmitEmpty, c.nilPolicy == NilAllow && required == nil:
+		return true, nil
+	case required != nil:
+		return true, required(v)
+	case !c.behavior.NilValueCode:
+		return false, nil
+	}
+	msg := c.translateMessage(verrs.CodeValueNil, "value must not be nil", nil)
+	return true, verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeValueNil, Msg: msg}}
+}
+
+// requiredFunc returns the check of a required rule, reporting its message
+// override when it has one.
+func (c *Compiler) requiredFunc(rule Rule) func(any) error {
+	if rule.Msg == "" {
+		return c.validateRequired
+	}
+	return func(v any) error { return overrideMessage(c.validateRequired(v), rule.Msg) }
+}
+
 // Compile compiles a slice of rules into a validator function.
 func (c *Compiler) Compile(rules []Rule) ValidatorFunc {
 	fn, err := c.CompileE(rules)
@@ -140,31 +276,65 @@ func (c *Compiler) CompileWithOptsE(rules []Rule, opts CompileOpts) (ValidatorFu
 	}
 
 	// Pre-compile regexes and other expensive operations
-	compiledRules := make([]compiledRule, 0, len(rules))
+	chain := make([]Rule, 0, len(rules))
 	hasOmitEmpty := false
-	hasRequired := false
+	unbounded := false
+	var required func(any) error
 	for _, rule := range rules {
-		if rule.Kind == KOmitempty {
+		switch rule.Kind {
+		case KOmitempty:
 			hasOmitEmpty = true
 			continue
-		}
-		if rule.Kind == KRequired {
-			hasRequired = true
+		case KRequired:
+			required = c.requiredFunc(rule)
+			continue
+		case KUnbounded:
+			unbounded = true
 			continue
 		}
-		compiled := c.compileRule(rule)
-		if compiled.err != nil {
-			return nil, compiled.err
-		}
-		compiledRules = append(compiledRules, compiled)
+		chain = append(chain, rule)
+	}
+	if !unbounded {
+		chain = c.withDefaultLimit(chain)
+	}
+	compiledRules, err := c.compileChain(chain, opts)
+	if err != nil {
+		return nil, err
 	}
+	convert := c.converterFor(rules)
+	builtin := derefsPointers(rules)
+	deref := builtin && c.behavior.DerefPointers
+	nilIsEmpty := c.nilAsEmpty && (baseKind(rules) == KSlice || baseKind(rules) == KMap)
+	coerce := c.coercionFor(rules)
 
 	return func(v any) error {
+		if convert != nil {
+			cv, err := convert(v)
+			if err != nil {
+				return err
+			}
+			v = cv
+		}
+		if deref {
+			v = derefValue(v)
+		}
+		if builtin {
+			if handled, err := c.checkNil(v, hasOmitEmpty, required); handled && !nilIsEmpty {
+				return err
+			}
+		}
+		if coerce != nil {
+			cv, err := coerce(v)
+			if err != nil {
+				return err
+			}
+			v = cv
+		}
 		if hasOmitEmpty && isZeroValue(v) {
 			return nil
 		}
-		if hasRequired && isZeroValue(v) {
-			return c.validateRequired(v)
+		if required != nil && isZeroValue(v) {
+			return required(v)
 		}
 		if opts.CollectAll {
 			var acc verrs.Errors
@@ -218,24 +388,36 @@ func (c *Compiler) CompileContextWithOptsE(rules []Rule, opts CompileOpts) (Cont
 		return func(context.Context, any) error { return nil }, nil
 	}
 
-	compiledRules := make([]compiledContextRule, 0, len(rules))
+	chain := make([]Rule, 0, len(rules))
 	hasOmitEmpty := false
-	hasRequired := false
+	unbounded := false
+	var required func(any) error
 	for _, rule := range rules {
-		if rule.Kind == KOmitempty {
+		switch rule.Kind {
+		case KOmitempty:
 			hasOmitEmpty = true
 			continue
-		}
-		if rule.Kind == KRequired {
-			hasRequired = true
+		case KRequired:
+			required = c.requiredFunc(rule)
+			continue
+		case KUnbounded:
+			unbounded = true
 			continue
 		}
-		compiled := c.compileContextRule(rule)
-		if compiled.err != nil {
-			return nil, compiled.err
-		}
-		compiledRules = append(compiledRules, compiled)
+		chain = append(chain, rule)
+	}
+	if !unbounded {
+		chain = c.withDefaultLimit(chain)
+	}
+	compiledRules, err := c.compileContextChain(chain, opts)
+	if err != nil {
+		return nil, err
 	}
+	convert := c.converterFor(rules)
+	builtin := derefsPointers(rules)
+	deref := builtin && c.behavior.DerefPointers
+	nilIsEmpty := c.nilAsEmpty && (baseKind(rules) == KSlice || baseKind(rules) == KMap)
+	coerce := c.coercionFor(rules)
 
 	return func(ctx context.Context, v any) error {
 		if ctx == nil {
@@ -244,11 +426,33 @@ func (c *Compiler) CompileContextWithOptsE(rules []Rule, opts CompileOpts) (Cont
 		if err := ctx.Err(); err != nil {
 			return err
 		}
+		if convert != nil {
+			cv, err := convert(v)
+			if err != nil {
+				return err
+			}
+			v = cv
+		}
+		if deref {
+			v = derefValue(v)
+		}
+		if builtin {
+			if handled, err := c.checkNil(v, hasOmitEmpty, required); handled && !nilIsEmpty {
+				return err
+			}
+		}
+		if coerce != nil {
+			cv, err := coerce(v)
+			if err != nil {
+				return err
+			}
+			v = cv
+		}
 		if hasOmitEmpty && isZeroValue(v) {
 			return nil
 		}
-		if hasRequired && isZeroValue(v) {
-			return c.validateRequired(v)
+		if required != nil && isZeroValue(v) {
+			return required(v)
 		}
 		if opts.CollectAll {
 			var acc verrs.Errors
@@ -333,44 +537,112 @@ type compiledContextRule struct {
 }
 
 func (c *Compiler) compileContextRule(rule Rule) compiledContextRule {
+	var compiled compiledContextRule
+	if err := c.hookCompile(rule, func() error {
+		compiled = c.compileContextRuleBase(rule)
+		return compiled.err
+	}); err != nil {
+		return compiledContextRule{err: err}
+	}
+	if msg := rule.Msg; msg != "" {
+		validate := compiled.validate
+		compiled.validate = func(ctx context.Context, v any) error {
+			return overrideMessage(validate(ctx, v), msg)
+		}
+	}
+	if len(c.middleware) > 0 {
+		compiled.validate = c.wrapContextRule(rule, compiled.validate)
+	}
+	return compiled
+}
+
+func (c *Compiler) compileContextRuleBase(rule Rule) compiledContextRule {
 	if rc, ok := c.contextCustom[rule.Kind]; ok {
 		fn, err := rc(c, rule)
 		if err != nil {
-			return compiledContextRule{err: fmt.Errorf("compile rule %s: %w", safeRuleKindForError(rule.Kind), err)}
+			return compiledContextRule{err: newCompileError(rule.Kind, fmt.Errorf("compile rule %s: %w", safeRuleKindForError(rule.Kind), err))}
 		}
 		if fn != nil {
-			return compiledContextRule{validate: fn}
+			return c.shadowContextRule(rule.Kind, c.outageContextRule(rule.Kind, c.cacheContextRule(rule, c.retryContextRule(rule.Kind, compiledContextRule{validate: fn}))))
 		}
 	}
-	compiled := c.compileRule(rule)
+	compiled := c.shadowRule(rule.Kind, c.compileRuleBase(rule))
 	if compiled.err != nil {
 		return compiledContextRule{err: compiled.err}
 	}
-	return compiledContextRule{validate: func(ctx context.Context, v any) error {
-		if ctx == nil {
-			ctx = context.Background()
-		}
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-		return compiled.validate(v)
-	}}
+	return withContext(compiled)
 }
 
 func (c *Compiler) compileRule(rule Rule) compiledRule {
+	var compiled compiledRule
+	if err := c.hookCompile(rule, func() error {
+		compiled = c.shadowRule(rule.Kind, c.compileRuleBase(rule))
+		return compiled.err
+	}); err != nil {
+		return compiledRule{err: err}
+	}
+	if msg := rule.Msg; msg != "" {
+		validate := compiled.validate
+		compiled.validate = func(v any) error { return overrideMessage(validate(v), msg) }
+	}
+	if len(c.middleware) > 0 {
+		compiled.validate = c.wrapRule(rule, compiled.validate)
+	}
+	return compiled
+}
+
+// checkNaNParams rejects a NaN numeric bound under
+// Behavior.RejectNaNParams. Without it such bounds compile, and every
+// comparison with them is false.
+func (c *Compiler) checkNaNParams(rule Rule) error {
+	if !c.behavior.RejectNaNParams {
+		return nil
+	}
+	for _, key := range []string{"n", "min", "max"} {
+		if f, ok := rule.Args[key].(float64); ok && math.IsNaN(f) {
+			return newCompileError(rule.Kind, fmt.Errorf("%s bound is NaN", key))
+		}
+	}
+	return nil
+}
+
+// overrideMessage replaces the messages of the field errors in err with
+// msg, the override of the rule that reported them. Other errors, such as
+// context errors, are returned unchanged.
+func overrideMessage(err error, msg string) error {
+	var es verrs.Errors
+	if err == nil || !errors.As(err, &es) {
+		return err
+	}
+	out := make(verrs.Errors, len(es))
+	for i, e := range es {
+		e.Msg = msg
+		out[i] = e
+	}
+	return out
+}
+
+func (c *Compiler) compileRuleBase(rule Rule) compiledRule {
 	// Allow custom compilers to handle the rule first
 	if rc, ok := c.custom[rule.Kind]; ok {
 		fn, err := rc(c, rule)
 		if err != nil {
-			return compiledRule{err: fmt.Errorf("compile rule %s: %w", safeRuleKindForError(rule.Kind), err)}
+			return compiledRule{err: newCompileError(rule.Kind, fmt.Errorf("compile rule %s: %w", safeRuleKindForError(rule.Kind), err))}
 		}
 		if fn != nil {
 			return compiledRule{validate: fn}
 		}
 	}
+	if err := c.checkNaNParams(rule); err != nil {
+		return compiledRule{err: err}
+	}
 	switch rule.Kind {
 	case KRequired:
 		return compiledRule{validate: c.validateRequired}
+	case KOmitempty, KUnbounded:
+		// Chains hoist omitempty and unbounded; see CompileWithOptsE.
+		// Compiled on their own they accept all values.
+		return compiledRule{validate: func(any) error { return nil }}
 	case KString:
 		return compiledRule{validate: c.validateString}
 	case KLength:
@@ -410,6 +682,11 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 		return compiledRule{validate: func(v any) error {
 			return c.validateStringContains(v, value, false)
 		}}
+	case KExcludesAll:
+		chars := c.getStringArg(rule, "value", "")
+		return compiledRule{validate: func(v any) error {
+			return c.validateStringExcludesAll(v, chars)
+		}}
 	case KPrefix:
 		value := c.getStringArg(rule, "value", "")
 		return compiledRule{validate: func(v any) error {
@@ -438,6 +715,31 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 		return compiledRule{validate: c.validateAlpha}
 	case KAlnum:
 		return compiledRule{validate: c.validateAlnum}
+	case KAlphaUni:
+		return compiledRule{validate: c.validateAlphaUnicode}
+	case KAlnumUni:
+		return compiledRule{validate: c.validateAlnumUnicode}
+	case KNumeric:
+		return compiledRule{validate: c.validateNumeric}
+	case KPrintASCII:
+		return compiledRule{validate: c.validatePrintASCII}
+	case KNumStr:
+		return compiledRule{validate: func(v any) error {
+			return c.validateNumStr(v, nil, 0, "")
+		}}
+	case KNumStrMin, KNumStrMax:
+		bound := c.getStringArg(rule, "value", "")
+		limit, ok := parseDecimal(bound)
+		if !ok {
+			return compiledRule{err: newCompileError(rule.Kind, fmt.Errorf("%s: %w: %q is not a decimal number", rule.Kind, ErrInvalidRuleArg, truncateForError(bound, 30)))}
+		}
+		sign := -1
+		if rule.Kind == KNumStrMax {
+			sign = 1
+		}
+		return compiledRule{validate: func(v any) error {
+			return c.validateNumStr(v, limit, sign, bound)
+		}}
 	case KRegex:
 		pattern := c.getStringArg(rule, "pattern", "")
 		re, err := c.compileRegexSafe(pattern) // returns (*regexp.Regexp, error)
@@ -461,15 +763,29 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 	case KInt64:
 		return compiledRule{validate: c.validateInt64}
 	case KMinInt:
-		n := c.getInt64Arg(rule, "n", 0)
+		n := c.getIntBoundArg(rule, "n")
 		return compiledRule{validate: func(v any) error {
 			return c.validateMinInt(v, n)
 		}}
 	case KMaxInt:
-		n := c.getInt64Arg(rule, "n", 0)
+		n := c.getIntBoundArg(rule, "n")
 		return compiledRule{validate: func(v any) error {
 			return c.validateMaxInt(v, n)
 		}}
+	case KUint:
+		return compiledRule{validate: c.validateUint}
+	case KUint64:
+		return compiledRule{validate: c.validateUint64}
+	case KMinUint:
+		n := c.getUintArg(rule, "n")
+		return compiledRule{validate: func(v any) error {
+			return c.validateMinUint(v, n)
+		}}
+	case KMaxUint:
+		n := c.getUintArg(rule, "n")
+		return compiledRule{validate: func(v any) error {
+			return c.validateMaxUint(v, n)
+		}}
 	case KFloat:
 		return compiledRule{validate: c.validateFloat}
 	case KMinNumber:
@@ -498,8 +814,14 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 		return compiledRule{validate: c.validateNumberPositive}
 	case KNonNegative:
 		return compiledRule{validate: c.validateNumberNonNegative}
+	case KNegative:
+		return compiledRule{validate: c.validateNumberNegative}
+	case KNonPositive:
+		return compiledRule{validate: c.validateNumberNonPositive}
 	case KFinite:
 		return compiledRule{validate: c.validateNumberFinite}
+	case KMultipleOf:
+		return c.compileMultipleOf(rule)
 	case KSlice:
 		return compiledRule{validate: c.validateSlice}
 	case KSliceLength:
@@ -550,7 +872,8 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 		}
 		return compiledRule{validate: func(any) error { return nil }}
 	case KSliceUnique:
-		return compiledRule{validate: c.validateSliceUnique}
+		field := argString(rule, "field")
+		return compiledRule{validate: func(v any) error { return c.validateSliceUnique(v, field) }}
 	case KSliceContains:
 		value := rule.Args["value"]
 		return compiledRule{validate: func(v any) error { return c.validateSliceContains(v, value) }}
@@ -601,7 +924,8 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 		}
 		return compiledRule{validate: func(any) error { return nil }}
 	case KArrayUnique:
-		return compiledRule{validate: c.validateArrayUnique}
+		field := argString(rule, "field")
+		return compiledRule{validate: func(v any) error { return c.validateArrayUnique(v, field) }}
 	case KArrayContains:
 		value := rule.Args["value"]
 		return compiledRule{validate: func(v any) error { return c.validateArrayContains(v, value) }}
@@ -636,26 +960,51 @@ func (c *Compiler) compileRule(rule Rule) compiledRule {
 		return compiledRule{validate: func(v any) error { return c.validateBoolValue(v, true) }}
 	case KBoolFalse:
 		return compiledRule{validate: func(v any) error { return c.validateBoolValue(v, false) }}
+	case KBoolParse:
+		// Strings are coerced before the chain runs; see parseBoolString.
+		return compiledRule{validate: func(any) error { return nil }}
 	case KTime:
 		return compiledRule{validate: c.validateTime}
 	case KTimeNotZero:
 		return compiledRule{validate: c.validateTimeNotZero}
 	case KTimeBefore:
-		target := c.getTimeArg(rule, "time")
+		target := rule.Args["time"]
 		return compiledRule{validate: func(v any) error { return c.validateTimeBefore(v, target) }}
 	case KTimeAfter:
-		target := c.getTimeArg(rule, "time")
+		target := rule.Args["time"]
 		return compiledRule{validate: func(v any) error { return c.validateTimeAfter(v, target) }}
+	case KTimeMin:
+		target := rule.Args["time"]
+		return compiledRule{validate: func(v any) error { return c.validateTimeMin(v, target) }}
+	case KTimeMax:
+		target := rule.Args["time"]
+		return compiledRule{validate: func(v any) error { return c.validateTimeMax(v, target) }}
 	case KTimeBetween:
-		start := c.getTimeArg(rule, "start")
-		end := c.getTimeArg(rule, "end")
+		start, end := rule.Args["start"], rule.Args["end"]
 		return compiledRule{validate: func(v any) error { return c.validateTimeBetween(v, start, end) }}
+	case KTimeLayout:
+		// Strings are parsed before the chain runs; see parseTimeString.
+		return compiledRule{validate: func(any) error { return nil }}
+	case KEnum:
+		return c.compileEnum(rule)
+	case KStringer:
+		// Values are rendered before the chain runs; see stringerValue.
+		return compiledRule{validate: func(any) error { return nil }}
 	default:
 		// Check if it's a custom type
 		if c.isTypeRegistered(string(rule.Kind)) {
 			return compiledRule{validate: c.validateCustomType(rule.Kind)}
 		}
-		return compiledRule{err: unknownRuleKindError(rule.Kind)}
+		// Fall back to a uniquely namespaced kind, e.g. phone -> acme.phone.
+		resolved, ok, err := c.resolveShortKind(rule.Kind)
+		if err != nil {
+			return compiledRule{err: newCompileError(rule.Kind, err)}
+		}
+		if ok {
+			rule.Kind = resolved
+			return c.compileRuleBase(rule)
+		}
+		return compiledRule{err: newCompileError(rule.Kind, unknownRuleKindError(rule.Kind))}
 	}
 }
 
@@ -675,24 +1024,32 @@ func safeRuleKindForError(kind Kind) string {
 
 // Helper methods for argument extraction
 func (c *Compiler) getIntArg(rule Rule, key string, defaultVal int) int {
+	if n, ok := rule.IntArg(key); ok && n >= math.MinInt && n <= math.MaxInt {
+		return int(n)
+	}
+	return defaultVal
+}
+
+func (c *Compiler) getIntBoundArg(rule Rule, key string) intBound {
 	if val, ok := rule.Args[key]; ok {
-		if n, ok := val.(int); ok {
-			return n
-		}
-		if n, ok := val.(int64); ok {
-			return int(n)
+		if _, isString := val.(string); !isString {
+			if n, ok := toIntBound(val); ok {
+				return n
+			}
 		}
 	}
-	return defaultVal
+	return intBound{}
 }
 
-func (c *Compiler) getInt64Arg(rule Rule, key string, defaultVal int64) int64 {
+func (c *Compiler) getUintArg(rule Rule, key string) uint64 {
 	if val, ok := rule.Args[key]; ok {
-		if n, ok := val.(int64); ok {
-			return n
+		if _, isString := val.(string); !isString {
+			if n, ok := toUint64(val); ok {
+				return n
+			}
 		}
 	}
-	return defaultVal
+	return 0
 }
 
 func (c *Compiler) getStringArg(
@@ -737,15 +1094,6 @@ func (c *Compiler) getFloatArg(rule Rule, key string, defaultVal float64) float6
 	return defaultVal
 }
 
-func (c *Compiler) getTimeArg(rule Rule, key string) time.Time {
-	if val, ok := rule.Args[key]; ok {
-		if t, ok := val.(time.Time); ok {
-			return t
-		}
-	}
-	return time.Time{}
-}
-
 // Validation methods
 func (c *Compiler) validateRequired(v any) error {
 	if isZeroValue(v) {
@@ -771,7 +1119,7 @@ func (c *Compiler) validateLength(v any, n int) error {
 	}
 	if len(s) != n {
 		msg := c.translateMessage("string.length", fmt.Sprintf("length must be %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringLength, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringLength, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -784,7 +1132,7 @@ func (c *Compiler) validateMinLength(v any, n int) error {
 	}
 	if len(s) < n {
 		msg := c.translateMessage("string.min", fmt.Sprintf("minimum length is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMin, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMin, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -797,7 +1145,7 @@ func (c *Compiler) validateMaxLength(v any, n int) error {
 	}
 	if len(s) > n {
 		msg := c.translateMessage("string.max", fmt.Sprintf("maximum length is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMax, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMax, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -819,19 +1167,29 @@ func (c *Compiler) validateRegexWithPattern(v any, regex *regexp.Regexp, pattern
 	if len(s) > maxInputLength {
 		msg := c.translateMessage("string.regex.inputTooLong", fmt.Sprintf("input too long (max %d characters)", maxInputLength), []any{maxInputLength})
 		return verrs.Errors{verrs.FieldError{
-			Path: "",
-			Code: verrs.CodeStringRegexInputTooLong,
-			Msg:  msg,
+			Path:  "",
+			Code:  verrs.CodeStringRegexInputTooLong,
+			Msg:   msg,
+			Param: maxInputLength,
 		}}
 	}
 
 	if !regex.MatchString(s) {
 		msg := c.translateMessage("string.regex.noMatch", "does not match required pattern", []any{})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringRegexNoMatch, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringRegexNoMatch, Msg: msg, Param: regexParam(regex, pattern)}}
 	}
 	return nil
 }
 
+// regexParam returns the pattern reported in regex errors: the tag's
+// pattern, or the compiled expression when it is unknown.
+func regexParam(regex *regexp.Regexp, pattern string) string {
+	if pattern == "" {
+		return regex.String()
+	}
+	return pattern
+}
+
 // Backward-compat wrapper (without pattern context)
 func (c *Compiler) validateRegex(v any, regex *regexp.Regexp) error {
 	return c.validateRegexWithPattern(v, regex, "")
@@ -844,15 +1202,16 @@ func (c *Compiler) validateOneOf(v any, values []string) error {
 		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
 	}
 	for _, val := range values {
-		if s == val {
+		if s == val || c.behavior.OneOfCaseFold && strings.EqualFold(s, val) {
 			return nil
 		}
 	}
 	msg := c.translateMessage("string.oneof", fmt.Sprintf("must be one of: %s", strings.Join(values, ", ")), []any{strings.Join(values, ", ")})
 	return verrs.Errors{verrs.FieldError{
-		Path: "",
-		Code: verrs.CodeStringOneOf,
-		Msg:  msg,
+		Path:  "",
+		Code:  verrs.CodeStringOneOf,
+		Msg:   msg,
+		Param: append([]string(nil), values...),
 	}}
 }
 
@@ -878,15 +1237,53 @@ func (c *Compiler) validateStringContains(v any, value string, shouldContain boo
 	contains := strings.Contains(s, value)
 	if shouldContain && !contains {
 		msg := c.translateMessage("string.contains", "must contain required text", nil)
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringContains, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringContains, Msg: msg, Param: value}}
 	}
 	if !shouldContain && contains {
 		msg := c.translateMessage("string.notContains", "must not contain prohibited text", nil)
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringNotContains, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringNotContains, Msg: msg, Param: value}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateStringExcludesAll(v any, chars string) error {
+	s, ok := v.(string)
+	if !ok {
+		msg := c.translateMessage("string.type", "expected string", []any{})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+	}
+	if strings.ContainsAny(s, chars) {
+		msg := c.translateMessage("string.excludesAll", fmt.Sprintf("must not contain any of: %s", chars), []any{chars})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringExcludesAll, Msg: msg, Param: chars}}
 	}
 	return nil
 }
 
+// validateNumStr checks that v is a decimal number string and, when limit
+// is set, that comparing it with limit does not give sign: -1 for a lower
+// bound, 1 for an upper bound.
+func (c *Compiler) validateNumStr(v any, limit *big.Rat, sign int, bound string) error {
+	s, ok := v.(string)
+	if !ok {
+		msg := c.translateMessage("string.type", "expected string", []any{})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+	}
+	n, ok := parseDecimal(s)
+	if !ok {
+		msg := c.translateMessage("string.numStr", "must be a decimal number", nil)
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringNumStr, Msg: msg}}
+	}
+	if limit == nil || n.Cmp(limit) != sign {
+		return nil
+	}
+	if sign < 0 {
+		msg := c.translateMessage("string.numStr.min", fmt.Sprintf("must be at least %s", bound), []any{bound})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringNumStrMin, Msg: msg, Param: bound}}
+	}
+	msg := c.translateMessage("string.numStr.max", fmt.Sprintf("must be at most %s", bound), []any{bound})
+	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringNumStrMax, Msg: msg, Param: bound}}
+}
+
 func (c *Compiler) validateStringPrefix(v any, value string) error {
 	s, ok := v.(string)
 	if !ok {
@@ -895,7 +1292,7 @@ func (c *Compiler) validateStringPrefix(v any, value string) error {
 	}
 	if !strings.HasPrefix(s, value) {
 		msg := c.translateMessage("string.prefix", "must have required prefix", nil)
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringPrefix, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringPrefix, Msg: msg, Param: value}}
 	}
 	return nil
 }
@@ -908,7 +1305,7 @@ func (c *Compiler) validateStringSuffix(v any, value string) error {
 	}
 	if !strings.HasSuffix(s, value) {
 		msg := c.translateMessage("string.suffix", "must have required suffix", nil)
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringSuffix, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringSuffix, Msg: msg, Param: value}}
 	}
 	return nil
 }
@@ -968,17 +1365,62 @@ func (c *Compiler) validateCIDR(v any) error {
 }
 
 func (c *Compiler) validateASCII(v any) error {
-	return c.validateStringRunes(v, verrs.CodeStringASCII, "string.ascii", func(r rune) bool { return r <= 127 })
+	return c.validateStringBytes(v, verrs.CodeStringASCII, "string.ascii", asciiAny)
 }
 
 func (c *Compiler) validateAlpha(v any) error {
-	return c.validateStringRunes(v, verrs.CodeStringAlpha, "string.alpha", unicode.IsLetter)
+	return c.validateStringClass(v, verrs.CodeStringAlpha, "string.alpha", asciiAlpha, isLetter)
 }
 
 func (c *Compiler) validateAlnum(v any) error {
-	return c.validateStringRunes(v, verrs.CodeStringAlnum, "string.alnum", func(r rune) bool {
-		return unicode.IsLetter(r) || unicode.IsDigit(r)
-	})
+	return c.validateStringClass(v, verrs.CodeStringAlnum, "string.alnum", asciiAlnum, isLetterOrDigit)
+}
+
+func (c *Compiler) validateNumeric(v any) error {
+	return c.validateStringBytes(v, verrs.CodeStringNumeric, "string.numeric", asciiDigits)
+}
+
+func (c *Compiler) validatePrintASCII(v any) error {
+	return c.validateStringBytes(v, verrs.CodeStringPrintASCII, "string.printAscii", asciiPrint)
+}
+
+func (c *Compiler) validateAlphaUnicode(v any) error {
+	return c.validateStringClass(v, verrs.CodeStringAlphaUnicode, "string.alphaUnicode", asciiAlpha, isLetter)
+}
+
+func (c *Compiler) validateAlnumUnicode(v any) error {
+	return c.validateStringClass(v, verrs.CodeStringAlnumUnicode, "string.alnumUnicode", asciiAlnum, isLetterOrDigit)
+}
+
+func isASCIILetter(b byte) bool { return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' }
+
+func isLetter(r rune) bool { return unicode.IsLetter(r) }
+
+func isLetterOrDigit(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+// validateStringClass checks a Unicode character class whose ASCII members
+// are class: ASCII strings, the common case, are checked a word at a time
+// and other strings rune by rune with okFn.
+func (c *Compiler) validateStringClass(v any, code, key string, class asciiClass, okFn func(rune) bool) error {
+	if s, ok := v.(string); ok && class.all(s) {
+		return nil
+	}
+	return c.validateStringRunes(v, code, key, okFn)
+}
+
+// validateStringBytes checks ASCII character classes a word at a time; see
+// asciiClass.
+func (c *Compiler) validateStringBytes(v any, code, key string, class asciiClass) error {
+	s, ok := v.(string)
+	if !ok {
+		msg := c.translateMessage("string.type", "expected string", []any{})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+	}
+	if !class.all(s) {
+		msg := c.translateMessage(key, key, nil)
+		return verrs.Errors{verrs.FieldError{Path: "", Code: code, Msg: msg}}
+	}
+	return nil
 }
 
 func (c *Compiler) validateStringRunes(v any, code, key string, okFn func(rune) bool) error {
@@ -1016,28 +1458,76 @@ func (c *Compiler) validateInt64(v any) error {
 	}
 }
 
-func (c *Compiler) validateMinInt(v any, n int64) error {
-	val, err := c.toInt64(v)
-	if err != nil {
+func (c *Compiler) validateMinInt(v any, n intBound) error {
+	val, ok := toIntBound(v)
+	if !ok {
 		msg := c.translateMessage("int.type", "expected integer", []any{})
 		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntType, Msg: msg}}
 	}
-	if val < n {
-		msg := c.translateMessage("int.min", fmt.Sprintf("minimum value is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntMin, Msg: msg}}
+	if val.cmp(n) < 0 {
+		msg := c.translateMessage("int.min", fmt.Sprintf("minimum value is %d", n.value()), []any{n.value()})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntMin, Msg: msg, Param: n.value()}}
 	}
 	return nil
 }
 
-func (c *Compiler) validateMaxInt(v any, n int64) error {
-	val, err := c.toInt64(v)
-	if err != nil {
+func (c *Compiler) validateMaxInt(v any, n intBound) error {
+	val, ok := toIntBound(v)
+	if !ok {
 		msg := c.translateMessage("int.type", "expected integer", []any{})
 		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntType, Msg: msg}}
 	}
+	if val.cmp(n) > 0 {
+		msg := c.translateMessage("int.max", fmt.Sprintf("maximum value is %d", n.value()), []any{n.value()})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntMax, Msg: msg, Param: n.value()}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateUint(v any) error {
+	switch v.(type) {
+	case uint, uint8, uint16, uint32, uint64:
+		return nil
+	default:
+		return c.uintTypeError()
+	}
+}
+
+func (c *Compiler) validateUint64(v any) error {
+	switch v.(type) {
+	case uint64:
+		return nil
+	default:
+		msg := c.translateMessage("uint64.type", "expected uint64", []any{})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeUint64Type, Msg: msg}}
+	}
+}
+
+func (c *Compiler) uintTypeError() error {
+	msg := c.translateMessage("uint.type", "expected unsigned integer", []any{})
+	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeUintType, Msg: msg}}
+}
+
+func (c *Compiler) validateMinUint(v any, n uint64) error {
+	val, ok := toUint64(v)
+	if !ok {
+		return c.uintTypeError()
+	}
+	if val < n {
+		msg := c.translateMessage("uint.min", fmt.Sprintf("minimum value is %d", n), []any{n})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeUintMin, Msg: msg, Param: n}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateMaxUint(v any, n uint64) error {
+	val, ok := toUint64(v)
+	if !ok {
+		return c.uintTypeError()
+	}
 	if val > n {
-		msg := c.translateMessage("int.max", fmt.Sprintf("maximum value is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntMax, Msg: msg}}
+		msg := c.translateMessage("uint.max", fmt.Sprintf("maximum value is %d", n), []any{n})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeUintMax, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1059,7 +1549,7 @@ func (c *Compiler) validateNumberMin(v any, n float64) error {
 	}
 	if val < n {
 		msg := c.translateMessage("number.min", fmt.Sprintf("minimum value is %g", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberMin, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberMin, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1071,7 +1561,7 @@ func (c *Compiler) validateNumberMax(v any, n float64) error {
 	}
 	if val > n {
 		msg := c.translateMessage("number.max", fmt.Sprintf("maximum value is %g", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberMax, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberMax, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1081,21 +1571,27 @@ func (c *Compiler) validateNumberCompare(v any, n float64, op string) error {
 	if !ok {
 		return c.numberTypeError()
 	}
+	// Under ExactIntBounds integers compare exactly; other values compare
+	// as float64, so NaN fails every bound.
+	cmp, exact := 0, false
+	if c.behavior.ExactIntBounds {
+		cmp, exact = cmpIntFloat(v, n)
+	}
 	var pass bool
 	var code, key string
 	switch op {
 	case "gt":
-		pass, code, key = val > n, verrs.CodeNumberGreaterThan, "number.gt"
+		pass, code, key = exact && cmp > 0 || !exact && val > n, verrs.CodeNumberGreaterThan, "number.gt"
 	case "gte":
-		pass, code, key = val >= n, verrs.CodeNumberGreaterThanEqual, "number.gte"
+		pass, code, key = exact && cmp >= 0 || !exact && val >= n, verrs.CodeNumberGreaterThanEqual, "number.gte"
 	case "lt":
-		pass, code, key = val < n, verrs.CodeNumberLessThan, "number.lt"
+		pass, code, key = exact && cmp < 0 || !exact && val < n, verrs.CodeNumberLessThan, "number.lt"
 	case "lte":
-		pass, code, key = val <= n, verrs.CodeNumberLessThanEqual, "number.lte"
+		pass, code, key = exact && cmp <= 0 || !exact && val <= n, verrs.CodeNumberLessThanEqual, "number.lte"
 	}
 	if !pass {
 		msg := c.translateMessage(key, key, []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: code, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: code, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1105,9 +1601,14 @@ func (c *Compiler) validateNumberBetween(v any, min, max float64) error {
 	if !ok {
 		return c.numberTypeError()
 	}
-	if val < min || val > max {
+	outside := val < min || val > max
+	if lo, ok := cmpIntFloat(v, min); ok && c.behavior.ExactIntBounds {
+		hi, _ := cmpIntFloat(v, max)
+		outside = lo < 0 || hi > 0
+	}
+	if outside {
 		msg := c.translateMessage("number.between", fmt.Sprintf("must be between %g and %g", min, max), []any{min, max})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberBetween, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberBetween, Msg: msg, Param: []float64{min, max}}}
 	}
 	return nil
 }
@@ -1136,6 +1637,30 @@ func (c *Compiler) validateNumberNonNegative(v any) error {
 	return nil
 }
 
+func (c *Compiler) validateNumberNegative(v any) error {
+	val, ok := toNumberFloat64(v)
+	if !ok {
+		return c.numberTypeError()
+	}
+	if !(val < 0) {
+		msg := c.translateMessage("number.negative", "must be negative", nil)
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberNegative, Msg: msg}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateNumberNonPositive(v any) error {
+	val, ok := toNumberFloat64(v)
+	if !ok {
+		return c.numberTypeError()
+	}
+	if !(val <= 0) {
+		msg := c.translateMessage("number.nonpositive", "must be nonpositive", nil)
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberNonPos, Msg: msg}}
+	}
+	return nil
+}
+
 func (c *Compiler) validateNumberFinite(v any) error {
 	val, ok := toNumberFloat64(v)
 	if !ok {
@@ -1148,6 +1673,61 @@ func (c *Compiler) validateNumberFinite(v any) error {
 	return nil
 }
 
+// compileMultipleOf compiles multipleof=n: exactly for integer n, reported
+// as int.multipleOf, and within a relative tolerance of 1e-9 for float n,
+// reported as number.multipleOf, so 19.99 is a multiple of 0.01.
+func (c *Compiler) compileMultipleOf(rule Rule) compiledRule {
+	switch n := rule.Args["n"].(type) {
+	case float64, float32:
+		step, _ := toNumberFloat64(n)
+		if step <= 0 || math.IsInf(step, 0) || math.IsNaN(step) {
+			break
+		}
+		return compiledRule{validate: func(v any) error { return c.validateNumberMultipleOf(v, step) }}
+	case string:
+	default:
+		if step, ok := toIntBound(n); ok && step.cmp(intBound{}) > 0 {
+			return compiledRule{validate: func(v any) error { return c.validateIntMultipleOf(v, step) }}
+		}
+	}
+	return compiledRule{err: newCompileError(rule.Kind, fmt.Errorf("%s: %w: n must be a positive number", rule.Kind, ErrInvalidRuleArg))}
+}
+
+func (c *Compiler) validateIntMultipleOf(v any, n intBound) error {
+	val, ok := toIntBound(v)
+	if !ok {
+		msg := c.translateMessage("int.type", "expected integer", []any{})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntType, Msg: msg}}
+	}
+	var rem uint64
+	switch {
+	case !val.wide && !n.wide:
+		rem = uint64(val.i % n.i)
+	case val.wide:
+		rem = val.u % n.magnitude()
+	default:
+		rem = val.magnitude() % n.u
+	}
+	if rem != 0 {
+		msg := c.translateMessage("int.multipleOf", fmt.Sprintf("must be a multiple of %d", n.value()), []any{n.value()})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntMultipleOf, Msg: msg, Param: n.value()}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateNumberMultipleOf(v any, n float64) error {
+	val, ok := toNumberFloat64(v)
+	if !ok {
+		return c.numberTypeError()
+	}
+	q := val / n
+	if math.IsInf(q, 0) || math.IsNaN(q) || math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+		msg := c.translateMessage("number.multipleOf", fmt.Sprintf("must be a multiple of %g", n), []any{n})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberMultipleOf, Msg: msg, Param: n}}
+	}
+	return nil
+}
+
 func (c *Compiler) numberTypeError() error {
 	msg := c.translateMessage("number.type", "expected number", nil)
 	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeNumberType, Msg: msg}}
@@ -1165,7 +1745,7 @@ func (c *Compiler) validateSliceLength(v any, n int) error {
 	}
 	if rv.Len() != n {
 		msg := c.translateMessage("slice.length", fmt.Sprintf("length must be %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceLength, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceLength, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1177,7 +1757,7 @@ func (c *Compiler) validateMinSliceLength(v any, n int) error {
 	}
 	if rv.Len() < n {
 		msg := c.translateMessage("slice.min", fmt.Sprintf("minimum length is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceMin, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceMin, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1189,12 +1769,26 @@ func (c *Compiler) validateMaxSliceLength(v any, n int) error {
 	}
 	if rv.Len() > n {
 		msg := c.translateMessage("slice.max", fmt.Sprintf("maximum length is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceMax, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceMax, Msg: msg, Param: n}}
 	}
 	return nil
 }
 
 func (c *Compiler) validateForEach(v any, elemValidator ValidatorFunc) error {
+	// Common slice types are ranged over directly, avoiding reflect.Value
+	// indexing and boxing; named and other slice types use reflection.
+	switch s := v.(type) {
+	case []string:
+		return forEachSlice(s, elemValidator)
+	case []int:
+		return forEachSlice(s, elemValidator)
+	case []int64:
+		return forEachSlice(s, elemValidator)
+	case []float64:
+		return forEachSlice(s, elemValidator)
+	case []any:
+		return forEachSlice(s, elemValidator)
+	}
 	rv, err := c.sliceValue(v)
 	if err != nil {
 		return err
@@ -1202,33 +1796,51 @@ func (c *Compiler) validateForEach(v any, elemValidator ValidatorFunc) error {
 
 	var acc verrs.Errors
 	for i := 0; i < rv.Len(); i++ {
-		elem := rv.Index(i).Interface()
-		if err := elemValidator(elem); err != nil {
-			var es verrs.Errors
-			if errors.As(err, &es) {
-				// Prefix each child path with [i]
-				for _, fe := range es {
-					fe.Path = fmt.Sprintf("[%d]%s", i, fe.Path)
-					acc = append(acc, fe)
-				}
-				continue
-			}
-			// Fallback for non-structured errors
-			acc = append(acc, verrs.FieldError{
-				Path: fmt.Sprintf("[%d]", i),
-				Code: verrs.CodeUnknown,
-				Msg:  err.Error(),
-			})
-		}
+		acc = appendElemErrors(acc, i, elemValidator(rv.Index(i).Interface()))
+	}
+	if len(acc) > 0 {
+		return acc
 	}
+	return nil
+}
 
+func forEachSlice[T any](s []T, elemValidator ValidatorFunc) error {
+	var acc verrs.Errors
+	for i, elem := range s {
+		acc = appendElemErrors(acc, i, elemValidator(elem))
+	}
 	if len(acc) > 0 {
 		return acc
 	}
 	return nil
 }
 
+// appendElemErrors appends the error of element i to acc, prefixing each
+// child path with [i].
+func appendElemErrors(acc verrs.Errors, i int, err error) verrs.Errors {
+	if err == nil {
+		return acc
+	}
+	var es verrs.Errors
+	if errors.As(err, &es) {
+		for _, fe := range es {
+			fe.Path = fmt.Sprintf("[%d]%s", i, fe.Path)
+			acc = append(acc, fe)
+		}
+		return acc
+	}
+	// Fallback for non-structured errors
+	return append(acc, verrs.FieldError{
+		Path: fmt.Sprintf("[%d]", i),
+		Code: verrs.CodeUnknown,
+		Msg:  err.Error(),
+	})
+}
+
 func (c *Compiler) sliceValue(v any) (reflect.Value, error) {
+	if v == nil && c.nilAsEmpty {
+		return reflect.ValueOf([]any{}), nil
+	}
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() || rv.Kind() != reflect.Slice {
 		return reflect.Value{}, c.sliceTypeError()
@@ -1241,31 +1853,92 @@ func (c *Compiler) sliceTypeError() error {
 	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceType, Msg: msg}}
 }
 
-func (c *Compiler) validateSliceUnique(v any) error {
-	rv := reflect.ValueOf(v)
-	if !rv.IsValid() || rv.Kind() != reflect.Slice {
-		msg := c.translateMessage("slice.type", "expected slice", []any{})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceType, Msg: msg}}
+func (c *Compiler) validateSliceUnique(v any, field string) error {
+	rv, err := c.sliceValue(v)
+	if err != nil {
+		return err
+	}
+	dup, ok := hasDuplicate(rv, field)
+	if !ok {
+		msg := c.translateMessage("slice.unique.field", fmt.Sprintf("expected elements with field %s", field), []any{field})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceType, Msg: msg, Param: field}}
+	}
+	if dup {
+		return c.sliceUniqueError()
 	}
+	return nil
+}
+
+// hasDuplicate reports whether two elements of the slice or array rv are
+// equal. With a field path, elements are compared by that field and
+// elements that are nil along the path are skipped; ok is false when an
+// element is not a struct with the exported field. Incomparable values are
+// compared by their %#v representation.
+func hasDuplicate(rv reflect.Value, field string) (dup, ok bool) {
 	seenComparable := map[any]struct{}{}
 	seenFallback := map[string]struct{}{}
 	for i := 0; i < rv.Len(); i++ {
-		elem := rv.Index(i).Interface()
-		var key any = elem
+		ev := rv.Index(i)
+		if field != "" {
+			if ev, ok = uniqueFieldValue(ev, field); !ok {
+				return false, false
+			}
+			if !ev.IsValid() {
+				continue
+			}
+		}
+		elem := ev.Interface()
 		if elem != nil && !reflect.TypeOf(elem).Comparable() {
 			fallback := fmt.Sprintf("%#v", elem)
 			if _, ok := seenFallback[fallback]; ok {
-				return c.sliceUniqueError()
+				return true, true
 			}
 			seenFallback[fallback] = struct{}{}
 			continue
 		}
-		if _, ok := seenComparable[key]; ok {
-			return c.sliceUniqueError()
+		if _, ok := seenComparable[elem]; ok {
+			return true, true
 		}
-		seenComparable[key] = struct{}{}
+		seenComparable[elem] = struct{}{}
 	}
-	return nil
+	return false, true
+}
+
+// uniqueFieldValue resolves the dotted field path on v through pointers
+// and interfaces. It returns the zero Value and true when a nil is met on
+// the way, and false when a step is not an exported struct field.
+func uniqueFieldValue(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		if v = indirectNonNil(v); !v.IsValid() {
+			return v, true
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		sf, found := v.Type().FieldByName(name)
+		if !found || !sf.IsExported() {
+			return reflect.Value{}, false
+		}
+		fv, err := v.FieldByIndexErr(sf.Index)
+		if err != nil {
+			// A nil embedded pointer holds the promoted field.
+			return reflect.Value{}, true
+		}
+		v = fv
+	}
+	return indirectNonNil(v), true
+}
+
+// indirectNonNil dereferences pointers and interfaces, returning the zero
+// Value for nil.
+func indirectNonNil(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
 }
 
 func (c *Compiler) sliceUniqueError() error {
@@ -1274,10 +1947,9 @@ func (c *Compiler) sliceUniqueError() error {
 }
 
 func (c *Compiler) validateSliceContains(v any, want any) error {
-	rv := reflect.ValueOf(v)
-	if !rv.IsValid() || rv.Kind() != reflect.Slice {
-		msg := c.translateMessage("slice.type", "expected slice", []any{})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceType, Msg: msg}}
+	rv, err := c.sliceValue(v)
+	if err != nil {
+		return err
 	}
 	for i := 0; i < rv.Len(); i++ {
 		elem := rv.Index(i).Interface()
@@ -1286,7 +1958,7 @@ func (c *Compiler) validateSliceContains(v any, want any) error {
 		}
 	}
 	msg := c.translateMessage("slice.contains", "must contain required element", nil)
-	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceContains, Msg: msg}}
+	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeSliceContains, Msg: msg, Param: want}}
 }
 
 func (c *Compiler) validateArray(v any) error {
@@ -1301,7 +1973,7 @@ func (c *Compiler) validateArrayLength(v any, n int) error {
 	}
 	if rv.Len() != n {
 		msg := c.translateMessage("array.length", fmt.Sprintf("length must be %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayLength, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayLength, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1313,7 +1985,7 @@ func (c *Compiler) validateMinArrayLength(v any, n int) error {
 	}
 	if rv.Len() < n {
 		msg := c.translateMessage("array.min", fmt.Sprintf("minimum length is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayMin, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayMin, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1325,7 +1997,7 @@ func (c *Compiler) validateMaxArrayLength(v any, n int) error {
 	}
 	if rv.Len() > n {
 		msg := c.translateMessage("array.max", fmt.Sprintf("maximum length is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayMax, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayMax, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1338,22 +2010,7 @@ func (c *Compiler) validateArrayForEach(v any, elemValidator ValidatorFunc) erro
 
 	var acc verrs.Errors
 	for i := 0; i < rv.Len(); i++ {
-		elem := rv.Index(i).Interface()
-		if err := elemValidator(elem); err != nil {
-			var es verrs.Errors
-			if errors.As(err, &es) {
-				for _, fe := range es {
-					fe.Path = fmt.Sprintf("[%d]%s", i, fe.Path)
-					acc = append(acc, fe)
-				}
-				continue
-			}
-			acc = append(acc, verrs.FieldError{
-				Path: fmt.Sprintf("[%d]", i),
-				Code: verrs.CodeUnknown,
-				Msg:  err.Error(),
-			})
-		}
+		acc = appendElemErrors(acc, i, elemValidator(rv.Index(i).Interface()))
 	}
 	if len(acc) > 0 {
 		return acc
@@ -1374,28 +2031,18 @@ func (c *Compiler) arrayTypeError() error {
 	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayType, Msg: msg}}
 }
 
-func (c *Compiler) validateArrayUnique(v any) error {
+func (c *Compiler) validateArrayUnique(v any, field string) error {
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() || rv.Kind() != reflect.Array {
 		return c.arrayTypeError()
 	}
-	seenComparable := map[any]struct{}{}
-	seenFallback := map[string]struct{}{}
-	for i := 0; i < rv.Len(); i++ {
-		elem := rv.Index(i).Interface()
-		var key any = elem
-		if elem != nil && !reflect.TypeOf(elem).Comparable() {
-			fallback := fmt.Sprintf("%#v", elem)
-			if _, ok := seenFallback[fallback]; ok {
-				return c.arrayUniqueError()
-			}
-			seenFallback[fallback] = struct{}{}
-			continue
-		}
-		if _, ok := seenComparable[key]; ok {
-			return c.arrayUniqueError()
-		}
-		seenComparable[key] = struct{}{}
+	dup, ok := hasDuplicate(rv, field)
+	if !ok {
+		msg := c.translateMessage("array.unique.field", fmt.Sprintf("expected elements with field %s", field), []any{field})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayType, Msg: msg, Param: field}}
+	}
+	if dup {
+		return c.arrayUniqueError()
 	}
 	return nil
 }
@@ -1417,16 +2064,12 @@ func (c *Compiler) validateArrayContains(v any, want any) error {
 		}
 	}
 	msg := c.translateMessage("array.contains", "must contain required element", nil)
-	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayContains, Msg: msg}}
+	return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeArrayContains, Msg: msg, Param: want}}
 }
 
 func (c *Compiler) validateMap(v any) error {
-	rv := reflect.ValueOf(v)
-	if !rv.IsValid() || rv.Kind() != reflect.Map {
-		msg := c.translateMessage("map.type", "expected map", nil)
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapType, Msg: msg}}
-	}
-	return nil
+	_, err := c.mapValue(v)
+	return err
 }
 
 func (c *Compiler) validateMapLength(v any, n int) error {
@@ -1436,7 +2079,7 @@ func (c *Compiler) validateMapLength(v any, n int) error {
 	}
 	if rv.Len() != n {
 		msg := c.translateMessage("map.length", fmt.Sprintf("length must be %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapLength, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapLength, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1448,7 +2091,7 @@ func (c *Compiler) validateMinMapKeys(v any, n int) error {
 	}
 	if rv.Len() < n {
 		msg := c.translateMessage("map.minkeys", fmt.Sprintf("minimum key count is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapMinKeys, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapMinKeys, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1460,7 +2103,7 @@ func (c *Compiler) validateMaxMapKeys(v any, n int) error {
 	}
 	if rv.Len() > n {
 		msg := c.translateMessage("map.maxkeys", fmt.Sprintf("maximum key count is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapMaxKeys, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeMapMaxKeys, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1514,6 +2157,9 @@ func (c *Compiler) validateMapItems(rv reflect.Value, validator ValidatorFunc, k
 }
 
 func (c *Compiler) mapValue(v any) (reflect.Value, error) {
+	if v == nil && c.nilAsEmpty {
+		return reflect.ValueOf(map[string]any{}), nil
+	}
 	rv := reflect.ValueOf(v)
 	if !rv.IsValid() || rv.Kind() != reflect.Map {
 		msg := c.translateMessage("map.type", "expected map", nil)
@@ -1522,6 +2168,23 @@ func (c *Compiler) mapValue(v any) (reflect.Value, error) {
 	return rv, nil
 }
 
+// parseBoolString coerces "true", "false", "1" and "0" to bools for chains
+// with bool;parse. Empty strings are left for omitempty and required.
+func (c *Compiler) parseBoolString(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return v, nil
+	}
+	switch s {
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	}
+	msg := c.translateMessage("bool.parse", "expected true, false, 1 or 0", nil)
+	return nil, verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeBoolParse, Msg: msg}}
+}
+
 func (c *Compiler) validateBool(v any) error {
 	if _, ok := v.(bool); !ok {
 		msg := c.translateMessage("bool.type", "expected boolean", []any{})
@@ -1569,38 +2232,70 @@ func (c *Compiler) validateTimeNotZero(v any) error {
 	return nil
 }
 
-func (c *Compiler) validateTimeBefore(v any, target time.Time) error {
+// validateTimeBefore checks v against bound, a time.Time or a RelativeTime
+// resolved against the current time on every call, like the other bounds.
+func (c *Compiler) validateTimeBefore(v any, bound any) error {
 	t, ok := v.(time.Time)
 	if !ok {
 		return c.validateTime(v)
 	}
+	target := resolveTimeBound(bound, time.Now())
 	if !t.Before(target) {
 		msg := c.translateMessage("time.before", fmt.Sprintf("must be before %s", target.Format(time.RFC3339Nano)), []any{target.Format(time.RFC3339Nano)})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeBefore, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeBefore, Msg: msg, Param: target}}
 	}
 	return nil
 }
 
-func (c *Compiler) validateTimeAfter(v any, target time.Time) error {
+func (c *Compiler) validateTimeAfter(v any, bound any) error {
 	t, ok := v.(time.Time)
 	if !ok {
 		return c.validateTime(v)
 	}
+	target := resolveTimeBound(bound, time.Now())
 	if !t.After(target) {
 		msg := c.translateMessage("time.after", fmt.Sprintf("must be after %s", target.Format(time.RFC3339Nano)), []any{target.Format(time.RFC3339Nano)})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeAfter, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeAfter, Msg: msg, Param: target}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateTimeMin(v any, bound any) error {
+	t, ok := v.(time.Time)
+	if !ok {
+		return c.validateTime(v)
+	}
+	target := resolveTimeBound(bound, time.Now())
+	if t.Before(target) {
+		msg := c.translateMessage("time.min", fmt.Sprintf("must not be before %s", target.Format(time.RFC3339Nano)), []any{target.Format(time.RFC3339Nano)})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeMin, Msg: msg, Param: target}}
+	}
+	return nil
+}
+
+func (c *Compiler) validateTimeMax(v any, bound any) error {
+	t, ok := v.(time.Time)
+	if !ok {
+		return c.validateTime(v)
+	}
+	target := resolveTimeBound(bound, time.Now())
+	if t.After(target) {
+		msg := c.translateMessage("time.max", fmt.Sprintf("must not be after %s", target.Format(time.RFC3339Nano)), []any{target.Format(time.RFC3339Nano)})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeMax, Msg: msg, Param: target}}
 	}
 	return nil
 }
 
-func (c *Compiler) validateTimeBetween(v any, start, end time.Time) error {
+func (c *Compiler) validateTimeBetween(v any, startBound, endBound any) error {
 	t, ok := v.(time.Time)
 	if !ok {
 		return c.validateTime(v)
 	}
+	now := time.Now()
+	start, end := resolveTimeBound(startBound, now), resolveTimeBound(endBound, now)
 	if t.Before(start) || t.After(end) {
 		msg := c.translateMessage("time.between", fmt.Sprintf("must be between %s and %s", start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano)), []any{start.Format(time.RFC3339Nano), end.Format(time.RFC3339Nano)})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeBetween, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeBetween, Msg: msg, Param: []time.Time{start, end}}}
 	}
 	return nil
 }
@@ -1622,7 +2317,7 @@ func (c *Compiler) validateMinRunes(v any, n int) error {
 	}
 	if utf8.RuneCountInString(s) < n {
 		msg := c.translateMessage("string.minRunes", fmt.Sprintf("minimum rune count is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMinRunes, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMinRunes, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -1635,7 +2330,7 @@ func (c *Compiler) validateMaxRunes(v any, n int) error {
 	}
 	if utf8.RuneCountInString(s) > n {
 		msg := c.translateMessage("string.maxRunes", fmt.Sprintf("maximum rune count is %d", n), []any{n})
-		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMaxRunes, Msg: msg}}
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringMaxRunes, Msg: msg, Param: n}}
 	}
 	return nil
 }
@@ -0,0 +1,146 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// ErrInvalidRuleArg is wrapped by the errors of the rule constructors below
+// when a parameter can never validate anything sensibly.
+var ErrInvalidRuleArg = errors.New("invalid rule argument")
+
+// Length returns the string rule `length=n`.
+func Length(n int) (Rule, error) { return lengthRule(KLength, "length", n) }
+
+// MinLength returns the string rule `min=n`.
+func MinLength(n int) (Rule, error) { return lengthRule(KMinLength, "min", n) }
+
+// MaxLength returns the string rule `max=n`.
+func MaxLength(n int) (Rule, error) { return lengthRule(KMaxLength, "max", n) }
+
+// MinRunes returns the string rule `minRunes=n`.
+func MinRunes(n int) (Rule, error) { return lengthRule(KMinRunes, "minRunes", n) }
+
+// MaxRunes returns the string rule `maxRunes=n`.
+func MaxRunes(n int) (Rule, error) { return lengthRule(KMaxRunes, "maxRunes", n) }
+
+func lengthRule(kind Kind, name string, n int) (Rule, error) {
+	if n < 0 {
+		return Rule{}, fmt.Errorf("%s=%d: %w: length must not be negative", name, n, ErrInvalidRuleArg)
+	}
+	return NewRule(kind, map[string]any{"n": int64(n)}), nil
+}
+
+// Regex returns the string rule `regex=pattern`. The pattern is anchored
+// like tag patterns and compiled here, so syntax errors surface at
+// construction instead of as string.regex.invalidPattern at validation
+// time. Error messages redact patterns that look like secrets.
+func Regex(pattern string) (Rule, error) {
+	if pattern == "" {
+		return Rule{}, fmt.Errorf("regex: %w: empty pattern", ErrInvalidRuleArg)
+	}
+	if _, err := regexp.Compile(normalizeRegexPattern(pattern)); err != nil {
+		return Rule{}, fmt.Errorf("regex=%s: %w: does not compile", regexPatternForMessage(pattern), ErrInvalidRuleArg)
+	}
+	return NewRule(KRegex, map[string]any{"pattern": pattern}), nil
+}
+
+// OneOf returns the string rule `oneof=values`. values must be non-empty
+// and free of duplicates; the slice is copied.
+func OneOf(values ...string) (Rule, error) {
+	if len(values) == 0 {
+		return Rule{}, fmt.Errorf("oneof: %w: no values", ErrInvalidRuleArg)
+	}
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if seen[v] {
+			return Rule{}, fmt.Errorf("oneof: %w: duplicate value %q", ErrInvalidRuleArg, truncateForError(v, 30))
+		}
+		seen[v] = true
+	}
+	return NewRule(KOneOf, map[string]any{"values": append([]string(nil), values...)}), nil
+}
+
+// MinInt returns the int rule `min=n`. Every int64 is a valid bound.
+func MinInt(n int64) Rule { return NewRule(KMinInt, map[string]any{"n": n}) }
+
+// MaxInt returns the int rule `max=n`. Every int64 is a valid bound.
+func MaxInt(n int64) Rule { return NewRule(KMaxInt, map[string]any{"n": n}) }
+
+// MultipleOf returns the int rule `multipleof=n`. n must be positive.
+func MultipleOf(n int64) (Rule, error) {
+	if n <= 0 {
+		return Rule{}, fmt.Errorf("multipleof=%d: %w: step must be positive", n, ErrInvalidRuleArg)
+	}
+	return NewRule(KMultipleOf, map[string]any{"n": n}), nil
+}
+
+// MultipleOfFloat returns the float rule `multipleof=n`. n must be finite
+// and positive.
+func MultipleOfFloat(n float64) (Rule, error) {
+	if math.IsNaN(n) || math.IsInf(n, 0) || n <= 0 {
+		return Rule{}, fmt.Errorf("multipleof=%v: %w: step must be finite and positive", n, ErrInvalidRuleArg)
+	}
+	return NewRule(KMultipleOf, map[string]any{"n": n}), nil
+}
+
+// MinUint returns the uint rule `min=n`. Every uint64 is a valid bound.
+func MinUint(n uint64) Rule { return NewRule(KMinUint, map[string]any{"n": n}) }
+
+// MaxUint returns the uint rule `max=n`. Every uint64 is a valid bound.
+func MaxUint(n uint64) Rule { return NewRule(KMaxUint, map[string]any{"n": n}) }
+
+// MinNumber returns the float rule `min=n`. n must be finite.
+func MinNumber(n float64) (Rule, error) { return numberRule(KMinNumber, "min", n) }
+
+// MaxNumber returns the float rule `max=n`. n must be finite.
+func MaxNumber(n float64) (Rule, error) { return numberRule(KMaxNumber, "max", n) }
+
+func numberRule(kind Kind, name string, n float64) (Rule, error) {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return Rule{}, fmt.Errorf("%s=%v: %w: bound must be finite", name, n, ErrInvalidRuleArg)
+	}
+	return NewRule(kind, map[string]any{"n": n}), nil
+}
+
+// Between returns the number rule `between=min,max`. Both bounds must be
+// finite and min must not exceed max.
+func Between(min, max float64) (Rule, error) {
+	for _, n := range []float64{min, max} {
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return Rule{}, fmt.Errorf("between=%v,%v: %w: bounds must be finite", min, max, ErrInvalidRuleArg)
+		}
+	}
+	if min > max {
+		return Rule{}, fmt.Errorf("between=%v,%v: %w: min exceeds max", min, max, ErrInvalidRuleArg)
+	}
+	return NewRule(KBetween, map[string]any{"min": min, "max": max}), nil
+}
+
+// MinValue returns the string rule `min_value=n`, a lower bound for numstr
+// strings. n must be a plain decimal number such as "0" or "-2.5".
+func MinValue(n string) (Rule, error) { return numStrRule(KNumStrMin, "min_value", n) }
+
+// MaxValue returns the string rule `max_value=n`, an upper bound for numstr
+// strings.
+func MaxValue(n string) (Rule, error) { return numStrRule(KNumStrMax, "max_value", n) }
+
+func numStrRule(kind Kind, name, n string) (Rule, error) {
+	if _, ok := parseDecimal(n); !ok {
+		return Rule{}, fmt.Errorf("%s=%s: %w: not a decimal number", name, truncateForError(n, 30), ErrInvalidRuleArg)
+	}
+	return NewRule(kind, map[string]any{"value": n}), nil
+}
+
+// Must returns r, panicking if err is non-nil. It simplifies rules held in
+// package-level variables:
+//
+//	var sku = types.Must(types.Regex(`[A-Z]{3}-\d{4}`))
+func Must(r Rule, err error) Rule {
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRuleConstructors_MatchParsedRules(t *testing.T) {
+	tests := []struct {
+		tag  string
+		rule Rule
+	}{
+		{"string;length=5", Must(Length(5))},
+		{"string;min=3", Must(MinLength(3))},
+		{"string;max=0", Must(MaxLength(0))},
+		{"string;minRunes=2", Must(MinRunes(2))},
+		{"string;maxRunes=9", Must(MaxRunes(9))},
+		{"string;regex=[a-z]+", Must(Regex("[a-z]+"))},
+		{"string;oneof=red,green", Must(OneOf("red", "green"))},
+		{"int;min=-4", MinInt(-4)},
+		{"int;max=4", MaxInt(4)},
+		{"float;min=0.5", Must(MinNumber(0.5))},
+		{"float;max=2.5", Must(MaxNumber(2.5))},
+		{"float;between=1,2", Must(Between(1, 2))},
+		{"string;min_value=-1.5", Must(MinValue("-1.5"))},
+		{"string;max_value=100", Must(MaxValue("100"))},
+		{"int;multipleof=10", Must(MultipleOf(10))},
+		{"float;multipleof=0.25", Must(MultipleOfFloat(0.25))},
+		{"uint;min=2", MinUint(2)},
+	}
+	for _, tt := range tests {
+		parsed, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		if !reflect.DeepEqual(tt.rule, parsed[1]) {
+			t.Errorf("%s: constructor = %#v, want %#v", tt.tag, tt.rule, parsed[1])
+		}
+	}
+}
+
+func TestRuleConstructors_RejectInvalidArgs(t *testing.T) {
+	for name, fn := range map[string]func() (Rule, error){
+		"negative length": func() (Rule, error) { return MinLength(-1) },
+		"empty regex":     func() (Rule, error) { return Regex("") },
+		"bad regex":       func() (Rule, error) { return Regex("[a-") },
+		"empty oneof":     func() (Rule, error) { return OneOf() },
+		"duplicate oneof": func() (Rule, error) { return OneOf("a", "b", "a") },
+		"NaN bound":       func() (Rule, error) { return MaxNumber(math.NaN()) },
+		"infinite bound":  func() (Rule, error) { return Between(0, math.Inf(1)) },
+		"reversed bounds": func() (Rule, error) { return Between(2, 1) },
+		"non-decimal":     func() (Rule, error) { return MinValue("1e3") },
+		"zero step":       func() (Rule, error) { return MultipleOf(0) },
+		"negative step":   func() (Rule, error) { return MultipleOfFloat(-0.5) },
+	} {
+		if _, err := fn(); !errors.Is(err, ErrInvalidRuleArg) {
+			t.Errorf("%s: err = %v, want ErrInvalidRuleArg", name, err)
+		}
+	}
+
+	if _, err := Regex("(secret_token"); err == nil || strings.Contains(err.Error(), "secret_token") {
+		t.Fatalf("regex error leaked pattern: %v", err)
+	}
+
+	values := []string{"a", "b"}
+	r := Must(OneOf(values...))
+	values[0] = "z"
+	if got := r.Args["values"].([]string); got[0] != "a" {
+		t.Fatalf("OneOf kept caller slice: %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Must did not panic")
+		}
+	}()
+	Must(Length(-1))
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"reflect"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// ConverterFunc adapts a value of a custom type to a value the built-in rules
+// of a kind understand, e.g. null.String to string. Returning nil lets
+// omitempty and required treat the value as absent.
+type ConverterFunc func(any) (any, error)
+
+// RegisterConverter registers fn to convert values whose dynamic type is
+// from before rule chains of kind to run. A chain uses the converters of
+// the first rule kind in it that has any registered.
+func (c *Compiler) RegisterConverter(from reflect.Type, to Kind, fn ConverterFunc) {
+	if c.converters == nil {
+		c.converters = map[Kind]map[reflect.Type]ConverterFunc{}
+	}
+	if c.converters[to] == nil {
+		c.converters[to] = map[reflect.Type]ConverterFunc{}
+	}
+	c.converters[to][from] = fn
+}
+
+// converterFor returns the conversion step for a rule chain, or nil when no
+// converter applies.
+func (c *Compiler) converterFor(rules []Rule) func(any) (any, error) {
+	for _, rule := range rules {
+		convs, ok := c.converters[rule.Kind]
+		if !ok || len(convs) == 0 {
+			continue
+		}
+		return func(v any) (any, error) {
+			if v == nil {
+				return nil, nil
+			}
+			fn, ok := convs[reflect.TypeOf(v)]
+			if !ok {
+				return v, nil
+			}
+			out, err := fn(v)
+			if err != nil {
+				return nil, verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeUnknown, Msg: "conversion failed: " + err.Error()}}
+			}
+			return out, nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,457 @@
+package types
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CounterValues returns candidate values that are likely to break rule
+// while keeping valid's type, starting from the valid value. Callers pick
+// the candidate that violates only rule by checking each rule separately;
+// no candidate is guaranteed to isolate it.
+//
+// Parameters:
+//   - rule: The rule to violate.
+//   - valid: A value that satisfies the rule chain, e.g. from ExampleValue.
+//
+// Returns:
+//   - []any: Candidates of valid's type; candidates that cannot be
+//     represented in that type are dropped.
+func CounterValues(rule Rule, valid any) []any {
+	v := reflect.ValueOf(valid)
+	if !v.IsValid() {
+		return nil
+	}
+	var out []any
+	for _, c := range counterCandidates(rule, v) {
+		if !c.IsValid() {
+			continue
+		}
+		if c.Type() != v.Type() {
+			if !c.Type().ConvertibleTo(v.Type()) || !sameKindFamily(c.Type(), v.Type()) {
+				continue
+			}
+			c = c.Convert(v.Type())
+		}
+		out = append(out, c.Interface())
+	}
+	return out
+}
+
+// sameKindFamily rejects conversions that change meaning, such as int to
+// string.
+func sameKindFamily(a, b reflect.Type) bool {
+	family := func(k reflect.Kind) int {
+		switch {
+		case k == reflect.String:
+			return 1
+		case k >= reflect.Int && k <= reflect.Uintptr:
+			return 2
+		case k == reflect.Float32 || k == reflect.Float64:
+			return 3
+		}
+		return int(k) + 10
+	}
+	return family(a.Kind()) == family(b.Kind())
+}
+
+func counterCandidates(rule Rule, v reflect.Value) []reflect.Value {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		var out []reflect.Value
+		for _, c := range counterCandidates(rule, v.Elem()) {
+			if !c.IsValid() || !c.Type().ConvertibleTo(v.Type().Elem()) {
+				continue
+			}
+			p := reflect.New(v.Type().Elem())
+			p.Elem().Set(c.Convert(v.Type().Elem()))
+			out = append(out, p)
+		}
+		if rule.Kind == KRequired {
+			out = append(out, reflect.Zero(v.Type()))
+		}
+		return out
+	}
+
+	of := func(xs ...any) []reflect.Value {
+		out := make([]reflect.Value, 0, len(xs))
+		for _, x := range xs {
+			out = append(out, reflect.ValueOf(x))
+		}
+		return out
+	}
+	zero := reflect.Zero(v.Type())
+	if rule.Kind == KRequired {
+		return []reflect.Value{zero}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		if out := counterTimeStrings(rule, v.String(), of); out != nil {
+			return out
+		}
+		return counterStrings(rule, v.String(), of)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return counterInts(rule, v)
+	case reflect.Float32, reflect.Float64:
+		return counterFloats(rule, v.Float(), of)
+	case reflect.Bool:
+		return of(!v.Bool())
+	case reflect.Slice, reflect.Array:
+		return counterList(rule, v)
+	case reflect.Map:
+		return counterMap(rule, v)
+	}
+	if at, ok := v.Interface().(time.Time); ok {
+		return counterTimes(rule, at, of)
+	}
+	return []reflect.Value{zero}
+}
+
+func counterStrings(rule Rule, s string, of func(...any) []reflect.Value) []reflect.Value {
+	pad := func(n int) string {
+		if n <= len(s) {
+			return s
+		}
+		return s + strings.Repeat("a", n-len(s))
+	}
+	cut := func(n int) string {
+		if n < 0 {
+			n = 0
+		}
+		if n >= len(s) {
+			return s
+		}
+		return s[:n]
+	}
+	n := argInt(rule, "n")
+	switch rule.Kind {
+	case KLength:
+		return of(pad(n+1), cut(n-1))
+	case KMinLength:
+		return of(cut(n - 1))
+	case KMaxLength:
+		return of(pad(n + 1))
+	case KMinRunes:
+		return of(string([]rune(s)[:max(0, min(n-1, len([]rune(s))))]))
+	case KMaxRunes:
+		return of(s + strings.Repeat("é", max(1, n+1-len([]rune(s)))))
+	case KNonEmpty:
+		return of("")
+	case KOneOf:
+		return of(s+"x", "x"+s, "")
+	case KContains:
+		return of(strings.ReplaceAll(s, argString(rule, "value"), ""))
+	case KNotContains:
+		return of(s+argString(rule, "value"), argString(rule, "value")+s)
+	case KExcludesAll:
+		var out []any
+		for _, r := range argString(rule, "value") {
+			out = append(out, s+string(r))
+		}
+		return of(out...)
+	case KPrefix:
+		return of("x"+strings.TrimPrefix(s, argString(rule, "value")), "x"+s)
+	case KSuffix:
+		return of(strings.TrimSuffix(s, argString(rule, "value"))+"x", s+"x")
+	case KASCII:
+		return of(s+"é", cut(len(s)-1)+"é")
+	case KAlpha:
+		return of(s+"1", cut(len(s)-1)+"1")
+	case KAlnum:
+		return of(s+"-", cut(len(s)-1)+"-")
+	case KAlphaUni:
+		return of(s+"1", cut(len(s)-1)+"1")
+	case KAlnumUni:
+		return of(s+"-", cut(len(s)-1)+"-")
+	case KNumeric:
+		return of(s+"a", cut(len(s)-1)+"a")
+	case KPrintASCII:
+		return of(s+"\t", cut(len(s)-1)+"\t")
+	case KNumStr:
+		return of(s+"x", "1e3", "")
+	case KNumStrMin:
+		return of(decimalStep(argString(rule, "value"), -1))
+	case KNumStrMax:
+		return of(decimalStep(argString(rule, "value"), 1))
+	}
+	// Patterns and format rules: small edits of the valid value first so
+	// length rules keep passing.
+	return of(s+"!", "!"+s, cut(len(s)-1)+"!", s+" ", "not valid", "")
+}
+
+// decimalStep adds delta to the decimal string bound, keeping its number of
+// fraction digits.
+func decimalStep(bound string, delta int64) string {
+	r, ok := parseDecimal(bound)
+	if !ok {
+		return "x"
+	}
+	_, frac, _ := strings.Cut(bound, ".")
+	return r.Add(r, big.NewRat(delta, 1)).FloatString(len(frac))
+}
+
+func counterInts(rule Rule, v reflect.Value) []reflect.Value {
+	var xs []float64
+	n, _ := argNumber(rule, "n")
+	switch rule.Kind {
+	case KMinInt, KMinUint, KGreaterThanEqual:
+		xs = []float64{math.Ceil(n) - 1}
+	case KMaxInt, KMaxUint, KLessThanEqual:
+		xs = []float64{math.Floor(n) + 1}
+	case KGreaterThan:
+		xs = []float64{math.Floor(n)}
+	case KLessThan:
+		xs = []float64{math.Ceil(n)}
+	case KBetween:
+		lo, _ := argNumber(rule, "min")
+		hi, _ := argNumber(rule, "max")
+		xs = []float64{math.Ceil(lo) - 1, math.Floor(hi) + 1}
+	case KPositive:
+		xs = []float64{0, -1}
+	case KNonNegative:
+		xs = []float64{-1}
+	case KNegative:
+		xs = []float64{0, 1}
+	case KNonPositive:
+		xs = []float64{1}
+	case KMultipleOf:
+		if n > 1 {
+			xs = []float64{n + 1}
+		}
+	case KEnum:
+		if values, _ := enumRuleValues(rule); len(values) > 0 {
+			xs = []float64{float64(values[len(values)-1]) + 1, float64(values[0]) - 1}
+		}
+	default:
+		xs = []float64{0, -1}
+	}
+	var out []reflect.Value
+	for _, x := range xs {
+		c := reflect.New(v.Type()).Elem()
+		switch {
+		case v.Kind() >= reflect.Uint:
+			if x < 0 || x > math.MaxUint64 || c.OverflowUint(uint64(x)) {
+				continue
+			}
+			c.SetUint(uint64(x))
+		default:
+			if x < math.MinInt64 || x >= math.MaxInt64 || c.OverflowInt(int64(x)) {
+				continue
+			}
+			c.SetInt(int64(x))
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func counterFloats(rule Rule, f float64, of func(...any) []reflect.Value) []reflect.Value {
+	n, _ := argNumber(rule, "n")
+	switch rule.Kind {
+	case KMinNumber, KGreaterThanEqual:
+		return of(n-1, math.Nextafter(n, math.Inf(-1)))
+	case KMaxNumber, KLessThanEqual:
+		return of(n+1, math.Nextafter(n, math.Inf(1)))
+	case KGreaterThan:
+		return of(n, n-1)
+	case KLessThan:
+		return of(n, n+1)
+	case KBetween:
+		lo, _ := argNumber(rule, "min")
+		hi, _ := argNumber(rule, "max")
+		return of(lo-1, hi+1)
+	case KPositive:
+		return of(0.0, -1.0)
+	case KNonNegative:
+		return of(-1.0)
+	case KNegative:
+		return of(0.0, 1.0)
+	case KNonPositive:
+		return of(1.0)
+	case KFinite:
+		return of(math.NaN(), math.Inf(1), math.Inf(-1))
+	case KMultipleOf:
+		return of(n * 1.5)
+	}
+	return of(0.0, -f, math.NaN())
+}
+
+func counterTimes(rule Rule, at time.Time, of func(...any) []reflect.Value) []reflect.Value {
+	now := time.Now()
+	bound := func(key string) time.Time {
+		return resolveTimeBound(rule.Args[key], now)
+	}
+	switch rule.Kind {
+	case KTimeNotZero:
+		return of(time.Time{})
+	case KTimeBefore:
+		return of(bound("time"), bound("time").Add(time.Hour))
+	case KTimeAfter:
+		return of(bound("time"), bound("time").Add(-time.Hour))
+	case KTimeMin:
+		return of(bound("time").Add(-time.Hour))
+	case KTimeMax:
+		return of(bound("time").Add(time.Hour))
+	case KTimeBetween:
+		return of(bound("start").Add(-time.Hour), bound("end").Add(time.Hour))
+	}
+	return of(time.Time{}, at.AddDate(100, 0, 0), at.AddDate(-100, 0, 0))
+}
+
+// counterTimeStrings returns counterTimes formatted like s for time rules
+// on string fields holding dates, or nil when s is not such a date.
+func counterTimeStrings(rule Rule, s string, of func(...any) []reflect.Value) []reflect.Value {
+	switch rule.Kind {
+	case KTimeNotZero, KTimeBefore, KTimeAfter, KTimeMin, KTimeMax, KTimeBetween:
+	default:
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, DateLayout} {
+		at, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		var out []reflect.Value
+		for _, c := range counterTimes(rule, at, of) {
+			out = append(out, reflect.ValueOf(c.Interface().(time.Time).Format(layout)))
+		}
+		return out
+	}
+	return nil
+}
+
+func counterList(rule Rule, v reflect.Value) []reflect.Value {
+	n := v.Len()
+	resized := func(size int) reflect.Value {
+		if v.Kind() == reflect.Array || size < 0 {
+			return reflect.Value{}
+		}
+		out := reflect.MakeSlice(v.Type(), size, size)
+		for i := 0; i < size && n > 0; i++ {
+			out.Index(i).Set(v.Index(i % n))
+		}
+		return out
+	}
+	withFirst := func(elem reflect.Value) reflect.Value {
+		out := reflect.New(v.Type()).Elem()
+		if v.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(v.Type(), n, n)
+		}
+		reflect.Copy(out, v)
+		out.Index(0).Set(elem)
+		return out
+	}
+	size := argInt(rule, "n")
+	switch rule.Kind {
+	case KSliceLength:
+		return []reflect.Value{resized(size + 1), resized(size - 1)}
+	case KMinSliceLength:
+		return []reflect.Value{resized(size - 1)}
+	case KMaxSliceLength:
+		return []reflect.Value{resized(size + 1)}
+	case KSliceUnique, KArrayUnique:
+		if n > 1 {
+			return []reflect.Value{withFirst(v.Index(1))}
+		}
+		return []reflect.Value{resized(2)}
+	case KSliceContains, KArrayContains:
+		want := argString(rule, "value")
+		var out []reflect.Value
+		if n > 0 {
+			for _, c := range counterStrings(Rule{Kind: KOneOf}, want, func(xs ...any) []reflect.Value {
+				vs := make([]reflect.Value, len(xs))
+				for i, x := range xs {
+					vs[i] = reflect.ValueOf(x)
+				}
+				return vs
+			}) {
+				if c.Type().ConvertibleTo(v.Type().Elem()) && sameKindFamily(c.Type(), v.Type().Elem()) {
+					out = append(out, withFirst(c.Convert(v.Type().Elem())))
+				}
+			}
+		}
+		return out
+	case KForEach, KArrayForEach:
+		return nestedCounters(rule, v, n, withFirst)
+	}
+	return []reflect.Value{resized(0)}
+}
+
+// nestedCounters breaks the first element with each nested rule in turn.
+func nestedCounters(rule Rule, v reflect.Value, n int, withFirst func(reflect.Value) reflect.Value) []reflect.Value {
+	nested, _ := rule.Args["rules"].([]Rule)
+	if n == 0 {
+		return nil
+	}
+	var out []reflect.Value
+	for _, r := range nested {
+		for _, c := range counterCandidates(r, v.Index(0)) {
+			if c.IsValid() && c.Type().ConvertibleTo(v.Type().Elem()) && sameKindFamily(c.Type(), v.Type().Elem()) {
+				out = append(out, withFirst(c.Convert(v.Type().Elem())))
+			}
+		}
+	}
+	return out
+}
+
+func counterMap(rule Rule, v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	copyMap := func(size int) reflect.Value {
+		out := reflect.MakeMapWithSize(v.Type(), size)
+		for i := 0; i < size && i < len(keys); i++ {
+			out.SetMapIndex(keys[i], v.MapIndex(keys[i]))
+		}
+		return out
+	}
+	size := argInt(rule, "n")
+	switch rule.Kind {
+	case KMapLength:
+		return []reflect.Value{copyMap(size - 1)}
+	case KMinMapKeys:
+		return []reflect.Value{copyMap(size - 1)}
+	case KMaxMapKeys:
+		if len(keys) == 0 {
+			return nil
+		}
+		m := copyMap(len(keys))
+		for i := 1; m.Len() <= size && i <= size+64; i++ {
+			m.SetMapIndex(varyExample(keys[0], i), v.MapIndex(keys[0]))
+		}
+		return []reflect.Value{m}
+	case KMapKeys, KMapValues:
+		if len(keys) == 0 {
+			return nil
+		}
+		nested, _ := rule.Args["rules"].([]Rule)
+		var out []reflect.Value
+		for _, r := range nested {
+			if rule.Kind == KMapValues {
+				for _, c := range counterCandidates(r, v.MapIndex(keys[0])) {
+					if c.IsValid() && c.Type().ConvertibleTo(v.Type().Elem()) && sameKindFamily(c.Type(), v.Type().Elem()) {
+						m := copyMap(len(keys))
+						m.SetMapIndex(keys[0], c.Convert(v.Type().Elem()))
+						out = append(out, m)
+					}
+				}
+				continue
+			}
+			for _, c := range counterCandidates(r, keys[0]) {
+				if c.IsValid() && c.Type().ConvertibleTo(v.Type().Key()) && sameKindFamily(c.Type(), v.Type().Key()) {
+					m := copyMap(len(keys))
+					m.SetMapIndex(keys[0], reflect.Value{})
+					m.SetMapIndex(c.Convert(v.Type().Key()), v.MapIndex(keys[0]))
+					out = append(out, m)
+				}
+			}
+		}
+		return out
+	}
+	return []reflect.Value{reflect.Zero(v.Type())}
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCounterValues_BreakRule(t *testing.T) {
+	c := NewCompiler(nil)
+	tests := []struct {
+		name  string
+		rules []Rule
+		valid any
+	}{
+		{"min length", []Rule{NewRule(KString, nil), NewRule(KMinLength, map[string]any{"n": int64(3)})}, "abcd"},
+		{"max length", []Rule{NewRule(KString, nil), NewRule(KMaxLength, map[string]any{"n": int64(5)})}, "abc"},
+		{"int min", []Rule{NewRule(KInt, nil), NewRule(KMinInt, map[string]any{"n": int64(10)})}, 12},
+		{"slice min", []Rule{NewRule(KSlice, nil), NewRule(KMinSliceLength, map[string]any{"n": int64(2)})}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := c.CompileContext(tt.rules)
+			if err := check(context.Background(), tt.valid); err != nil {
+				t.Fatalf("valid value rejected: %v", err)
+			}
+			broken := false
+			for _, v := range CounterValues(tt.rules[len(tt.rules)-1], tt.valid) {
+				if check(context.Background(), v) != nil {
+					broken = true
+				}
+			}
+			if !broken {
+				t.Fatalf("no counter value breaks %s", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package types
+
+import (
+	"sort"
+	"sync"
+)
+
+// KindDoc documents a rule kind for doc generators, linters and help text.
+//
+// Fields:
+//   - Kind: The documented rule kind.
+//   - Summary: One-line description of what the rule checks.
+//   - Params: Rule parameters, keyed by their Args name.
+//   - Examples: Example tags using the rule.
+//   - Sample: A value that satisfies the rule, used by example generators
+//     for format rules that cannot be synthesized from parameters.
+//   - Format: OpenAPI format of values the rule accepts, e.g. "email",
+//     used by OpenAPI schema generation.
+type KindDoc struct {
+	Kind     Kind
+	Summary  string
+	Params   []ParamDoc
+	Examples []string
+	Sample   string
+	Format   string
+}
+
+// ParamDoc documents one rule parameter.
+//
+// Fields:
+//   - Name: Key in Rule.Args.
+//   - Type: Go type of the parsed value, e.g. "int64" or "string".
+//   - Description: Short description of the parameter.
+type ParamDoc struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+var (
+	kindDocs   = map[Kind]KindDoc{}
+	kindDocsMu sync.RWMutex
+)
+
+// RegisterKindDoc registers documentation for a rule kind. Plugins call it
+// from init next to RegisterRule; a later registration for the same kind
+// replaces the earlier one.
+func RegisterKindDoc(doc KindDoc) {
+	kindDocsMu.Lock()
+	defer kindDocsMu.Unlock()
+	doc.Params = append([]ParamDoc(nil), doc.Params...)
+	doc.Examples = append([]string(nil), doc.Examples...)
+	kindDocs[doc.Kind] = doc
+}
+
+// DescribeKind returns the documentation registered for kind.
+func DescribeKind(kind Kind) (KindDoc, bool) {
+	kindDocsMu.RLock()
+	defer kindDocsMu.RUnlock()
+	doc, ok := kindDocs[kind]
+	if !ok {
+		return KindDoc{}, false
+	}
+	doc.Params = append([]ParamDoc(nil), doc.Params...)
+	doc.Examples = append([]string(nil), doc.Examples...)
+	return doc, true
+}
+
+// DescribedKinds returns every documented kind in sorted order.
+func DescribedKinds() []Kind {
+	kindDocsMu.RLock()
+	defer kindDocsMu.RUnlock()
+	kinds := make([]Kind, 0, len(kindDocs))
+	for kind := range kindDocs {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+func init() {
+	n := func(desc string) []ParamDoc {
+		return []ParamDoc{{Name: "n", Type: "int", Description: desc}}
+	}
+	n64 := func(desc string) []ParamDoc {
+		return []ParamDoc{{Name: "n", Type: "int64", Description: desc}}
+	}
+	u64 := func(desc string) []ParamDoc {
+		return []ParamDoc{{Name: "n", Type: "uint64", Description: desc}}
+	}
+	f := func(desc string) []ParamDoc {
+		return []ParamDoc{{Name: "n", Type: "float64", Description: desc}}
+	}
+	value := func(desc string) []ParamDoc {
+		return []ParamDoc{{Name: "value", Type: "string", Description: desc}}
+	}
+	at := func(desc string) []ParamDoc {
+		return []ParamDoc{{Name: "time", Type: "time.Time", Description: desc + "; RelativeTime for now[+-]duration"}}
+	}
+	elem := []ParamDoc{{Name: "rules", Type: "[]Rule", Description: "nested rules for each element"}}
+	type builtinDoc struct {
+		Kind     Kind
+		Summary  string
+		Params   []ParamDoc
+		Examples []string
+	}
+	samples := map[Kind]string{
+		KURL:      "https://example.com/",
+		KHostname: "example.com",
+		KIP:       "192.0.2.1",
+		KIPv4:     "192.0.2.1",
+		KIPv6:     "2001:db8::1",
+		KCIDR:     "192.0.2.0/24",
+	}
+	formats := map[Kind]string{
+		KURL:      "uri",
+		KHostname: "hostname",
+		KIPv4:     "ipv4",
+		KIPv6:     "ipv6",
+	}
+	for _, doc := range []builtinDoc{
+		{KRequired, "Value must be non-zero and non-empty", nil, []string{"string;required"}},
+		{KOmitempty, "Skip remaining rules for zero or empty values", nil, []string{"string;omitempty;min=3"}},
+		{KUnbounded, "Opt out of the engine's default max length", nil, []string{"string;unbounded"}},
+
+		{KString, "Value must be a string", nil, []string{"string"}},
+		{KLength, "Exact byte length", n("required length"), []string{"string;len=5"}},
+		{KMinLength, "Minimum byte length", n("minimum length"), []string{"string;min=3"}},
+		{KMaxLength, "Maximum byte length", n("maximum length"), []string{"string;max=50"}},
+		{KMinRunes, "Minimum Unicode rune count", n("minimum rune count"), []string{"string;minRunes=2"}},
+		{KMaxRunes, "Maximum Unicode rune count", n("maximum rune count"), []string{"string;maxRunes=20"}},
+		{KRegex, "Full match against a regular expression", []ParamDoc{{Name: "pattern", Type: "string", Description: "RE2 pattern; anchors are added"}}, []string{"string;regex=^[a-z]+$"}},
+		{KOneOf, "Value must be one of the listed values", []ParamDoc{{Name: "values", Type: "[]string", Description: "allowed values"}}, []string{"string;oneof=red,green,blue"}},
+		{KNonEmpty, "String must not be empty", nil, []string{"string;nonempty"}},
+		{KContains, "String must contain a substring", value("required substring"), []string{"string;contains=@"}},
+		{KNotContains, "String must not contain a substring", value("prohibited substring"), []string{"string;notContains=.."}},
+		{KPrefix, "String must start with a prefix", value("required prefix"), []string{"string;prefix=sk_"}},
+		{KSuffix, "String must end with a suffix", value("required suffix"), []string{"string;suffix=.json"}},
+		{KExcludesAll, "String must not contain any of the given characters", value("prohibited characters"), []string{"string;excludesall=<>\"'"}},
+		{KURL, "Absolute URL", nil, []string{"string;url"}},
+		{KHostname, "RFC 1123 hostname", nil, []string{"string;hostname"}},
+		{KIP, "IPv4 or IPv6 address", nil, []string{"string;ip"}},
+		{KIPv4, "IPv4 address", nil, []string{"string;ipv4"}},
+		{KIPv6, "IPv6 address", nil, []string{"string;ipv6"}},
+		{KCIDR, "CIDR prefix", nil, []string{"string;cidr"}},
+		{KASCII, "ASCII characters only", nil, []string{"string;ascii"}},
+		{KAlpha, "Unicode letters only", nil, []string{"string;alpha"}},
+		{KAlnum, "Unicode letters and digits only", nil, []string{"string;alnum", "string;alphanum"}},
+		{KAlphaUni, "Unicode letters only, like alpha", nil, []string{"string;alphaunicode"}},
+		{KAlnumUni, "Unicode letters and digits only, like alnum", nil, []string{"string;alphanumunicode"}},
+		{KNumeric, "ASCII digits only", nil, []string{"string;numeric"}},
+		{KPrintASCII, "Printable ASCII characters only, space through tilde", nil, []string{"string;printascii"}},
+		{KNumStr, "Plain decimal number such as -12 or 0.25", nil, []string{"string;numstr"}},
+		{KNumStrMin, "Minimum value of a decimal number string", value("inclusive lower bound, a decimal number"), []string{"string;numstr;min_value=0"}},
+		{KNumStrMax, "Maximum value of a decimal number string", value("inclusive upper bound, a decimal number"), []string{"string;numstr;max_value=100"}},
+
+		{KInt, "Value must be an integer of any Go integer type", nil, []string{"int"}},
+		{KInt64, "Value must be an int64", nil, []string{"int64"}},
+		{KMinInt, "Minimum integer value", n64("minimum value; uint64 above the int64 range"), []string{"int;min=1"}},
+		{KMaxInt, "Maximum integer value", n64("maximum value; uint64 above the int64 range"), []string{"int;max=100"}},
+		{KUint, "Value must be an unsigned integer of any Go unsigned type", nil, []string{"uint"}},
+		{KUint64, "Value must be a uint64", nil, []string{"uint64"}},
+		{KMinUint, "Minimum unsigned integer value", u64("minimum value"), []string{"uint;min=1"}},
+		{KMaxUint, "Maximum unsigned integer value", u64("maximum value"), []string{"uint;max=18446744073709551615"}},
+		{KFloat, "Value must be a float32 or float64", nil, []string{"float"}},
+		{KMinNumber, "Minimum numeric value", f("minimum value"), []string{"float;min=0.5"}},
+		{KMaxNumber, "Maximum numeric value", f("maximum value"), []string{"float;max=9.5"}},
+		{KGreaterThan, "Value must be greater than a bound", f("exclusive lower bound"), []string{"int;gt=0"}},
+		{KGreaterThanEqual, "Value must be greater than or equal to a bound", f("inclusive lower bound"), []string{"int;gte=1"}},
+		{KLessThan, "Value must be less than a bound", f("exclusive upper bound"), []string{"float;lt=1"}},
+		{KLessThanEqual, "Value must be less than or equal to a bound", f("inclusive upper bound"), []string{"float;lte=1"}},
+		{KBetween, "Value must lie within an inclusive range", []ParamDoc{
+			{Name: "min", Type: "float64", Description: "inclusive lower bound"},
+			{Name: "max", Type: "float64", Description: "inclusive upper bound"},
+		}, []string{"int;between=1,10"}},
+		{KPositive, "Value must be greater than zero", nil, []string{"int;positive"}},
+		{KNonNegative, "Value must be zero or greater", nil, []string{"float;nonnegative"}},
+		{KNegative, "Value must be less than zero", nil, []string{"float;negative"}},
+		{KNonPositive, "Value must be zero or less", nil, []string{"int;nonpositive"}},
+		{KFinite, "Float must not be NaN or infinite", nil, []string{"float;finite"}},
+		{KMultipleOf, "Value must be a whole multiple of a step", f("positive step; integer for int and uint"), []string{"int;multipleof=10", "float;multipleof=0.01"}},
+
+		{KSlice, "Value must be a slice", nil, []string{"slice"}},
+		{KSliceLength, "Exact slice length", n("required length"), []string{"slice;len=3"}},
+		{KMinSliceLength, "Minimum slice length", n("minimum length"), []string{"slice;min=1"}},
+		{KMaxSliceLength, "Maximum slice length", n("maximum length"), []string{"slice;max=10"}},
+		{KForEach, "Apply nested rules to each slice element", elem, []string{"slice;foreach=(string;min=1)"}},
+		{KSliceUnique, "Slice elements, or the given struct field of each, must be unique", nil, []string{"slice;unique", "slice;unique=Email"}},
+		{KSliceContains, "Slice must contain an element", value("required element"), []string{"slice;contains=admin"}},
+
+		{KArray, "Value must be an array", nil, []string{"array"}},
+		{KArrayLength, "Exact array length", n("required length"), []string{"array;len=2"}},
+		{KMinArrayLength, "Minimum array length", n("minimum length"), []string{"array;min=1"}},
+		{KMaxArrayLength, "Maximum array length", n("maximum length"), []string{"array;max=4"}},
+		{KArrayForEach, "Apply nested rules to each array element", elem, []string{"array;foreach=(string;slug)"}},
+		{KArrayUnique, "Array elements, or the given struct field of each, must be unique", nil, []string{"array;unique", "array;unique=ID"}},
+		{KArrayContains, "Array must contain an element", value("required element"), []string{"array;contains=a"}},
+
+		{KMap, "Value must be a map", nil, []string{"map"}},
+		{KMapLength, "Exact number of map keys", n("required key count"), []string{"map;len=2"}},
+		{KMinMapKeys, "Minimum number of map keys", n("minimum key count"), []string{"map;minKeys=1"}},
+		{KMaxMapKeys, "Maximum number of map keys", n("maximum key count"), []string{"map;maxKeys=10"}},
+		{KMapKeys, "Apply nested rules to each map key", elem, []string{"map;keys=(string;min=2)"}},
+		{KMapValues, "Apply nested rules to each map value", elem, []string{"map;values=(int;positive)"}},
+
+		{KBool, "Value must be a bool", nil, []string{"bool"}},
+		{KBoolTrue, "Bool must be true", nil, []string{"bool;true"}},
+		{KBoolFalse, "Bool must be false", nil, []string{"bool;false"}},
+		{KBoolParse, "Accept \"true\", \"false\", \"1\" and \"0\" strings as bools", nil, []string{"bool;parse;istrue"}},
+
+		{KTime, "Value must be a time.Time", nil, []string{"time"}},
+		{KTimeNotZero, "Time must not be the zero time", nil, []string{"time;notzero"}},
+		{KTimeBefore, "Time must be before a bound", at("exclusive upper bound"), []string{"time;before=2030-01-01T00:00:00Z", "time;before=now"}},
+		{KTimeAfter, "Time must be after a bound", at("exclusive lower bound"), []string{"time;after=2020-01-01", "time;after=now-24h"}},
+		{KTimeMin, "Time must not be before a bound", at("inclusive lower bound"), []string{"time;min=2020-01-01"}},
+		{KTimeMax, "Time must not be after a bound", at("inclusive upper bound"), []string{"time;max=now+720h"}},
+		{KTimeBetween, "Time must lie within a range", []ParamDoc{
+			{Name: "start", Type: "time.Time", Description: "lower bound"},
+			{Name: "end", Type: "time.Time", Description: "upper bound"},
+		}, []string{"time;between=2020-01-01T00:00:00Z,2030-01-01T00:00:00Z"}},
+		{KEnum, "Integer must be a registered enum value, string a registered name", []ParamDoc{
+			{Name: "name", Type: "string", Description: "enum type registered with RegisterIntEnum"},
+		}, []string{"int;enum=OrderStatus", "string;enum=OrderStatus"}},
+		{KStringer, "Render fmt.Stringer values with String before string rules run; requires enum=Name", nil, []string{"string;stringer;enum=OrderStatus"}},
+		{KTimeLayout, "Layout for parsing string values; RFC 3339 by default", []ParamDoc{
+			{Name: "layout", Type: "string", Description: "Go time layout"},
+		}, []string{"time;layout=2006-01-02;before=now"}},
+	} {
+		RegisterKindDoc(KindDoc{Kind: doc.Kind, Summary: doc.Summary, Params: doc.Params, Examples: doc.Examples, Sample: samples[doc.Kind], Format: formats[doc.Kind]})
+	}
+}
@@ -0,0 +1,49 @@
+package types
+
+import "testing"
+
+func TestDescribeKind_BuiltIns(t *testing.T) {
+	doc, ok := DescribeKind(KMinLength)
+	if !ok {
+		t.Fatal("expected minLength to be documented")
+	}
+	if doc.Summary == "" || len(doc.Params) != 1 || doc.Params[0].Name != "n" || len(doc.Examples) == 0 {
+		t.Fatalf("unexpected doc: %#v", doc)
+	}
+
+	// Every documented example parses, and built-in param names match Args.
+	for _, kind := range DescribedKinds() {
+		doc, _ := DescribeKind(kind)
+		for _, example := range doc.Examples {
+			rules, err := ParseTag(example)
+			if err != nil {
+				t.Fatalf("%s example %q: %v", kind, example, err)
+			}
+			for _, rule := range rules {
+				if rule.Kind != kind {
+					continue
+				}
+				for _, param := range doc.Params {
+					if _, ok := rule.Args[param.Name]; !ok {
+						t.Fatalf("%s example %q has no arg %q", kind, example, param.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRegisterKindDoc_Plugin(t *testing.T) {
+	kind := Kind("describeTestRule")
+	doc := KindDoc{Kind: kind, Summary: "test rule", Examples: []string{"string;describeTestRule"}}
+	RegisterKindDoc(doc)
+	doc.Examples[0] = "mutated"
+
+	got, ok := DescribeKind(kind)
+	if !ok || got.Summary != "test rule" || got.Examples[0] != "string;describeTestRule" {
+		t.Fatalf("unexpected doc: %#v", got)
+	}
+	if _, ok := DescribeKind("undocumentedRule"); ok {
+		t.Fatal("expected undocumented kind to be missing")
+	}
+}
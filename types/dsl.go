@@ -0,0 +1,161 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ParseRules parses a rule expression: the tokens of a tag separated by
+// whitespace instead of semicolons, e.g.
+//
+//	string min=3 max=50 regex='^a.+z$'
+//
+// Values may be quoted with single or double quotes to hold spaces or
+// semicolons; quotes are removed and do not support escapes. Nested rules
+// use the same syntax, as in `slice foreach=(string min=2)`. Tokens are
+// parsed exactly like tag segments, so ParseRules and ParseTag accept the
+// same rules.
+//
+// Parameters:
+//   - expr: The rule expression.
+//
+// Returns:
+//   - []Rule: The parsed rules; nil for an empty expression.
+//   - error: A *ParseError naming the offending token.
+func ParseRules(expr string) ([]Rule, error) {
+	return ParseRulesWithRegistry(expr, nil)
+}
+
+// ParseRulesWithRegistry is ParseRules with an optional per-instance custom
+// type registry, checked before global types.
+func ParseRulesWithRegistry(expr string, registry *TypeRegistry) ([]Rule, error) {
+	parts, err := splitRules(expr)
+	if err != nil {
+		return nil, &ParseError{Tag: expr, Reason: err.Error(), err: err}
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	rules, segment, err := parseParts(parts, registry)
+	if err != nil {
+		return nil, &ParseError{Tag: expr, Segment: segment, Reason: err.Error(), err: err}
+	}
+	return rules, nil
+}
+
+// MustRules is ParseRules panicking on error. Used for package-level
+// variables, it turns a malformed expression into a failure at program
+// start, and the tagcheck package reports it without running the program:
+//
+//	var nameRules = types.MustRules("string min=3 max=50")
+func MustRules(expr string) []Rule {
+	rules, err := ParseRules(expr)
+	if err != nil {
+		panic(fmt.Sprintf("validate: MustRules(%q): %v", expr, err))
+	}
+	return rules
+}
+
+// splitRules splits a rule expression into tag segments, rewriting nested
+// rule lists to tag syntax so that the nested parsers can read them.
+func splitRules(expr string) ([]string, error) {
+	parts, err := tokenizeRules(expr)
+	if err != nil {
+		return nil, err
+	}
+	for i, part := range parts {
+		if parts[i], err = nestedRulesToTag(part); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
+
+// tokenizeRules splits expr at top-level whitespace and removes the quotes
+// around top-level values.
+func tokenizeRules(expr string) ([]string, error) {
+	var parts []string
+	var current strings.Builder
+	var quote rune
+	depth := 0
+	started := false
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				if depth == 0 {
+					continue
+				}
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			started = true
+			if depth == 0 {
+				continue
+			}
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth == 0 {
+				return nil, fmt.Errorf("unbalanced ')'")
+			}
+			depth--
+		case depth == 0 && unicode.IsSpace(r):
+			if started {
+				parts = append(parts, current.String())
+				current.Reset()
+				started = false
+			}
+			continue
+		}
+		current.WriteRune(r)
+		started = true
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced '('")
+	}
+	if started {
+		parts = append(parts, current.String())
+	}
+	return parts, nil
+}
+
+// nestedRulesToTag rewrites `name=(a b)` to `name=(a;b)` for tokens whose
+// parameter is a rule list, such as foreach.
+func nestedRulesToTag(part string) (string, error) {
+	name, value, ok := strings.Cut(part, "=")
+	if !ok || !isNestedRulesToken(name) || !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+		return part, nil
+	}
+	inner, err := tokenizeRules(value[1 : len(value)-1])
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	for i, segment := range inner {
+		// The nested parsers split at semicolons, so they cannot appear in
+		// nested values even when quoted.
+		if strings.Contains(segment, ";") {
+			return "", fmt.Errorf("%s: nested rules cannot contain ';'", name)
+		}
+		if inner[i], err = nestedRulesToTag(segment); err != nil {
+			return "", err
+		}
+	}
+	return name + "=(" + strings.Join(inner, ";") + ")", nil
+}
+
+func isNestedRulesToken(name string) bool {
+	for _, spec := range tagTypeTokens {
+		for _, token := range spec.Tokens {
+			if token.Param == ParamRules && token.Token == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRules_MatchesParseTag(t *testing.T) {
+	tests := []struct {
+		expr string
+		tag  string
+	}{
+		{"string min=3 max=50 regex='^a.+z$'", "string;min=3;max=50;regex=^a.+z$"},
+		{"  string\trequired\n  oneof=a,b  ", "string;required;oneof=a,b"},
+		{"string regex='(a b)'", "string;regex=(a b)"},
+		{"slice min=1 foreach=(string min=2 regex='x y')", "slice;min=1;foreach=(string;min=2;regex=x y)"},
+		{"map keys=(string min=1) values=(slice foreach=(int min=0))", "map;keys=(string;min=1);values=(slice;foreach=(int;min=0))"},
+		{"omitempty", "omitempty"},
+	}
+	for _, tt := range tests {
+		got, err := ParseRules(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseRules(%q): %v", tt.expr, err)
+		}
+		want, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseRules(%q) = %#v, want %#v", tt.expr, got, want)
+		}
+	}
+
+	// Quoted values may hold semicolons, which tags cannot express.
+	if rules, err := ParseRules(`string contains="a b;c"`); err != nil || rules[1].Args["value"] != "a b;c" {
+		t.Fatalf("quoted value = %v, %v", rules, err)
+	}
+	if rules, err := ParseRules("   "); err != nil || rules != nil {
+		t.Fatalf("empty expression = %v, %v", rules, err)
+	}
+}
+
+func TestParseRules_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"string min=x",
+		"string regex='abc",
+		"slice foreach=(string min=1",
+		"string )",
+		"slice foreach=(string contains='a;b')",
+		"nosuchtype",
+	} {
+		_, err := ParseRules(expr)
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Tag != expr {
+			t.Errorf("ParseRules(%q) err = %v, want *ParseError", expr, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustRules did not panic")
+		}
+	}()
+	MustRules("int max=")
+}
@@ -0,0 +1,235 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// Integer is the constraint for enum types registered with RegisterIntEnum.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// enumDef is a registered enum: its type name, the key it is registered
+// under, its valid values and their names.
+type enumDef struct {
+	name   string
+	key    string
+	values map[int64]string
+	names  map[string]int64
+	sorted []int64
+}
+
+// enums holds registered enums keyed by package path and type name, so
+// types of the same name in different packages do not replace each other.
+var (
+	enums   = map[string]*enumDef{}
+	enumsMu sync.RWMutex
+)
+
+// RegisterIntEnum registers the integer enum type T for the enum=Name rule,
+// e.g. RegisterIntEnum[OrderStatus] for enum=OrderStatus. Name is the type
+// name, or the package path and type name, as in
+// enum=example.com/shop.OrderStatus, which tags must use when types of the
+// same name in different packages are registered. Call it at init. A later
+// registration for the same type replaces the earlier one. It panics when T
+// is not a named type.
+//
+// Parameters:
+//   - names: The valid values of T mapped to their names; an empty name
+//     marks a valid value that has no string form.
+func RegisterIntEnum[T Integer](names map[T]string) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Name() == "" {
+		panic(fmt.Errorf("RegisterIntEnum: %s is not a named type", typ))
+	}
+	def := &enumDef{name: typ.Name(), key: typ.PkgPath() + "." + typ.Name(), values: map[int64]string{}, names: map[string]int64{}}
+	for value, name := range names {
+		n, _ := enumInt64(reflect.ValueOf(value))
+		def.values[n] = name
+		if name != "" {
+			def.names[name] = n
+		}
+		def.sorted = append(def.sorted, n)
+	}
+	sort.Slice(def.sorted, func(i, j int) bool { return def.sorted[i] < def.sorted[j] })
+	enumsMu.Lock()
+	defer enumsMu.Unlock()
+	enums[def.key] = def
+}
+
+// RegisterStringerEnum registers the integer enum type T like
+// RegisterIntEnum, naming each value by its String method, so
+// string;stringer;enum=Name accepts exactly the listed values.
+//
+// Parameters:
+//   - values: The valid values of T.
+func RegisterStringerEnum[T interface {
+	Integer
+	fmt.Stringer
+}](values ...T) {
+	names := make(map[T]string, len(values))
+	for _, v := range values {
+		names[v] = v.String()
+	}
+	RegisterIntEnum(names)
+}
+
+// EnumNames returns the names registered for enum name ordered by value,
+// and whether the enum is registered. Like enum=Name, name is a type name
+// or a package path and type name.
+func EnumNames(name string) ([]string, bool) {
+	def, err := lookupEnum(name)
+	if err != nil {
+		return nil, false
+	}
+	out := make([]string, 0, len(def.names))
+	for _, n := range def.sorted {
+		if s := def.values[n]; s != "" {
+			out = append(out, s)
+		}
+	}
+	return out, true
+}
+
+// enumRuleValues returns the sorted values and the names of the enum an
+// enum rule refers to, for example and random generators.
+func enumRuleValues(r Rule) ([]int64, []string) {
+	name, _ := r.Args["name"].(string)
+	def, err := lookupEnum(name)
+	if err != nil {
+		return nil, nil
+	}
+	names, _ := EnumNames(name)
+	return def.sorted, names
+}
+
+// lookupEnum returns the enum registered under name, a package path and
+// type name, or else the only enum whose type is called name. Type names
+// shared by enums of several packages are ambiguous.
+func lookupEnum(name string) (*enumDef, error) {
+	enumsMu.RLock()
+	defer enumsMu.RUnlock()
+	if def, ok := enums[name]; ok {
+		return def, nil
+	}
+	var matches []string
+	for key, def := range enums {
+		if def.name == name {
+			matches = append(matches, key)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("enum %q is not registered", name)
+	case 1:
+		return enums[matches[0]], nil
+	}
+	sort.Strings(matches)
+	return nil, fmt.Errorf("enum %q is ambiguous; use one of %s", name, strings.Join(matches, ", "))
+}
+
+// enumInt64 returns the value of an integer of any integer type, including
+// named enum types. Values above math.MaxInt64 wrap, consistently with
+// RegisterIntEnum.
+func enumInt64(rv reflect.Value) (int64, bool) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// enumIntValue converts named integer types to int64 for int chains with an
+// enum rule, so enum-typed fields pass the int base type check.
+func enumIntValue(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if n, ok := enumInt64(reflect.ValueOf(v)); ok {
+		return n, nil
+	}
+	return v, nil
+}
+
+// stringerValue renders fmt.Stringer values with String for string chains
+// with the stringer rule, so typed enums validate by name.
+func stringerValue(v any) (any, error) {
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	return v, nil
+}
+
+// enumValueOf returns name as a value of the integer type t for string
+// chains with the stringer and enum rules, for example and random
+// generators.
+func enumValueOf(rules []Rule, name string, t reflect.Type) (reflect.Value, bool) {
+	if t == nil || !hasKind(rules, KStringer) {
+		return reflect.Value{}, false
+	}
+	for _, r := range rules {
+		if r.Kind != KEnum {
+			continue
+		}
+		enumName, _ := r.Args["name"].(string)
+		def, err := lookupEnum(enumName)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		n, ok := def.names[name]
+		if !ok {
+			return reflect.Value{}, false
+		}
+		out := reflect.New(t).Elem()
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			out.SetInt(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			out.SetUint(uint64(n))
+		default:
+			return reflect.Value{}, false
+		}
+		return out, true
+	}
+	return reflect.Value{}, false
+}
+
+// compileEnum compiles an enum=Name rule: integers must be registered
+// values and strings registered names.
+func (c *Compiler) compileEnum(rule Rule) compiledRule {
+	name, _ := rule.Args["name"].(string)
+	def, err := lookupEnum(name)
+	if err != nil {
+		return compiledRule{err: newCompileError(rule.Kind, err)}
+	}
+	return compiledRule{validate: func(v any) error { return c.validateEnum(v, def) }}
+}
+
+func (c *Compiler) validateEnum(v any, def *enumDef) error {
+	if s, ok := v.(string); ok {
+		if _, ok := def.names[s]; ok {
+			return nil
+		}
+		msg := c.translateMessage("enum.name", fmt.Sprintf("must be a %s name", def.name), []any{def.name})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeEnumName, Msg: msg, Param: def.name}}
+	}
+	n, ok := enumInt64(reflect.ValueOf(v))
+	if !ok {
+		msg := c.translateMessage("int.type", "expected integer", []any{})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeIntType, Msg: msg}}
+	}
+	if _, ok := def.values[n]; !ok {
+		msg := c.translateMessage("enum.value", fmt.Sprintf("must be a valid %s", def.name), []any{def.name})
+		return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeEnumValue, Msg: msg, Param: def.name}}
+	}
+	return nil
+}
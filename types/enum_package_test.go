@@ -0,0 +1,48 @@
+package types_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+type enumDupStatus int
+
+func TestRegisterIntEnum_KeysByPackage(t *testing.T) {
+	types.RegisterEnumDupStatus()
+	types.RegisterIntEnum(map[enumDupStatus]string{2: "external"})
+
+	for name, want := range map[string][]string{
+		"github.com/aatuh/validate/v3/types.enumDupStatus":      {"internal"},
+		"github.com/aatuh/validate/v3/types_test.enumDupStatus": {"external"},
+	} {
+		if names, ok := types.EnumNames(name); !ok || !reflect.DeepEqual(names, want) {
+			t.Fatalf("EnumNames(%q) = %v, %v, want %v", name, names, ok, want)
+		}
+	}
+
+	rules, err := types.ParseTag("int;enum=github.com/aatuh/validate/v3/types_test.enumDupStatus")
+	if err != nil {
+		t.Fatalf("ParseTag: %v", err)
+	}
+	fn, err := types.NewCompiler(nil).CompileE(rules)
+	if err != nil {
+		t.Fatalf("CompileE: %v", err)
+	}
+	var es verrs.Errors
+	if err := fn(enumDupStatus(2)); err != nil {
+		t.Fatalf("registered value: %v", err)
+	}
+	if err := fn(enumDupStatus(1)); !errors.As(err, &es) || es[0].Code != verrs.CodeEnumValue {
+		t.Fatalf("other package's value: err = %v, want %s", err, verrs.CodeEnumValue)
+	}
+
+	rules, _ = types.ParseTag("int;enum=enumDupStatus")
+	if _, err := types.NewCompiler(nil).CompileE(rules); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("bare name of two packages' enums: err = %v, want ambiguous", err)
+	}
+}
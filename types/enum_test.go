@@ -0,0 +1,147 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+)
+
+type enumTestStatus uint8
+
+const (
+	enumTestPending enumTestStatus = iota + 1
+	enumTestPaid
+	enumTestShipped
+)
+
+func TestIntEnumRule(t *testing.T) {
+	RegisterIntEnum(map[enumTestStatus]string{
+		enumTestPending: "pending",
+		enumTestPaid:    "paid",
+		enumTestShipped: "",
+	})
+	c := NewCompiler(translator.NewSimpleTranslator(translator.DefaultEnglishTranslations()))
+
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"int;enum=enumTestStatus", enumTestPaid, ""},
+		{"int;enum=enumTestStatus", enumTestShipped, ""},
+		{"int;enum=enumTestStatus", 1, ""},
+		{"int;enum=enumTestStatus", enumTestStatus(0), verrs.CodeEnumValue},
+		{"int;enum=enumTestStatus;omitempty", enumTestStatus(0), ""},
+		{"int;enum=enumTestStatus;min=2", enumTestPending, verrs.CodeIntMin},
+		{"int64;enum=enumTestStatus", int64(9), verrs.CodeEnumValue},
+		{"string;enum=enumTestStatus", "pending", ""},
+		{"string;enum=enumTestStatus", "shipped", verrs.CodeEnumName},
+		{"int;enum=enumTestStatus", "paid", verrs.CodeIntType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			rules, err := ParseTag(tt.tag)
+			if err != nil {
+				t.Fatalf("ParseTag: %v", err)
+			}
+			err = c.Compile(rules)(tt.value)
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("%v: unexpected error %v", tt.value, err)
+				}
+				return
+			}
+			var es verrs.Errors
+			if !errors.As(err, &es) || es[0].Code != tt.code {
+				t.Fatalf("%v: error = %v, want %s", tt.value, err, tt.code)
+			}
+		})
+	}
+
+	if names, ok := EnumNames("enumTestStatus"); !ok || !reflect.DeepEqual(names, []string{"pending", "paid"}) {
+		t.Fatalf("EnumNames = %v, %v", names, ok)
+	}
+	rules, _ := ParseTag("int;enum=enumTestStatus")
+	if v, err := ExampleValue(rules, reflect.TypeOf(enumTestStatus(0))); err != nil || v != enumTestPending {
+		t.Fatalf("ExampleValue = %v, %v", v, err)
+	}
+	rules, _ = ParseTag("int;enum=missingEnum")
+	if _, err := c.CompileE(rules); err == nil {
+		t.Fatal("unregistered enum compiled")
+	}
+	if _, err := ParseTag("int;enum=bad name"); err == nil {
+		t.Fatal("invalid enum name parsed")
+	}
+}
+
+type enumTestColor int
+
+func (c enumTestColor) String() string {
+	switch c {
+	case 1:
+		return "red"
+	case 2:
+		return "green"
+	}
+	return "color(" + strconv.Itoa(int(c)) + ")"
+}
+
+func TestStringerEnumRule(t *testing.T) {
+	RegisterStringerEnum(enumTestColor(1), enumTestColor(2))
+	c := NewCompiler(translator.NewSimpleTranslator(translator.DefaultEnglishTranslations()))
+
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"string;stringer;enum=enumTestColor", enumTestColor(2), ""},
+		{"string;stringer;enum=enumTestColor", "red", ""},
+		{"string;stringer;enum=enumTestColor", enumTestColor(3), verrs.CodeEnumName},
+		{"string;stringer;enum=enumTestColor;oneof=red", enumTestColor(1), ""},
+		{"string;stringer;enum=enumTestColor;oneof=red", enumTestColor(2), verrs.CodeStringOneOf},
+		{"string;enum=enumTestColor", enumTestColor(1), verrs.CodeStringType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			rules, err := ParseTag(tt.tag)
+			if err != nil {
+				t.Fatalf("ParseTag: %v", err)
+			}
+			err = c.Compile(rules)(tt.value)
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("%v: unexpected error %v", tt.value, err)
+				}
+				return
+			}
+			var es verrs.Errors
+			if !errors.As(err, &es) || es[0].Code != tt.code {
+				t.Fatalf("%v: error = %v, want %s", tt.value, err, tt.code)
+			}
+		})
+	}
+
+	rules, _ := ParseTag("string;stringer;enum=enumTestColor")
+	if v, err := ExampleValue(rules, reflect.TypeOf(enumTestColor(0))); err != nil || v != enumTestColor(1) {
+		t.Fatalf("ExampleValue = %v, %v", v, err)
+	}
+	for _, tag := range []string{"string;stringer", "string;stringer;oneof=red"} {
+		if _, err := ParseTag(tag); err == nil {
+			t.Fatalf("ParseTag(%q) succeeded without enum=", tag)
+		}
+	}
+}
+
+type enumDupStatus int
+
+// RegisterEnumDupStatus registers enumDupStatus of package types, for
+// TestRegisterIntEnum_KeysByPackage in package types_test, which registers
+// a type of the same name.
+func RegisterEnumDupStatus() {
+	RegisterIntEnum(map[enumDupStatus]string{1: "internal"})
+}
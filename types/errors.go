@@ -0,0 +1,41 @@
+package types
+
+// ParseError reports a tag that could not be parsed.
+//
+// Fields:
+//   - Tag: The full tag being parsed.
+//   - Segment: The offending token, or "" when the tag as a whole is invalid.
+//   - Reason: Human-readable description; also returned by Error.
+type ParseError struct {
+	Tag     string
+	Segment string
+	Reason  string
+	err     error
+}
+
+func (e *ParseError) Error() string { return e.Reason }
+
+// Unwrap returns the underlying cause, e.g. a nested ParseError.
+func (e *ParseError) Unwrap() error { return e.err }
+
+// CompileError reports a rule that could not be compiled, such as an unknown
+// kind or a custom compiler returning an error. Unwrap exposes the cause, so
+// errors.As still finds structured errors.Errors with code unknown.
+//
+// Fields:
+//   - Kind: The rule kind that failed.
+//   - Reason: Human-readable description; also returned by Error.
+type CompileError struct {
+	Kind   Kind
+	Reason string
+	err    error
+}
+
+func (e *CompileError) Error() string { return e.Reason }
+
+// Unwrap returns the underlying cause.
+func (e *CompileError) Unwrap() error { return e.err }
+
+func newCompileError(kind Kind, err error) error {
+	return &CompileError{Kind: kind, Reason: err.Error(), err: err}
+}
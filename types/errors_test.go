@@ -0,0 +1,60 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestParseTag_ReturnsParseError(t *testing.T) {
+	tests := []struct {
+		tag     string
+		segment string
+	}{
+		{"string;min=x;max=3", "min=x"},
+		{"nosuchtype;min=1", "nosuchtype"},
+		{"required;bogus", "bogus"},
+		{"slice;foreach=(int;max=y)", "foreach=(int;max=y)"},
+	}
+	for _, tt := range tests {
+		_, err := ParseTag(tt.tag)
+		var pe *ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("%s: expected *ParseError, got %T %v", tt.tag, err, err)
+		}
+		if pe.Tag != tt.tag || pe.Segment != tt.segment || pe.Reason != err.Error() {
+			t.Fatalf("%s: got %#v", tt.tag, pe)
+		}
+	}
+
+	// Nested tags expose the inner failure too.
+	_, err := ParseTag("slice;foreach=(int;max=y)")
+	var outer *ParseError
+	errors.As(err, &outer)
+	var inner *ParseError
+	if !errors.As(outer.Unwrap(), &inner) || inner.Tag != "int;max=y" || inner.Segment != "max=y" {
+		t.Fatalf("inner parse error = %#v", inner)
+	}
+}
+
+func TestCompileE_ReturnsCompileError(t *testing.T) {
+	_, err := NewCompiler(nil).CompileE([]Rule{NewRule("compileErrorUnknownKind", nil)})
+	var ce *CompileError
+	if !errors.As(err, &ce) || ce.Kind != "compileErrorUnknownKind" || ce.Reason != err.Error() {
+		t.Fatalf("expected *CompileError, got %T %v", err, err)
+	}
+	var es verrs.Errors
+	if !errors.As(err, &es) || es[0].Code != verrs.CodeUnknown {
+		t.Fatalf("compile error must still unwrap to errors.Errors, got %v", err)
+	}
+
+	c := NewCompiler(nil)
+	c.RegisterRule("failing", func(*Compiler, Rule) (func(any) error, error) {
+		return nil, errors.New("bad args")
+	})
+	_, err = c.CompileE([]Rule{NewRule("failing", nil)})
+	if !errors.As(err, &ce) || ce.Kind != "failing" {
+		t.Fatalf("expected *CompileError for failing compiler, got %v", err)
+	}
+}
@@ -0,0 +1,701 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp/syntax"
+	"strings"
+	"time"
+)
+
+// exampleTime is the reference point for generated time values, so
+// examples are stable across runs.
+var exampleTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ExampleValue returns a value intended to satisfy rules, for docs and
+// contract tests. It honors length, range, pattern, enum, prefix/suffix,
+// containment and collection constraints; regex patterns are synthesized
+// from their simplest match and format rules use KindDoc.Sample. The result
+// is best-effort: callers should validate it before relying on it.
+//
+// Parameters:
+//   - rules: Parsed rules, starting with the base type rule.
+//   - t: Go type of the result, or nil for the base type's default type
+//     (string, int, int64, float64, bool, time.Time, []T, [N]T, map[K]V).
+//
+// Returns:
+//   - any: The generated value.
+//   - error: If rules are empty or the value cannot be converted to t.
+func ExampleValue(rules []Rule, t reflect.Type) (any, error) {
+	v, err := exampleValue(rules, t)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+func exampleValue(rules []Rule, t reflect.Type) (reflect.Value, error) {
+	if len(rules) == 0 {
+		return reflect.Value{}, fmt.Errorf("example: no rules")
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		elem, err := exampleValue(rules, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(elem)
+		return p, nil
+	}
+	if t != nil && t.Kind() == reflect.Interface {
+		t = nil
+	}
+
+	var out reflect.Value
+	switch base := baseKind(rules); base {
+	case KString:
+		out = reflect.ValueOf(exampleString(rules))
+		if v, ok := enumValueOf(rules, out.String(), t); ok {
+			out = v
+		}
+	case KInt:
+		out = reflect.ValueOf(int(exampleInt(rules)))
+		if t != nil && t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr {
+			n := exampleInt(rules)
+			if n < 0 {
+				n = 0
+			}
+			out = reflect.ValueOf(uint64(n))
+		}
+	case KInt64:
+		out = reflect.ValueOf(exampleInt(rules))
+	case KUint, KUint64:
+		n := exampleInt(rules)
+		if n < 0 {
+			n = 0
+		}
+		out = reflect.ValueOf(uint64(n))
+		if base == KUint {
+			out = reflect.ValueOf(uint(n))
+		}
+	case KFloat:
+		out = reflect.ValueOf(exampleFloat(rules))
+	case KBool:
+		out = reflect.ValueOf(!hasKind(rules, KBoolFalse))
+	case KTime:
+		out = formatTimeAs(exampleTimeValue(rules), t, timeLayout(rules))
+	case KSlice, KArray:
+		v, err := exampleList(rules, base, t)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out = v
+	case KMap:
+		v, err := exampleMap(rules, t)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out = v
+	default:
+		if t == nil {
+			return reflect.Value{}, fmt.Errorf("example: no default value for type %s", safeRuleKindForError(base))
+		}
+		out = reflect.New(t).Elem()
+	}
+
+	if t == nil || out.Type() == t {
+		return out, nil
+	}
+	if out.Type().ConvertibleTo(t) {
+		return out.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("example: cannot use %s value as %s", out.Type(), t)
+}
+
+// baseKind returns the base type rule kind, skipping leading generic rules.
+func baseKind(rules []Rule) Kind {
+	for _, r := range rules {
+		if r.Kind != KRequired && r.Kind != KOmitempty && r.Kind != KUnbounded {
+			return r.Kind
+		}
+	}
+	return KString
+}
+
+func hasKind(rules []Rule, kind Kind) bool {
+	for _, r := range rules {
+		if r.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func argNumber(r Rule, key string) (float64, bool) {
+	return toNumberFloat64(r.Args[key])
+}
+
+func argInt(r Rule, key string) int {
+	n, _ := argNumber(r, key)
+	return int(n)
+}
+
+func argString(r Rule, key string) string {
+	s, _ := r.Args[key].(string)
+	return s
+}
+
+func exampleString(rules []Rule) string {
+	exact, minLen, maxLen := -1, 0, -1
+	var oneof, contains []string
+	var prefix, suffix, pattern, sample string
+	var numStr bool
+	var numMin, numMax string
+	fill := "example"
+	for _, r := range rules {
+		switch r.Kind {
+		case KLength:
+			exact = argInt(r, "n")
+		case KMinLength, KMinRunes:
+			if n := argInt(r, "n"); n > minLen {
+				minLen = n
+			}
+		case KMaxLength, KMaxRunes:
+			if n := argInt(r, "n"); maxLen < 0 || n < maxLen {
+				maxLen = n
+			}
+		case KNonEmpty, KRequired:
+			if minLen < 1 {
+				minLen = 1
+			}
+		case KOneOf:
+			oneof, _ = r.Args["values"].([]string)
+		case KEnum:
+			_, oneof = enumRuleValues(r)
+		case KRegex:
+			pattern = argString(r, "pattern")
+		case KPrefix:
+			prefix = argString(r, "value")
+		case KSuffix:
+			suffix = argString(r, "value")
+		case KContains:
+			contains = append(contains, argString(r, "value"))
+		case KNumeric:
+			fill = "1234567890"
+		case KNumStr:
+			numStr = true
+		case KNumStrMin:
+			numStr, numMin = true, argString(r, "value")
+		case KNumStrMax:
+			numStr, numMax = true, argString(r, "value")
+		default:
+			if doc, ok := DescribeKind(r.Kind); ok && doc.Sample != "" {
+				sample = doc.Sample
+			}
+		}
+	}
+
+	fits := func(s string) bool {
+		n := len(s)
+		return (exact < 0 || n == exact) && n >= minLen && (maxLen < 0 || n <= maxLen) &&
+			strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+	}
+	for _, v := range oneof {
+		if fits(v) {
+			return v
+		}
+	}
+	if len(oneof) > 0 {
+		return oneof[0]
+	}
+	if numStr {
+		return exampleDecimal(numMin, numMax)
+	}
+	if sample != "" {
+		return sample
+	}
+	if pattern != "" {
+		if s, ok := synthesizeRegex(pattern, minLen); ok {
+			return s
+		}
+	}
+
+	fixed := prefix + strings.Join(contains, "") + suffix
+	target := len(fixed)
+	if fixed == "" {
+		target = len("example")
+	}
+	if target < minLen {
+		target = minLen
+	}
+	if maxLen >= 0 && target > maxLen {
+		target = maxLen
+	}
+	if exact >= 0 {
+		target = exact
+	}
+	filler := ""
+	if n := target - len(fixed); n > 0 {
+		filler = strings.Repeat(fill, n/len(fill)+1)[:n]
+	}
+	return prefix + strings.Join(contains, "") + filler + suffix
+}
+
+// exampleDecimal returns a decimal number string within the optional
+// bounds: the lower bound, else 0 or a negative upper bound.
+func exampleDecimal(lo, hi string) string {
+	if lo != "" {
+		return lo
+	}
+	if r, ok := parseDecimal(hi); ok && r.Sign() < 0 {
+		return hi
+	}
+	return "0"
+}
+
+// synthesizeRegex returns a short string matching pattern, repeating
+// unbounded elements until the result reaches minLen where possible.
+func synthesizeRegex(pattern string, minLen int) (string, bool) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	re = re.Simplify()
+	for repeat := 1; repeat <= 64; repeat *= 2 {
+		var b strings.Builder
+		if !writeRegexExample(&b, re, repeat) {
+			return "", false
+		}
+		if b.Len() >= minLen || repeat == 64 {
+			return b.String(), true
+		}
+	}
+	return "", false
+}
+
+func writeRegexExample(b *strings.Builder, re *syntax.Regexp, repeat int) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	case syntax.OpLiteral:
+		b.WriteString(string(re.Rune))
+		return true
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+		b.WriteRune(pickClassRune(re.Rune))
+		return true
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte('a')
+		return true
+	case syntax.OpCapture:
+		return writeRegexExample(b, re.Sub[0], repeat)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !writeRegexExample(b, sub, repeat) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			var alt strings.Builder
+			if writeRegexExample(&alt, sub, repeat) {
+				b.WriteString(alt.String())
+				return true
+			}
+		}
+		return false
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		lo, hi := 0, -1
+		switch re.Op {
+		case syntax.OpPlus:
+			lo = 1
+		case syntax.OpQuest:
+			hi = 1
+		case syntax.OpRepeat:
+			lo, hi = re.Min, re.Max
+		}
+		n := lo
+		if n < repeat && re.Op != syntax.OpQuest {
+			n = repeat
+		}
+		if hi >= 0 && n > hi {
+			n = hi
+		}
+		for i := 0; i < n; i++ {
+			if !writeRegexExample(b, re.Sub[0], repeat) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// pickClassRune prefers a letter or digit from a character class.
+func pickClassRune(ranges []rune) rune {
+	for _, want := range [][2]rune{{'a', 'z'}, {'A', 'Z'}, {'0', '9'}, {'!', '~'}} {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			lo, hi := ranges[i], ranges[i+1]
+			if lo <= want[1] && hi >= want[0] {
+				if lo < want[0] {
+					return want[0]
+				}
+				return lo
+			}
+		}
+	}
+	return ranges[0]
+}
+
+// numberBounds collects the numeric range of rules. Exclusive flags mark
+// gt/lt/positive bounds.
+func numberBounds(rules []Rule) (lo, hi float64, loEx, hiEx bool) {
+	lo, hi = math.Inf(-1), math.Inf(1)
+	raise := func(n float64, ex bool) {
+		if n > lo || (n == lo && ex) {
+			lo, loEx = n, ex
+		}
+	}
+	lower := func(n float64, ex bool) {
+		if n < hi || (n == hi && ex) {
+			hi, hiEx = n, ex
+		}
+	}
+	for _, r := range rules {
+		n, _ := argNumber(r, "n")
+		switch r.Kind {
+		case KMinInt, KMinUint, KMinNumber, KGreaterThanEqual:
+			raise(n, false)
+		case KGreaterThan:
+			raise(n, true)
+		case KMaxInt, KMaxUint, KMaxNumber, KLessThanEqual:
+			lower(n, false)
+		case KLessThan:
+			lower(n, true)
+		case KBetween:
+			minV, _ := argNumber(r, "min")
+			maxV, _ := argNumber(r, "max")
+			raise(minV, false)
+			lower(maxV, false)
+		case KPositive:
+			raise(0, true)
+		case KNonNegative:
+			raise(0, false)
+		case KNegative:
+			lower(0, true)
+		case KNonPositive:
+			lower(0, false)
+		}
+	}
+	return lo, hi, loEx, hiEx
+}
+
+// multipleOfStep returns the step of the first multipleof rule in rules, or
+// 0 without one.
+func multipleOfStep(rules []Rule) float64 {
+	for _, r := range rules {
+		if r.Kind == KMultipleOf {
+			if n, ok := argNumber(r, "n"); ok && n > 0 && !math.IsInf(n, 0) {
+				return n
+			}
+		}
+	}
+	return 0
+}
+
+// snapToStep moves c to a multiple of step within [lo, hi], preferring the
+// next one up, and leaves c unchanged when the range holds none.
+func snapToStep(c, step, lo, hi float64) float64 {
+	if step <= 0 {
+		return c
+	}
+	for _, m := range []float64{math.Ceil(c/step) * step, math.Floor(c/step) * step} {
+		if m >= lo && m <= hi {
+			return m
+		}
+	}
+	return c
+}
+
+func exampleInt(rules []Rule) int64 {
+	lo, hi, loEx, hiEx := numberBounds(rules)
+	low, high := math.Ceil(lo), math.Floor(hi)
+	if loEx && low == lo {
+		low++
+	}
+	if hiEx && high == hi {
+		high--
+	}
+	c := 1.0
+	if c < low {
+		c = low
+	}
+	if c > high {
+		c = high
+	}
+	c = snapToStep(c, multipleOfStep(rules), low, high)
+	if values := exampleEnumValues(rules); len(values) > 0 {
+		c = float64(values[0])
+		for _, n := range values {
+			if f := float64(n); f >= low && f <= high {
+				return n
+			}
+		}
+	}
+	switch {
+	case c >= math.MaxInt64:
+		return math.MaxInt64
+	case c <= math.MinInt64:
+		return math.MinInt64
+	}
+	return int64(c)
+}
+
+// exampleEnumValues returns the values of the first enum rule in rules.
+func exampleEnumValues(rules []Rule) []int64 {
+	for _, r := range rules {
+		if r.Kind == KEnum {
+			values, _ := enumRuleValues(r)
+			return values
+		}
+	}
+	return nil
+}
+
+func exampleFloat(rules []Rule) float64 {
+	lo, hi, loEx, hiEx := numberBounds(rules)
+	c := 1.0
+	if c < lo || (loEx && c == lo) {
+		if math.IsInf(hi, 1) {
+			c = lo + 1
+		} else {
+			c = lo + (hi-lo)/2
+		}
+	}
+	if c > hi || (hiEx && c == hi) {
+		if math.IsInf(lo, -1) {
+			c = hi - 1
+		} else {
+			c = lo + (hi-lo)/2
+		}
+	}
+	if step := multipleOfStep(rules); step > 0 {
+		if m := snapToStep(c, step, lo, hi); (m > lo || !loEx) && (m < hi || !hiEx) {
+			c = m
+		}
+	}
+	return c
+}
+
+func exampleTimeValue(rules []Rule) time.Time {
+	var after, before time.Time
+	now := time.Now()
+	for _, r := range rules {
+		switch r.Kind {
+		case KTimeAfter:
+			after = resolveTimeBound(r.Args["time"], now)
+		case KTimeMin:
+			after = resolveTimeBound(r.Args["time"], now).Add(-time.Nanosecond)
+		case KTimeBefore:
+			before = resolveTimeBound(r.Args["time"], now)
+		case KTimeMax:
+			before = resolveTimeBound(r.Args["time"], now).Add(time.Nanosecond)
+		case KTimeBetween:
+			after = resolveTimeBound(r.Args["start"], now)
+			before = resolveTimeBound(r.Args["end"], now)
+		}
+	}
+	switch {
+	case !after.IsZero() && !before.IsZero():
+		return after.Add(before.Sub(after) / 2)
+	case !after.IsZero() && !exampleTime.After(after):
+		return after.Add(24 * time.Hour)
+	case !before.IsZero() && !exampleTime.Before(before):
+		return before.Add(-24 * time.Hour)
+	}
+	return exampleTime
+}
+
+// exampleCount picks a collection size from exact, minimum and maximum
+// length rules, preferring one element.
+func exampleCount(rules []Rule, exactKind, minKind, maxKind Kind) int {
+	n, maxN := 1, -1
+	for _, r := range rules {
+		switch r.Kind {
+		case exactKind:
+			return argInt(r, "n")
+		case minKind:
+			if m := argInt(r, "n"); m > n {
+				n = m
+			}
+		case maxKind:
+			maxN = argInt(r, "n")
+		}
+	}
+	if maxN >= 0 && n > maxN {
+		n = maxN
+	}
+	return n
+}
+
+// nestedRules returns the rules of a foreach/keys/values rule.
+func nestedRules(rules []Rule, kind Kind) []Rule {
+	for _, r := range rules {
+		if r.Kind == kind {
+			nested, _ := r.Args["rules"].([]Rule)
+			return nested
+		}
+	}
+	return nil
+}
+
+var defaultElemRules = []Rule{NewRule(KString, nil)}
+
+// defaultNestedRules returns rules, or string rules for untyped and string
+// elements without nested rules. Nil means zero values of t.
+func defaultNestedRules(rules []Rule, t reflect.Type) []Rule {
+	if rules == nil && (t == nil || t.Kind() == reflect.String) {
+		return defaultElemRules
+	}
+	return rules
+}
+
+func exampleList(rules []Rule, base Kind, t reflect.Type) (reflect.Value, error) {
+	forEach, exactKind, minKind, maxKind, containsKind, uniqueKind :=
+		KForEach, KSliceLength, KMinSliceLength, KMaxSliceLength, KSliceContains, KSliceUnique
+	if base == KArray {
+		forEach, exactKind, minKind, maxKind, containsKind, uniqueKind =
+			KArrayForEach, KArrayLength, KMinArrayLength, KMaxArrayLength, KArrayContains, KArrayUnique
+	}
+	var elemType reflect.Type
+	if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		elemType = t.Elem()
+	}
+	elemRules := defaultNestedRules(nestedRules(rules, forEach), elemType)
+	n := exampleCount(rules, exactKind, minKind, maxKind)
+	if t != nil && t.Kind() == reflect.Array {
+		n = t.Len()
+	}
+
+	elems, err := exampleElems(elemRules, elemType, n, hasKind(rules, uniqueKind))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	for _, r := range rules {
+		if r.Kind == containsKind && n > 0 {
+			want := reflect.ValueOf(argString(r, "value"))
+			if want.Type().ConvertibleTo(elems[0].Type()) {
+				elems[0] = want.Convert(elems[0].Type())
+			}
+		}
+	}
+
+	if elemType == nil && len(elems) > 0 {
+		elemType = elems[0].Type()
+	} else if elemType == nil {
+		elemType = reflect.TypeOf("")
+	}
+	var out reflect.Value
+	if base == KArray {
+		out = reflect.New(reflect.ArrayOf(len(elems), elemType)).Elem()
+	} else {
+		out = reflect.MakeSlice(reflect.SliceOf(elemType), len(elems), len(elems))
+	}
+	if t != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+		out = reflect.New(t).Elem()
+		if t.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(t, len(elems), len(elems))
+		}
+	}
+	for i, e := range elems {
+		if i < out.Len() {
+			out.Index(i).Set(e)
+		}
+	}
+	return out, nil
+}
+
+func exampleMap(rules []Rule, t reflect.Type) (reflect.Value, error) {
+	var keyType, valueType reflect.Type
+	if t != nil && t.Kind() == reflect.Map {
+		keyType, valueType = t.Key(), t.Elem()
+	}
+	keyRules := defaultNestedRules(nestedRules(rules, KMapKeys), keyType)
+	valueRules := defaultNestedRules(nestedRules(rules, KMapValues), valueType)
+	n := exampleCount(rules, KMapLength, KMinMapKeys, KMaxMapKeys)
+	keys, err := exampleElems(keyRules, keyType, n, true)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	values, err := exampleElems(valueRules, valueType, n, false)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if t == nil || t.Kind() != reflect.Map {
+		kt, vt := reflect.TypeOf(""), reflect.TypeOf("")
+		if n > 0 {
+			kt, vt = keys[0].Type(), values[0].Type()
+		}
+		t = reflect.MapOf(kt, vt)
+	}
+	out := reflect.MakeMapWithSize(t, n)
+	for i := range keys {
+		out.SetMapIndex(keys[i], values[i])
+	}
+	return out, nil
+}
+
+// exampleElems generates n element values, or zero values of t for nil
+// rules. With distinct set, strings get a varying last character and
+// numbers an increasing offset.
+func exampleElems(rules []Rule, t reflect.Type, n int, distinct bool) ([]reflect.Value, error) {
+	elems := make([]reflect.Value, 0, n)
+	for i := 0; i < n; i++ {
+		if rules == nil {
+			e := reflect.New(t).Elem()
+			if distinct && i > 0 {
+				e = varyExample(e, i)
+			}
+			elems = append(elems, e)
+			continue
+		}
+		e, err := exampleValue(rules, t)
+		if err != nil {
+			return nil, err
+		}
+		if distinct && i > 0 {
+			e = varyExample(e, i)
+		}
+		elems = append(elems, e)
+	}
+	return elems, nil
+}
+
+func varyExample(v reflect.Value, i int) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		s, last := v.String(), ""
+		if s != "" {
+			s, last = s[:len(s)-1], s[len(s)-1:]
+		}
+		letters := strings.Replace("abcdefghijklmnopqrstuvwxyz", last, "", 1)
+		out.SetString(s + string(letters[(i-1)%len(letters)]))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		out.SetInt(v.Int() + int64(i))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		out.SetUint(v.Uint() + uint64(i))
+	case reflect.Float32, reflect.Float64:
+		out.SetFloat(v.Float() + float64(i))
+	default:
+		return v
+	}
+	return out
+}
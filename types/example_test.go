@@ -0,0 +1,66 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExampleValueSatisfiesRules(t *testing.T) {
+	tags := []string{
+		"string",
+		"string;required;min=10;max=12",
+		"string;len=3",
+		"string;oneof=red,green,blue;prefix=g",
+		"string;regex=^[A-Z]{3}-[0-9]{2,4}$",
+		"string;regex=^(foo|bar)+baz\\d*$;min=8",
+		"string;prefix=sk_;suffix=.json;contains=key;max=20",
+		"string;url",
+		"string;ipv6",
+		"string;alpha;minRunes=9",
+		"int;min=10;max=20",
+		"int;gt=-5;lt=-2",
+		"int64;positive;between=100,200",
+		"float;gt=0;lt=0.5",
+		"float;nonnegative;finite",
+		"bool;false",
+		"time;after=2030-01-01T00:00:00Z",
+		"time;between=2000-01-01T00:00:00Z,2001-01-01T00:00:00Z",
+		"slice;min=3;unique;foreach=(string;min=2)",
+		"array;len=2;foreach=(int;positive)",
+		"slice;contains=admin;min=2",
+		"map;minKeys=2;keys=(string;min=3);values=(int;min=5)",
+	}
+	for _, tag := range tags {
+		rules, err := ParseTag(tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tag, err)
+		}
+		value, err := ExampleValue(rules, nil)
+		if err != nil {
+			t.Fatalf("ExampleValue(%q): %v", tag, err)
+		}
+		if err := NewCompiler(nil).Compile(rules)(value); err != nil {
+			t.Fatalf("example %#v for %q does not validate: %v", value, tag, err)
+		}
+	}
+}
+
+func TestExampleValueConvertsToFieldType(t *testing.T) {
+	type status string
+	rules, err := ParseTag("string;oneof=active,disabled")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := ExampleValue(rules, reflect.TypeOf((*status)(nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := value.(*status); !ok || *p != "active" {
+		t.Fatalf("value = %#v, want *status(active)", value)
+	}
+
+	rules, _ = ParseTag("int;min=1")
+	if _, err := ExampleValue(rules, reflect.TypeOf(struct{}{})); err == nil {
+		t.Fatal("expected error for incompatible type")
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"errors"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -25,6 +26,16 @@ func TestParseTag_DocumentedAliasesAndExpandedRules(t *testing.T) {
 				KIPv6, KCIDR, KASCII, KAlpha, KAlnum, KNonEmpty,
 			},
 		},
+		{
+			name: "substring aliases",
+			tag:  "string;notcontains=x;startswith=h;endswith=o;excludesall=<>",
+			want: []Kind{KString, KNotContains, KPrefix, KSuffix, KExcludesAll},
+		},
+		{
+			name: "character classes",
+			tag:  "string;alphanum;alphaunicode;alphanumunicode;numeric;printascii",
+			want: []Kind{KString, KAlnum, KAlphaUni, KAlnumUni, KNumeric, KPrintASCII},
+		},
 		{
 			name: "slice len alias and collection rules",
 			tag:  "slice;len=2;unique;contains=a;foreach=(string;min=1)",
@@ -54,6 +65,11 @@ func TestParseTag_DocumentedAliasesAndExpandedRules(t *testing.T) {
 			tag:  "time;notzero;after=2026-01-01T00:00:00Z;before=2027-01-01T00:00:00Z;between=2026-01-01T00:00:00Z,2027-01-01T00:00:00Z",
 			want: []Kind{KTime, KTimeNotZero, KTimeAfter, KTimeBefore, KTimeBetween},
 		},
+		{
+			name: "bool aliases and parse",
+			tag:  "bool;parse;istrue;isfalse",
+			want: []Kind{KBool, KBoolParse, KBoolTrue, KBoolFalse},
+		},
 		{
 			name: "generic required only",
 			tag:  "required",
@@ -100,14 +116,51 @@ func TestCompiler_ExpandedRuleBehavior(t *testing.T) {
 		code    string
 	}{
 		{"string contains", "string;contains=go", "gopher", "java", verrs.CodeStringContains},
+		{"string startswith", "string;startswith=sk_", "sk_live", "pk_live", verrs.CodeStringPrefix},
+		{"string endswith", "string;endswith=.json", "a.json", "a.yaml", verrs.CodeStringSuffix},
+		{"string notcontains", "string;notcontains=..", "a/b", "../b", verrs.CodeStringNotContains},
+		{"string alpha", "string;alpha", "Gödel", "Gödel1", verrs.CodeStringAlpha},
+		{"string alphanum", "string;alphanum", "Gödel123", "go-123", verrs.CodeStringAlnum},
+		{"string alphaunicode", "string;alphaunicode", "Gödel", "Gödel1", verrs.CodeStringAlphaUnicode},
+		{"string alphanumunicode", "string;alphanumunicode", "Gödel1", "Gödel 1", verrs.CodeStringAlnumUnicode},
+		{"string numeric", "string;numeric", "0123", "-1", verrs.CodeStringNumeric},
+		{"string printascii", "string;printascii", "a b~", "a\tb", verrs.CodeStringPrintASCII},
+		{"string ascii", "string;ascii", "a\tb", "é", verrs.CodeStringASCII},
+		{"string numstr", "string;numstr", "-0.25", "1e3", verrs.CodeStringNumStr},
+		{"string min_value", "string;numstr;min_value=0.1", "0.10", "0.0999", verrs.CodeStringNumStrMin},
+		{"string max_value", "string;numstr;max_value=100", "+100", "100.0001", verrs.CodeStringNumStrMax},
+		{"string excludesall", "string;excludesall=<>\"", "plain text", "<b>", verrs.CodeStringExcludesAll},
 		{"string url", "string;url", "https://example.com/a", "not a url", verrs.CodeStringURL},
 		{"string ipv4", "string;ipv4", "127.0.0.1", "::1", verrs.CodeStringIP},
 		{"float finite", "float;finite;between=1,2", 1.5, 3.0, verrs.CodeNumberBetween},
+		{"int negative", "int;negative", -1, 0, verrs.CodeNumberNegative},
+		{"int nonpositive", "int;nonpositive", 0, 1, verrs.CodeNumberNonPos},
+		{"float negative", "float;negative", -0.01, 0.0, verrs.CodeNumberNegative},
+		{"float nonpositive", "float;nonpositive", -0.0, 0.5, verrs.CodeNumberNonPos},
+		{"float negative NaN", "float;negative", -1.0, math.NaN(), verrs.CodeNumberNegative},
+		{"float nonpositive NaN", "float;nonpositive", 0.0, math.NaN(), verrs.CodeNumberNonPos},
+		{"uint nonpositive", "uint;nonpositive", uint(0), uint(1), verrs.CodeNumberNonPos},
 		{"bool true", "bool;true", true, false, verrs.CodeBoolTrue},
+		{"bool istrue parse", "bool;parse;istrue", "1", "0", verrs.CodeBoolTrue},
+		{"bool parse", "bool;parse", "false", "yes", verrs.CodeBoolParse},
+		{"bool parse omitempty", "bool;omitempty;parse", "", 3, verrs.CodeBoolType},
 		{"slice unique", "slice;unique", []string{"a", "b"}, []string{"a", "a"}, verrs.CodeSliceUnique},
 		{"array unique", "array;unique", [2]string{"a", "b"}, [2]string{"a", "a"}, verrs.CodeArrayUnique},
 		{"map min", "map;minKeys=1", map[string]int{"a": 1}, map[string]int{}, verrs.CodeMapMinKeys},
 		{"time after", "time;after=2026-01-01T00:00:00Z", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), verrs.CodeTimeAfter},
+		{"time after date", "time;after=2020-01-01", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), verrs.CodeTimeAfter},
+		{"time min inclusive", "time;min=2020-01-01", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC), verrs.CodeTimeMin},
+		{"time max inclusive", "time;max=2020-01-01", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), verrs.CodeTimeMax},
+		{"time before now", "time;before=now", time.Now().Add(-time.Hour), time.Now().Add(time.Hour), verrs.CodeTimeBefore},
+		{"time after relative", "time;after=now-24h", time.Now(), time.Now().Add(-48 * time.Hour), verrs.CodeTimeAfter},
+		{"time rfc3339 string", "time;before=now;after=2020-01-01", "2021-06-01T12:00:00Z", "2019-06-01T12:00:00Z", verrs.CodeTimeAfter},
+		{"time layout string", "time;layout=2006-01-02;max=2030-01-01", "2029-12-31", "2030-01-02", verrs.CodeTimeMax},
+		{"time layout parse", "time;layout=02/01/2006", "31/12/2024", "2024-12-31", verrs.CodeTimeParse},
+		{"time string omitempty", "time;omitempty;after=2020-01-01", "", "nope", verrs.CodeTimeParse},
+		{"time date string", "time;after=2020-01-01", "2020-01-02", "2019-01-01", verrs.CodeTimeAfter},
+		{"time layout excludes dates", "time;layout=2006-01-02T15:04:05Z07:00", "2020-01-01T00:00:00Z", "2020-01-01", verrs.CodeTimeParse},
+		{"time min without now", "time;min=-1h", time.Now(), time.Now().Add(-2 * time.Hour), verrs.CodeTimeMin},
+		{"time max without now", "time;max=+1h", time.Now(), time.Now().Add(2 * time.Hour), verrs.CodeTimeMax},
 	}
 
 	for _, tt := range tests {
@@ -195,6 +248,10 @@ func TestParseTag_CustomRulesRejectMalformedBuiltInArgs(t *testing.T) {
 		"slice;max=bad",
 		"map;minKeys=bad",
 		"time;after=not-rfc3339",
+		"time;before=now+soon",
+		"time;min=nowish",
+		"time;min=-soon",
+		"time;layout=",
 	} {
 		t.Run(tag, func(t *testing.T) {
 			if _, err := ParseTag(tag); err == nil {
@@ -203,3 +260,133 @@ func TestParseTag_CustomRulesRejectMalformedBuiltInArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTag_RegisteredRuleTakesValue(t *testing.T) {
+	RegisterRule("testregion", func(*Compiler, Rule) (func(any) error, error) {
+		return func(any) error { return nil }, nil
+	})
+	rules, err := ParseTag("string;testregion=US")
+	if err != nil {
+		t.Fatalf("ParseTag: %v", err)
+	}
+	if got := rules[1]; got.Kind != "testregion" || got.Args["value"] != "US" {
+		t.Fatalf("rule = %#v, want testregion with value US", got)
+	}
+	if _, err := ParseTag("string;unregistered=US"); err == nil {
+		t.Fatal("expected unknown rule error")
+	}
+}
+
+func TestUniqueByField(t *testing.T) {
+	type owner struct{ ID int }
+	type member struct {
+		Email string
+		Tags  []string
+		Owner *owner
+		note  string
+	}
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"slice;unique=Email", []member{{Email: "a", note: "x"}, {Email: "b", note: "x"}}, ""},
+		{"slice;unique=Email", []member{{Email: "a"}, {Email: "a", note: "x"}}, verrs.CodeSliceUnique},
+		{"slice;unique=Email", []*member{{Email: "a"}, nil, {Email: "a"}}, verrs.CodeSliceUnique},
+		{"slice;unique=Owner.ID", []member{{Owner: &owner{1}}, {}, {}, {Owner: &owner{2}}}, ""},
+		{"slice;unique=Owner.ID", []member{{Owner: &owner{1}}, {Owner: &owner{1}}}, verrs.CodeSliceUnique},
+		{"slice;unique=Tags", []member{{Tags: []string{"a"}}, {Tags: []string{"a"}}}, verrs.CodeSliceUnique},
+		{"slice;unique=Email", []any{member{Email: "a"}, &member{Email: "a"}}, verrs.CodeSliceUnique},
+		{"slice;unique=Email", []string{"a"}, verrs.CodeSliceType},
+		{"slice;unique=Missing", []member{{}}, verrs.CodeSliceType},
+		{"array;unique=Email", [2]member{{Email: "a"}, {Email: "a"}}, verrs.CodeArrayUnique},
+		{"array;unique=Email", [1]int{1}, verrs.CodeArrayType},
+	}
+	c := NewCompiler(translator.NewSimpleTranslator(translator.DefaultEnglishTranslations()))
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		code := ""
+		var es verrs.Errors
+		if err := c.Compile(rules)(tt.value); errors.As(err, &es) {
+			code = es[0].Code
+		} else if err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.tag, err)
+		}
+		if code != tt.code {
+			t.Errorf("%s %#v: code = %q, want %q", tt.tag, tt.value, code, tt.code)
+		}
+	}
+
+	for _, tag := range []string{"slice;unique=", "slice;unique=note", "array;unique=Owner..ID"} {
+		if _, err := ParseTag(tag); err == nil {
+			t.Errorf("ParseTag(%q) accepted an invalid field path", tag)
+		}
+	}
+}
+
+func TestExcludesAll_MessageAndEmptyParam(t *testing.T) {
+	rules, err := ParseTag("string;excludesall=<>")
+	if err != nil {
+		t.Fatalf("ParseTag: %v", err)
+	}
+	var es verrs.Errors
+	if err := NewCompiler(nil).Compile(rules)("a>b"); !errors.As(err, &es) {
+		t.Fatalf("expected structured error, got %v", err)
+	}
+	if es[0].Msg != "must not contain any of: <>" || es[0].Param != "<>" {
+		t.Fatalf("unexpected error: %#v", es[0])
+	}
+	if _, err := ParseTag("string;excludesall="); err == nil {
+		t.Fatal("expected empty excludesall to fail")
+	}
+}
+
+func TestNumStr_BoundsAndSyntax(t *testing.T) {
+	c := NewCompiler(nil)
+	check := func(tag, value string) string {
+		t.Helper()
+		rules, err := ParseTag(tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tag, err)
+		}
+		var es verrs.Errors
+		if err := c.Compile(rules)(value); err == nil {
+			return ""
+		} else if !errors.As(err, &es) {
+			t.Fatalf("%s %q: unexpected error %v", tag, value, err)
+		}
+		return es[0].Code
+	}
+
+	for _, value := range []string{"0", "-12", "+3.50", "007"} {
+		if code := check("string;numstr", value); code != "" {
+			t.Errorf("numstr rejected %q: %s", value, code)
+		}
+	}
+	for _, value := range []string{"", " 1", "1.", ".5", "--1", "1_000", "0x10", "Inf", "NaN", "1e3"} {
+		if code := check("string;numstr", value); code != verrs.CodeStringNumStr {
+			t.Errorf("numstr accepted %q: %q", value, code)
+		}
+	}
+	// Bounds compare exactly, beyond float64 precision.
+	if code := check("string;max_value=9007199254740992", "9007199254740993"); code != verrs.CodeStringNumStrMax {
+		t.Errorf("max_value compared inexactly: %q", code)
+	}
+	if code := check("string;min_value=0", "abc"); code != verrs.CodeStringNumStr {
+		t.Errorf("min_value on a non-number = %q", code)
+	}
+
+	rules, _ := ParseTag("string;min_value=10")
+	var es verrs.Errors
+	if err := c.Compile(rules)("9.5"); !errors.As(err, &es) || es[0].Msg != "must be at least 10" || es[0].Param != "10" {
+		t.Fatalf("min_value error = %v", err)
+	}
+	for _, tag := range []string{"string;min_value=", "string;max_value=1e3", "string;min_value=abc"} {
+		if _, err := ParseTag(tag); err == nil {
+			t.Errorf("ParseTag(%q) accepted an invalid bound", tag)
+		}
+	}
+}
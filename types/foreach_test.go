@@ -0,0 +1,37 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+type namedStrings []string
+
+func TestForEach_FastPathsMatchReflection(t *testing.T) {
+	c := NewCompiler(nil)
+	tests := []struct {
+		tag  string
+		fast any
+		slow any
+	}{
+		{"slice;foreach=(string;min=2)", []string{"ok", "x", "fine", ""}, namedStrings{"ok", "x", "fine", ""}},
+		{"slice;foreach=(int;min=0)", []int{1, -1, 2}, []int32{1, -1, 2}},
+		{"slice;foreach=(int64;max=5)", []int64{9, 1}, []any{int64(9), int64(1)}},
+		{"slice;foreach=(float;lt=1)", []float64{0.5, 2}, []float32{0.5, 2}},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		fn := c.Compile(rules)
+		fast, slow := fn(tt.fast), fn(tt.slow)
+		if fast == nil || !reflect.DeepEqual(fast, slow) {
+			t.Errorf("%s: fast path %v, reflection %v", tt.tag, fast, slow)
+		}
+	}
+	rules, _ := ParseTag("slice;foreach=(string;min=1)")
+	if err := c.Compile(rules)([]string(nil)); err != nil {
+		t.Fatalf("nil []string: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+package types
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// SetRuleFusion turns the rule fusion pass on or off; it is on by default.
+// Fusion joins runs of string length or integer bound rules into one
+// compiled check that asserts the value's type once and tests all bounds
+// together, falling back to the individual rules, in order, to report a
+// failure. Errors are identical either way, so turning it off is only useful
+// when debugging or benchmarking rule chains.
+func (c *Compiler) SetRuleFusion(enabled bool) {
+	c.noFusion = !enabled
+}
+
+// fuseFamily groups the kinds that fuse together.
+type fuseFamily int
+
+const (
+	fuseNone fuseFamily = iota
+	fuseString
+	fuseInt
+)
+
+func fuseFamilyOf(kind Kind) fuseFamily {
+	switch kind {
+	case KString, KLength, KMinLength, KMaxLength, KMinRunes, KMaxRunes:
+		return fuseString
+	case KInt, KMinInt, KMaxInt:
+		return fuseInt
+	default:
+		return fuseNone
+	}
+}
+
+// fusible reports whether rules of kind may be fused: fusion replaces the
+// built-in validators, so kinds with custom compilers or shadow sampling
+// keep their own closures.
+func (c *Compiler) fusible(kind Kind) bool {
+	if _, ok := c.custom[kind]; ok {
+		return false
+	}
+	if _, ok := c.contextCustom[kind]; ok {
+		return false
+	}
+	_, shadowed := c.shadow[kind]
+	return !shadowed
+}
+
+// fuseLen returns the length of the run of rules at the start of rules
+// that fuseRun joins, or 0 when fewer than two fuse.
+func (c *Compiler) fuseLen(rules []Rule, opts CompileOpts) int {
+	if c.noFusion || opts.CollectAll || len(c.middleware) > 0 || len(rules) < 2 {
+		return 0
+	}
+	family := fuseFamilyOf(rules[0].Kind)
+	if family == fuseNone {
+		return 0
+	}
+	n := 0
+	for n < len(rules) && fuseFamilyOf(rules[n].Kind) == family && c.fusible(rules[n].Kind) {
+		n++
+	}
+	if n < 2 {
+		return 0
+	}
+	return n
+}
+
+// fuseRun fuses the longest run of fusible rules of one family at the start
+// of rules. It returns the number of rules fused, or 0 when fewer than two
+// fuse. Collecting every rule's error needs the rules apart, so CollectAll
+// disables fusion.
+func (c *Compiler) fuseRun(rules []Rule, opts CompileOpts) (int, compiledRule) {
+	n := c.fuseLen(rules, opts)
+	if n == 0 {
+		return 0, compiledRule{}
+	}
+	family := fuseFamilyOf(rules[0].Kind)
+	parts := make([]compiledRule, n)
+	for i, rule := range rules[:n] {
+		if parts[i] = c.compileRule(rule); parts[i].err != nil {
+			return 0, compiledRule{}
+		}
+	}
+	var fast func(v any) bool
+	if family == fuseString {
+		fast = c.stringBounds(rules[:n])
+	} else {
+		fast = c.intBounds(rules[:n])
+	}
+	return n, compiledRule{validate: func(v any) error {
+		if fast(v) {
+			return nil
+		}
+		for _, part := range parts {
+			if err := part.validate(v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+// stringBounds returns a check that reports true when v is a string that
+// passes every length rule in rules.
+func (c *Compiler) stringBounds(rules []Rule) func(v any) bool {
+	minLen, maxLen := 0, -1
+	minRunes, maxRunes := 0, -1
+	runes := false
+	for _, rule := range rules {
+		n := c.getIntArg(rule, "n", 0)
+		switch rule.Kind {
+		case KLength:
+			minLen, maxLen = max(minLen, n), tighterMax(maxLen, n)
+		case KMinLength:
+			minLen = max(minLen, n)
+		case KMaxLength:
+			maxLen = tighterMax(maxLen, n)
+		case KMinRunes:
+			minRunes, runes = max(minRunes, n), true
+		case KMaxRunes:
+			maxRunes, runes = tighterMax(maxRunes, n), true
+		}
+	}
+	return func(v any) bool {
+		s, ok := v.(string)
+		if !ok || len(s) < minLen || maxLen >= 0 && len(s) > maxLen {
+			return false
+		}
+		if !runes {
+			return true
+		}
+		n := utf8.RuneCountInString(s)
+		return n >= minRunes && (maxRunes < 0 || n <= maxRunes)
+	}
+}
+
+// tighterMax returns the smaller of two maximums, where a negative current
+// maximum means unbounded.
+func tighterMax(current, n int) int {
+	if current < 0 {
+		return n
+	}
+	return min(current, n)
+}
+
+// intBounds returns a check that reports true when v is a signed integer
+// that passes every bound rule in rules.
+func (c *Compiler) intBounds(rules []Rule) func(v any) bool {
+	var lo, hi intBound
+	hasLo, hasHi := false, false
+	for _, rule := range rules {
+		n := c.getIntBoundArg(rule, "n")
+		switch rule.Kind {
+		case KMinInt:
+			if !hasLo || n.cmp(lo) > 0 {
+				lo, hasLo = n, true
+			}
+		case KMaxInt:
+			if !hasHi || n.cmp(hi) < 0 {
+				hi, hasHi = n, true
+			}
+		}
+	}
+	return func(v any) bool {
+		var x int64
+		switch n := v.(type) {
+		case int:
+			x = int64(n)
+		case int64:
+			x = n
+		case int32:
+			x = int64(n)
+		case int16:
+			x = int64(n)
+		case int8:
+			x = int64(n)
+		default:
+			return false
+		}
+		val := intBound{i: x}
+		return (!hasLo || val.cmp(lo) >= 0) && (!hasHi || val.cmp(hi) <= 0)
+	}
+}
+
+// compileChain compiles the value rules of a chain, fusing where it can.
+func (c *Compiler) compileChain(rules []Rule, opts CompileOpts) ([]compiledRule, error) {
+	out := make([]compiledRule, 0, len(rules))
+	for i := 0; i < len(rules); {
+		if n, fused := c.fuseRun(rules[i:], opts); n > 0 {
+			out = append(out, fused)
+			i += n
+			continue
+		}
+		compiled := c.compileRule(rules[i])
+		if compiled.err != nil {
+			return nil, compiled.err
+		}
+		out = append(out, compiled)
+		i++
+	}
+	return out, nil
+}
+
+// compileContextChain is compileChain for context-aware chains.
+func (c *Compiler) compileContextChain(rules []Rule, opts CompileOpts) ([]compiledContextRule, error) {
+	out := make([]compiledContextRule, 0, len(rules))
+	for i := 0; i < len(rules); {
+		if n, fused := c.fuseRun(rules[i:], opts); n > 0 {
+			out = append(out, withContext(fused))
+			i += n
+			continue
+		}
+		compiled := c.compileContextRule(rules[i])
+		if compiled.err != nil {
+			return nil, compiled.err
+		}
+		out = append(out, compiled)
+		i++
+	}
+	return out, nil
+}
+
+// withContext adapts a compiled rule to a context-aware chain, checking the
+// context before it runs.
+func withContext(compiled compiledRule) compiledContextRule {
+	return compiledContextRule{validate: func(ctx context.Context, v any) error {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return compiled.validate(v)
+	}}
+}
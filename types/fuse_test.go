@@ -0,0 +1,63 @@
+package types
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRuleFusion_MatchesUnfusedErrors(t *testing.T) {
+	tags := []string{
+		"string;min=3;max=10",
+		"string;len=4",
+		"string;min=2;minRunes=2;maxRunes=3;max=12",
+		"string;max=5;min=8",
+		"int;min=0;max=150",
+		"int;min=-5;max=9223372036854775807",
+		"int;max=10;min=20;positive",
+		"string;min=1;alpha;max=4",
+	}
+	values := []any{nil, "", "ab", "abc", "abcd", "héllo", "ééé", "abcdefghijk", 0, -6, 5, int8(-1), int64(151), uint(3), "42", 3.5, true}
+	fused := NewCompiler(nil)
+	plain := NewCompiler(nil)
+	plain.SetRuleFusion(false)
+	for _, tag := range tags {
+		rules, err := ParseTag(tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tag, err)
+		}
+		f, err := fused.CompileE(rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, _ := plain.CompileE(rules)
+		fc, _ := fused.CompileContextE(rules)
+		for _, v := range values {
+			want := p(v)
+			if got := f(v); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %#v: fused %v, unfused %v", tag, v, got, want)
+			}
+			if got := fc(context.Background(), v); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: %#v: fused context %v, unfused %v", tag, v, got, want)
+			}
+		}
+	}
+}
+
+func TestRuleFusion_Runs(t *testing.T) {
+	rules, _ := ParseTag("string;min=1;max=4;alpha;minRunes=1;maxRunes=3")
+	c := NewCompiler(nil)
+	chain, err := c.compileChain(rules, CompileOpts{})
+	if err != nil || len(chain) != 3 {
+		t.Fatalf("fused chain has %d rules, want 3 (err %v)", len(chain), err)
+	}
+	if chain, _ := c.compileChain(rules, CompileOpts{CollectAll: true}); len(chain) != len(rules) {
+		t.Fatalf("CollectAll chain has %d rules, want %d", len(chain), len(rules))
+	}
+	c.RegisterRule(KMaxLength, func(c *Compiler, rule Rule) (func(any) error, error) {
+		return func(any) error { return nil }, nil
+	})
+	if chain, _ := c.compileChain(rules, CompileOpts{}); len(chain) != 4 {
+		t.Fatalf("chain with custom max has %d rules, want 4", len(chain))
+	}
+}
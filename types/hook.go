@@ -0,0 +1,55 @@
+package types
+
+import "fmt"
+
+// CompileHook wraps the compilation of one rule of the kind it is
+// registered for. It calls compile to compile the rule and returns its
+// error, so it can time or log the compilation, or it returns an error of
+// its own, with or without calling compile, to reject the rule. Returning
+// nil without calling compile is an error. Hooks run when a rule is
+// compiled, never when a compiled validator runs.
+type CompileHook func(rule Rule, compile func() error) error
+
+// OnCompile registers hook around the compilation of rules of kind,
+// including rules nested in foreach, keys and values. Hooks for one kind
+// chain: the first registered runs outermost.
+//
+// Parameters:
+//   - kind: The rule kind to wrap, such as KRegex.
+//   - hook: The hook; it must be safe for concurrent use when the compiler
+//     is shared.
+func (c *Compiler) OnCompile(kind Kind, hook CompileHook) {
+	if c.compileHooks == nil {
+		c.compileHooks = map[Kind][]CompileHook{}
+	}
+	c.compileHooks[kind] = append(c.compileHooks[kind], hook)
+}
+
+// hookCompile runs compile inside the hooks registered for rule's kind.
+// Errors returned by a hook, other than compile's own, become compile
+// errors of the rule.
+func (c *Compiler) hookCompile(rule Rule, compile func() error) error {
+	hooks := c.compileHooks[rule.Kind]
+	if len(hooks) == 0 {
+		return compile()
+	}
+	ran := false
+	var compileErr error
+	next := func() error {
+		ran = true
+		compileErr = compile()
+		return compileErr
+	}
+	for i := len(hooks) - 1; i > 0; i-- {
+		hook, inner := hooks[i], next
+		next = func() error { return hook(rule, inner) }
+	}
+	err := hooks[0](rule, next)
+	switch {
+	case err == nil && !ran:
+		err = fmt.Errorf("compile hook returned without compiling")
+	case err == nil || err == compileErr:
+		return compileErr
+	}
+	return newCompileError(rule.Kind, fmt.Errorf("compile rule %s: %w", safeRuleKindForError(rule.Kind), err))
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOnCompile_WrapsNestedRulesInOrder(t *testing.T) {
+	c := NewCompiler(nil)
+	var calls []string
+	c.OnCompile(KRegex, func(rule Rule, compile func() error) error {
+		calls = append(calls, "outer:"+rule.Args["pattern"].(string))
+		return compile()
+	})
+	c.OnCompile(KRegex, func(rule Rule, compile func() error) error {
+		calls = append(calls, "inner")
+		return compile()
+	})
+	rules, err := ParseTag("slice;foreach=(string;regex=^[a-z]+$)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn, err := c.CompileE(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(calls, ","); got != "outer:^[a-z]+$,inner" {
+		t.Fatalf("calls = %s", got)
+	}
+	if err := fn([]string{"A"}); err == nil {
+		t.Fatal("hooked regex rule did not validate")
+	}
+}
+
+func TestOnCompile_RejectsRules(t *testing.T) {
+	c := NewCompiler(nil)
+	banned := errors.New("regex rules are not allowed")
+	c.OnCompile(KRegex, func(Rule, func() error) error { return banned })
+	rules, _ := ParseTag("string;min=1;regex=^a$")
+	_, err := c.CompileE(rules)
+	var ce *CompileError
+	if !errors.As(err, &ce) || ce.Kind != KRegex || !errors.Is(err, banned) {
+		t.Fatalf("CompileE = %v", err)
+	}
+	if _, err := c.CompileContextE(rules); !errors.Is(err, banned) {
+		t.Fatalf("CompileContextE = %v", err)
+	}
+
+	// Hooks on other kinds leave the chain alone.
+	rules, _ = ParseTag("string;min=1")
+	if _, err := c.CompileE(rules); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOnCompile_HookMustCompile(t *testing.T) {
+	c := NewCompiler(nil)
+	c.OnCompile(KMinLength, func(Rule, func() error) error { return nil })
+	rules, _ := ParseTag("string;min=1")
+	if _, err := c.CompileE(rules); err == nil {
+		t.Fatal("hook that skipped compile did not fail")
+	}
+}
+
+func TestOnCompile_ContextRules(t *testing.T) {
+	c := NewCompiler(nil)
+	c.RegisterContextRule("remote", func(*Compiler, Rule) (ContextValidatorFunc, error) {
+		return func(context.Context, any) error { return nil }, nil
+	})
+	compiled := 0
+	c.OnCompile("remote", func(_ Rule, compile func() error) error {
+		compiled++
+		return compile()
+	})
+	if _, err := c.CompileContextE([]Rule{NewRule(KString, nil), NewRule("remote", nil)}); err != nil {
+		t.Fatal(err)
+	}
+	if compiled != 1 {
+		t.Fatalf("hook ran %d times", compiled)
+	}
+}
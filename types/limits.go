@@ -0,0 +1,57 @@
+package types
+
+// DefaultLimits bounds string, slice and map rule chains that have no
+// explicit maximum, so inputs are never unbounded by accident. A chain
+// counts as bounded when it has max, len or maxRunes (and oneof for
+// strings); the unbounded modifier opts a chain out.
+//
+// Fields:
+//   - String: Maximum byte length of strings; 0 leaves them unbounded.
+//   - Slice: Maximum number of slice elements; 0 leaves them unbounded.
+//   - Map: Maximum number of map keys; 0 leaves them unbounded.
+type DefaultLimits struct {
+	String int
+	Slice  int
+	Map    int
+}
+
+// SetDefaultLimits applies l to chains compiled afterwards, including the
+// element chains of foreach, keys and values.
+func (c *Compiler) SetDefaultLimits(l DefaultLimits) {
+	c.limits = l
+}
+
+// boundedKinds lists, per base kind, the rules that bound a chain.
+var boundedKinds = map[Kind][]Kind{
+	KString: {KMaxLength, KLength, KMaxRunes, KOneOf},
+	KSlice:  {KMaxSliceLength, KSliceLength},
+	KMap:    {KMaxMapKeys, KMapLength},
+}
+
+// withDefaultLimit returns chain with the default maximum of its base kind
+// inserted right after the base rule, ahead of costlier rules such as
+// regex, when the chain has no bound of its own.
+func (c *Compiler) withDefaultLimit(chain []Rule) []Rule {
+	if len(chain) == 0 {
+		return chain
+	}
+	var limit Rule
+	switch base := chain[0].Kind; {
+	case base == KString && c.limits.String > 0:
+		limit = NewRule(KMaxLength, map[string]any{"n": c.limits.String})
+	case base == KSlice && c.limits.Slice > 0:
+		limit = NewRule(KMaxSliceLength, map[string]any{"n": c.limits.Slice})
+	case base == KMap && c.limits.Map > 0:
+		limit = NewRule(KMaxMapKeys, map[string]any{"n": c.limits.Map})
+	default:
+		return chain
+	}
+	for _, kind := range boundedKinds[chain[0].Kind] {
+		if hasKind(chain, kind) {
+			return chain
+		}
+	}
+	out := make([]Rule, 0, len(chain)+1)
+	out = append(out, chain[0], limit)
+	return append(out, chain[1:]...)
+}
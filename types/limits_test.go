@@ -0,0 +1,45 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestSetDefaultLimits(t *testing.T) {
+	c := NewCompiler(nil)
+	c.SetDefaultLimits(DefaultLimits{String: 4, Slice: 2, Map: 1})
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"string", "abcde", verrs.CodeStringMax},
+		{"string;regex=^[a-z]+$", "abcde", verrs.CodeStringMax},
+		{"string;max=10", "abcde", ""},
+		{"string;maxRunes=10", "abcde", ""},
+		{"string;unbounded", strings.Repeat("a", 100), ""},
+		{"slice", []int{1, 2, 3}, verrs.CodeSliceMax},
+		{"slice;foreach=(string)", []string{"abcde"}, verrs.CodeStringMax},
+		{"map", map[string]int{"a": 1, "b": 2}, verrs.CodeMapMaxKeys},
+		{"map;max=5", map[string]int{"a": 1, "b": 2}, ""},
+		{"int", 1 << 40, ""},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tag, err)
+		}
+		err = c.Compile(rules)(tt.value)
+		if tt.code == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.tag, err)
+			}
+			continue
+		}
+		if es, ok := err.(verrs.Errors); !ok || es[0].Code != tt.code {
+			t.Errorf("%s: error = %v, want %s", tt.tag, err, tt.code)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package types
+
+import "context"
+
+// RuleInfo describes the rule a Middleware wraps.
+//
+// Fields:
+//   - Kind: The rule kind, such as KRegex, for labeling metrics and spans.
+//   - Args: The rule arguments; shared with the compiled rule, so they must
+//     not be modified.
+type RuleInfo struct {
+	Kind Kind
+	Args map[string]any
+}
+
+// Middleware wraps the check of one compiled rule for logging, metrics,
+// tracing or panic recovery. It is called once per rule at compile time and
+// returns the check to run instead of next, which it calls to run the rule.
+// During struct validation FieldPath(ctx) reports the path of the field
+// being checked.
+type Middleware func(info RuleInfo, next ContextValidatorFunc) ContextValidatorFunc
+
+// Use registers mw around every rule compiled afterwards, including rules
+// nested in foreach, keys and values. Middleware chains: the first
+// registered runs outermost. Rules are not fused while middleware is
+// registered, so every rule's check runs through it.
+//
+// Parameters:
+//   - mw: The middleware; it must be safe for concurrent use when the
+//     compiler is shared.
+func (c *Compiler) Use(mw Middleware) {
+	c.middleware = append(c.middleware, mw)
+}
+
+// wrapContextRule runs validate inside the registered middleware.
+func (c *Compiler) wrapContextRule(rule Rule, validate ContextValidatorFunc) ContextValidatorFunc {
+	info := RuleInfo{Kind: rule.Kind, Args: rule.Args}
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		validate = c.middleware[i](info, validate)
+	}
+	return validate
+}
+
+// wrapRule is wrapContextRule for checks without a context; the
+// middleware sees context.Background().
+func (c *Compiler) wrapRule(rule Rule, validate func(any) error) func(any) error {
+	wrapped := c.wrapContextRule(rule, func(_ context.Context, v any) error { return validate(v) })
+	return func(v any) error { return wrapped(context.Background(), v) }
+}
+
+type fieldPathKey struct{}
+
+// WithFieldPath returns a context carrying the path of the field being
+// validated, for FieldPath.
+func WithFieldPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, fieldPathKey{}, path)
+}
+
+// FieldPath returns the field path carried by ctx, or "" outside struct
+// validation.
+func FieldPath(ctx context.Context) string {
+	path, _ := ctx.Value(fieldPathKey{}).(string)
+	return path
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestUse_WrapsEveryRuleInOrder(t *testing.T) {
+	c := NewCompiler(nil)
+	var calls []string
+	c.Use(func(info RuleInfo, next ContextValidatorFunc) ContextValidatorFunc {
+		return func(ctx context.Context, v any) error {
+			calls = append(calls, "outer:"+string(info.Kind))
+			return next(ctx, v)
+		}
+	})
+	c.Use(func(info RuleInfo, next ContextValidatorFunc) ContextValidatorFunc {
+		return func(ctx context.Context, v any) error {
+			calls = append(calls, "inner:"+FieldPath(ctx))
+			return next(ctx, v)
+		}
+	})
+	rules, _ := ParseTag("string;min=1;max=5")
+	fn, err := c.CompileContextE(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fusion is off, so valid values still run every rule.
+	if err := fn(WithFieldPath(context.Background(), "Name"), "abc"); err != nil {
+		t.Fatal(err)
+	}
+	want := "outer:string,inner:Name,outer:minLength,inner:Name,outer:maxLength,inner:Name"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("calls = %s, want %s", got, want)
+	}
+
+	calls = nil
+	if err := c.Compile(rules)("abcdef"); err == nil {
+		t.Fatal("wrapped rule did not fail")
+	}
+	if len(calls) != 6 || calls[1] != "inner:" {
+		t.Fatalf("calls = %v", calls)
+	}
+}
+
+func TestUse_RecoversPanics(t *testing.T) {
+	c := NewCompiler(nil)
+	c.RegisterRule("boom", func(*Compiler, Rule) (func(any) error, error) {
+		return func(any) error { panic("boom") }, nil
+	})
+	c.Use(func(info RuleInfo, next ContextValidatorFunc) ContextValidatorFunc {
+		return func(ctx context.Context, v any) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = context.Canceled
+				}
+			}()
+			return next(ctx, v)
+		}
+	})
+	if err := c.Compile([]Rule{NewRule(KString, nil), NewRule("boom", nil)})("x"); err != context.Canceled {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// ErrKindConflict is returned when a namespaced kind is already registered.
+var ErrKindConflict = errors.New("rule kind already registered")
+
+// NamespacedKind returns the kind for name in namespace, e.g. "acme.phone".
+func NamespacedKind(namespace, name string) Kind {
+	return Kind(namespace + "." + name)
+}
+
+// RegisterNamespacedRule registers a global rule compiler under
+// namespace.name. Unlike RegisterRule it never replaces an existing
+// registration: a second plugin claiming the same kind gets ErrKindConflict.
+//
+// Tags may use the full kind (`string;acme.phone`) or, when exactly one
+// namespace defines the name and no plain kind of that name exists, the
+// short name (`string;phone`).
+func RegisterNamespacedRule(namespace, name string, rc RuleCompiler) (Kind, error) {
+	for _, part := range []string{namespace, name} {
+		if err := validateCustomRuleName(part); err != nil {
+			return "", err
+		}
+		if strings.Contains(part, ".") {
+			return "", fmt.Errorf("namespace and rule name must not contain '.': %s", truncateForError(part, 50))
+		}
+	}
+	kind := NamespacedKind(namespace, name)
+	globalRegistryMu.Lock()
+	defer globalRegistryMu.Unlock()
+	if _, exists := globalRegistry[kind]; exists {
+		return "", fmt.Errorf("%w: %s", ErrKindConflict, kind)
+	}
+	globalRegistry[kind] = rc
+	globalRegistryVersion++
+	return kind, nil
+}
+
+// resolveShortKind finds the namespaced compiler for a kind without a
+// namespace. It reports whether a compiler was found and returns a compile
+// error when several namespaces define the name.
+func (c *Compiler) resolveShortKind(kind Kind) (Kind, bool, error) {
+	if strings.Contains(string(kind), ".") {
+		return "", false, nil
+	}
+	suffix := "." + string(kind)
+	var matches []string
+	for candidate := range c.custom {
+		if strings.HasSuffix(string(candidate), suffix) {
+			matches = append(matches, string(candidate))
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", false, nil
+	case 1:
+		return Kind(matches[0]), true, nil
+	default:
+		sort.Strings(matches)
+		msg := fmt.Sprintf("ambiguous rule kind %s: matches %s; use a namespaced kind",
+			safeRuleKindForError(kind), strings.Join(matches, ", "))
+		return "", false, verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeUnknown, Msg: msg}}
+	}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRegisterNamespacedRule_ConflictsAndResolution(t *testing.T) {
+	accept := func(*Compiler, Rule) (func(any) error, error) {
+		return func(any) error { return nil }, nil
+	}
+	reject := func(*Compiler, Rule) (func(any) error, error) {
+		return func(any) error { return errors.New("rejected") }, nil
+	}
+
+	kind, err := RegisterNamespacedRule("nstesta", "nsphone", accept)
+	if err != nil || kind != "nstesta.nsphone" {
+		t.Fatalf("register: kind=%q err=%v", kind, err)
+	}
+	if _, err := RegisterNamespacedRule("nstesta", "nsphone", reject); !errors.Is(err, ErrKindConflict) {
+		t.Fatalf("expected ErrKindConflict, got %v", err)
+	}
+	if _, err := RegisterNamespacedRule("bad.ns", "x", accept); err == nil {
+		t.Fatal("expected dotted namespace to be rejected")
+	}
+
+	rules, err := ParseTag("string;nsphone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn, err := NewCompiler(nil).CompileE(rules)
+	if err != nil {
+		t.Fatalf("short name should resolve: %v", err)
+	}
+	if err := fn("x"); err != nil {
+		t.Fatalf("resolved rule: %v", err)
+	}
+
+	if _, err := RegisterNamespacedRule("nstestb", "nsphone", reject); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCompiler(nil).CompileE(rules); err == nil || !strings.Contains(err.Error(), "ambiguous rule kind nsphone") {
+		t.Fatalf("expected ambiguity error, got %v", err)
+	}
+
+	rules, err = ParseTag("string;nstestb.nsphone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn, err = NewCompiler(nil).CompileE(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn("x"); err == nil {
+		t.Fatal("full kind must select the nstestb rule")
+	}
+}
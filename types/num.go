@@ -53,3 +53,140 @@ func toInt64(v any) (int64, bool) {
 	// No float acceptance to avoid silent truncation.
 	return 0, false
 }
+
+/*
+toUint64 attempts to coerce supported integer representations to uint64.
+It rejects negative values, non-integer floats and strings that are not
+base-10 unsigned integers.
+*/
+func toUint64(v any) (uint64, bool) {
+	switch x := v.(type) {
+	case uint:
+		return uint64(x), true
+	case uint8:
+		return uint64(x), true
+	case uint16:
+		return uint64(x), true
+	case uint32:
+		return uint64(x), true
+	case uint64:
+		return x, true
+	case string:
+		u, err := strconv.ParseUint(x, 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		return u, true
+	}
+	if n, ok := toInt64(v); ok && n >= 0 {
+		return uint64(n), true
+	}
+	return 0, false
+}
+
+/*
+intBound is an integer that may exceed the int64 range on the positive side.
+It lets `int` rules compare uint64 values and parameters without overflow.
+*/
+type intBound struct {
+	i    int64
+	u    uint64
+	wide bool // u holds a value above math.MaxInt64
+}
+
+// toIntBound coerces integer representations, including uint64 values above
+// math.MaxInt64, to an intBound.
+func toIntBound(v any) (intBound, bool) {
+	if n, ok := toInt64(v); ok {
+		return intBound{i: n}, true
+	}
+	switch x := v.(type) {
+	case uint:
+		return intBound{u: uint64(x), wide: true}, true
+	case uint64:
+		return intBound{u: x, wide: true}, true
+	case string:
+		u, err := strconv.ParseUint(x, 10, 64)
+		if err != nil {
+			return intBound{}, false
+		}
+		return intBound{u: u, wide: true}, true
+	}
+	return intBound{}, false
+}
+
+// cmp returns -1, 0 or 1 as a is less than, equal to or greater than b.
+func (a intBound) cmp(b intBound) int {
+	switch {
+	case a.wide && b.wide:
+		return cmpOrdered(a.u, b.u)
+	case a.wide:
+		return 1
+	case b.wide:
+		return -1
+	default:
+		return cmpOrdered(a.i, b.i)
+	}
+}
+
+// magnitude returns the absolute value of the bound. It is exact for
+// math.MinInt64, whose negation wraps to itself as int64.
+func (a intBound) magnitude() uint64 {
+	switch {
+	case a.wide:
+		return a.u
+	case a.i < 0:
+		return uint64(-a.i)
+	default:
+		return uint64(a.i)
+	}
+}
+
+// value returns the bound as int64 or uint64 for messages and params.
+func (a intBound) value() any {
+	if a.wide {
+		return a.u
+	}
+	return a.i
+}
+
+// cmpIntFloat compares the integer v with the float bound n exactly, even
+// beyond the 2^53 range where float64 loses integers. ok is false when v
+// is not an integer type or n is NaN.
+func cmpIntFloat(v any, n float64) (cmp int, ok bool) {
+	if _, isString := v.(string); isString || math.IsNaN(n) {
+		return 0, false
+	}
+	b, ok := toIntBound(v)
+	if !ok {
+		return 0, false
+	}
+	// Rounding to float64 is monotonic, so unequal floats order the
+	// integers; equal ones are integral when they are this large.
+	f := float64(b.i)
+	if b.wide {
+		f = float64(b.u)
+	}
+	if f != n {
+		return cmpOrdered(f, n), true
+	}
+	switch {
+	case b.wide && n >= 1<<64, !b.wide && n >= 1<<63:
+		return -1, true
+	case b.wide:
+		return cmpOrdered(b.u, uint64(n)), true
+	default:
+		return cmpOrdered(b.i, int64(n)), true
+	}
+}
+
+func cmpOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,265 @@
+package types
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestParseTag_IntParamsBeyondInt64(t *testing.T) {
+	rules, err := ParseTag("int;min=1;max=18446744073709551615")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := rules[2].Args["n"].(uint64); !ok || got != math.MaxUint64 {
+		t.Fatalf("max arg = %#v", rules[2].Args["n"])
+	}
+
+	fn := NewCompiler(nil).Compile(rules)
+	if err := fn(uint64(math.MaxUint64)); err != nil {
+		t.Fatalf("MaxUint64 within bounds: %v", err)
+	}
+	fn = NewCompiler(nil).Compile([]Rule{NewRule(KInt, nil), NewRule(KMaxInt, map[string]any{"n": int64(10)})})
+	var es verrs.Errors
+	if err := fn(uint64(math.MaxUint64)); !errors.As(err, &es) || es[0].Code != verrs.CodeIntMax {
+		t.Fatalf("expected int.max for large uint64, got %v", err)
+	}
+}
+
+func TestUint_BoundsAndTypes(t *testing.T) {
+	rules, err := ParseTag("uint;min=1;max=18446744073709551615")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := rules[2].Args["n"].(uint64); rules[2].Kind != KMaxUint || !ok || got != math.MaxUint64 {
+		t.Fatalf("max rule = %s %#v", rules[2].Kind, rules[2].Args["n"])
+	}
+	fn := NewCompiler(nil).Compile(rules)
+	code := func(v any) string {
+		t.Helper()
+		var es verrs.Errors
+		if err := fn(v); err == nil {
+			return ""
+		} else if !errors.As(err, &es) {
+			t.Fatalf("%#v: unexpected error %v", v, err)
+		}
+		return es[0].Code
+	}
+	for _, v := range []any{uint64(math.MaxUint64), uint8(1), uint(7)} {
+		if got := code(v); got != "" {
+			t.Errorf("%#v: %s", v, got)
+		}
+	}
+	if got := code(uint32(0)); got != verrs.CodeUintMin {
+		t.Errorf("zero = %q, want uint.min", got)
+	}
+	if got := code(int(5)); got != verrs.CodeUintType {
+		t.Errorf("signed int = %q, want uint.type", got)
+	}
+
+	rules, _ = ParseTag("uint64;max=1e19")
+	fn = NewCompiler(nil).Compile(rules)
+	var es verrs.Errors
+	if err := fn(uint64(1e19) + 1); !errors.As(err, &es) || es[0].Code != verrs.CodeUintMax || es[0].Param != uint64(1e19) {
+		t.Fatalf("uint64 max error = %v", err)
+	}
+	if err := fn(uint32(1)); !errors.As(err, &es) || es[0].Code != verrs.CodeUint64Type {
+		t.Fatalf("uint32 for uint64 = %v", err)
+	}
+}
+
+func TestMultipleOf_IntsAndFloats(t *testing.T) {
+	c := NewCompiler(nil)
+	check := func(tag string, v any) string {
+		t.Helper()
+		rules, err := ParseTag(tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tag, err)
+		}
+		var es verrs.Errors
+		if err := c.Compile(rules)(v); err == nil {
+			return ""
+		} else if !errors.As(err, &es) {
+			t.Fatalf("%s %#v: unexpected error %v", tag, v, err)
+		}
+		return es[0].Code
+	}
+	tests := []struct {
+		tag  string
+		v    any
+		want string
+	}{
+		{"int;multipleof=10", 30, ""},
+		{"int;multipleof=10", -20, ""},
+		{"int;multipleof=10", 25, verrs.CodeIntMultipleOf},
+		{"int;multipleof=10", -25, verrs.CodeIntMultipleOf},
+		{"int;multipleof=9223372036854775808", int64(math.MinInt64), ""},
+		{"int;multipleof=3", uint64(math.MaxUint64), ""},
+		{"uint;multipleof=4096", uint64(8192), ""},
+		{"uint;multipleof=4096", uint(4097), verrs.CodeIntMultipleOf},
+		{"float;multipleof=0.01", 19.99, ""},
+		{"float;multipleof=0.01", 0.1 + 0.2, ""},
+		{"float;multipleof=0.01", 19.995, verrs.CodeNumberMultipleOf},
+		{"float;multipleof=0.5", math.Inf(1), verrs.CodeNumberMultipleOf},
+	}
+	for _, tt := range tests {
+		if got := check(tt.tag, tt.v); got != tt.want {
+			t.Errorf("%s %#v: code %q, want %q", tt.tag, tt.v, got, tt.want)
+		}
+	}
+
+	rules, _ := ParseTag("int;multipleof=10")
+	var es verrs.Errors
+	if err := c.Compile(rules)(7); !errors.As(err, &es) || es[0].Msg != "must be a multiple of 10" || es[0].Param != int64(10) {
+		t.Fatalf("int.multipleOf error = %v", err)
+	}
+	for _, tag := range []string{"int;multipleof=0", "int;multipleof=-5", "uint;multipleof=0", "float;multipleof=0", "float;multipleof=-0.1", "int;multipleof=1.5"} {
+		if _, err := ParseTag(tag); err == nil {
+			t.Errorf("ParseTag(%q) accepted an invalid step", tag)
+		}
+	}
+	if _, err := c.CompileE([]Rule{NewRule(KInt, nil), NewRule(KMultipleOf, map[string]any{"n": 0})}); !errors.Is(err, ErrInvalidRuleArg) {
+		t.Fatalf("compile with zero step: %v", err)
+	}
+}
+
+func TestNumberCompare_ExactForLargeIntegers(t *testing.T) {
+	c := NewCompiler(nil)
+	c.SetBehavior(Behavior{ExactIntBounds: true})
+	tests := []struct {
+		tag  string
+		v    any
+		want string
+	}{
+		{"int;lte=9007199254740992", int64(9007199254740993), verrs.CodeNumberLessThanEqual},
+		{"int;lte=9007199254740992", int64(9007199254740992), ""},
+		{"int;gt=9007199254740992", int64(9007199254740993), ""},
+		{"int;lt=9223372036854775807", int64(math.MaxInt64), ""},
+		{"int;gte=9223372036854775807", int64(math.MaxInt64), verrs.CodeNumberGreaterThanEqual},
+		{"uint;gt=18446744073709551615", uint64(math.MaxUint64), verrs.CodeNumberGreaterThan}, // the bound rounds to 2^64
+		{"int;between=0,9007199254740992", int64(9007199254740993), verrs.CodeNumberBetween},
+		{"int;gt=0", 0, verrs.CodeNumberGreaterThan},
+		{"int;gte=0.5", 0, verrs.CodeNumberGreaterThanEqual},
+		{"float;lt=1", math.NaN(), verrs.CodeNumberLessThan},
+		{"float;gte=1", 1.0, ""},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		var got string
+		var es verrs.Errors
+		if err := c.Compile(rules)(tt.v); errors.As(err, &es) {
+			got = es[0].Code
+		} else if err != nil {
+			t.Fatalf("%s %#v: unexpected error %v", tt.tag, tt.v, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s %#v: code %q, want %q", tt.tag, tt.v, got, tt.want)
+		}
+	}
+
+	// Without ExactIntBounds integers round to float64 first.
+	rules, err := ParseTag("int;lte=9007199254740992")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewCompiler(nil).Compile(rules)(int64(9007199254740993)); err != nil {
+		t.Fatalf("default behavior rejected a value that rounds to the bound: %v", err)
+	}
+}
+
+func TestParseTag_NumericParamErrors(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"int;min=1.5", "min=1.5 is not an integer"},
+		{"int;max=18446744073709551616", "max=18446744073709551616 overflows uint64"},
+		{"int;min=-9223372036854775809", "min=-9223372036854775809 overflows int64"},
+		{"int64;max=9223372036854775808", "max=9223372036854775808 overflows int64"},
+		{"int;max=abc", "max=abc is not an integer"},
+		{"uint;min=-1", "min=-1 must not be negative"},
+		{"uint64;max=18446744073709551616", "max=18446744073709551616 overflows uint64"},
+		{"float;max=1e400", "max=1e400 overflows float64"},
+		{"float;between=1,x", "between=x is not a number"},
+	}
+	for _, tt := range tests {
+		_, err := ParseTag(tt.tag)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Fatalf("%s: error = %v, want %q", tt.tag, err, tt.want)
+		}
+	}
+}
+
+func TestCompile_NaNParams(t *testing.T) {
+	for _, tag := range []string{"float;min=NaN", "int;gt=nan", "float;between=0,NaN"} {
+		rules, err := ParseTag(tag)
+		if err != nil {
+			t.Fatalf("%s: parse error %v", tag, err)
+		}
+		if _, err := NewCompiler(nil).CompileE(rules); err != nil {
+			t.Fatalf("%s: default behavior rejected the tag: %v", tag, err)
+		}
+		c := NewCompiler(nil)
+		c.SetBehavior(Behavior{RejectNaNParams: true})
+		var ce *CompileError
+		if _, err := c.CompileE(rules); !errors.As(err, &ce) || !strings.Contains(err.Error(), "bound is NaN") {
+			t.Fatalf("%s: RejectNaNParams error = %v", tag, err)
+		}
+	}
+}
+
+func TestParseTag_ReadableNumericParams(t *testing.T) {
+	tests := []struct {
+		tag  string
+		kind Kind
+		want any
+	}{
+		{"int;max=1e6", KMaxInt, int64(1_000_000)},
+		{"int;max=1_000_000", KMaxInt, int64(1_000_000)},
+		{"int;min=-2.5e3", KMinInt, int64(-2500)},
+		{"int;max=1.5e19", KMaxInt, uint64(15_000_000_000_000_000_000)},
+		{"float;max=1_000.5", KMaxNumber, 1000.5},
+		{"float;min=2.5e-3", KMinNumber, 0.0025},
+		{"string;max=64_000", KMaxLength, int64(64000)},
+		{"slice;max=1e3", KMaxSliceLength, int64(1000)},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.tag, err)
+		}
+		got := rules[1]
+		if got.Kind != tt.kind || got.Args["n"] != tt.want {
+			t.Fatalf("%s: got %s %#v, want %s %#v", tt.tag, got.Kind, got.Args["n"], tt.kind, tt.want)
+		}
+	}
+}
+
+func TestParseTag_AmbiguousNumericParams(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"int;max=1,000", "max=1,000 is ambiguous"},
+		{"float;max=1.000.000", "max=1.000.000 is ambiguous"},
+		{"int;max=_100", "max=_100 has a misplaced '_'"},
+		{"int;max=1__000", "max=1__000 has a misplaced '_'"},
+		{"float;max=1_.5", "max=1_.5 has a misplaced '_'"},
+		{"int;max=1.5e0", "max=1.5e0 is not an integer"},
+		{"int64;max=1e19", "max=1e19 overflows int64"},
+		{"string;max=1e30", "max=1e30 overflows int64"},
+		{"int;max=Inf", "max=Inf is not an integer"},
+	}
+	for _, tt := range tests {
+		_, err := ParseTag(tt.tag)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Fatalf("%s: error = %v, want %q", tt.tag, err, tt.want)
+		}
+	}
+}
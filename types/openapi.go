@@ -0,0 +1,168 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+// OpenAPISchema is an OpenAPI 3.0 schema object, as emitted for components.
+// Length bounds of string rules are emitted as minLength and maxLength,
+// which OpenAPI counts in characters; byte-length rules are only exact for
+// ASCII values.
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	ReadOnly             bool                      `json:"readOnly,omitempty"`
+	WriteOnly            bool                      `json:"writeOnly,omitempty"`
+	Enum                 []any                     `json:"enum,omitempty"`
+	MinLength            *int64                    `json:"minLength,omitempty"`
+	MaxLength            *int64                    `json:"maxLength,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool                      `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool                      `json:"exclusiveMaximum,omitempty"`
+	MultipleOf           *float64                  `json:"multipleOf,omitempty"`
+	MinItems             *int64                    `json:"minItems,omitempty"`
+	MaxItems             *int64                    `json:"maxItems,omitempty"`
+	UniqueItems          bool                      `json:"uniqueItems,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	MinProperties        *int64                    `json:"minProperties,omitempty"`
+	MaxProperties        *int64                    `json:"maxProperties,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+}
+
+// OpenAPISchemaFor returns the OpenAPI schema of a parsed rule chain. The
+// type comes from the base rule, formats from KindDoc.Format, and
+// constraints from length, range, pattern, enum and collection rules.
+// Rules without an OpenAPI equivalent, such as prefix or time bounds, are
+// left out, and Type is empty for custom base types.
+func OpenAPISchemaFor(rules []Rule) *OpenAPISchema {
+	s := &OpenAPISchema{}
+	base := baseKind(rules)
+	switch base {
+	case KString:
+		s.Type = "string"
+	case KInt:
+		s.Type = "integer"
+	case KInt64:
+		s.Type, s.Format = "integer", "int64"
+	case KUint:
+		s.Type = "integer"
+	case KUint64:
+		s.Type, s.Format = "integer", "uint64"
+	case KFloat:
+		s.Type = "number"
+	case KBool:
+		s.Type = "boolean"
+	case KTime:
+		s.Type, s.Format = "string", "date-time"
+		if layout := timeLayout(rules); layout == DateLayout {
+			s.Format = "date"
+		} else if layout != time.RFC3339Nano {
+			s.Format = ""
+		}
+	case KSlice, KArray:
+		s.Type = "array"
+	case KMap:
+		s.Type = "object"
+	}
+
+	for _, r := range rules {
+		n := int64(argInt(r, "n"))
+		switch r.Kind {
+		case KLength:
+			s.MinLength, s.MaxLength = &n, &n
+		case KMinLength, KMinRunes:
+			s.MinLength = maxBound(s.MinLength, n)
+		case KMaxLength, KMaxRunes:
+			s.MaxLength = minBound(s.MaxLength, n)
+		case KNonEmpty:
+			s.MinLength = maxBound(s.MinLength, 1)
+		case KRegex:
+			s.Pattern = normalizeRegexPattern(argString(r, "pattern"))
+		case KOneOf:
+			values, _ := r.Args["values"].([]string)
+			for _, v := range values {
+				s.Enum = append(s.Enum, v)
+			}
+		case KEnum:
+			values, names := enumRuleValues(r)
+			if base == KString {
+				for _, name := range names {
+					s.Enum = append(s.Enum, name)
+				}
+				continue
+			}
+			for _, v := range values {
+				s.Enum = append(s.Enum, v)
+			}
+		case KBoolTrue:
+			s.Enum = []any{true}
+		case KBoolFalse:
+			s.Enum = []any{false}
+		case KSliceLength, KArrayLength:
+			s.MinItems, s.MaxItems = &n, &n
+		case KMinSliceLength, KMinArrayLength:
+			s.MinItems = maxBound(s.MinItems, n)
+		case KMaxSliceLength, KMaxArrayLength:
+			s.MaxItems = minBound(s.MaxItems, n)
+		case KSliceUnique, KArrayUnique:
+			// uniqueItems compares whole items, which is stricter than
+			// uniqueness by field.
+			if argString(r, "field") == "" {
+				s.UniqueItems = true
+			}
+		case KForEach, KArrayForEach:
+			if elem, ok := r.Args["rules"].([]Rule); ok {
+				s.Items = OpenAPISchemaFor(elem)
+			}
+		case KMapLength:
+			s.MinProperties, s.MaxProperties = &n, &n
+		case KMinMapKeys:
+			s.MinProperties = maxBound(s.MinProperties, n)
+		case KMaxMapKeys:
+			s.MaxProperties = minBound(s.MaxProperties, n)
+		case KMapValues:
+			if elem, ok := r.Args["rules"].([]Rule); ok {
+				s.AdditionalProperties = OpenAPISchemaFor(elem)
+			}
+		default:
+			if doc, ok := DescribeKind(r.Kind); ok && doc.Format != "" {
+				s.Format = doc.Format
+			}
+		}
+	}
+
+	if s.Type == "integer" || s.Type == "number" {
+		lo, hi, loEx, hiEx := numberBounds(rules)
+		if !math.IsInf(lo, -1) {
+			s.Minimum, s.ExclusiveMinimum = &lo, loEx
+		}
+		if !math.IsInf(hi, 1) {
+			s.Maximum, s.ExclusiveMaximum = &hi, hiEx
+		}
+		if step := multipleOfStep(rules); step > 0 {
+			s.MultipleOf = &step
+		}
+	}
+	return s
+}
+
+func maxBound(cur *int64, n int64) *int64 {
+	if cur != nil && *cur >= n {
+		return cur
+	}
+	return &n
+}
+
+func minBound(cur *int64, n int64) *int64 {
+	if cur != nil && *cur <= n {
+		return cur
+	}
+	return &n
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenAPISchemaForRules(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"string;min=3;max=20", `{"type":"string","minLength":3,"maxLength":20}`},
+		{"string;len=4;regex=^[A-Z]+$", `{"type":"string","minLength":4,"maxLength":4,"pattern":"^[A-Z]+$"}`},
+		{"string;oneof=red,green", `{"type":"string","enum":["red","green"]}`},
+		{"string;url", `{"type":"string","format":"uri"}`},
+		{"int;min=1;max=10", `{"type":"integer","minimum":1,"maximum":10}`},
+		{"int64;gt=0", `{"type":"integer","format":"int64","minimum":0,"exclusiveMinimum":true}`},
+		{"float;between=0,1", `{"type":"number","minimum":0,"maximum":1}`},
+		{"bool;true", `{"type":"boolean","enum":[true]}`},
+		{"time", `{"type":"string","format":"date-time"}`},
+		{"time;layout=2006-01-02", `{"type":"string","format":"date"}`},
+		{"slice;min=1;max=5;unique;foreach=(string;min=2)", `{"type":"array","minItems":1,"maxItems":5,"uniqueItems":true,"items":{"type":"string","minLength":2}}`},
+		{"slice;unique=ID", `{"type":"array"}`},
+		{"map;minKeys=1;values=(int;positive)", `{"type":"object","minProperties":1,"additionalProperties":{"type":"integer","minimum":0,"exclusiveMinimum":true}}`},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		got, err := json.Marshal(OpenAPISchemaFor(rules))
+		if err != nil {
+			t.Fatalf("marshal %q: %v", tt.tag, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%q:\n got %s\nwant %s", tt.tag, got, tt.want)
+		}
+	}
+}
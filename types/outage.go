@@ -0,0 +1,88 @@
+package types
+
+import (
+	"context"
+	"errors"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// OutageMode selects how validation treats an outage of an external rule.
+type OutageMode int
+
+const (
+	// OutageFail returns the rule's error, failing validation (default).
+	OutageFail OutageMode = iota
+	// OutagePass treats the value as valid and reports the outage to the
+	// policy hook, favoring availability.
+	OutagePass
+	// OutageUnavailable fails with a rule.unavailable field error, so
+	// callers can tell outages from invalid input, e.g. to answer 503.
+	OutageUnavailable
+)
+
+// RuleOutage describes an outage of an external rule. Err is the rule's
+// error, for example a wrapped ErrCircuitOpen.
+type RuleOutage struct {
+	Kind Kind
+	Err  error
+}
+
+// OutageHook receives rule outages. It must be safe for concurrent use
+// because compiled validators are shared.
+type OutageHook func(RuleOutage)
+
+// OutagePolicy controls context-aware custom rules, such as DNS or API
+// lookups, whose errors are neither validation failures (errors.Errors) nor
+// context errors. It applies after any RetryPolicy of the rule has run out.
+//
+// Fields:
+//   - Mode: How outages affect validation.
+//   - Hook: Optional; receives every outage, in any mode.
+//   - IsOutage: Optional; reports whether an error is an outage. nil treats
+//     every error other than validation failures as one.
+type OutagePolicy struct {
+	Mode     OutageMode
+	Hook     OutageHook
+	IsOutage func(error) bool
+}
+
+// SetOutagePolicy applies p to every context-aware custom rule.
+func (c *Compiler) SetOutagePolicy(p OutagePolicy) {
+	c.outage = p
+}
+
+func (c *Compiler) outageContextRule(kind Kind, compiled compiledContextRule) compiledContextRule {
+	p := c.outage
+	if (p.Mode == OutageFail && p.Hook == nil) || compiled.err != nil {
+		return compiled
+	}
+	return compiledContextRule{validate: func(ctx context.Context, v any) error {
+		err := compiled.validate(ctx, v)
+		if !isOutageError(err) || (p.IsOutage != nil && !p.IsOutage(err)) {
+			return err
+		}
+		if p.Hook != nil {
+			p.Hook(RuleOutage{Kind: kind, Err: err})
+		}
+		switch p.Mode {
+		case OutagePass:
+			return nil
+		case OutageUnavailable:
+			name := safeRuleKindForError(kind)
+			msg := c.translateMessage(verrs.CodeRuleUnavailable, "validation rule "+name+" is temporarily unavailable", []any{name})
+			return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeRuleUnavailable, Msg: msg, Param: name}}
+		}
+		return err
+	}}
+}
+
+// isOutageError reports whether err is a rule failure other than a
+// validation failure or context error.
+func isOutageError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var fieldErrors verrs.Errors
+	return !errors.As(err, &fieldErrors)
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func compileWithOutage(t *testing.T, failures int, calls *int, p OutagePolicy, retry *RetryPolicy) ContextValidatorFunc {
+	t.Helper()
+	c := NewCompiler(nil)
+	c.RegisterContextRule("mx", flakyRule(failures, calls))
+	c.SetOutagePolicy(p)
+	if retry != nil {
+		c.SetRetryPolicy("mx", *retry)
+	}
+	fn, err := c.CompileContextWithOptsE([]Rule{NewRule(KString, nil), NewRule("mx", nil)}, CompileOpts{})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return fn
+}
+
+func TestOutagePolicy_Modes(t *testing.T) {
+	var outages []RuleOutage
+	hook := func(o RuleOutage) { outages = append(outages, o) }
+
+	calls := 0
+	fn := compileWithOutage(t, 1, &calls, OutagePolicy{Mode: OutageFail, Hook: hook}, nil)
+	if err := fn(context.Background(), "ok"); err == nil || err.Error() != "lookup timeout" {
+		t.Fatalf("OutageFail: expected rule error, got %v", err)
+	}
+
+	calls = 0
+	fn = compileWithOutage(t, 1, &calls, OutagePolicy{Mode: OutagePass, Hook: hook}, nil)
+	if err := fn(context.Background(), "ok"); err != nil {
+		t.Fatalf("OutagePass: expected pass, got %v", err)
+	}
+
+	calls = 0
+	fn = compileWithOutage(t, 1, &calls, OutagePolicy{Mode: OutageUnavailable, Hook: hook}, nil)
+	err := fn(context.Background(), "ok")
+	assertCodes(t, err, []string{verrs.CodeRuleUnavailable})
+	var es verrs.Errors
+	if !errors.As(err, &es) || es[0].Param != "mx" {
+		t.Fatalf("expected Param mx, got %v", err)
+	}
+
+	if len(outages) != 3 || outages[0].Kind != "mx" || outages[0].Err == nil {
+		t.Fatalf("outages = %#v", outages)
+	}
+
+	// Validation failures are not outages.
+	outages = nil
+	assertCodes(t, fn(context.Background(), "bad"), []string{"mx.missing"})
+	if len(outages) != 0 {
+		t.Fatalf("validation failure reported as outage: %#v", outages)
+	}
+}
+
+func TestOutagePolicy_IsOutage(t *testing.T) {
+	calls := 0
+	fn := compileWithOutage(t, 1, &calls, OutagePolicy{
+		Mode:     OutagePass,
+		IsOutage: func(err error) bool { return errors.Is(err, ErrCircuitOpen) },
+	}, nil)
+	if err := fn(context.Background(), "ok"); err == nil {
+		t.Fatal("expected error not classified as outage to fail")
+	}
+}
+
+func TestOutagePolicy_AfterRetryAndBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker(1, time.Hour)
+	calls := 0
+	fn := compileWithOutage(t, 10, &calls, OutagePolicy{Mode: OutageUnavailable},
+		&RetryPolicy{MaxAttempts: 2, Breaker: breaker})
+	assertCodes(t, fn(context.Background(), "ok"), []string{verrs.CodeRuleUnavailable})
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2 retried calls", calls)
+	}
+	assertCodes(t, fn(context.Background(), "ok"), []string{verrs.CodeRuleUnavailable})
+	if calls != 2 {
+		t.Fatalf("calls = %d, want no calls while the circuit is open", calls)
+	}
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func TestCompiler_RuleErrorsCarryParam(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+		param any
+	}{
+		{"string;len=3", "ab", verrs.CodeStringLength, 3},
+		{"string;min=3", "ab", verrs.CodeStringMin, 3},
+		{"string;max=1", "ab", verrs.CodeStringMax, 1},
+		{"string;minRunes=3", "äö", verrs.CodeStringMinRunes, 3},
+		{"string;regex=^[a-z]+$", "AB", verrs.CodeStringRegexNoMatch, "^[a-z]+$"},
+		{"string;oneof=red,green", "blue", verrs.CodeStringOneOf, []string{"red", "green"}},
+		{"string;prefix=ORD", "X1", verrs.CodeStringPrefix, "ORD"},
+		{"string;contains=@", "ab", verrs.CodeStringContains, "@"},
+		{"int;min=5", 4, verrs.CodeIntMin, int64(5)},
+		{"int;max=5", 6, verrs.CodeIntMax, int64(5)},
+		{"float;min=1.5", 1.0, verrs.CodeNumberMin, 1.5},
+		{"float;between=1,2", 3.0, verrs.CodeNumberBetween, []float64{1, 2}},
+		{"slice;min=2", []int{1}, verrs.CodeSliceMin, 2},
+		{"map;maxKeys=0", map[string]int{"a": 1}, verrs.CodeMapMaxKeys, 0},
+	}
+	for _, tt := range tests {
+		rules, err := ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tt.tag, err)
+		}
+		var es verrs.Errors
+		if err := NewCompiler(nil).Compile(rules)(tt.value); !errors.As(err, &es) || es[0].Code != tt.code {
+			t.Fatalf("%s: error = %v, want %s", tt.tag, err, tt.code)
+		}
+		if !reflect.DeepEqual(es[0].Param, tt.param) {
+			t.Fatalf("%s: Param = %#v, want %#v", tt.tag, es[0].Param, tt.param)
+		}
+	}
+}
+
+func TestCompiler_TimeRuleErrorsCarryResolvedBound(t *testing.T) {
+	bound := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	fn := NewCompiler(nil).Compile([]Rule{NewRule(KTime, nil), NewRule(KTimeBefore, map[string]any{"time": bound})})
+	var es verrs.Errors
+	if err := fn(bound.Add(time.Hour)); !errors.As(err, &es) || !reflect.DeepEqual(es[0].Param, bound) {
+		t.Fatalf("error = %#v, want Param %v", err, bound)
+	}
+
+	fn = NewCompiler(nil).Compile([]Rule{NewRule(KTime, nil), NewRule(KTimeBefore, map[string]any{"time": Now(0)})})
+	if err := fn(time.Now().Add(time.Hour)); !errors.As(err, &es) {
+		t.Fatalf("error = %v, want validation errors", err)
+	}
+	if param := es[0].Param.(time.Time); param != param.Round(0) {
+		t.Fatalf("relative bound Param %v keeps the monotonic clock reading", param)
+	}
+}
@@ -1,10 +1,14 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // truncateForError truncates a string for use in error messages to prevent
@@ -61,147 +65,231 @@ func ParseTag(tag string) ([]Rule, error) {
 // custom type registry. Per-instance types are checked before global types.
 // Example: "string;min=3;max=50" -> []Rule
 func ParseTagWithRegistry(tag string, registry *TypeRegistry) ([]Rule, error) {
+	rules, segment, err := parseTag(tag, registry)
+	if err != nil {
+		return nil, &ParseError{Tag: tag, Segment: segment, Reason: err.Error(), err: err}
+	}
+	return rules, nil
+}
+
+// parseTag parses tag and, on failure, returns the offending segment.
+func parseTag(tag string, registry *TypeRegistry) ([]Rule, string, error) {
 	if tag == "" {
-		return nil, nil
+		return nil, "", nil
 	}
 
 	parts := SplitTag(tag)
 	for i := range parts {
 		parts[i] = strings.TrimSpace(parts[i])
 	}
+	return parseParts(parts, registry)
+}
+
+// parseParts parses trimmed tag segments, the first being the base type.
+func parseParts(parts []string, registry *TypeRegistry) ([]Rule, string, error) {
 	if len(parts) == 0 {
-		return nil, fmt.Errorf("empty tag")
+		return nil, "", fmt.Errorf("empty tag")
 	}
 
+	parts, msgs := splitRuleMessages(parts)
 	var rules []Rule
 	baseType := parts[0]
 	if isGenericRuleToken(baseType) {
-		for _, part := range parts {
+		for i, part := range parts {
 			rule, err := parseGenericRule(part)
 			if err != nil {
-				return nil, err
+				return nil, part, err
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i]))
 			}
 		}
-		return rules, nil
+		return rules, "", nil
 	}
 
 	switch baseType {
 	case "string":
-		rules = append(rules, NewRule(KString, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KString, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseStringRule(part)
 			if err != nil {
-				return nil, fmt.Errorf("invalid string rule %q: %w", truncateForError(part, 20), err)
+				return nil, part, fmt.Errorf("invalid string rule %q: %w", truncateForError(part, 20), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
+		if hasKind(rules, KStringer) && !hasKind(rules, KEnum) {
+			return nil, "stringer", fmt.Errorf("stringer requires enum=Name")
+		}
 	case "int", "int64":
 		kind := KInt
 		if baseType == "int64" {
 			kind = KInt64
 		}
-		rules = append(rules, NewRule(kind, nil))
-		for _, part := range parts[1:] {
-			rule, err := parseIntRule(part)
+		rules = append(rules, withMessage(NewRule(kind, nil), msgs[0]))
+		for i, part := range parts[1:] {
+			rule, err := parseIntRule(part, kind)
 			if err != nil {
-				return nil, fmt.Errorf("invalid int rule %q: %w", truncateForError(part, 50), err)
+				return nil, part, fmt.Errorf("invalid int rule %q: %w", truncateForError(part, 50), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
+			}
+		}
+	case "uint", "uint64":
+		kind := KUint
+		if baseType == "uint64" {
+			kind = KUint64
+		}
+		rules = append(rules, withMessage(NewRule(kind, nil), msgs[0]))
+		for i, part := range parts[1:] {
+			rule, err := parseUintRule(part)
+			if err != nil {
+				return nil, part, fmt.Errorf("invalid uint rule %q: %w", truncateForError(part, 50), err)
+			}
+			if rule != nil {
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	case "float":
-		rules = append(rules, NewRule(KFloat, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KFloat, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseNumberRule(part)
 			if err != nil {
-				return nil, fmt.Errorf("invalid float rule %q: %w", truncateForError(part, 50), err)
+				return nil, part, fmt.Errorf("invalid float rule %q: %w", truncateForError(part, 50), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	case "slice":
-		rules = append(rules, NewRule(KSlice, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KSlice, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseSliceRule(part, registry)
 			if err != nil {
-				return nil, fmt.Errorf("invalid slice rule %q: %w", truncateForError(part, 50), err)
+				return nil, part, fmt.Errorf("invalid slice rule %q: %w", truncateForError(part, 50), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	case "array":
-		rules = append(rules, NewRule(KArray, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KArray, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseArrayRule(part, registry)
 			if err != nil {
-				return nil, fmt.Errorf("invalid array rule %q: %w", truncateForError(part, 50), err)
+				return nil, part, fmt.Errorf("invalid array rule %q: %w", truncateForError(part, 50), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	case "map":
-		rules = append(rules, NewRule(KMap, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KMap, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseMapRule(part, registry)
 			if err != nil {
-				return nil, fmt.Errorf("invalid map rule %q: %w", truncateForError(part, 50), err)
+				return nil, part, fmt.Errorf("invalid map rule %q: %w", truncateForError(part, 50), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	case "bool":
-		rules = append(rules, NewRule(KBool, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KBool, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseBoolRule(part)
 			if err != nil {
-				return nil, fmt.Errorf("invalid bool rule %q: %w", truncateForError(part, 20), err)
+				return nil, part, fmt.Errorf("invalid bool rule %q: %w", truncateForError(part, 20), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	case "time":
-		rules = append(rules, NewRule(KTime, nil))
-		for _, part := range parts[1:] {
+		rules = append(rules, withMessage(NewRule(KTime, nil), msgs[0]))
+		for i, part := range parts[1:] {
 			rule, err := parseTimeRule(part)
 			if err != nil {
-				return nil, fmt.Errorf("invalid time rule %q: %w", truncateForError(part, 50), err)
+				return nil, part, fmt.Errorf("invalid time rule %q: %w", truncateForError(part, 50), err)
 			}
 			if rule != nil {
-				rules = append(rules, *rule)
+				rules = append(rules, withMessage(*rule, msgs[i+1]))
 			}
 		}
 	default:
 		// Check if it's a custom type
 		if isTypeRegistered(baseType, registry) {
 			// Create a custom type rule
-			rules = append(rules, NewRule(Kind(baseType), nil))
+			rules = append(rules, withMessage(NewRule(Kind(baseType), nil), msgs[0]))
 			// Parse any additional rules for the custom type
-			for _, part := range parts[1:] {
+			for i, part := range parts[1:] {
 				rule, err := parseCustomTypeRule(part)
 				if err != nil {
-					return nil, fmt.Errorf("invalid %s rule %q: %w", baseType, truncateForError(part, 20), err)
+					return nil, part, fmt.Errorf("invalid %s rule %q: %w", baseType, truncateForError(part, 20), err)
 				}
 				if rule != nil {
-					rules = append(rules, *rule)
+					rules = append(rules, withMessage(*rule, msgs[i+1]))
 				}
 			}
 		} else {
-			return nil, fmt.Errorf("unknown type: %s", truncateForError(baseType, 50))
+			return nil, baseType, fmt.Errorf("unknown type: %s", truncateForError(baseType, 50))
 		}
 	}
 
-	return rules, nil
+	return rules, "", nil
+}
+
+// msgSuffix separates a rule token from its message override, as in
+// "min=3,msg=Name is too short".
+const msgSuffix = ",msg="
+
+// splitRuleMessages strips message overrides from tag segments and returns
+// them by segment index. Overrides inside parentheses belong to nested
+// rules and are left for their own parse.
+func splitRuleMessages(parts []string) ([]string, []string) {
+	msgs := make([]string, len(parts))
+	var out []string
+	for i, part := range parts {
+		idx := topLevelIndex(part, msgSuffix)
+		if idx < 0 {
+			continue
+		}
+		if out == nil {
+			out = append([]string(nil), parts...)
+		}
+		out[i] = strings.TrimSpace(part[:idx])
+		msgs[i] = strings.TrimSpace(part[idx+len(msgSuffix):])
+	}
+	if out == nil {
+		return parts, msgs
+	}
+	return out, msgs
+}
+
+// topLevelIndex returns the index of the first sep in s outside
+// parentheses, or -1.
+func topLevelIndex(s, sep string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		default:
+			if depth == 0 && strings.HasPrefix(s[i:], sep) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func withMessage(rule Rule, msg string) Rule {
+	rule.Msg = msg
+	return rule
 }
 
 func isTypeRegistered(name string, registry *TypeRegistry) bool {
@@ -221,36 +309,36 @@ func parseStringRule(part string) (*Rule, error) {
 
 	switch {
 	case strings.HasPrefix(part, "length="), strings.HasPrefix(part, "len="):
-		_, value, _ := strings.Cut(part, "=")
-		n, err := strconv.Atoi(value)
+		name, value, _ := strings.Cut(part, "=")
+		n, err := parseLengthParam(name, value)
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "min="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "min="))
+		n, err := parseLengthParam("min", strings.TrimPrefix(part, "min="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMinLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMinLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "max="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "max="))
+		n, err := parseLengthParam("max", strings.TrimPrefix(part, "max="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMaxLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMaxLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "minRunes="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "minRunes="))
+		n, err := parseLengthParam("minRunes", strings.TrimPrefix(part, "minRunes="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMinRunes, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMinRunes, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "maxRunes="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "maxRunes="))
+		n, err := parseLengthParam("maxRunes", strings.TrimPrefix(part, "maxRunes="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMaxRunes, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMaxRunes, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "regex="):
 		pattern := strings.TrimPrefix(part, "regex=")
 		return &Rule{Kind: KRegex, Args: map[string]any{"pattern": pattern}}, nil
@@ -266,16 +354,29 @@ func parseStringRule(part string) (*Rule, error) {
 			values = strings.Fields(valueStr)
 		}
 		return &Rule{Kind: KOneOf, Args: map[string]any{"values": values}}, nil
+	case strings.HasPrefix(part, "enum="):
+		return parseEnumRule(strings.TrimPrefix(part, "enum="))
+	case part == "stringer":
+		return &Rule{Kind: KStringer, Args: nil}, nil
 	case part == "nonempty":
 		return &Rule{Kind: KNonEmpty, Args: nil}, nil
 	case strings.HasPrefix(part, "contains="):
 		return &Rule{Kind: KContains, Args: map[string]any{"value": strings.TrimPrefix(part, "contains=")}}, nil
-	case strings.HasPrefix(part, "notContains="):
-		return &Rule{Kind: KNotContains, Args: map[string]any{"value": strings.TrimPrefix(part, "notContains=")}}, nil
-	case strings.HasPrefix(part, "prefix="):
-		return &Rule{Kind: KPrefix, Args: map[string]any{"value": strings.TrimPrefix(part, "prefix=")}}, nil
-	case strings.HasPrefix(part, "suffix="):
-		return &Rule{Kind: KSuffix, Args: map[string]any{"value": strings.TrimPrefix(part, "suffix=")}}, nil
+	case strings.HasPrefix(part, "notContains="), strings.HasPrefix(part, "notcontains="):
+		_, value, _ := strings.Cut(part, "=")
+		return &Rule{Kind: KNotContains, Args: map[string]any{"value": value}}, nil
+	case strings.HasPrefix(part, "prefix="), strings.HasPrefix(part, "startswith="):
+		_, value, _ := strings.Cut(part, "=")
+		return &Rule{Kind: KPrefix, Args: map[string]any{"value": value}}, nil
+	case strings.HasPrefix(part, "suffix="), strings.HasPrefix(part, "endswith="):
+		_, value, _ := strings.Cut(part, "=")
+		return &Rule{Kind: KSuffix, Args: map[string]any{"value": value}}, nil
+	case strings.HasPrefix(part, "excludesall="):
+		chars := strings.TrimPrefix(part, "excludesall=")
+		if chars == "" {
+			return nil, fmt.Errorf("excludesall requires at least one character")
+		}
+		return &Rule{Kind: KExcludesAll, Args: map[string]any{"value": chars}}, nil
 	case part == "url":
 		return &Rule{Kind: KURL, Args: nil}, nil
 	case part == "hostname":
@@ -292,14 +393,34 @@ func parseStringRule(part string) (*Rule, error) {
 		return &Rule{Kind: KASCII, Args: nil}, nil
 	case part == "alpha":
 		return &Rule{Kind: KAlpha, Args: nil}, nil
-	case part == "alnum":
+	case part == "alnum", part == "alphanum":
 		return &Rule{Kind: KAlnum, Args: nil}, nil
+	case part == "alphaunicode":
+		return &Rule{Kind: KAlphaUni, Args: nil}, nil
+	case part == "alphanumunicode":
+		return &Rule{Kind: KAlnumUni, Args: nil}, nil
+	case part == "numeric":
+		return &Rule{Kind: KNumeric, Args: nil}, nil
+	case part == "printascii":
+		return &Rule{Kind: KPrintASCII, Args: nil}, nil
+	case part == "numstr":
+		return &Rule{Kind: KNumStr, Args: nil}, nil
+	case strings.HasPrefix(part, "min_value="), strings.HasPrefix(part, "max_value="):
+		name, value, _ := strings.Cut(part, "=")
+		if _, ok := parseDecimal(value); !ok {
+			return nil, fmt.Errorf("%s must be a decimal number", name)
+		}
+		kind := KNumStrMin
+		if name == "max_value" {
+			kind = KNumStrMax
+		}
+		return &Rule{Kind: kind, Args: map[string]any{"value": value}}, nil
 	default:
 		return parseCustomRuleToken(part)
 	}
 }
 
-func parseIntRule(part string) (*Rule, error) {
+func parseIntRule(part string, kind Kind) (*Rule, error) {
 	if part == "" {
 		return nil, nil
 	}
@@ -309,17 +430,83 @@ func parseIntRule(part string) (*Rule, error) {
 
 	switch {
 	case strings.HasPrefix(part, "min="):
-		n, err := strconv.ParseInt(strings.TrimPrefix(part, "min="), 10, 64)
+		n, err := parseIntParam("min", strings.TrimPrefix(part, "min="), kind)
 		if err != nil {
 			return nil, err
 		}
 		return &Rule{Kind: KMinInt, Args: map[string]any{"n": n}}, nil
 	case strings.HasPrefix(part, "max="):
-		n, err := strconv.ParseInt(strings.TrimPrefix(part, "max="), 10, 64)
+		n, err := parseIntParam("max", strings.TrimPrefix(part, "max="), kind)
 		if err != nil {
 			return nil, err
 		}
 		return &Rule{Kind: KMaxInt, Args: map[string]any{"n": n}}, nil
+	case strings.HasPrefix(part, "multipleof="):
+		n, err := parseIntParam("multipleof", strings.TrimPrefix(part, "multipleof="), kind)
+		if err != nil {
+			return nil, err
+		}
+		if b, _ := toIntBound(n); b.cmp(intBound{}) <= 0 {
+			return nil, fmt.Errorf("multipleof=%v must be positive", n)
+		}
+		return &Rule{Kind: KMultipleOf, Args: map[string]any{"n": n}}, nil
+	case strings.HasPrefix(part, "gt="):
+		return parseFloatArgRule(KGreaterThan, part, "gt=")
+	case strings.HasPrefix(part, "gte="):
+		return parseFloatArgRule(KGreaterThanEqual, part, "gte=")
+	case strings.HasPrefix(part, "lt="):
+		return parseFloatArgRule(KLessThan, part, "lt=")
+	case strings.HasPrefix(part, "lte="):
+		return parseFloatArgRule(KLessThanEqual, part, "lte=")
+	case strings.HasPrefix(part, "between="):
+		return parseBetweenRule(part)
+	case part == "positive":
+		return &Rule{Kind: KPositive, Args: nil}, nil
+	case part == "nonnegative":
+		return &Rule{Kind: KNonNegative, Args: nil}, nil
+	case part == "negative":
+		return &Rule{Kind: KNegative, Args: nil}, nil
+	case part == "nonpositive":
+		return &Rule{Kind: KNonPositive, Args: nil}, nil
+	case strings.HasPrefix(part, "enum="):
+		return parseEnumRule(strings.TrimPrefix(part, "enum="))
+	default:
+		return parseCustomRuleToken(part)
+	}
+}
+
+// parseUintRule parses the rules of a uint or uint64 tag. min and max take
+// uint64 bounds; the other numeric rules match parseIntRule.
+func parseUintRule(part string) (*Rule, error) {
+	if part == "" {
+		return nil, nil
+	}
+	if rule, ok, err := parseGenericRuleMaybe(part); ok || err != nil {
+		return rule, err
+	}
+
+	switch {
+	case strings.HasPrefix(part, "min="):
+		n, err := parseUintParam("min", strings.TrimPrefix(part, "min="))
+		if err != nil {
+			return nil, err
+		}
+		return &Rule{Kind: KMinUint, Args: map[string]any{"n": n}}, nil
+	case strings.HasPrefix(part, "max="):
+		n, err := parseUintParam("max", strings.TrimPrefix(part, "max="))
+		if err != nil {
+			return nil, err
+		}
+		return &Rule{Kind: KMaxUint, Args: map[string]any{"n": n}}, nil
+	case strings.HasPrefix(part, "multipleof="):
+		n, err := parseUintParam("multipleof", strings.TrimPrefix(part, "multipleof="))
+		if err != nil {
+			return nil, err
+		}
+		if n == int64(0) {
+			return nil, fmt.Errorf("multipleof=0 must be positive")
+		}
+		return &Rule{Kind: KMultipleOf, Args: map[string]any{"n": n}}, nil
 	case strings.HasPrefix(part, "gt="):
 		return parseFloatArgRule(KGreaterThan, part, "gt=")
 	case strings.HasPrefix(part, "gte="):
@@ -334,11 +521,25 @@ func parseIntRule(part string) (*Rule, error) {
 		return &Rule{Kind: KPositive, Args: nil}, nil
 	case part == "nonnegative":
 		return &Rule{Kind: KNonNegative, Args: nil}, nil
+	case part == "negative":
+		return &Rule{Kind: KNegative, Args: nil}, nil
+	case part == "nonpositive":
+		return &Rule{Kind: KNonPositive, Args: nil}, nil
 	default:
 		return parseCustomRuleToken(part)
 	}
 }
 
+// parseEnumRule parses enum=Name, where Name may be qualified by a package
+// path. Whether Name is registered is checked at compile time, so tags may
+// be parsed before RegisterIntEnum runs.
+func parseEnumRule(name string) (*Rule, error) {
+	if err := validateCustomRuleName(strings.ReplaceAll(name, "/", ".")); err != nil {
+		return nil, fmt.Errorf("invalid enum name: %s", truncateForError(name, 50))
+	}
+	return &Rule{Kind: KEnum, Args: map[string]any{"name": name}}, nil
+}
+
 func parseNumberRule(part string) (*Rule, error) {
 	if part == "" {
 		return nil, nil
@@ -353,6 +554,12 @@ func parseNumberRule(part string) (*Rule, error) {
 		return parseFloatArgRule(KMinNumber, part, "min=")
 	case strings.HasPrefix(part, "max="):
 		return parseFloatArgRule(KMaxNumber, part, "max=")
+	case strings.HasPrefix(part, "multipleof="):
+		rule, err := parseFloatArgRule(KMultipleOf, part, "multipleof=")
+		if err == nil && rule.Args["n"].(float64) <= 0 {
+			return nil, fmt.Errorf("multipleof=%g must be positive", rule.Args["n"])
+		}
+		return rule, err
 	case strings.HasPrefix(part, "gt="):
 		return parseFloatArgRule(KGreaterThan, part, "gt=")
 	case strings.HasPrefix(part, "gte="):
@@ -367,6 +574,10 @@ func parseNumberRule(part string) (*Rule, error) {
 		return &Rule{Kind: KPositive, Args: nil}, nil
 	case part == "nonnegative":
 		return &Rule{Kind: KNonNegative, Args: nil}, nil
+	case part == "negative":
+		return &Rule{Kind: KNegative, Args: nil}, nil
+	case part == "nonpositive":
+		return &Rule{Kind: KNonPositive, Args: nil}, nil
 	default:
 		return parseCustomRuleToken(part)
 	}
@@ -382,24 +593,24 @@ func parseSliceRule(part string, registry *TypeRegistry) (*Rule, error) {
 
 	switch {
 	case strings.HasPrefix(part, "length="), strings.HasPrefix(part, "len="):
-		_, value, _ := strings.Cut(part, "=")
-		n, err := strconv.Atoi(value)
+		name, value, _ := strings.Cut(part, "=")
+		n, err := parseLengthParam(name, value)
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KSliceLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KSliceLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "min="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "min="))
+		n, err := parseLengthParam("min", strings.TrimPrefix(part, "min="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMinSliceLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMinSliceLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "max="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "max="))
+		n, err := parseLengthParam("max", strings.TrimPrefix(part, "max="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMaxSliceLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMaxSliceLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "foreach="):
 		// Parse nested rules from foreach=(string;min=2;max=10)
 		inner := strings.TrimPrefix(part, "foreach=")
@@ -427,6 +638,12 @@ func parseSliceRule(part string, registry *TypeRegistry) (*Rule, error) {
 		}, nil
 	case part == "unique":
 		return &Rule{Kind: KSliceUnique, Args: nil}, nil
+	case strings.HasPrefix(part, "unique="):
+		field, err := parseUniqueField(strings.TrimPrefix(part, "unique="))
+		if err != nil {
+			return nil, err
+		}
+		return &Rule{Kind: KSliceUnique, Args: map[string]any{"field": field}}, nil
 	case strings.HasPrefix(part, "contains="):
 		return &Rule{Kind: KSliceContains, Args: map[string]any{"value": strings.TrimPrefix(part, "contains=")}}, nil
 	default:
@@ -444,24 +661,24 @@ func parseArrayRule(part string, registry *TypeRegistry) (*Rule, error) {
 
 	switch {
 	case strings.HasPrefix(part, "length="), strings.HasPrefix(part, "len="):
-		_, value, _ := strings.Cut(part, "=")
-		n, err := strconv.Atoi(value)
+		name, value, _ := strings.Cut(part, "=")
+		n, err := parseLengthParam(name, value)
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KArrayLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KArrayLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "min="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "min="))
+		n, err := parseLengthParam("min", strings.TrimPrefix(part, "min="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMinArrayLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMinArrayLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "max="):
-		n, err := strconv.Atoi(strings.TrimPrefix(part, "max="))
+		n, err := parseLengthParam("max", strings.TrimPrefix(part, "max="))
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMaxArrayLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMaxArrayLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "foreach="):
 		inner := strings.TrimPrefix(part, "foreach=")
 		if !strings.HasPrefix(inner, "(") || !strings.HasSuffix(inner, ")") {
@@ -485,6 +702,12 @@ func parseArrayRule(part string, registry *TypeRegistry) (*Rule, error) {
 		}, nil
 	case part == "unique":
 		return &Rule{Kind: KArrayUnique, Args: nil}, nil
+	case strings.HasPrefix(part, "unique="):
+		field, err := parseUniqueField(strings.TrimPrefix(part, "unique="))
+		if err != nil {
+			return nil, err
+		}
+		return &Rule{Kind: KArrayUnique, Args: map[string]any{"field": field}}, nil
 	case strings.HasPrefix(part, "contains="):
 		return &Rule{Kind: KArrayContains, Args: map[string]any{"value": strings.TrimPrefix(part, "contains=")}}, nil
 	default:
@@ -501,26 +724,26 @@ func parseMapRule(part string, registry *TypeRegistry) (*Rule, error) {
 	}
 	switch {
 	case strings.HasPrefix(part, "length="), strings.HasPrefix(part, "len="):
-		_, value, _ := strings.Cut(part, "=")
-		n, err := strconv.Atoi(value)
+		name, value, _ := strings.Cut(part, "=")
+		n, err := parseLengthParam(name, value)
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMapLength, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMapLength, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "minKeys="), strings.HasPrefix(part, "min="):
-		_, value, _ := strings.Cut(part, "=")
-		n, err := strconv.Atoi(value)
+		name, value, _ := strings.Cut(part, "=")
+		n, err := parseLengthParam(name, value)
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMinMapKeys, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMinMapKeys, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "maxKeys="), strings.HasPrefix(part, "max="):
-		_, value, _ := strings.Cut(part, "=")
-		n, err := strconv.Atoi(value)
+		name, value, _ := strings.Cut(part, "=")
+		n, err := parseLengthParam(name, value)
 		if err != nil {
 			return nil, err
 		}
-		return &Rule{Kind: KMaxMapKeys, Args: map[string]any{"n": n}}, nil
+		return &Rule{Kind: KMaxMapKeys, Args: map[string]any{"n": int64(n)}}, nil
 	case strings.HasPrefix(part, "keys="):
 		return parseNestedRulesRule(KMapKeys, part, "keys=", registry)
 	case strings.HasPrefix(part, "values="):
@@ -541,28 +764,30 @@ func parseTimeRule(part string) (*Rule, error) {
 	case part == "notzero":
 		return &Rule{Kind: KTimeNotZero, Args: nil}, nil
 	case strings.HasPrefix(part, "before="):
-		t, err := parseRFC3339(strings.TrimPrefix(part, "before="))
-		if err != nil {
-			return nil, err
-		}
-		return &Rule{Kind: KTimeBefore, Args: map[string]any{"time": t}}, nil
+		return parseTimeBoundRule(KTimeBefore, strings.TrimPrefix(part, "before="))
 	case strings.HasPrefix(part, "after="):
-		t, err := parseRFC3339(strings.TrimPrefix(part, "after="))
-		if err != nil {
-			return nil, err
+		return parseTimeBoundRule(KTimeAfter, strings.TrimPrefix(part, "after="))
+	case strings.HasPrefix(part, "min="):
+		return parseTimeBoundRule(KTimeMin, strings.TrimPrefix(part, "min="))
+	case strings.HasPrefix(part, "max="):
+		return parseTimeBoundRule(KTimeMax, strings.TrimPrefix(part, "max="))
+	case strings.HasPrefix(part, "layout="):
+		layout := strings.TrimPrefix(part, "layout=")
+		if strings.TrimSpace(layout) == "" {
+			return nil, fmt.Errorf("layout must not be empty")
 		}
-		return &Rule{Kind: KTimeAfter, Args: map[string]any{"time": t}}, nil
+		return &Rule{Kind: KTimeLayout, Args: map[string]any{"layout": layout}}, nil
 	case strings.HasPrefix(part, "between="):
 		raw := strings.TrimPrefix(part, "between=")
 		values := strings.SplitN(raw, ",", 2)
 		if len(values) != 2 {
 			return nil, fmt.Errorf("between requires start,end")
 		}
-		start, err := parseRFC3339(values[0])
+		start, err := parseTimeBound(values[0])
 		if err != nil {
 			return nil, err
 		}
-		end, err := parseRFC3339(values[1])
+		end, err := parseTimeBound(values[1])
 		if err != nil {
 			return nil, err
 		}
@@ -572,6 +797,14 @@ func parseTimeRule(part string) (*Rule, error) {
 	}
 }
 
+func parseTimeBoundRule(kind Kind, value string) (*Rule, error) {
+	t, err := parseTimeBound(value)
+	if err != nil {
+		return nil, err
+	}
+	return &Rule{Kind: kind, Args: map[string]any{"time": t}}, nil
+}
+
 func parseCustomTypeRule(part string) (*Rule, error) {
 	if part == "" {
 		return nil, nil
@@ -591,10 +824,12 @@ func parseBoolRule(part string) (*Rule, error) {
 		return rule, err
 	}
 	switch part {
-	case "true":
+	case "true", "istrue":
 		return &Rule{Kind: KBoolTrue, Args: nil}, nil
-	case "false":
+	case "false", "isfalse":
 		return &Rule{Kind: KBoolFalse, Args: nil}, nil
+	case "parse":
+		return &Rule{Kind: KBoolParse, Args: nil}, nil
 	}
 	return parseCustomRuleToken(part)
 }
@@ -612,7 +847,12 @@ func parseCustomRuleToken(part string) (*Rule, error) {
 		}
 		return &Rule{Kind: Kind(name), Args: args}, nil
 	}
-	if strings.Contains(part, "=") {
+	if name, value, ok := strings.Cut(part, "="); ok {
+		// Globally registered plugin rules may take their parameter
+		// directly, e.g. `phone=US`.
+		if isGlobalRule(Kind(name)) {
+			return &Rule{Kind: Kind(name), Args: map[string]any{"value": value}}, nil
+		}
 		return nil, fmt.Errorf("unknown custom rule %q; use custom:name=value for parameterized custom rules", truncateForError(part, 50))
 	}
 	if err := validateCustomRuleName(part); err != nil {
@@ -639,7 +879,7 @@ func validateCustomRuleName(name string) error {
 }
 
 func isGenericRuleToken(part string) bool {
-	return part == "required" || part == "omitempty"
+	return part == "required" || part == "omitempty" || part == "unbounded"
 }
 
 func parseGenericRuleMaybe(part string) (*Rule, bool, error) {
@@ -658,30 +898,194 @@ func parseGenericRule(part string) (*Rule, error) {
 		return &Rule{Kind: KRequired, Args: nil}, nil
 	case "omitempty":
 		return &Rule{Kind: KOmitempty, Args: nil}, nil
+	case "unbounded":
+		return &Rule{Kind: KUnbounded, Args: nil}, nil
 	default:
 		return nil, fmt.Errorf("unknown generic rule: %s", truncateForError(part, 50))
 	}
 }
 
 func parseFloatArgRule(kind Kind, part, prefix string) (*Rule, error) {
-	n, err := strconv.ParseFloat(strings.TrimPrefix(part, prefix), 64)
+	n, err := parseFloatParam(strings.TrimSuffix(prefix, "="), strings.TrimPrefix(part, prefix))
 	if err != nil {
 		return nil, err
 	}
 	return &Rule{Kind: kind, Args: map[string]any{"n": n}}, nil
 }
 
+// parseIntParam parses an integer rule parameter for kind. `int` accepts
+// values up to math.MaxUint64 (returned as uint64); `int64` is limited to
+// the int64 range. Digits may be grouped with '_' and integral values may
+// use scientific notation, e.g. 1_000_000 or 1e6. Fractional, ambiguous and
+// out-of-range values get explicit errors.
+func parseIntParam(name, raw string, kind Kind) (any, error) {
+	digits, err := normalizeNumericParam(name, raw)
+	if err != nil {
+		return nil, err
+	}
+	if n, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return n, nil
+	}
+	if u, err := strconv.ParseUint(digits, 10, 64); err == nil {
+		if kind == KInt64 {
+			return nil, fmt.Errorf("%s=%s overflows int64", name, truncateForError(raw, 30))
+		}
+		return u, nil
+	}
+	f, err := strconv.ParseFloat(digits, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) || math.IsNaN(f) || err == nil && math.IsInf(f, 0) {
+		return nil, fmt.Errorf("%s=%s is not an integer", name, truncateForError(raw, 30))
+	}
+	if math.Abs(f) < math.MaxUint64 && !math.IsInf(f, 0) {
+		// Exact check: float64 cannot represent every integer in range.
+		r, ok := new(big.Rat).SetString(digits)
+		if !ok || strings.Contains(digits, "/") || !r.IsInt() {
+			return nil, fmt.Errorf("%s=%s is not an integer; use float for fractional limits", name, truncateForError(raw, 30))
+		}
+		switch n := r.Num(); {
+		case n.IsInt64():
+			return n.Int64(), nil
+		case n.IsUint64() && kind != KInt64:
+			return n.Uint64(), nil
+		}
+	}
+	if kind == KInt64 || f < 0 {
+		return nil, fmt.Errorf("%s=%s overflows int64", name, truncateForError(raw, 30))
+	}
+	return nil, fmt.Errorf("%s=%s overflows uint64", name, truncateForError(raw, 30))
+}
+
+// parseUintParam parses an unsigned integer rule parameter with the syntax
+// of parseIntParam, rejecting negative values. Like parseIntParam and
+// NewRule, it returns int64 when the value fits and uint64 above that.
+func parseUintParam(name, raw string) (any, error) {
+	v, err := parseIntParam(name, raw, KInt)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := toUint64(v); !ok {
+		return nil, fmt.Errorf("%s=%s must not be negative", name, truncateForError(raw, 30))
+	}
+	return v, nil
+}
+
+// parseLengthParam parses a length or count parameter with the same syntax
+// as parseIntParam.
+func parseLengthParam(name, raw string) (int, error) {
+	v, err := parseIntParam(name, raw, KInt64)
+	if err != nil {
+		return 0, err
+	}
+	n := v.(int64)
+	if n > math.MaxInt || n < math.MinInt {
+		return 0, fmt.Errorf("%s=%s overflows int", name, truncateForError(raw, 30))
+	}
+	return int(n), nil
+}
+
+// parseUniqueField parses the field path of `unique=Field`: exported field
+// names separated by dots, e.g. "Owner.ID".
+func parseUniqueField(raw string) (string, error) {
+	for _, name := range strings.Split(raw, ".") {
+		if !isExportedIdent(name) {
+			return "", fmt.Errorf("unique=%s: field path must be exported field names separated by dots", truncateForError(raw, 30))
+		}
+	}
+	return raw, nil
+}
+
+func isExportedIdent(s string) bool {
+	for i, r := range s {
+		switch {
+		case i == 0 && !unicode.IsUpper(r):
+			return false
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_':
+			return false
+		}
+	}
+	return s != ""
+}
+
+// parseFloatParam parses a float rule parameter, rejecting values outside
+// the float64 range. NaN is accepted here and rejected at compile time
+// under Behavior.RejectNaNParams. Digits may be grouped with '_'.
+func parseFloatParam(name, raw string) (float64, error) {
+	digits, err := normalizeNumericParam(name, raw)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseFloat(digits, 64)
+	if errors.Is(err, strconv.ErrRange) && math.IsInf(n, 0) {
+		return 0, fmt.Errorf("%s=%s overflows float64", name, truncateForError(raw, 30))
+	}
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("%s=%s is not a number", name, truncateForError(raw, 30))
+	}
+	return n, nil
+}
+
+// normalizeNumericParam strips '_' digit separators and rejects forms whose
+// meaning depends on locale, such as 1,5 or 1.000.000.
+func normalizeNumericParam(name, raw string) (string, error) {
+	if strings.Contains(raw, ",") {
+		return "", fmt.Errorf("%s=%s is ambiguous: use '.' for decimals and '_' to group digits", name, truncateForError(raw, 30))
+	}
+	if strings.Count(raw, ".") > 1 {
+		return "", fmt.Errorf("%s=%s is ambiguous: '.' is the decimal point and may appear once", name, truncateForError(raw, 30))
+	}
+	if !strings.Contains(raw, "_") {
+		return raw, nil
+	}
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '_' {
+			continue
+		}
+		if i == 0 || i == len(raw)-1 || !isASCIIDigit(raw[i-1]) || !isASCIIDigit(raw[i+1]) {
+			return "", fmt.Errorf("%s=%s has a misplaced '_': it must separate digits", name, truncateForError(raw, 30))
+		}
+	}
+	return strings.ReplaceAll(raw, "_", ""), nil
+}
+
+func isASCIIDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// parseDecimal parses a plain decimal number: an optional sign, digits, and
+// an optional fraction, as in "-12" or "0.25". Exponents, hex, underscores,
+// Inf and NaN are rejected. The value is exact, so bounds such as 0.1
+// compare without float rounding.
+func parseDecimal(s string) (*big.Rat, bool) {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 {
+		return nil, false
+	}
+	whole, frac, hasFrac := strings.Cut(digits, ".")
+	if whole == "" || hasFrac && frac == "" {
+		return nil, false
+	}
+	for _, part := range []string{whole, frac} {
+		for i := 0; i < len(part); i++ {
+			if !isASCIIDigit(part[i]) {
+				return nil, false
+			}
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	return r, ok
+}
+
 func parseBetweenRule(part string) (*Rule, error) {
 	raw := strings.TrimPrefix(part, "between=")
 	values := strings.SplitN(raw, ",", 2)
 	if len(values) != 2 {
 		return nil, fmt.Errorf("between requires min,max")
 	}
-	min, err := strconv.ParseFloat(strings.TrimSpace(values[0]), 64)
+	min, err := parseFloatParam("between", strings.TrimSpace(values[0]))
 	if err != nil {
 		return nil, err
 	}
-	max, err := strconv.ParseFloat(strings.TrimSpace(values[1]), 64)
+	max, err := parseFloatParam("between", strings.TrimSpace(values[1]))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,66 @@
+package types
+
+import "fmt"
+
+// PluginAPIVersion is the version of the rule compiler contract that
+// plugins build against: the RuleCompiler signature, the Rule.Args shapes
+// produced by the parser, and the Compiler helpers such as T. It changes
+// only when that contract changes incompatibly.
+//
+// Version 2 passes integer arguments as int64 (see NormalizeArgs); version
+// 1 passed them as int.
+const PluginAPIVersion = 2
+
+// MinPluginAPIVersion is the oldest plugin API version this compiler still
+// supports. Version 1 compilers registered through RegisterRuleForAPI
+// receive integer arguments as int, as they did before.
+const MinPluginAPIVersion = 1
+
+// CheckPluginAPI reports whether a plugin built against version can run with
+// this compiler.
+func CheckPluginAPI(plugin string, version int) error {
+	if version < MinPluginAPIVersion || version > PluginAPIVersion {
+		return fmt.Errorf("plugin %s requires plugin API v%d; this compiler supports v%d through v%d",
+			plugin, version, MinPluginAPIVersion, PluginAPIVersion)
+	}
+	return nil
+}
+
+// RegisterRuleForAPI registers a global rule compiler like RegisterRule
+// after checking that the plugin's API version is supported. It panics on a
+// mismatch so incompatible plugins fail at init instead of misbehaving
+// during compilation.
+func RegisterRuleForAPI(version int, kind Kind, rc RuleCompiler) {
+	if err := CheckPluginAPI(string(kind), version); err != nil {
+		panic(err)
+	}
+	if version < 2 {
+		rc = v1IntArgs(rc)
+	}
+	RegisterRule(kind, rc)
+}
+
+// v1IntArgs adapts a plugin API v1 compiler, which asserts integer
+// arguments as int, to the int64 arguments of v2.
+func v1IntArgs(rc RuleCompiler) RuleCompiler {
+	return func(c *Compiler, r Rule) (func(any) error, error) {
+		var args map[string]any
+		for k, v := range r.Args {
+			n, ok := v.(int64)
+			if !ok || int64(int(n)) != n {
+				continue
+			}
+			if args == nil {
+				args = make(map[string]any, len(r.Args))
+				for k2, v2 := range r.Args {
+					args[k2] = v2
+				}
+			}
+			args[k] = int(n)
+		}
+		if args != nil {
+			r.Args = args
+		}
+		return rc(c, r)
+	}
+}
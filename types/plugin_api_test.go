@@ -0,0 +1,95 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCheckPluginAPI(t *testing.T) {
+	if err := CheckPluginAPI("acme", PluginAPIVersion); err != nil {
+		t.Fatalf("current version must be supported: %v", err)
+	}
+	for _, version := range []int{MinPluginAPIVersion - 1, PluginAPIVersion + 1} {
+		err := CheckPluginAPI("acme", version)
+		if err == nil || !strings.Contains(err.Error(), "plugin acme requires plugin API") {
+			t.Fatalf("version %d: got %v", version, err)
+		}
+	}
+}
+
+func TestRegisterRuleForAPI_PanicsOnMismatch(t *testing.T) {
+	rc := func(*Compiler, Rule) (func(any) error, error) { return nil, nil }
+	RegisterRuleForAPI(PluginAPIVersion, "pluginAPITestRule", rc)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for unsupported version")
+		}
+		globalRegistryMu.RLock()
+		defer globalRegistryMu.RUnlock()
+		if _, ok := globalRegistry["pluginAPITestRuleNext"]; ok {
+			t.Fatal("incompatible plugin must not be registered")
+		}
+	}()
+	RegisterRuleForAPI(PluginAPIVersion+1, "pluginAPITestRuleNext", rc)
+}
+
+// pluginArgShapes records, per plugin API version, the Go types ParseTag
+// produces for rule arguments. A change to these shapes breaks plugins, so
+// it must come with a new PluginAPIVersion and a new entry here.
+var pluginArgShapes = map[int]map[string]map[string]string{
+	2: {
+		"string;min=3":   {"minLength": "n=int64"},
+		"int;max=9":      {"maxInt": "n=int64"},
+		"uint;max=7":     {"maxUint": "n=int64"},
+		"slice;min=1":    {"minSliceLength": "n=int64"},
+		"float;min=1.5":  {"minNumber": "n=float64"},
+		"int;gt=0":       {"greaterThan": "n=float64"},
+		"string;regex=a": {"regex": "pattern=string"},
+	},
+}
+
+func TestPluginAPIVersion_CoversArgShapes(t *testing.T) {
+	shapes, ok := pluginArgShapes[PluginAPIVersion]
+	if !ok {
+		t.Fatalf("no recorded arg shapes for plugin API v%d", PluginAPIVersion)
+	}
+	for tag, want := range shapes {
+		rules, err := ParseTag(tag)
+		if err != nil {
+			t.Fatalf("%s: %v", tag, err)
+		}
+		for _, r := range rules {
+			exp, ok := want[string(r.Kind)]
+			if !ok {
+				continue
+			}
+			var got []string
+			for k, v := range r.Args {
+				got = append(got, fmt.Sprintf("%s=%T", k, v))
+			}
+			if len(got) != 1 || got[0] != exp {
+				t.Errorf("%s: %s args %v, want %s; bump PluginAPIVersion if the shapes changed",
+					tag, r.Kind, got, exp)
+			}
+		}
+	}
+}
+
+func TestRegisterRuleForAPI_V1ReceivesIntArgs(t *testing.T) {
+	var got any
+	RegisterRuleForAPI(1, "pluginAPITestRuleV1", func(_ *Compiler, r Rule) (func(any) error, error) {
+		got = r.Args["n"]
+		return func(any) error { return nil }, nil
+	})
+	globalRegistryMu.RLock()
+	rc := globalRegistry["pluginAPITestRuleV1"]
+	globalRegistryMu.RUnlock()
+	if _, err := rc(NewCompiler(nil), NewRule("pluginAPITestRuleV1", map[string]any{"n": 3})); err != nil {
+		t.Fatal(err)
+	}
+	if got != 3 {
+		t.Fatalf("v1 compiler got n=%#v, want int 3", got)
+	}
+}
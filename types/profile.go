@@ -0,0 +1,136 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProfileEntry is the time spent in one rule kind at one field path.
+//
+// Fields:
+//   - Path: The field path from FieldPath; empty outside struct validation.
+//   - Kind: The rule kind.
+//   - Count: Sampled checks.
+//   - Total: Time spent in the sampled checks.
+//   - Max: Slowest sampled check.
+type ProfileEntry struct {
+	Path  string
+	Kind  Kind
+	Count int64
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Mean returns the average time of a sampled check.
+func (e ProfileEntry) Mean() time.Duration {
+	if e.Count == 0 {
+		return 0
+	}
+	return e.Total / time.Duration(e.Count)
+}
+
+// ProfileReport lists profile entries, slowest total first.
+type ProfileReport []ProfileEntry
+
+// String renders the report as an aligned table.
+func (r ProfileReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-32s %-16s %8s %12s %12s %12s\n", "PATH", "KIND", "COUNT", "TOTAL", "MEAN", "MAX")
+	for _, e := range r {
+		path := e.Path
+		if path == "" {
+			path = "-"
+		}
+		fmt.Fprintf(&b, "%-32s %-16s %8d %12s %12s %12s\n", path, e.Kind, e.Count, e.Total, e.Mean(), e.Max)
+	}
+	return b.String()
+}
+
+type profileKey struct {
+	path string
+	kind Kind
+}
+
+// Profiler records the time spent per field path and rule kind, to find the
+// regexes or plugins that dominate validation latency. Register its
+// Middleware on an engine; a Profiler is safe for concurrent use.
+type Profiler struct {
+	rate    float64
+	mu      sync.Mutex
+	entries map[profileKey]*ProfileEntry
+}
+
+// NewProfiler returns a Profiler that times a sample of rule checks.
+//
+// Parameters:
+//   - sampleRate: Fraction of checks timed; values >= 1 time every check
+//     and values <= 0 none.
+//
+// Returns:
+//   - *Profiler: The profiler, with an empty report.
+func NewProfiler(sampleRate float64) *Profiler {
+	return &Profiler{rate: sampleRate, entries: map[profileKey]*ProfileEntry{}}
+}
+
+// Middleware returns the middleware that times rule checks for p.
+func (p *Profiler) Middleware() Middleware {
+	return func(info RuleInfo, next ContextValidatorFunc) ContextValidatorFunc {
+		return func(ctx context.Context, v any) error {
+			if !sampled(p.rate) {
+				return next(ctx, v)
+			}
+			start := time.Now()
+			err := next(ctx, v)
+			p.record(FieldPath(ctx), info.Kind, time.Since(start))
+			return err
+		}
+	}
+}
+
+func (p *Profiler) record(path string, kind Kind, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := profileKey{path: path, kind: kind}
+	e := p.entries[key]
+	if e == nil {
+		e = &ProfileEntry{Path: path, Kind: kind}
+		p.entries[key] = e
+	}
+	e.Count++
+	e.Total += d
+	if d > e.Max {
+		e.Max = d
+	}
+}
+
+// Report returns the entries recorded so far, slowest total first; ties are
+// ordered by path and kind.
+func (p *Profiler) Report() ProfileReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make(ProfileReport, 0, len(p.entries))
+	for _, e := range p.entries {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		if out[i].Path != out[j].Path {
+			return out[i].Path < out[j].Path
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
+
+// Reset discards the recorded entries.
+func (p *Profiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.entries = map[profileKey]*ProfileEntry{}
+}
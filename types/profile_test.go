@@ -0,0 +1,59 @@
+package types
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfiler_RecordsPerPathAndKind(t *testing.T) {
+	c := NewCompiler(nil)
+	c.RegisterRule("slow", func(*Compiler, Rule) (func(any) error, error) {
+		return func(any) error { time.Sleep(time.Millisecond); return nil }, nil
+	})
+	prof := NewProfiler(1)
+	c.Use(prof.Middleware())
+	rules, _ := ParseTag("string;min=1;slow")
+	fn, err := c.CompileContextE(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"Name", "Name", "Bio"} {
+		if err := fn(WithFieldPath(context.Background(), path), "abc"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report := prof.Report()
+	if len(report) != 6 {
+		t.Fatalf("entries = %d, want 6:\n%s", len(report), report)
+	}
+	if top := report[0]; top.Path != "Name" || top.Kind != "slow" || top.Count != 2 || top.Mean() < time.Millisecond || top.Max < top.Mean() {
+		t.Fatalf("top entry = %+v", top)
+	}
+	if second := report[1]; second.Path != "Bio" || second.Kind != "slow" || second.Count != 1 {
+		t.Fatalf("second entry = %+v", second)
+	}
+	if lines := strings.Split(strings.TrimSpace(report.String()), "\n"); len(lines) != 7 || !strings.HasPrefix(lines[1], "Name ") {
+		t.Fatalf("report:\n%s", report)
+	}
+
+	prof.Reset()
+	if len(prof.Report()) != 0 {
+		t.Fatal("Reset kept entries")
+	}
+}
+
+func TestProfiler_SampleRate(t *testing.T) {
+	c := NewCompiler(nil)
+	prof := NewProfiler(0)
+	c.Use(prof.Middleware())
+	rules, _ := ParseTag("string;max=1")
+	if err := c.Compile(rules)("ab"); err == nil {
+		t.Fatal("unsampled check did not run")
+	}
+	if len(prof.Report()) != 0 || (ProfileEntry{}).Mean() != 0 {
+		t.Fatal("rate 0 recorded checks")
+	}
+}
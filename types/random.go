@@ -0,0 +1,420 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RandomValue returns a pseudo-random value intended to satisfy rules, for
+// property-based tests. Bounds, lengths and collection sizes are sampled
+// around the ExampleValue result within a window of size; enums are picked
+// at random, while regex and format rules keep their example value. Like
+// ExampleValue the result is best-effort and should be validated.
+//
+// Parameters:
+//   - rules: Parsed rules, starting with the base type rule.
+//   - t: Go type of the result, or nil for the base type's default type.
+//   - rnd: Source of randomness.
+//   - size: Size hint, as passed by testing/quick; values below 1 mean 1.
+//
+// Returns:
+//   - any: The generated value.
+//   - error: If rules are empty or the value cannot be converted to t.
+func RandomValue(rules []Rule, t reflect.Type, rnd *rand.Rand, size int) (any, error) {
+	if size < 1 {
+		size = 1
+	}
+	v, err := randomValue(rules, t, rnd, size)
+	if err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+func randomValue(rules []Rule, t reflect.Type, rnd *rand.Rand, size int) (reflect.Value, error) {
+	if len(rules) == 0 {
+		return reflect.Value{}, fmt.Errorf("example: no rules")
+	}
+	if t != nil && t.Kind() == reflect.Ptr {
+		elem, err := randomValue(rules, t.Elem(), rnd, size)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(elem)
+		return p, nil
+	}
+	if t != nil && t.Kind() == reflect.Interface {
+		t = nil
+	}
+
+	var out reflect.Value
+	switch base := baseKind(rules); base {
+	case KString:
+		out = reflect.ValueOf(randomString(rules, rnd, size))
+		if v, ok := enumValueOf(rules, out.String(), t); ok {
+			out = v
+		}
+	case KInt, KInt64:
+		n := randomInt(rules, rnd, size, t != nil && t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr)
+		switch {
+		case t != nil && t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr:
+			out = reflect.ValueOf(uint64(n))
+		case base == KInt:
+			out = reflect.ValueOf(int(n))
+		default:
+			out = reflect.ValueOf(n)
+		}
+	case KUint, KUint64:
+		n := uint64(randomInt(rules, rnd, size, true))
+		out = reflect.ValueOf(n)
+		if base == KUint {
+			out = reflect.ValueOf(uint(n))
+		}
+	case KFloat:
+		out = reflect.ValueOf(randomFloat(rules, rnd, size))
+	case KBool:
+		b := rnd.Intn(2) == 0
+		if hasKind(rules, KBoolTrue) || hasKind(rules, KBoolFalse) {
+			b = hasKind(rules, KBoolTrue)
+		}
+		out = reflect.ValueOf(b)
+	case KTime:
+		out = formatTimeAs(randomTime(rules, rnd, size), t, timeLayout(rules))
+	case KSlice, KArray:
+		v, err := randomList(rules, base, t, rnd, size)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out = v
+	case KMap:
+		v, err := randomMap(rules, t, rnd, size)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out = v
+	default:
+		return exampleValue(rules, t)
+	}
+
+	if t == nil || out.Type() == t {
+		return out, nil
+	}
+	if out.Type().ConvertibleTo(t) {
+		return out.Convert(t), nil
+	}
+	return reflect.Value{}, fmt.Errorf("example: cannot use %s value as %s", out.Type(), t)
+}
+
+const (
+	randomLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	randomDigits  = "0123456789"
+)
+
+func randomString(rules []Rule, rnd *rand.Rand, size int) string {
+	example := exampleString(rules)
+	exact, minLen, maxLen := -1, 0, -1
+	var oneof, contains []string
+	var prefix, suffix string
+	charset := randomLetters + randomDigits
+	for _, r := range rules {
+		switch r.Kind {
+		case KLength:
+			exact = argInt(r, "n")
+		case KMinLength, KMinRunes:
+			if n := argInt(r, "n"); n > minLen {
+				minLen = n
+			}
+		case KMaxLength, KMaxRunes:
+			if n := argInt(r, "n"); maxLen < 0 || n < maxLen {
+				maxLen = n
+			}
+		case KNonEmpty, KRequired:
+			if minLen < 1 {
+				minLen = 1
+			}
+		case KOneOf:
+			oneof, _ = r.Args["values"].([]string)
+		case KEnum:
+			_, oneof = enumRuleValues(r)
+		case KPrefix:
+			prefix = argString(r, "value")
+		case KSuffix:
+			suffix = argString(r, "value")
+		case KContains:
+			contains = append(contains, argString(r, "value"))
+		case KAlpha, KAlphaUni:
+			charset = randomLetters
+		case KNumeric:
+			charset = randomDigits
+		case KRegex, KNumStr, KNumStrMin, KNumStrMax:
+			return example
+		default:
+			if doc, ok := DescribeKind(r.Kind); ok && doc.Sample != "" {
+				return example
+			}
+		}
+	}
+	if len(oneof) > 0 {
+		return oneof[rnd.Intn(len(oneof))]
+	}
+
+	fixed := prefix + strings.Join(contains, "") + suffix
+	lo := minLen
+	if lo < len(fixed) {
+		lo = len(fixed)
+	}
+	hi := lo + size
+	if maxLen >= 0 && hi > maxLen {
+		hi = maxLen
+	}
+	if exact >= 0 {
+		lo, hi = exact, exact
+	}
+	if hi < lo {
+		return example
+	}
+	n := lo + rnd.Intn(hi-lo+1) - len(fixed)
+	filler := make([]byte, n)
+	for i := range filler {
+		filler[i] = charset[rnd.Intn(len(charset))]
+	}
+	return prefix + strings.Join(contains, "") + string(filler) + suffix
+}
+
+// randomWindow narrows the bounds of rules to size around center.
+func randomWindow(rules []Rule, center float64, size int) (lo, hi float64, loEx, hiEx bool) {
+	lo, hi, loEx, hiEx = numberBounds(rules)
+	if w := center - float64(size); w > lo {
+		lo, loEx = w, false
+	}
+	if w := center + float64(size); w < hi {
+		hi, hiEx = w, false
+	}
+	return lo, hi, loEx, hiEx
+}
+
+func randomInt(rules []Rule, rnd *rand.Rand, size int, unsigned bool) int64 {
+	center := exampleInt(rules)
+	if unsigned && center < 0 {
+		center = 0
+	}
+	lo, hi, loEx, hiEx := randomWindow(rules, float64(center), size)
+	low, high := math.Ceil(lo), math.Floor(hi)
+	if loEx && low == lo {
+		low++
+	}
+	if hiEx && high == hi {
+		high--
+	}
+	if unsigned && low < 0 {
+		low = 0
+	}
+	if values := exampleEnumValues(rules); len(values) > 0 {
+		n := values[rnd.Intn(len(values))]
+		if f := float64(n); f < low || f > high {
+			return center
+		}
+		return n
+	}
+	if high < low {
+		return center
+	}
+	n := int64(low) + rnd.Int63n(int64(high-low)+1)
+	if step := multipleOfStep(rules); step > 0 {
+		n = int64(snapToStep(float64(n), step, low, high))
+	}
+	return n
+}
+
+func randomFloat(rules []Rule, rnd *rand.Rand, size int) float64 {
+	center := exampleFloat(rules)
+	lo, hi, loEx, hiEx := randomWindow(rules, center, size)
+	if hi < lo {
+		return center
+	}
+	f := lo + rnd.Float64()*(hi-lo)
+	f = snapToStep(f, multipleOfStep(rules), lo, hi)
+	if (loEx && f == lo) || (hiEx && f == hi) {
+		return center
+	}
+	return f
+}
+
+func randomTime(rules []Rule, rnd *rand.Rand, size int) time.Time {
+	center := exampleTimeValue(rules)
+	at := center.Add(time.Duration(rnd.Intn(2*size+1)-size) * time.Hour)
+	now := time.Now()
+	for _, r := range rules {
+		bound := resolveTimeBound(r.Args["time"], now)
+		switch r.Kind {
+		case KTimeAfter:
+			if !at.After(bound) {
+				return center
+			}
+		case KTimeMin:
+			if at.Before(bound) {
+				return center
+			}
+		case KTimeBefore:
+			if !at.Before(bound) {
+				return center
+			}
+		case KTimeMax:
+			if at.After(bound) {
+				return center
+			}
+		case KTimeBetween:
+			start := resolveTimeBound(r.Args["start"], now)
+			end := resolveTimeBound(r.Args["end"], now)
+			if at.Before(start) || at.After(end) {
+				return center
+			}
+		}
+	}
+	return at
+}
+
+// randomCount picks a collection size between the minimum and maximum
+// length rules, at most size above the minimum.
+func randomCount(rules []Rule, exactKind, minKind, maxKind Kind, rnd *rand.Rand, size int) int {
+	lo, hi := 0, -1
+	for _, r := range rules {
+		switch r.Kind {
+		case exactKind:
+			return argInt(r, "n")
+		case minKind:
+			if m := argInt(r, "n"); m > lo {
+				lo = m
+			}
+		case maxKind:
+			hi = argInt(r, "n")
+		}
+	}
+	if hasKind(rules, KRequired) && lo < 1 {
+		lo = 1
+	}
+	if hi < 0 || hi > lo+size {
+		hi = lo + size
+	}
+	if hi < lo {
+		return exampleCount(rules, exactKind, minKind, maxKind)
+	}
+	return lo + rnd.Intn(hi-lo+1)
+}
+
+func randomList(rules []Rule, base Kind, t reflect.Type, rnd *rand.Rand, size int) (reflect.Value, error) {
+	forEach, exactKind, minKind, maxKind, containsKind, uniqueKind :=
+		KForEach, KSliceLength, KMinSliceLength, KMaxSliceLength, KSliceContains, KSliceUnique
+	if base == KArray {
+		forEach, exactKind, minKind, maxKind, containsKind, uniqueKind =
+			KArrayForEach, KArrayLength, KMinArrayLength, KMaxArrayLength, KArrayContains, KArrayUnique
+	}
+	if t != nil && t.Kind() == reflect.Array {
+		return exampleList(rules, base, t)
+	}
+	var elemType reflect.Type
+	if t != nil && t.Kind() == reflect.Slice {
+		elemType = t.Elem()
+	}
+	elemRules := defaultNestedRules(nestedRules(rules, forEach), elemType)
+	n := randomCount(rules, exactKind, minKind, maxKind, rnd, size)
+	if hasKind(rules, containsKind) && n < 1 {
+		n = 1
+	}
+
+	elems, err := randomElems(elemRules, elemType, n, hasKind(rules, uniqueKind), rnd, size)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	for _, r := range rules {
+		if r.Kind == containsKind && n > 0 {
+			want := reflect.ValueOf(argString(r, "value"))
+			if want.Type().ConvertibleTo(elems[0].Type()) {
+				elems[0] = want.Convert(elems[0].Type())
+			}
+		}
+	}
+
+	if elemType == nil && len(elems) > 0 {
+		elemType = elems[0].Type()
+	} else if elemType == nil {
+		elemType = reflect.TypeOf("")
+	}
+	sliceType := reflect.SliceOf(elemType)
+	if t != nil && t.Kind() == reflect.Slice {
+		sliceType = t
+	}
+	if base == KArray {
+		out := reflect.New(reflect.ArrayOf(len(elems), elemType)).Elem()
+		for i, e := range elems {
+			out.Index(i).Set(e)
+		}
+		return out, nil
+	}
+	out := reflect.MakeSlice(sliceType, len(elems), len(elems))
+	for i, e := range elems {
+		out.Index(i).Set(e)
+	}
+	return out, nil
+}
+
+func randomMap(rules []Rule, t reflect.Type, rnd *rand.Rand, size int) (reflect.Value, error) {
+	var keyType, valueType reflect.Type
+	if t != nil && t.Kind() == reflect.Map {
+		keyType, valueType = t.Key(), t.Elem()
+	}
+	keyRules := defaultNestedRules(nestedRules(rules, KMapKeys), keyType)
+	valueRules := defaultNestedRules(nestedRules(rules, KMapValues), valueType)
+	n := randomCount(rules, KMapLength, KMinMapKeys, KMaxMapKeys, rnd, size)
+	keys, err := randomElems(keyRules, keyType, n, true, rnd, size)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	values, err := randomElems(valueRules, valueType, n, false, rnd, size)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if t == nil || t.Kind() != reflect.Map {
+		kt, vt := reflect.TypeOf(""), reflect.TypeOf("")
+		if n > 0 {
+			kt, vt = keys[0].Type(), values[0].Type()
+		}
+		t = reflect.MapOf(kt, vt)
+	}
+	out := reflect.MakeMapWithSize(t, n)
+	for i := range keys {
+		out.SetMapIndex(keys[i], values[i])
+	}
+	return out, nil
+}
+
+// randomElems generates n element values, or zero values of t for nil
+// rules. With distinct set, duplicates are replaced by varied examples.
+func randomElems(rules []Rule, t reflect.Type, n int, distinct bool, rnd *rand.Rand, size int) ([]reflect.Value, error) {
+	elems := make([]reflect.Value, 0, n)
+	seen := map[any]bool{}
+	for i := 0; i < n; i++ {
+		var e reflect.Value
+		if rules == nil {
+			e = reflect.New(t).Elem()
+		} else {
+			var err error
+			if e, err = randomValue(rules, t, rnd, size); err != nil {
+				return nil, err
+			}
+		}
+		if distinct && e.Type().Comparable() {
+			for j := 1; seen[e.Interface()] && j <= n; j++ {
+				e = varyExample(e, i+j)
+			}
+			seen[e.Interface()] = true
+		}
+		elems = append(elems, e)
+	}
+	return elems, nil
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestRandomValue_SatisfiesRules(t *testing.T) {
+	c := NewCompiler(nil)
+	rnd := rand.New(rand.NewSource(1))
+	tests := [][]Rule{
+		{NewRule(KString, nil), NewRule(KMinLength, map[string]any{"n": int64(3)}), NewRule(KMaxLength, map[string]any{"n": int64(8)}), NewRule(KAlpha, nil)},
+		{NewRule(KString, nil), NewRule(KOneOf, map[string]any{"values": []string{"a", "b", "c"}})},
+		{NewRule(KInt, nil), NewRule(KBetween, map[string]any{"min": int64(-3), "max": int64(3)})},
+		{NewRule(KFloat, nil), NewRule(KGreaterThan, map[string]any{"n": 0.5})},
+		{NewRule(KSlice, nil), NewRule(KMaxSliceLength, map[string]any{"n": int64(4)}), NewRule(KSliceUnique, nil)},
+	}
+	for _, rules := range tests {
+		check := c.CompileContext(rules)
+		for i := 0; i < 100; i++ {
+			v, err := RandomValue(rules, nil, rnd, 10)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", rules[1].Kind, err)
+			}
+			if err := check(context.Background(), v); err != nil {
+				t.Fatalf("%s: value %#v does not validate: %v", rules[1].Kind, v, err)
+			}
+		}
+	}
+}
@@ -10,12 +10,12 @@ import (
 const maxRegexPatternMessageRunes = 100
 
 /*
-compileRegexSafe prepares a regexp for a pattern, ensuring it is anchored and
-that invalid pattern errors can use a sanitized pattern for translation.
+compileRegexSafe prepares a regexp for a pattern, ensuring it is anchored per
+the compiler's Behavior and that invalid pattern errors can use a sanitized
+pattern for translation.
 */
 func (c *Compiler) compileRegexSafe(pattern string) (*regexp.Regexp, error) {
-	pattern = normalizeRegexPattern(pattern)
-	return regexp.Compile(pattern)
+	return regexp.Compile(c.regexPattern(pattern))
 }
 
 func normalizeRegexPattern(pattern string) string {
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"reflect"
+	"regexp/syntax"
+)
+
+// CompileReport describes the cost of a compiled rule chain.
+//
+// Fields:
+//   - Rules: Rules in the chain, omitempty and required included.
+//   - Checks: Compiled checks run per value once fusion has joined rules.
+//   - Fused: Rules folded into fused checks; see SetRuleFusion.
+//   - Regexes: Regular expressions of the chain, nested rules included.
+//   - Cacheable: Whether no rule has function arguments, so engines cache
+//     the compiled validator.
+type CompileReport struct {
+	Rules     int
+	Checks    int
+	Fused     int
+	Regexes   []RegexReport
+	Cacheable bool
+}
+
+// RegexReport describes one regular expression of a rule chain.
+//
+// Fields:
+//   - Pattern: The pattern as written in the rule.
+//   - ProgSize: Instructions in the compiled program, a rough measure of
+//     matching cost; 0 when the pattern does not compile.
+type RegexReport struct {
+	Pattern  string
+	ProgSize int
+}
+
+// CompileWithReport compiles rules like CompileE and also returns a report
+// of the chain's cost, to help tune expensive validators.
+//
+// Parameters:
+//   - rules: The rules to compile.
+//
+// Returns:
+//   - ValidatorFunc: The compiled validator.
+//   - CompileReport: Rule, check and fusion counts, regex program sizes and
+//     cacheability.
+//   - error: The compile error, if any.
+func (c *Compiler) CompileWithReport(rules []Rule) (ValidatorFunc, CompileReport, error) {
+	fn, err := c.CompileE(rules)
+	if err != nil {
+		return nil, CompileReport{}, err
+	}
+	report := CompileReport{Rules: len(rules), Cacheable: !HasFuncArgs(rules)}
+	chain := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Kind != KOmitempty && rule.Kind != KRequired && rule.Kind != KUnbounded {
+			chain = append(chain, rule)
+		}
+	}
+	if !hasKind(rules, KUnbounded) {
+		chain = c.withDefaultLimit(chain)
+	}
+	for i := 0; i < len(chain); report.Checks++ {
+		if n := c.fuseLen(chain[i:], CompileOpts{}); n > 0 {
+			report.Fused += n
+			i += n
+			continue
+		}
+		i++
+	}
+	report.Regexes = appendRegexReports(nil, rules)
+	return fn, report, nil
+}
+
+// appendRegexReports appends a report for every regex rule in rules and in
+// their nested rules.
+func appendRegexReports(out []RegexReport, rules []Rule) []RegexReport {
+	for _, rule := range rules {
+		if rule.Kind == KRegex {
+			pattern, _ := rule.Args["pattern"].(string)
+			out = append(out, RegexReport{Pattern: pattern, ProgSize: regexProgSize(pattern)})
+		}
+		if nested, ok := rule.Args["rules"].([]Rule); ok {
+			out = appendRegexReports(out, nested)
+		} else if rule.Elem != nil {
+			out = appendRegexReports(out, []Rule{*rule.Elem})
+		}
+	}
+	return out
+}
+
+// regexProgSize returns the instruction count of pattern compiled as the
+// regex rule compiles it, or 0 if it does not compile.
+func regexProgSize(pattern string) int {
+	re, err := syntax.Parse(normalizeRegexPattern(pattern), syntax.Perl)
+	if err != nil {
+		return 0
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0
+	}
+	return len(prog.Inst)
+}
+
+// HasFuncArgs reports whether any rule argument is a function, directly or
+// nested inside maps, slices, pointers, exported struct fields or Elem
+// rules. Engines skip caching such rules, because function addresses are
+// not deterministic cache keys.
+func HasFuncArgs(rules []Rule) bool {
+	for _, r := range rules {
+		if ruleHasFunc(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleHasFunc(r Rule) bool {
+	if argHasFunc(r.Args) {
+		return true
+	}
+	if r.Elem != nil {
+		return ruleHasFunc(*r.Elem)
+	}
+	return false
+}
+
+func argHasFunc(v any) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Func:
+		return true
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return false
+		}
+		return argHasFunc(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if argHasFunc(rv.Index(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			if argHasFunc(iter.Value().Interface()) {
+				return true
+			}
+		}
+		return false
+	case reflect.Struct:
+		// Best-effort: iterate exported fields only.
+		rt := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			if rt.Field(i).IsExported() &&
+				argHasFunc(rv.Field(i).Interface()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
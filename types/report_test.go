@@ -0,0 +1,42 @@
+package types
+
+import "testing"
+
+func TestCompileWithReport(t *testing.T) {
+	rules, err := ParseTag("slice;min=1;foreach=(string;omitempty;min=2;max=8;regex=[a-z]+)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewCompiler(nil)
+	fn, report, err := c.CompileWithReport(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fn([]string{"ab"}); err != nil {
+		t.Fatalf("validator: %v", err)
+	}
+	if report.Rules != 3 || report.Checks != 3 || report.Fused != 0 || !report.Cacheable {
+		t.Fatalf("slice report = %+v", report)
+	}
+	if len(report.Regexes) != 1 || report.Regexes[0].Pattern != "[a-z]+" || report.Regexes[0].ProgSize == 0 {
+		t.Fatalf("regexes = %+v", report.Regexes)
+	}
+
+	rules, _ = ParseTag("string;required;min=2;max=8;alpha")
+	_, report, _ = c.CompileWithReport(rules)
+	if report.Rules != 5 || report.Checks != 2 || report.Fused != 3 {
+		t.Fatalf("string report = %+v", report)
+	}
+	c.SetRuleFusion(false)
+	if _, report, _ = c.CompileWithReport(rules); report.Checks != 4 || report.Fused != 0 {
+		t.Fatalf("unfused report = %+v", report)
+	}
+
+	withFunc := []Rule{NewRule(KString, nil), {Kind: "custom", Args: map[string]any{"fn": func() {}}}}
+	c.RegisterRule("custom", func(*Compiler, Rule) (func(any) error, error) {
+		return func(any) error { return nil }, nil
+	})
+	if _, report, _ = c.CompileWithReport(withFunc); report.Cacheable {
+		t.Fatal("rules with function arguments reported cacheable")
+	}
+}
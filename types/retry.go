@@ -0,0 +1,184 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped, by rules whose circuit breaker is
+// open, without calling the rule.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// RetryPolicy retries context-aware custom rules, such as DNS or API
+// lookups, when they fail with a transient error. Validation failures
+// (errors.Errors) and context errors are never retried.
+//
+// Fields:
+//   - MaxAttempts: Total calls per validation, including the first; values
+//     below 1 mean 1.
+//   - InitialBackoff: Delay before the first retry.
+//   - MaxBackoff: Upper bound of any delay; 0 means no bound.
+//   - Multiplier: Growth factor of the delay per retry; values below 1
+//     mean 2.
+//   - Jitter: Fraction in [0, 1] of each delay that is randomized, so
+//     concurrent validations do not retry in lockstep.
+//   - Retryable: Reports whether an error is transient; nil retries every
+//     error other than validation failures.
+//   - Breaker: Optional circuit breaker, shared by every validator of the
+//     rule kind; see NewCircuitBreaker.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Jitter         float64
+	Retryable      func(error) bool
+	Breaker        *CircuitBreaker
+}
+
+// CircuitBreaker stops calling a rule after repeated transient failures.
+// After threshold consecutive failed validations it opens and rules fail
+// fast with ErrCircuitOpen; once cooldown passes, one trial call is let
+// through, closing the circuit on success and reopening it on failure. It
+// is safe for concurrent use.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker.
+//
+// Parameters:
+//   - threshold: Consecutive failures that open the circuit; values below 1
+//     mean 1.
+//   - cooldown: Time the circuit stays open before a trial call.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Open reports whether calls are currently rejected.
+func (b *CircuitBreaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures >= b.threshold && (b.trial || b.now().Before(b.openUntil))
+}
+
+// allow reports whether a call may proceed, claiming the trial call when
+// the cooldown has passed.
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// record updates the breaker with the outcome of an allowed call.
+func (b *CircuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// SetRetryPolicy retries context-aware custom rules of kind per p.
+func (c *Compiler) SetRetryPolicy(kind Kind, p RetryPolicy) {
+	if c.retry == nil {
+		c.retry = map[Kind]RetryPolicy{}
+	}
+	c.retry[kind] = p
+}
+
+func (c *Compiler) retryContextRule(kind Kind, compiled compiledContextRule) compiledContextRule {
+	p, ok := c.retry[kind]
+	if !ok || compiled.err != nil {
+		return compiled
+	}
+	return compiledContextRule{validate: func(ctx context.Context, v any) error {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if p.Breaker != nil && !p.Breaker.allow() {
+			return fmt.Errorf("rule %s: %w", safeRuleKindForError(kind), ErrCircuitOpen)
+		}
+		err := p.run(ctx, compiled.validate, v)
+		if p.Breaker != nil {
+			p.Breaker.record(p.transient(err))
+		}
+		return err
+	}}
+}
+
+// run calls validate until it succeeds, fails with a non-transient error,
+// or runs out of attempts.
+func (p RetryPolicy) run(ctx context.Context, validate ContextValidatorFunc, v any) error {
+	attempts := max(p.MaxAttempts, 1)
+	delay := p.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := validate(ctx, v)
+		if attempt >= attempts || !p.transient(err) {
+			return err
+		}
+		timer := time.NewTimer(p.jittered(delay))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		delay = p.next(delay)
+	}
+}
+
+// transient reports whether err is a retryable rule failure.
+func (p RetryPolicy) transient(err error) bool {
+	return isOutageError(err) && (p.Retryable == nil || p.Retryable(err))
+}
+
+func (p RetryPolicy) next(delay time.Duration) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	next := time.Duration(float64(delay) * multiplier)
+	if p.MaxBackoff > 0 && next > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return next
+}
+
+func (p RetryPolicy) jittered(delay time.Duration) time.Duration {
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter == 0 || delay <= 0 {
+		return delay
+	}
+	return delay - time.Duration(jitter*rand.Float64()*float64(delay))
+}
@@ -0,0 +1,151 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// flakyRule returns a context rule failing with a transient error for the
+// first failures calls and counting every call.
+func flakyRule(failures int, calls *int) ContextRuleCompiler {
+	return func(c *Compiler, rule Rule) (ContextValidatorFunc, error) {
+		return func(ctx context.Context, v any) error {
+			*calls++
+			if *calls <= failures {
+				return errors.New("lookup timeout")
+			}
+			if v == "bad" {
+				return verrs.Errors{verrs.FieldError{Code: "mx.missing", Msg: "no mail server"}}
+			}
+			return nil
+		}, nil
+	}
+}
+
+func compileFlaky(t *testing.T, failures int, calls *int, p RetryPolicy) ContextValidatorFunc {
+	t.Helper()
+	c := NewCompiler(nil)
+	c.RegisterContextRule("mx", flakyRule(failures, calls))
+	c.SetRetryPolicy("mx", p)
+	fn, err := c.CompileContextWithOptsE([]Rule{NewRule(KString, nil), NewRule("mx", nil)}, CompileOpts{})
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	return fn
+}
+
+func TestRetryPolicy_RetriesTransientErrors(t *testing.T) {
+	calls := 0
+	fn := compileFlaky(t, 2, &calls, RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	if err := fn(context.Background(), "ok"); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+
+	calls = 0
+	fn = compileFlaky(t, 5, &calls, RetryPolicy{MaxAttempts: 2})
+	if err := fn(context.Background(), "ok"); err == nil || err.Error() != "lookup timeout" {
+		t.Fatalf("expected last transient error, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRetryPolicy_DoesNotRetryValidationFailures(t *testing.T) {
+	calls := 0
+	fn := compileFlaky(t, 0, &calls, RetryPolicy{MaxAttempts: 5})
+	assertCodes(t, fn(context.Background(), "bad"), []string{"mx.missing"})
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+
+	calls = 0
+	fn = compileFlaky(t, 5, &calls, RetryPolicy{MaxAttempts: 5, Retryable: func(error) bool { return false }})
+	if err := fn(context.Background(), "ok"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d with Retryable false, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_StopsOnContextCancel(t *testing.T) {
+	calls := 0
+	fn := compileFlaky(t, 5, &calls, RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := fn(ctx, "ok"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 30 * time.Millisecond}
+	if got := p.next(10 * time.Millisecond); got != 20*time.Millisecond {
+		t.Fatalf("next = %v, want 20ms", got)
+	}
+	if got := p.next(20 * time.Millisecond); got != 30*time.Millisecond {
+		t.Fatalf("next = %v, want capped 30ms", got)
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.jittered(20 * time.Millisecond); got < 10*time.Millisecond || got > 20*time.Millisecond {
+			t.Fatalf("jittered = %v, want within [10ms, 20ms]", got)
+		}
+	}
+}
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	calls := 0
+	fn := compileFlaky(t, 3, &calls, RetryPolicy{Breaker: breaker})
+
+	for i := 0; i < 2; i++ {
+		if err := fn(context.Background(), "ok"); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d: expected transient error, got %v", i, err)
+		}
+	}
+	if !breaker.Open() {
+		t.Fatal("expected breaker to open after 2 failures")
+	}
+	if err := fn(context.Background(), "ok"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2 while open", calls)
+	}
+
+	// The trial call after the cooldown fails and reopens the circuit.
+	now = now.Add(time.Minute)
+	if err := fn(context.Background(), "ok"); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected trial call error, got %v", err)
+	}
+	if !breaker.Open() {
+		t.Fatal("expected breaker to reopen after failed trial")
+	}
+
+	// The next trial succeeds and closes it.
+	now = now.Add(time.Minute)
+	if err := fn(context.Background(), "ok"); err != nil {
+		t.Fatalf("expected trial success, got %v", err)
+	}
+	if breaker.Open() {
+		t.Fatal("expected breaker to close after successful trial")
+	}
+	assertCodes(t, fn(context.Background(), "bad"), []string{"mx.missing"})
+	if breaker.Open() {
+		t.Fatal("validation failures must not open the breaker")
+	}
+}
@@ -1,6 +1,9 @@
 package types
 
-import "context"
+import (
+	"context"
+	"math"
+)
 
 // Kind represents the type of validation rule.
 //
@@ -22,6 +25,7 @@ const (
 	KNotContains Kind = "notContains"
 	KPrefix      Kind = "prefix"
 	KSuffix      Kind = "suffix"
+	KExcludesAll Kind = "excludesAll"
 	KURL         Kind = "url"
 	KHostname    Kind = "hostname"
 	KIP          Kind = "ip"
@@ -31,16 +35,28 @@ const (
 	KASCII       Kind = "ascii"
 	KAlpha       Kind = "alpha"
 	KAlnum       Kind = "alnum"
+	KAlphaUni    Kind = "alphaUnicode"
+	KAlnumUni    Kind = "alnumUnicode"
+	KNumeric     Kind = "numeric"
+	KPrintASCII  Kind = "printAscii"
+	KNumStr      Kind = "numStr"
+	KNumStrMin   Kind = "numStrMin"
+	KNumStrMax   Kind = "numStrMax"
 
 	// Generic modifiers
 	KOmitempty Kind = "omitempty"
 	KRequired  Kind = "required"
+	KUnbounded Kind = "unbounded"
 
 	// Integer validation kinds
 	KInt              Kind = "int"
 	KInt64            Kind = "int64"
 	KMinInt           Kind = "minInt"
 	KMaxInt           Kind = "maxInt"
+	KUint             Kind = "uint"
+	KUint64           Kind = "uint64"
+	KMinUint          Kind = "minUint"
+	KMaxUint          Kind = "maxUint"
 	KFloat            Kind = "float"
 	KMinNumber        Kind = "minNumber"
 	KMaxNumber        Kind = "maxNumber"
@@ -51,7 +67,10 @@ const (
 	KBetween          Kind = "between"
 	KPositive         Kind = "positive"
 	KNonNegative      Kind = "nonNegative"
+	KNegative         Kind = "negative"
+	KNonPositive      Kind = "nonPositive"
 	KFinite           Kind = "finite"
+	KMultipleOf       Kind = "multipleOf"
 
 	// Slice validation kinds
 	KSlice          Kind = "slice"
@@ -83,6 +102,7 @@ const (
 	KBool      Kind = "bool"
 	KBoolTrue  Kind = "boolTrue"
 	KBoolFalse Kind = "boolFalse"
+	KBoolParse Kind = "boolParse"
 
 	// Time validation kinds
 	KTime        Kind = "time"
@@ -90,6 +110,13 @@ const (
 	KTimeBefore  Kind = "timeBefore"
 	KTimeAfter   Kind = "timeAfter"
 	KTimeBetween Kind = "timeBetween"
+	KTimeMin     Kind = "timeMin"
+	KTimeMax     Kind = "timeMax"
+	KTimeLayout  Kind = "timeLayout"
+
+	// Enum validation kinds
+	KEnum     Kind = "enum"
+	KStringer Kind = "stringer"
 )
 
 // Rule represents a single validation rule with its arguments.
@@ -99,22 +126,91 @@ const (
 //   - Args: Map of rule-specific arguments (e.g., {"n": int64(3),
 //     "pattern": ".*"}).
 //   - Elem: For nested rules (e.g., slice element validation).
+//   - Msg: Message reported instead of the translated default when the
+//     rule fails, e.g. from "min=3,msg=Name is too short" in a tag.
 type Rule struct {
 	Kind Kind
 	Args map[string]any // e.g. {"n": int64(3), "pattern": ".*"}
 	Elem *Rule          // For nested rules (e.g., slice element validation)
+	Msg  string         // Message override; "" keeps the default
 }
 
 // NewRuleWithElem builds a Rule with an element sub-rule for nesting.
+// Numeric arguments are normalized as by NewRule.
 func NewRuleWithElem(kind Kind, args map[string]any, elem *Rule) Rule {
-	return Rule{Kind: kind, Args: args, Elem: elem}
+	return Rule{Kind: kind, Args: NormalizeArgs(args), Elem: elem}
 }
 
-// NewRule creates a new rule with the given kind and arguments.
+// NewRule creates a new rule with the given kind and arguments. Numeric
+// arguments are normalized as by NormalizeArgs, so NewRule(KMinLength,
+// map[string]any{"n": 3}) equals the rule ParseTag returns for "min=3".
 func NewRule(kind Kind, args map[string]any) Rule {
 	return Rule{
 		Kind: kind,
-		Args: args,
+		Args: NormalizeArgs(args),
+	}
+}
+
+// NormalizeArgs returns args with top-level integer values converted to
+// int64 and float32 values to float64, the types ParseTag produces.
+// Unsigned values above math.MaxInt64 become uint64. args is returned
+// unchanged when nothing needs converting; otherwise a copy is returned.
+func NormalizeArgs(args map[string]any) map[string]any {
+	var out map[string]any
+	for k, v := range args {
+		n, changed := normalizeArg(v)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]any, len(args))
+			for k2, v2 := range args {
+				out[k2] = v2
+			}
+		}
+		out[k] = n
+	}
+	if out == nil {
+		return args
+	}
+	return out
+}
+
+// normalizeArg converts v to the canonical numeric type and reports
+// whether it changed.
+func normalizeArg(v any) (any, bool) {
+	switch x := v.(type) {
+	case int64, float64:
+		return v, false
+	case uint64:
+		if x > math.MaxInt64 {
+			return v, false
+		}
+		return int64(x), true
+	case uint:
+		if uint64(x) > math.MaxInt64 {
+			return uint64(x), true
+		}
+		return int64(x), true
+	case int, int8, int16, int32, uint8, uint16, uint32:
+		n, _ := toInt64(x)
+		return n, true
+	case float32:
+		return float64(x), true
+	}
+	return v, false
+}
+
+// IntArg returns the integer argument key of r. It accepts every Go
+// integer type, so rules built by hand, by builders and by ParseTag read
+// alike; strings, floats and unsigned values above math.MaxInt64 are
+// rejected.
+func (r Rule) IntArg(key string) (int64, bool) {
+	switch v := r.Args[key].(type) {
+	case nil, string:
+		return 0, false
+	default:
+		return toInt64(v)
 	}
 }
 
@@ -132,8 +228,14 @@ type ValidatorFunc func(v any) error
 type ContextValidatorFunc func(ctx context.Context, v any) error
 
 // CompileOpts tunes rule compilation without changing existing defaults.
+//
+// Fields:
+//   - CollectAll: Report every failing rule instead of the first.
+//   - Sensitive: The rules guard sensitive data; replay recorders skip
+//     their values.
 type CompileOpts struct {
 	CollectAll bool
+	Sensitive  bool
 }
 
 // FieldValidator represents a field-specific validation function.
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
+	verrs "github.com/aatuh/validate/v3/errors"
 	"github.com/aatuh/validate/v3/translator"
 )
 
@@ -188,3 +191,96 @@ func TestCompiler_Compile(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRule_NormalizesNumericArgs(t *testing.T) {
+	parsed, err := ParseTag("string;min=3;max=50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []any{3, int8(3), int32(3), uint(3), uint16(3), int64(3), uint64(3)} {
+		r := NewRule(KMinLength, map[string]any{"n": n})
+		if !reflect.DeepEqual(r, parsed[1]) {
+			t.Errorf("NewRule(n=%T) = %#v, want %#v", n, r, parsed[1])
+		}
+		if got, ok := r.IntArg("n"); !ok || got != 3 {
+			t.Errorf("IntArg(n=%T) = %d, %v", n, got, ok)
+		}
+	}
+
+	args := map[string]any{"n": 1, "pattern": "x"}
+	r := NewRule(KRegex, args)
+	if args["n"] != 1 || r.Args["n"] != int64(1) {
+		t.Fatalf("NewRule must copy before converting: args %v, rule %v", args, r.Args)
+	}
+	if r := NewRule(KMaxNumber, map[string]any{"n": float32(1.5)}); r.Args["n"] != 1.5 {
+		t.Fatalf("float32 arg = %#v, want float64", r.Args["n"])
+	}
+	if r := NewRule(KMaxInt, map[string]any{"n": uint64(1 << 63)}); r.Args["n"] != uint64(1<<63) {
+		t.Fatalf("wide arg = %#v, want uint64", r.Args["n"])
+	}
+	for _, v := range []any{"3", 3.0, uint64(1 << 63), nil} {
+		if _, ok := (Rule{Args: map[string]any{"n": v}}).IntArg("n"); ok {
+			t.Errorf("IntArg accepted %#v", v)
+		}
+	}
+}
+
+func TestCompile_HandBuiltArgsMatchParsedRules(t *testing.T) {
+	c := NewCompiler(nil)
+	parsed, err := ParseTag("string;min=2;max=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	built := []Rule{{Kind: KString}, {Kind: KMinLength, Args: map[string]any{"n": int32(2)}}, {Kind: KMaxLength, Args: map[string]any{"n": uint8(3)}}}
+	for _, s := range []string{"a", "ab", "abcd"} {
+		want, got := c.Compile(parsed)(s), c.Compile(built)(s)
+		if (want == nil) != (got == nil) {
+			t.Errorf("%q: parsed %v, hand-built %v", s, want, got)
+		}
+	}
+}
+
+func TestParseTag_MessageOverrides(t *testing.T) {
+	rules, err := ParseTag("string;required,msg=Name is required;min=3,msg=Name is too short, try again;oneof=ab,abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msgs []string
+	for _, r := range rules {
+		msgs = append(msgs, r.Msg)
+	}
+	if want := []string{"", "Name is required", "Name is too short, try again", ""}; !reflect.DeepEqual(msgs, want) {
+		t.Fatalf("msgs = %q, want %q", msgs, want)
+	}
+	if got := rules[3].Args["values"]; !reflect.DeepEqual(got, []string{"ab", "abc"}) {
+		t.Fatalf("oneof values = %v", got)
+	}
+
+	rules, err = ParseTag("slice;foreach=(string;max=2,msg=Tag too long)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inner := rules[1].Args["rules"].([]Rule)
+	if rules[1].Msg != "" || inner[1].Msg != "Tag too long" {
+		t.Fatalf("nested override = %+v", rules[1])
+	}
+}
+
+func TestCompiler_MessageOverrides(t *testing.T) {
+	c := NewCompiler(translator.NewSimpleTranslator(translator.DefaultEnglishTranslations()))
+	rules, _ := ParseTag("string;required,msg=Name is required;min=3,msg=Name is too short")
+	fn := c.Compile(rules)
+	for value, want := range map[string]string{"": "Name is required", "ab": "Name is too short"} {
+		es, ok := fn(value).(verrs.Errors)
+		if !ok || len(es) != 1 || es[0].Msg != want {
+			t.Fatalf("%q: errors = %v, want message %q", value, es, want)
+		}
+	}
+	ctxFn, err := c.CompileContextE(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es, _ := ctxFn(context.Background(), "ab").(verrs.Errors); len(es) != 1 || es[0].Msg != "Name is too short" || es[0].Code != verrs.CodeStringMin {
+		t.Fatalf("context errors = %v", es)
+	}
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"context"
+	"math/rand/v2"
+)
+
+// ShadowFailure describes a failure of a rule running in shadow mode. Err
+// carries the rule's error with paths relative to the validated value.
+type ShadowFailure struct {
+	Kind Kind
+	Err  error
+}
+
+// ShadowHook receives shadow rule failures. It must be safe for concurrent
+// use because compiled validators are shared.
+type ShadowHook func(ShadowFailure)
+
+// SetShadowRule runs rules of kind in shadow mode: failures are reported to
+// the shadow hook and never fail validation. sampleRate in (0, 1] selects
+// the fraction of validations that execute the rule; values >= 1 always run
+// it and values <= 0 never do.
+func (c *Compiler) SetShadowRule(kind Kind, sampleRate float64) {
+	if c.shadow == nil {
+		c.shadow = map[Kind]float64{}
+	}
+	c.shadow[kind] = sampleRate
+}
+
+// SetShadowHook sets the hook that receives shadow rule failures.
+func (c *Compiler) SetShadowHook(hook ShadowHook) {
+	c.shadowHook = hook
+}
+
+func (c *Compiler) shadowRule(kind Kind, compiled compiledRule) compiledRule {
+	rate, ok := c.shadow[kind]
+	if !ok || compiled.err != nil {
+		return compiled
+	}
+	hook := c.shadowHook
+	return compiledRule{validate: func(v any) error {
+		if !sampled(rate) {
+			return nil
+		}
+		if err := compiled.validate(v); err != nil && hook != nil {
+			hook(ShadowFailure{Kind: kind, Err: err})
+		}
+		return nil
+	}}
+}
+
+func (c *Compiler) shadowContextRule(kind Kind, compiled compiledContextRule) compiledContextRule {
+	rate, ok := c.shadow[kind]
+	if !ok || compiled.err != nil {
+		return compiled
+	}
+	hook := c.shadowHook
+	return compiledContextRule{validate: func(ctx context.Context, v any) error {
+		if !sampled(rate) {
+			return nil
+		}
+		err := compiled.validate(ctx, v)
+		if err == nil {
+			return nil
+		}
+		if ctx != nil && ctx.Err() != nil {
+			return err
+		}
+		if hook != nil {
+			hook(ShadowFailure{Kind: kind, Err: err})
+		}
+		return nil
+	}}
+}
+
+func sampled(rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return rand.Float64() < rate
+}
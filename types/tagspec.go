@@ -0,0 +1,234 @@
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagSpec is a machine-readable description of the tag grammar for editor
+// completion and hover docs. It is built from the parser's token table and
+// the kind documentation registry, so it reflects registered plugins.
+//
+// Fields:
+//   - Version: Plugin API version of the grammar.
+//   - Generic: Tokens accepted after every base type.
+//   - Types: Base types with their tokens, in tag order.
+//   - Plugins: Globally registered custom rule kinds usable as bare tokens.
+//   - Struct: Struct-only tokens; filled by the root package.
+type TagSpec struct {
+	Version int           `json:"version"`
+	Generic []TagToken    `json:"generic"`
+	Types   []TagTypeSpec `json:"types"`
+	Plugins []TagToken    `json:"plugins"`
+	Struct  []TagToken    `json:"struct,omitempty"`
+}
+
+// TagTypeSpec describes one base type, the first token of a tag.
+type TagTypeSpec struct {
+	Name    string     `json:"name"`
+	Kind    Kind       `json:"kind"`
+	Summary string     `json:"summary,omitempty"`
+	Tokens  []TagToken `json:"tokens"`
+}
+
+// TagToken describes one tag token.
+//
+// Fields:
+//   - Token: Token name before '=', e.g. "min" or "url".
+//   - Aliases: Alternative names accepted by the parser.
+//   - Param: Value type after '=', e.g. "int", "float64" or "rules"; empty
+//     for flag tokens.
+//   - Kind: Rule kind the token produces.
+//   - Summary: Short description from DescribeKind.
+type TagToken struct {
+	Token   string   `json:"token"`
+	Aliases []string `json:"aliases,omitempty"`
+	Param   string   `json:"param,omitempty"`
+	Kind    Kind     `json:"kind,omitempty"`
+	Summary string   `json:"summary,omitempty"`
+}
+
+// Param value types used in TagToken.Param.
+const (
+	ParamInt      = "int"
+	ParamInteger  = "integer" // int64, or uint64 above the int64 range
+	ParamUint     = "uint64"
+	ParamFloat    = "float64"
+	ParamDecimal  = "decimal" // [+-]digits[.digits], kept as written
+	ParamString   = "string"
+	ParamList     = "string,..."
+	ParamRange    = "float64,float64"
+	ParamTime     = "rfc3339" // also a 2006-01-02 date or [now][+-]duration
+	ParamTimes    = "rfc3339,rfc3339"
+	ParamRules    = "(rules)"
+	ParamRuleName = "name[=value]"
+	ParamEnum     = "enum" // name of a type registered with RegisterIntEnum
+)
+
+func tok(token string, param string, kind Kind, aliases ...string) TagToken {
+	return TagToken{Token: token, Aliases: aliases, Param: param, Kind: kind}
+}
+
+var numberTokens = []TagToken{
+	tok("gt", ParamFloat, KGreaterThan),
+	tok("gte", ParamFloat, KGreaterThanEqual),
+	tok("lt", ParamFloat, KLessThan),
+	tok("lte", ParamFloat, KLessThanEqual),
+	tok("between", ParamRange, KBetween),
+	tok("positive", "", KPositive),
+	tok("nonnegative", "", KNonNegative),
+	tok("negative", "", KNegative),
+	tok("nonpositive", "", KNonPositive),
+}
+
+// tagTypeTokens mirrors the token switch of each parse*Rule function.
+var tagTypeTokens = []TagTypeSpec{
+	{Name: "string", Kind: KString, Tokens: []TagToken{
+		tok("len", ParamInt, KLength, "length"),
+		tok("min", ParamInt, KMinLength),
+		tok("max", ParamInt, KMaxLength),
+		tok("minRunes", ParamInt, KMinRunes),
+		tok("maxRunes", ParamInt, KMaxRunes),
+		tok("regex", ParamString, KRegex),
+		tok("oneof", ParamList, KOneOf),
+		tok("enum", ParamEnum, KEnum),
+		tok("stringer", "", KStringer),
+		tok("nonempty", "", KNonEmpty),
+		tok("contains", ParamString, KContains),
+		tok("notContains", ParamString, KNotContains, "notcontains"),
+		tok("prefix", ParamString, KPrefix, "startswith"),
+		tok("suffix", ParamString, KSuffix, "endswith"),
+		tok("excludesall", ParamString, KExcludesAll),
+		tok("url", "", KURL),
+		tok("hostname", "", KHostname),
+		tok("ip", "", KIP),
+		tok("ipv4", "", KIPv4),
+		tok("ipv6", "", KIPv6),
+		tok("cidr", "", KCIDR),
+		tok("ascii", "", KASCII),
+		tok("alpha", "", KAlpha),
+		tok("alnum", "", KAlnum, "alphanum"),
+		tok("alphaunicode", "", KAlphaUni),
+		tok("alphanumunicode", "", KAlnumUni),
+		tok("numeric", "", KNumeric),
+		tok("printascii", "", KPrintASCII),
+		tok("numstr", "", KNumStr),
+		tok("min_value", ParamDecimal, KNumStrMin),
+		tok("max_value", ParamDecimal, KNumStrMax),
+	}},
+	{Name: "int", Kind: KInt, Tokens: append([]TagToken{
+		tok("min", ParamInteger, KMinInt),
+		tok("max", ParamInteger, KMaxInt),
+		tok("multipleof", ParamInteger, KMultipleOf),
+		tok("enum", ParamEnum, KEnum),
+	}, numberTokens...)},
+	{Name: "int64", Kind: KInt64, Tokens: append([]TagToken{
+		tok("min", ParamInteger, KMinInt),
+		tok("max", ParamInteger, KMaxInt),
+		tok("multipleof", ParamInteger, KMultipleOf),
+		tok("enum", ParamEnum, KEnum),
+	}, numberTokens...)},
+	{Name: "uint", Kind: KUint, Tokens: append([]TagToken{
+		tok("min", ParamUint, KMinUint),
+		tok("max", ParamUint, KMaxUint),
+		tok("multipleof", ParamUint, KMultipleOf),
+	}, numberTokens...)},
+	{Name: "uint64", Kind: KUint64, Tokens: append([]TagToken{
+		tok("min", ParamUint, KMinUint),
+		tok("max", ParamUint, KMaxUint),
+		tok("multipleof", ParamUint, KMultipleOf),
+	}, numberTokens...)},
+	{Name: "float", Kind: KFloat, Tokens: append([]TagToken{
+		tok("finite", "", KFinite),
+		tok("min", ParamFloat, KMinNumber),
+		tok("max", ParamFloat, KMaxNumber),
+		tok("multipleof", ParamFloat, KMultipleOf),
+	}, numberTokens...)},
+	{Name: "slice", Kind: KSlice, Tokens: []TagToken{
+		tok("len", ParamInt, KSliceLength, "length"),
+		tok("min", ParamInt, KMinSliceLength),
+		tok("max", ParamInt, KMaxSliceLength),
+		tok("foreach", ParamRules, KForEach),
+		tok("unique", "", KSliceUnique),
+		tok("contains", ParamString, KSliceContains),
+	}},
+	{Name: "array", Kind: KArray, Tokens: []TagToken{
+		tok("len", ParamInt, KArrayLength, "length"),
+		tok("min", ParamInt, KMinArrayLength),
+		tok("max", ParamInt, KMaxArrayLength),
+		tok("foreach", ParamRules, KArrayForEach),
+		tok("unique", "", KArrayUnique),
+		tok("contains", ParamString, KArrayContains),
+	}},
+	{Name: "map", Kind: KMap, Tokens: []TagToken{
+		tok("len", ParamInt, KMapLength, "length"),
+		tok("minKeys", ParamInt, KMinMapKeys, "min"),
+		tok("maxKeys", ParamInt, KMaxMapKeys, "max"),
+		tok("keys", ParamRules, KMapKeys),
+		tok("values", ParamRules, KMapValues),
+	}},
+	{Name: "bool", Kind: KBool, Tokens: []TagToken{
+		tok("true", "", KBoolTrue, "istrue"),
+		tok("false", "", KBoolFalse, "isfalse"),
+		tok("parse", "", KBoolParse),
+	}},
+	{Name: "time", Kind: KTime, Tokens: []TagToken{
+		tok("notzero", "", KTimeNotZero),
+		tok("before", ParamTime, KTimeBefore),
+		tok("after", ParamTime, KTimeAfter),
+		tok("between", ParamTimes, KTimeBetween),
+		tok("min", ParamTime, KTimeMin),
+		tok("max", ParamTime, KTimeMax),
+		tok("layout", ParamString, KTimeLayout),
+	}},
+}
+
+// BuildTagSpec returns the current tag grammar, including globally
+// registered plugin kinds and their documentation.
+func BuildTagSpec() TagSpec {
+	spec := TagSpec{
+		Version: PluginAPIVersion,
+		Generic: []TagToken{
+			describeToken(tok("required", "", KRequired)),
+			describeToken(tok("omitempty", "", KOmitempty)),
+			{Token: "custom:", Param: ParamRuleName, Summary: "Apply a registered custom rule; the value is passed as Args[\"value\"]"},
+		},
+	}
+	for _, typ := range tagTypeTokens {
+		out := TagTypeSpec{Name: typ.Name, Kind: typ.Kind, Summary: kindSummary(typ.Kind)}
+		for _, t := range typ.Tokens {
+			out.Tokens = append(out.Tokens, describeToken(t))
+		}
+		spec.Types = append(spec.Types, out)
+	}
+
+	globalRegistryMu.RLock()
+	kinds := make([]Kind, 0, len(globalRegistry))
+	for kind := range globalRegistry {
+		kinds = append(kinds, kind)
+	}
+	globalRegistryMu.RUnlock()
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	for _, kind := range kinds {
+		if strings.TrimSpace(string(kind)) == "" {
+			continue
+		}
+		spec.Plugins = append(spec.Plugins, describeToken(tok(string(kind), "", kind)))
+	}
+	return spec
+}
+
+func describeToken(t TagToken) TagToken {
+	t.Aliases = append([]string(nil), t.Aliases...)
+	if t.Summary == "" {
+		t.Summary = kindSummary(t.Kind)
+	}
+	return t
+}
+
+func kindSummary(kind Kind) string {
+	if doc, ok := DescribeKind(kind); ok {
+		return doc.Summary
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package types
+
+import "testing"
+
+func TestBuildTagSpecTokensParse(t *testing.T) {
+	samples := map[string]string{
+		ParamInt:     "2",
+		ParamInteger: "2",
+		ParamUint:    "2",
+		ParamFloat:   "1.5",
+		ParamDecimal: "-0.5",
+		ParamString:  "a",
+		ParamList:    "a,b",
+		ParamRange:   "1,2",
+		ParamTime:    "2020-01-01T00:00:00Z",
+		ParamTimes:   "2020-01-01T00:00:00Z,2030-01-01T00:00:00Z",
+		ParamRules:   "(string;min=1)",
+		ParamEnum:    "OrderStatus",
+	}
+	// Tokens that only parse alongside another token.
+	requires := map[Kind]string{KStringer: "enum=OrderStatus"}
+	spec := BuildTagSpec()
+	if len(spec.Types) == 0 || len(spec.Generic) == 0 {
+		t.Fatalf("spec is missing sections: %+v", spec)
+	}
+	for _, typ := range spec.Types {
+		for _, token := range typ.Tokens {
+			names := append([]string{token.Token}, token.Aliases...)
+			for _, name := range names {
+				tag := typ.Name + ";" + name
+				if req, ok := requires[token.Kind]; ok {
+					tag = typ.Name + ";" + req + ";" + name
+				}
+				if token.Param != "" {
+					sample, ok := samples[token.Param]
+					if !ok {
+						t.Fatalf("no sample for param type %q", token.Param)
+					}
+					tag += "=" + sample
+				}
+				rules, err := ParseTag(tag)
+				if err != nil {
+					t.Fatalf("ParseTag(%q): %v", tag, err)
+				}
+				if got := rules[len(rules)-1].Kind; got != token.Kind {
+					t.Fatalf("ParseTag(%q) kind = %s, want %s", tag, got, token.Kind)
+				}
+			}
+			if token.Summary == "" {
+				t.Fatalf("%s;%s has no summary", typ.Name, token.Token)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+// DateLayout is the date-only layout accepted for time bounds such as
+// after=2020-01-01. Dates are read as midnight UTC.
+const DateLayout = "2006-01-02"
+
+// RelativeTime is a time bound relative to the moment of validation,
+// written "now", "now+24h" or "now-30m" in tags. It is stored in Rule.Args
+// in place of a time.Time and resolved each time a value is validated.
+type RelativeTime struct {
+	Offset time.Duration
+}
+
+// Now returns a RelativeTime offset from the moment of validation.
+func Now(offset time.Duration) RelativeTime {
+	return RelativeTime{Offset: offset}
+}
+
+// Resolve returns the bound for the moment now.
+func (r RelativeTime) Resolve(now time.Time) time.Time {
+	return now.Add(r.Offset)
+}
+
+// String returns the tag form of r, e.g. "now+24h0m0s".
+func (r RelativeTime) String() string {
+	switch {
+	case r.Offset > 0:
+		return "now+" + r.Offset.String()
+	case r.Offset < 0:
+		return "now" + r.Offset.String()
+	}
+	return "now"
+}
+
+// parseTimeBound parses a time rule bound: an RFC 3339 timestamp, a
+// DateLayout date, or a RelativeTime, written with or without "now", as in
+// now-1h or -1h.
+func parseTimeBound(value string) (any, error) {
+	value = strings.TrimSpace(value)
+	if rest, ok := strings.CutPrefix(value, "now"); ok || strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+		if !ok {
+			rest = value
+		}
+		if rest == "" {
+			return RelativeTime{}, nil
+		}
+		if rest[0] != '+' && rest[0] != '-' {
+			return nil, fmt.Errorf("relative time must be now, now+duration or now-duration")
+		}
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return nil, err
+		}
+		return RelativeTime{Offset: d}, nil
+	}
+	if t, err := time.Parse(DateLayout, value); err == nil {
+		return t, nil
+	}
+	return parseRFC3339(value)
+}
+
+// resolveTimeBound returns the time.Time for a bound stored in Rule.Args,
+// resolving a RelativeTime against now without its monotonic clock reading,
+// which would otherwise show in error params.
+func resolveTimeBound(v any, now time.Time) time.Time {
+	switch b := v.(type) {
+	case time.Time:
+		return b
+	case RelativeTime:
+		return b.Resolve(now.Round(0))
+	}
+	return time.Time{}
+}
+
+// timeLayout returns the layout of the layout= rule in rules, or
+// time.RFC3339Nano.
+func timeLayout(rules []Rule) string {
+	for _, r := range rules {
+		if r.Kind == KTimeLayout {
+			if layout, _ := r.Args["layout"].(string); layout != "" {
+				return layout
+			}
+		}
+	}
+	return time.RFC3339Nano
+}
+
+// timeParseLayouts returns the layouts strings in a time chain are parsed
+// with: the layout= rule's, or else RFC 3339 and DateLayout, the formats
+// time bounds are written in.
+func timeParseLayouts(rules []Rule) []string {
+	if hasKind(rules, KTimeLayout) {
+		return []string{timeLayout(rules)}
+	}
+	return []string{time.RFC3339Nano, DateLayout}
+}
+
+// formatTimeAs returns at as a value of type t: formatted with layout for
+// string types, unchanged otherwise.
+func formatTimeAs(at time.Time, t reflect.Type, layout string) reflect.Value {
+	if t != nil && t.Kind() == reflect.String {
+		return reflect.ValueOf(at.Format(layout))
+	}
+	return reflect.ValueOf(at)
+}
+
+// parseTimeString parses strings with the first of layouts that matches
+// for time chains, so string fields holding dates validate like time.Time
+// fields. Empty strings are left for omitempty and required.
+func (c *Compiler) parseTimeString(v any, layouts []string) (any, error) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return v, nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	layout := strings.Join(layouts, " or ")
+	msg := c.translateMessage("time.parse", fmt.Sprintf("expected time in layout %s", layout), []any{layout})
+	return nil, verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeTimeParse, Msg: msg, Param: layout}}
+}
@@ -2,6 +2,8 @@ package validate
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/aatuh/validate/v3/core"
 	"github.com/aatuh/validate/v3/errors"
@@ -11,9 +13,15 @@ import (
 	"github.com/aatuh/validate/v3/types"
 
 	// Ensure built-in plugin validators register themselves.
+	_ "github.com/aatuh/validate/v3/validators/creditcard"
 	_ "github.com/aatuh/validate/v3/validators/domain"
 	_ "github.com/aatuh/validate/v3/validators/email"
+	_ "github.com/aatuh/validate/v3/validators/iso"
+	_ "github.com/aatuh/validate/v3/validators/jwt"
+	_ "github.com/aatuh/validate/v3/validators/netname"
+	_ "github.com/aatuh/validate/v3/validators/phone"
 	_ "github.com/aatuh/validate/v3/validators/ulid"
+	_ "github.com/aatuh/validate/v3/validators/url"
 	_ "github.com/aatuh/validate/v3/validators/uuid"
 )
 
@@ -21,6 +29,7 @@ import (
 type Validate = glue.Validate
 type StringBuilder = glue.StringBuilder
 type IntBuilder = glue.IntBuilder
+type UintBuilder = glue.UintBuilder
 type FloatBuilder = glue.FloatBuilder
 type BoolBuilder = glue.BoolBuilder
 type SliceBuilder = glue.SliceBuilder
@@ -28,8 +37,41 @@ type ArrayBuilder = glue.ArrayBuilder
 type MapBuilder = glue.MapBuilder
 type TimeBuilder = glue.TimeBuilder
 type CustomTypeBuilder = glue.CustomTypeBuilder
+type Difference = glue.Difference
+type DualRun = glue.DualRun
 type Errors = errors.Errors
+type TruncationPolicy = errors.TruncationPolicy
+type ProblemDetails = errors.ProblemDetails
+type ProblemError = errors.ProblemError
 type ValidateOpts = core.ValidateOpts
+type FieldMode = core.FieldMode
+type TagCompileError = structvalidator.TagCompileError
+type TagCompileErrors = structvalidator.TagCompileErrors
+type CoverageReport = structvalidator.CoverageReport
+type RuleAudit = structvalidator.RuleAudit
+type Policy = structvalidator.Policy
+type PolicyRule = structvalidator.PolicyRule
+type PolicyViolation = structvalidator.PolicyViolation
+type RuleAuditEntry = structvalidator.RuleAuditEntry
+type CounterExample = structvalidator.CounterExample
+type Generator = structvalidator.Generator
+type TypedValidator = structvalidator.TypedValidator
+type Validatable = structvalidator.Validatable
+type Incremental = structvalidator.Incremental
+type GeneratedValidator = structvalidator.GeneratedValidator
+type Schema = core.Schema
+type ClientSchema = core.ClientSchema
+type ClientField = core.ClientField
+type PayloadDecoder = core.PayloadDecoder
+type Result = core.Result
+type PayloadDecoderFunc = core.PayloadDecoderFunc
+type Observer = core.Observer
+type Recorder = core.Recorder
+type CorpusEntry = core.CorpusEntry
+type ReplayDiff = core.ReplayDiff
+type TagSpecDoc = types.TagSpec
+type TagTypeSpec = types.TagTypeSpec
+type TagToken = types.TagToken
 
 // Re-export types package for manual rule construction
 type Rule = types.Rule
@@ -41,10 +83,52 @@ type RuleCompiler = types.RuleCompiler
 type ContextRuleCompiler = types.ContextRuleCompiler
 type TypeValidator = types.TypeValidator
 type TypeValidatorFactory = types.TypeValidatorFactory
+type ShadowFailure = types.ShadowFailure
+type ShadowHook = types.ShadowHook
+type CompileHook = types.CompileHook
+type Middleware = types.Middleware
+type RuleInfo = types.RuleInfo
+type Profiler = types.Profiler
+type ProfileEntry = types.ProfileEntry
+type ProfileReport = types.ProfileReport
+type RetryPolicy = types.RetryPolicy
+type CircuitBreaker = types.CircuitBreaker
+type OutagePolicy = types.OutagePolicy
+type OutageMode = types.OutageMode
+type RuleOutage = types.RuleOutage
+type OutageHook = types.OutageHook
+type ResultCache = types.ResultCache
+type ResultCacheStats = types.ResultCacheStats
+type ConverterFunc = types.ConverterFunc
+type KindDoc = types.KindDoc
+type NilPolicy = types.NilPolicy
+type DefaultLimits = types.DefaultLimits
+type Behavior = types.Behavior
+type ParseError = types.ParseError
+type CompileError = types.CompileError
+type ParamDoc = types.ParamDoc
+type RelativeTime = types.RelativeTime
+type OpenAPISchema = types.OpenAPISchema
+type ClientRules = types.ClientRules
+type ClientCheck = types.ClientCheck
 type StructRuleContext = core.StructRuleContext
 type StructRuleFunc = core.StructRuleFunc
 type StructRuleCompiler = core.StructRuleCompiler
 
+// Re-export struct validation modes
+const (
+	ModeAny    = core.ModeAny
+	ModeInput  = core.ModeInput
+	ModeOutput = core.ModeOutput
+)
+
+// Re-export external rule outage modes
+const (
+	OutageFail        = types.OutageFail
+	OutagePass        = types.OutagePass
+	OutageUnavailable = types.OutageUnavailable
+)
+
 // Re-export commonly used rule kinds
 const (
 	// String validation kinds
@@ -61,6 +145,7 @@ const (
 	KNotContains = types.KNotContains
 	KPrefix      = types.KPrefix
 	KSuffix      = types.KSuffix
+	KExcludesAll = types.KExcludesAll
 	KURL         = types.KURL
 	KHostname    = types.KHostname
 	KIP          = types.KIP
@@ -70,16 +155,28 @@ const (
 	KASCII       = types.KASCII
 	KAlpha       = types.KAlpha
 	KAlnum       = types.KAlnum
+	KAlphaUni    = types.KAlphaUni
+	KAlnumUni    = types.KAlnumUni
+	KNumeric     = types.KNumeric
+	KPrintASCII  = types.KPrintASCII
+	KNumStr      = types.KNumStr
+	KNumStrMin   = types.KNumStrMin
+	KNumStrMax   = types.KNumStrMax
 
 	// Generic modifiers
 	KOmitempty = types.KOmitempty
 	KRequired  = types.KRequired
+	KUnbounded = types.KUnbounded
 
 	// Integer validation kinds
 	KInt              = types.KInt
 	KInt64            = types.KInt64
 	KMinInt           = types.KMinInt
 	KMaxInt           = types.KMaxInt
+	KUint             = types.KUint
+	KUint64           = types.KUint64
+	KMinUint          = types.KMinUint
+	KMaxUint          = types.KMaxUint
 	KFloat            = types.KFloat
 	KMinNumber        = types.KMinNumber
 	KMaxNumber        = types.KMaxNumber
@@ -90,7 +187,10 @@ const (
 	KBetween          = types.KBetween
 	KPositive         = types.KPositive
 	KNonNegative      = types.KNonNegative
+	KNegative         = types.KNegative
+	KNonPositive      = types.KNonPositive
 	KFinite           = types.KFinite
+	KMultipleOf       = types.KMultipleOf
 
 	// Slice validation kinds
 	KSlice          = types.KSlice
@@ -122,6 +222,7 @@ const (
 	KBool      = types.KBool
 	KBoolTrue  = types.KBoolTrue
 	KBoolFalse = types.KBoolFalse
+	KBoolParse = types.KBoolParse
 
 	// Time validation kinds
 	KTime        = types.KTime
@@ -129,8 +230,27 @@ const (
 	KTimeBefore  = types.KTimeBefore
 	KTimeAfter   = types.KTimeAfter
 	KTimeBetween = types.KTimeBetween
+	KTimeMin     = types.KTimeMin
+	KTimeMax     = types.KTimeMax
+	KTimeLayout  = types.KTimeLayout
+
+	// Enum validation kinds
+	KEnum     = types.KEnum
+	KStringer = types.KStringer
+)
+
+// Nil policies for WithNilPolicy.
+const (
+	NilReject = types.NilReject
+	NilAllow  = types.NilAllow
 )
 
+// PluginAPIVersion is the rule compiler contract version plugins build against.
+const PluginAPIVersion = types.PluginAPIVersion
+
+// ProblemContentType is the media type of ProblemDetails responses.
+const ProblemContentType = errors.ProblemContentType
+
 // Re-export translator package
 type Translator = translator.Translator
 type SimpleTranslator = translator.SimpleTranslator
@@ -142,20 +262,68 @@ var (
 	MergeTranslations                  = translator.MergeTranslations
 	RegisterDefaultEnglishTranslations = translator.RegisterDefaultEnglishTranslations
 	JSONFieldName                      = structvalidator.JSONFieldName
+	XMLFieldName                       = structvalidator.XMLFieldName
+	RequireMaxLength                   = structvalidator.RequireMaxLength
+)
+
+// Re-export errors functions
+var (
+	SetTruncationPolicy     = errors.SetTruncationPolicy
+	CurrentTruncationPolicy = errors.CurrentTruncationPolicy
 )
 
 // Re-export types functions
 var (
-	NewRule            = types.NewRule
-	RegisterRule       = types.RegisterRule
-	RegisterGlobalType = types.RegisterGlobalType
+	NewRule                = types.NewRule
+	RegisterRule           = types.RegisterRule
+	RegisterGlobalType     = types.RegisterGlobalType
+	RegisterKindDoc        = types.RegisterKindDoc
+	RegisterNamespacedRule = types.RegisterNamespacedRule
+	NamespacedKind         = types.NamespacedKind
+	ErrKindConflict        = types.ErrKindConflict
+	CheckPluginAPI         = types.CheckPluginAPI
+	RegisterRuleForAPI     = types.RegisterRuleForAPI
+	DescribeKind           = types.DescribeKind
+	DescribedKinds         = types.DescribedKinds
+	EnumNames              = types.EnumNames
+	OpenAPISchemaFor       = types.OpenAPISchemaFor
+	ClientRulesFor         = types.ClientRulesFor
+	NewCircuitBreaker      = types.NewCircuitBreaker
+	ErrCircuitOpen         = types.ErrCircuitOpen
+	NewResultCache         = types.NewResultCache
+	Memoize                = types.Memoize
+	MemoizeContext         = types.MemoizeContext
+	FieldPath              = types.FieldPath
+	NewProfiler            = types.NewProfiler
+	LatestBehavior         = types.LatestBehavior
+	RegisterSchema         = core.RegisterSchema
+	LookupSchema           = core.LookupSchema
+	Fingerprint            = core.Fingerprint
+	NewRecorder            = core.NewRecorder
+	MaskString             = core.MaskString
+	Compare                = glue.Compare
 )
 
+// RegisterIntEnum registers the integer enum type T for the enum=Name rule.
+// See types.RegisterIntEnum.
+func RegisterIntEnum[T types.Integer](names map[T]string) {
+	types.RegisterIntEnum(names)
+}
+
+// RegisterStringerEnum registers the integer enum type T, naming values by
+// their String method. See types.RegisterStringerEnum.
+func RegisterStringerEnum[T interface {
+	types.Integer
+	fmt.Stringer
+}](values ...T) {
+	types.RegisterStringerEnum(values...)
+}
+
 // New returns a Validate configured with sensible defaults.
 //
 // Defaults:
 // - Installs default English translations via SimpleTranslator.
-// - Registers built-in plugins (domain, email, ulid, uuid) via blank imports.
+// - Registers built-in plugins (creditcard, domain, email, iso, jwt, netname, phone, ulid, url, uuid) via blank imports.
 func New() *Validate {
 	v := glue.New()
 	tr := translator.NewSimpleTranslator(
@@ -174,6 +342,74 @@ func NewWithTranslator(tr translator.Translator) *Validate {
 // Useful for advanced setups that manage translations differently.
 func NewBare() *Validate { return glue.NewBare() }
 
+// TagSpec returns a JSON description of every tag token, its parameter
+// value type and summary, including struct-only tokens and globally
+// registered plugin kinds. IDE plugins and gopls analyzers can use it for
+// tag completion and hover docs.
+func TagSpec() ([]byte, error) {
+	spec := types.BuildTagSpec()
+	spec.Struct = structvalidator.TagTokens()
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// ExampleFor generates a valid example for schema, a tag string or a struct
+// type, using a default Validate. See Validate.ExampleFor for instances with
+// custom rules.
+func ExampleFor(schema any) (any, error) {
+	return New().ExampleFor(schema, ValidateOpts{})
+}
+
+// CounterExamples returns, for each rule of schema, a value that violates
+// it, using a default Validate. See Validate.CounterExamples.
+func CounterExamples(schema any) ([]CounterExample, error) {
+	return New().CounterExamples(schema, ValidateOpts{})
+}
+
+// CheckPolicy reports fields reachable from the struct types of schemas
+// whose rules break p, using a default Validate. See Validate.CheckPolicy.
+func CheckPolicy(p Policy, schemas ...any) ([]PolicyViolation, error) {
+	return New().CheckPolicy(p, ValidateOpts{}, schemas...)
+}
+
+// GeneratorFor returns a random value generator for schema, using a default
+// Validate. See Validate.Generator.
+func GeneratorFor(schema any) (*Generator, error) {
+	return New().Generator(schema, ValidateOpts{})
+}
+
+// OpenAPIComponents returns OpenAPI 3.0 component schemas for the struct
+// type of schema and the struct types it references, using a default
+// Validate. See Validate.OpenAPIComponents.
+func OpenAPIComponents(schema any) (map[string]*OpenAPISchema, error) {
+	return New().OpenAPIComponents(schema, ValidateOpts{})
+}
+
+// ValidateJSON decodes data and validates it against schema, reporting
+// errors with JSON Pointer paths. See Schema.ValidateJSON.
+func ValidateJSON(data []byte, schema *Schema) error {
+	return schema.ValidateJSON(data)
+}
+
+// ValidatePayload decodes a binary payload, such as MessagePack or CBOR,
+// with dec and validates it against schema, reporting errors with JSON
+// Pointer paths. See Schema.ValidatePayload.
+func ValidatePayload(data []byte, schema *Schema, dec PayloadDecoder) error {
+	return schema.ValidatePayload(data, dec)
+}
+
+// YAML decodes a YAML document, such as a configuration file, and validates
+// it against schema. Errors have JSON Pointer paths and source line and
+// column. See Schema.ValidateYAML.
+func YAML(schema *Schema, data []byte) error {
+	return schema.ValidateYAML(data)
+}
+
+// ValidateXML decodes an XML document into dst, a pointer to struct, and
+// validates it with xml tag names in error paths, using a default Validate.
+func ValidateXML(data []byte, dst any) error {
+	return New().ValidateXML(data, dst)
+}
+
 // FromTag compiles a single tag string using v (or a fresh instance).
 func FromTag(v *Validate, tag string) (func(any) error, error) {
 	if v == nil {
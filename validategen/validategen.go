@@ -0,0 +1,522 @@
+// Package validategen generates reflection-free struct validators from
+// `validate` tags.
+//
+// For each struct type, Generate emits a ValidateGenerated method, which
+// ValidateStruct prefers over its reflective walk, and a Validate method
+// using an engine configured like validate.New. Built-in string and integer rules are
+// inlined; other rule chains are compiled once by the engine and called
+// directly, and nested structs dispatch to their own generated validators.
+// Types using struct-level tokens such as eqField, quota, readonly or dive are
+// skipped and keep validating through reflection.
+//
+// Generated files import only core, structvalidator, translator and the
+// plugin packages their tags use, so they also build with the
+// validate_lite tag, which drops the reflective walk for tinygo and WASM.
+//
+// The cmd/validategen command wraps Generate for go:generate.
+package validategen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aatuh/validate/v3/types"
+	"github.com/aatuh/validate/v3/validators/creditcard"
+	"github.com/aatuh/validate/v3/validators/domain"
+	"github.com/aatuh/validate/v3/validators/email"
+	"github.com/aatuh/validate/v3/validators/iso"
+	"github.com/aatuh/validate/v3/validators/jwt"
+	"github.com/aatuh/validate/v3/validators/netname"
+	"github.com/aatuh/validate/v3/validators/phone"
+	"github.com/aatuh/validate/v3/validators/ulid"
+	"github.com/aatuh/validate/v3/validators/url"
+	"github.com/aatuh/validate/v3/validators/uuid"
+)
+
+// Config selects what Generate emits.
+//
+// Fields:
+//   - Types: Struct type names to generate; empty generates every struct
+//     type with at least one `validate` tag.
+type Config struct {
+	Types []string
+}
+
+// Result is the output of Generate.
+//
+// Fields:
+//   - Source: The formatted Go file; nil when no type was generated.
+//   - Generated: Names of the generated types, in source order.
+//   - Skipped: Types that were not generated, with the reason.
+type Result struct {
+	Source    []byte
+	Generated []string
+	Skipped   map[string]string
+}
+
+// structOnlyTokens are tag tokens handled by the reflective struct walk
+// only. Types using them are skipped.
+var structOnlyTokens = []string{
+	"eqField=", "neField=", "requiredWith=", "requiredIf=", "requiredUnless=",
+	"struct:", "schema=", "constantTime", "quota=", "readonly", "writeonly", "sensitive", "dive",
+}
+
+// pluginPackages maps the kinds of the built-in plugins, which validate.New
+// registers, to their packages. Generated files import the packages whose
+// kinds their tags use.
+var pluginPackages = func() map[types.Kind]string {
+	const base = "github.com/aatuh/validate/v3/validators/"
+	m := map[types.Kind]string{
+		creditcard.KCreditCard: base + "creditcard", email.KEmail: base + "email",
+		phone.KPhone: base + "phone", ulid.KULID: base + "ulid",
+		iso.KCountry: base + "iso", iso.KCurrency: base + "iso", iso.KLanguageTag: base + "iso",
+		jwt.KJWT: base + "jwt", netname.KDNSLabel: base + "netname",
+		netname.KHostname: base + "netname", netname.KFQDN: base + "netname",
+		url.KURL: base + "url", url.KHTTPURL: base + "url",
+	}
+	for _, k := range []types.Kind{uuid.KUUID, uuid.KUUIDv1, uuid.KUUIDv3, uuid.KUUIDv4, uuid.KUUIDv5, uuid.KUUIDv6, uuid.KUUIDv7, uuid.KUUIDv8} {
+		m[k] = base + "uuid"
+	}
+	for _, k := range []types.Kind{domain.KSlug, domain.KSemVer, domain.KJSON, domain.KBase64, domain.KBase64URL,
+		domain.KHex, domain.KMAC, domain.KE164, domain.KDate, domain.KRFC3339, domain.KLuhn} {
+		m[k] = base + "domain"
+	}
+	return m
+}()
+
+// basicTypes are predeclared types the reflective walk never recurses into.
+var basicTypes = map[string]bool{
+	"bool": true, "string": true, "error": true, "any": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+	"byte": true, "rune": true,
+}
+
+// Generate returns validators for the struct types declared in files,
+// which must belong to one package.
+//
+// Parameters:
+//   - files: Parsed files of the package, without previously generated
+//     output.
+//   - cfg: Type selection.
+//
+// Returns:
+//   - *Result: Generated source and per-type outcome.
+//   - error: If files is empty, a requested type is not a struct, or the
+//     output does not format.
+func Generate(files []*ast.File, cfg Config) (*Result, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("validategen: no files")
+	}
+	g := &generator{
+		pkg:      files[0].Name.Name,
+		structs:  map[string]*ast.StructType{},
+		named:    map[string]ast.Expr{},
+		validate: map[string]bool{},
+		plugins:  map[string]bool{},
+	}
+	var order []string
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || ts.TypeParams != nil {
+						continue
+					}
+					g.named[ts.Name.Name] = ts.Type
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						g.structs[ts.Name.Name] = st
+						order = append(order, ts.Name.Name)
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Recv != nil && (d.Name.Name == "Validate" || d.Name.Name == "ValidateGenerated") {
+					g.validate[receiverName(d.Recv.List[0].Type)] = true
+				}
+			}
+		}
+	}
+
+	names := order
+	if len(cfg.Types) > 0 {
+		names = cfg.Types
+		for _, name := range names {
+			if _, ok := g.structs[name]; !ok {
+				return nil, fmt.Errorf("validategen: %s is not a struct type in package %s", name, g.pkg)
+			}
+		}
+	}
+
+	res := &Result{Skipped: map[string]string{}}
+	var body bytes.Buffer
+	for _, name := range names {
+		st := g.structs[name]
+		if len(cfg.Types) == 0 && !hasValidateTag(st) {
+			continue
+		}
+		if g.validate[name] {
+			res.Skipped[name] = "type already has a Validate or ValidateGenerated method"
+			continue
+		}
+		code, err := g.structValidator(name, st)
+		if err != nil {
+			res.Skipped[name] = err.Error()
+			continue
+		}
+		body.WriteString(code)
+		res.Generated = append(res.Generated, name)
+	}
+	if len(res.Generated) == 0 {
+		return res, nil
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by validategen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg)
+	out.WriteString("import (\n\t\"context\"\n\n")
+	out.WriteString("\t\"github.com/aatuh/validate/v3/core\"\n")
+	out.WriteString("\t\"github.com/aatuh/validate/v3/structvalidator\"\n")
+	out.WriteString("\t\"github.com/aatuh/validate/v3/translator\"\n")
+	if len(g.plugins) > 0 {
+		plugins := make([]string, 0, len(g.plugins))
+		for path := range g.plugins {
+			plugins = append(plugins, path)
+		}
+		sort.Strings(plugins)
+		out.WriteString("\n")
+		for _, path := range plugins {
+			fmt.Fprintf(&out, "\t_ %q\n", path)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.WriteString("// validategenEngine is the engine generated Validate methods use,\n// configured like validate.New.\n")
+	out.WriteString("var validategenEngine = core.NewEngine(core.WithTranslator(translator.NewSimpleTranslator(translator.DefaultEnglishTranslations())))\n")
+	out.Write(body.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("validategen: format output: %w", err)
+	}
+	res.Source = src
+	return res, nil
+}
+
+type generator struct {
+	pkg      string
+	structs  map[string]*ast.StructType
+	named    map[string]ast.Expr
+	validate map[string]bool
+	plugins  map[string]bool // plugin packages used by generated tags
+}
+
+func (g *generator) structValidator(name string, st *ast.StructType) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n// ValidateGenerated validates s like ValidateStruct, without reflection.\n")
+	fmt.Fprintf(&b, "func (s %s) ValidateGenerated(ctx context.Context, e *core.Engine, opts core.ValidateOpts) error {\n", name)
+	b.WriteString("\tg := structvalidator.NewGeneratedRun(ctx, e, opts)\n")
+	for _, field := range st.Fields.List {
+		tag := ""
+		if field.Tag != nil {
+			raw, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return "", fmt.Errorf("field tag %s: %w", field.Tag.Value, err)
+			}
+			tag = reflect.StructTag(raw).Get("validate")
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(receiverName(field.Type))}
+		}
+		for _, ident := range names {
+			if !ast.IsExported(ident.Name) {
+				continue
+			}
+			code, err := g.field(ident.Name, field.Type, tag)
+			if err != nil {
+				return "", fmt.Errorf("field %s: %w", ident.Name, err)
+			}
+			if code == "" {
+				continue
+			}
+			b.WriteString(code)
+			b.WriteString("\tif g.Stop() {\n\t\treturn g.Err()\n\t}\n")
+		}
+	}
+	b.WriteString("\treturn g.Err()\n}\n")
+	fmt.Fprintf(&b, "\n// Validate validates s with its generated validator.\n")
+	fmt.Fprintf(&b, "func (s %s) Validate() error {\n\treturn s.ValidateGenerated(context.Background(), validategenEngine, core.ValidateOpts{})\n}\n", name)
+	return b.String(), nil
+}
+
+// field returns the code validating one exported field, or "" when the
+// reflective walk does nothing for it.
+func (g *generator) field(name string, typ ast.Expr, tag string) (string, error) {
+	if tag == "" {
+		return g.untaggedField(name, typ), nil
+	}
+	for _, token := range types.SplitTag(tag) {
+		token = strings.TrimSpace(token)
+		for _, structOnly := range structOnlyTokens {
+			if token == structOnly || (strings.HasSuffix(structOnly, "=") || strings.HasSuffix(structOnly, ":")) && strings.HasPrefix(token, structOnly) {
+				return "", fmt.Errorf("uses struct-level token %s", token)
+			}
+		}
+	}
+	g.usePlugins(tag)
+	if code, ok := staticRules(name, typ, tag); ok {
+		return code, nil
+	}
+	access := "s." + name
+	if star, ok := typ.(*ast.StarExpr); ok {
+		if _, nested := star.X.(*ast.StarExpr); nested {
+			return "", fmt.Errorf("multi-level pointers are not supported")
+		}
+		return fmt.Sprintf("\tif %s != nil {\n\t\tg.Rules(%q, *%s, %q)\n\t} else {\n\t\tg.Rules(%q, nil, %q)\n\t}\n",
+			access, name, access, tag, name, tag), nil
+	}
+	return fmt.Sprintf("\tg.Rules(%q, %s, %q)\n", name, access, tag), nil
+}
+
+// usePlugins records the plugin packages whose kinds appear in tag,
+// including inside foreach and map rules.
+func (g *generator) usePlugins(tag string) {
+	words := strings.FieldsFunc(tag, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	for _, word := range words {
+		if path, ok := pluginPackages[types.Kind(word)]; ok {
+			g.plugins[path] = true
+		}
+	}
+}
+
+// untaggedField mirrors the reflective walk for untagged fields: structs
+// are validated and struct elements of collections are visited.
+func (g *generator) untaggedField(name string, typ ast.Expr) string {
+	access := "s." + name
+	if star, ok := typ.(*ast.StarExpr); ok {
+		switch t := star.X.(type) {
+		case *ast.ArrayType:
+			typ = t.Elt
+		case *ast.MapType:
+			typ = t.Value
+		}
+		if !g.mayBeStruct(typ) {
+			return ""
+		}
+		return fmt.Sprintf("\tg.Nested(%q, %s)\n", name, access)
+	}
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		if !g.mayBeStruct(t.Elt) {
+			return ""
+		}
+		return fmt.Sprintf("\tfor i := range %s {\n\t\tg.Nested(g.Index(%q, i), %s[i])\n\t\tif g.Stop() {\n\t\t\tbreak\n\t\t}\n\t}\n", access, name, access)
+	case *ast.MapType:
+		if !g.mayBeStruct(t.Value) {
+			return ""
+		}
+		return fmt.Sprintf("\tfor _, k := range structvalidator.SortedKeys(%s) {\n\t\tg.Nested(g.Key(%q, k), %s[k])\n\t\tif g.Stop() {\n\t\t\tbreak\n\t\t}\n\t}\n", access, name, access)
+	}
+	if !g.mayBeStruct(typ) {
+		return ""
+	}
+	return fmt.Sprintf("\tg.Nested(%q, %s)\n", name, access)
+}
+
+// mayBeStruct reports whether values of typ, after dereferencing pointers,
+// may be structs. Types of other packages may be.
+func (g *generator) mayBeStruct(typ ast.Expr) bool {
+	seen := map[string]bool{}
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.ParenExpr:
+			typ = t.X
+		case *ast.StructType, *ast.SelectorExpr:
+			return true
+		case *ast.Ident:
+			if basicTypes[t.Name] || seen[t.Name] {
+				return false
+			}
+			seen[t.Name] = true
+			underlying, ok := g.named[t.Name]
+			if !ok {
+				return false
+			}
+			typ = underlying
+		default:
+			return false
+		}
+	}
+}
+
+// staticRules returns inlined checks for string and signed integer fields
+// whose rules are all built-in comparisons.
+func staticRules(name string, typ ast.Expr, tag string) (string, bool) {
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	rules, err := types.ParseTag(tag)
+	if err != nil || len(rules) == 0 {
+		return "", false
+	}
+	var zero string
+	switch {
+	case ident.Name == "string" && rules[0].Kind == types.KString:
+		zero = `""`
+	case rules[0].Kind == types.KInt && (ident.Name == "int" || ident.Name == "int8" || ident.Name == "int16" || ident.Name == "int32" || ident.Name == "int64"),
+		rules[0].Kind == types.KInt64 && ident.Name == "int64":
+		zero = "0"
+	default:
+		return "", false
+	}
+
+	var cases []string
+	omitEmpty, required := false, false
+	for _, r := range rules {
+		if r.Msg != "" {
+			// Message overrides are reported by the compiled rules.
+			return "", false
+		}
+	}
+	for _, r := range rules[1:] {
+		switch r.Kind {
+		case types.KOmitempty:
+			omitEmpty = true
+			continue
+		case types.KRequired:
+			required = true
+			continue
+		}
+		c, ok := staticCase(name, r, zero == "0")
+		if !ok {
+			return "", false
+		}
+		cases = append(cases, c)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\tswitch v := s.%s; {\n", name)
+	switch {
+	case omitEmpty:
+		fmt.Fprintf(&b, "\tcase v == %s:\n", zero)
+	case required:
+		fmt.Fprintf(&b, "\tcase v == %s:\n\t\tg.Fail(%q, %q, %q, nil)\n", zero, name, "required", "value is required")
+	}
+	for _, c := range cases {
+		b.WriteString(c)
+	}
+	b.WriteString("\t}\n")
+	if len(cases) == 0 && !required {
+		return "", true
+	}
+	return b.String(), true
+}
+
+// staticCase returns the switch case of one built-in rule, failing with
+// the code, English message and translation arguments of the compiler.
+func staticCase(name string, r types.Rule, integer bool) (string, bool) {
+	fail := func(cond, code, msg, param string, args ...string) string {
+		call := fmt.Sprintf("g.Fail(%q, %q, %q, %s", name, code, msg, param)
+		for _, a := range args {
+			call += ", " + a
+		}
+		return fmt.Sprintf("\tcase %s:\n\t\t%s)\n", cond, call)
+	}
+	if integer {
+		n, ok := r.IntArg("n")
+		if !ok {
+			return "", false
+		}
+		lit := fmt.Sprintf("int64(%d)", n)
+		switch r.Kind {
+		case types.KMinInt:
+			return fail(fmt.Sprintf("int64(v) < %d", n), "int.min", fmt.Sprintf("minimum value is %d", n), lit, lit), true
+		case types.KMaxInt:
+			return fail(fmt.Sprintf("int64(v) > %d", n), "int.max", fmt.Sprintf("maximum value is %d", n), lit, lit), true
+		}
+		return "", false
+	}
+	n64, hasN := r.IntArg("n")
+	n := int(n64)
+	value, _ := r.Args["value"].(string)
+	switch r.Kind {
+	case types.KLength, types.KMinLength, types.KMaxLength:
+		if !hasN {
+			return "", false
+		}
+	}
+	switch r.Kind {
+	case types.KLength:
+		return fail(fmt.Sprintf("len(v) != %d", n), "string.length", fmt.Sprintf("length must be %d", n), strconv.Itoa(n), strconv.Itoa(n)), true
+	case types.KMinLength:
+		return fail(fmt.Sprintf("len(v) < %d", n), "string.min", fmt.Sprintf("minimum length is %d", n), strconv.Itoa(n), strconv.Itoa(n)), true
+	case types.KMaxLength:
+		return fail(fmt.Sprintf("len(v) > %d", n), "string.max", fmt.Sprintf("maximum length is %d", n), strconv.Itoa(n), strconv.Itoa(n)), true
+	case types.KNonEmpty:
+		return fail(`v == ""`, "string.nonempty", "must not be empty", "nil"), true
+	case types.KOneOf:
+		values, _ := r.Args["values"].([]string)
+		conds := make([]string, len(values))
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = strconv.Quote(v)
+			conds[i] = "v != " + quoted[i]
+		}
+		cond := "true"
+		if len(conds) > 0 {
+			cond = strings.Join(conds, " && ")
+		}
+		joined := strings.Join(values, ", ")
+		return fail(cond, "string.oneof", "must be one of: "+joined, "[]string{"+strings.Join(quoted, ", ")+"}", strconv.Quote(joined)), true
+	case types.KPrefix:
+		return fail(fmt.Sprintf("len(v) < %d || v[:%d] != %q", len(value), len(value), value), "string.prefix", "must have required prefix", strconv.Quote(value)), true
+	case types.KSuffix:
+		return fail(fmt.Sprintf("len(v) < %d || v[len(v)-%d:] != %q", len(value), len(value), value), "string.suffix", "must have required suffix", strconv.Quote(value)), true
+	}
+	return "", false
+}
+
+func hasValidateTag(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		raw, err := strconv.Unquote(field.Tag.Value)
+		if err == nil && reflect.StructTag(raw).Get("validate") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// receiverName returns the type name of a receiver or embedded field type.
+func receiverName(typ ast.Expr) string {
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.ParenExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.SelectorExpr:
+			return t.Sel.Name
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package validategen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `package p
+
+type Address struct {
+	City string ` + "`validate:\"string;required;max=40\"`" + `
+	Zip  string ` + "`validate:\"string;regex=^[0-9]{5}$\"`" + `
+}
+
+type Order struct {
+	ID     string             ` + "`validate:\"string;required;min=8;prefix=ORD\"`" + `
+	Qty    int                ` + "`validate:\"int;min=1;max=99\"`" + `
+	Email  *string            ` + "`validate:\"string;omitempty;email\"`" + `
+	Home   Address
+	Others []Address
+	ByName map[string]*Address
+}
+
+type Match struct {
+	A string ` + "`validate:\"string;eqField=B\"`" + `
+	B string
+}
+
+type Untagged struct {
+	Name string
+}
+`
+
+func parseSample(t *testing.T, src string) []*ast.File {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return []*ast.File{f}
+}
+
+func TestGenerate_SelectsTaggedStructs(t *testing.T) {
+	res, err := Generate(parseSample(t, sample), Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"Address", "Order"}; !reflect.DeepEqual(res.Generated, want) {
+		t.Fatalf("Generated = %v, want %v", res.Generated, want)
+	}
+	if reason := res.Skipped["Match"]; !strings.Contains(reason, "eqField") {
+		t.Fatalf("Skipped[Match] = %q", reason)
+	}
+	if _, ok := res.Skipped["Untagged"]; ok {
+		t.Fatalf("untagged struct reported as skipped")
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", res.Source, 0); err != nil {
+		t.Fatalf("generated source does not parse: %v\n%s", err, res.Source)
+	}
+}
+
+func TestGenerate_InlinesBuiltinRules(t *testing.T) {
+	res, err := Generate(parseSample(t, sample), Config{Types: []string{"Order"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src := string(res.Source)
+	for _, want := range []string{
+		"// Code generated by validategen. DO NOT EDIT.",
+		"func (s Order) ValidateGenerated(",
+		`g.Fail("ID", "string.min", "minimum length is 8", 8, 8)`,
+		`g.Fail("Qty", "int.max", "maximum value is 99", int64(99), int64(99))`,
+		`g.Rules("Email", *s.Email, "string;omitempty;email")`,
+		`g.Nested("Home", s.Home)`,
+		`g.Nested(g.Index("Others", i), s.Others[i])`,
+		"structvalidator.SortedKeys(s.ByName)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("missing %q in:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "func (s Address)") {
+		t.Fatalf("unrequested type generated:\n%s", src)
+	}
+}
+
+func TestGenerate_ImportsUsedPluginsOnly(t *testing.T) {
+	res, err := Generate(parseSample(t, sample), Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	src := string(res.Source)
+	if !strings.Contains(src, `_ "github.com/aatuh/validate/v3/validators/email"`) {
+		t.Fatalf("email plugin not imported:\n%s", src)
+	}
+	for _, unwanted := range []string{`validate "github.com/aatuh/validate/v3"`, "validators/uuid", "validators/domain"} {
+		if strings.Contains(src, unwanted) {
+			t.Fatalf("unexpected import %q in:\n%s", unwanted, src)
+		}
+	}
+}
+
+func TestGenerate_SkipsTypesWithValidateMethods(t *testing.T) {
+	src := sample + `
+func (o Order) Validate() error { return nil }
+`
+	res, err := Generate(parseSample(t, src), Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := res.Skipped["Order"]; !ok {
+		t.Fatalf("Order not skipped: %v", res.Generated)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	if _, err := Generate(nil, Config{}); err == nil {
+		t.Fatalf("expected error for no files")
+	}
+	if _, err := Generate(parseSample(t, sample), Config{Types: []string{"Missing"}}); err == nil {
+		t.Fatalf("expected error for unknown type")
+	}
+}
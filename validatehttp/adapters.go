@@ -0,0 +1,61 @@
+package validatehttp
+
+import "net/http"
+
+// The adapters below bind request bodies in echo and gin handlers. They
+// depend on small interfaces that the frameworks' contexts satisfy, so the
+// module does not import the frameworks. chi needs no adapter: it uses
+// net/http handlers and middleware, such as Validated and Guard.Middleware.
+
+// EchoContext is the part of echo.Context used by BindEcho.
+type EchoContext interface {
+	Request() *http.Request
+	Blob(code int, contentType string, b []byte) error
+}
+
+// BindEcho decodes and validates the request body of c into dst with g.
+// On failure it writes the Response to c and returns false with the write
+// error, which the handler returns:
+//
+//	func signup(c echo.Context) error {
+//		var req Signup
+//		if ok, err := validatehttp.BindEcho(c, guard, &req); !ok {
+//			return err
+//		}
+//		...
+//	}
+func BindEcho(c EchoContext, g Guard, dst any) (bool, error) {
+	r := c.Request()
+	if err := g.DecodeJSON(nil, r, dst); err != nil {
+		status, contentType, body := Response(err)
+		return false, c.Blob(status, contentType, body)
+	}
+	return true, nil
+}
+
+// GinContext is the part of *gin.Context used by BindGin.
+type GinContext interface {
+	Data(code int, contentType string, data []byte)
+	Abort()
+}
+
+// BindGin decodes and validates the body of r, the request of c, into dst
+// with g. On failure it writes the Response to c, aborts the handler chain
+// and returns false:
+//
+//	func signup(c *gin.Context) {
+//		var req Signup
+//		if !validatehttp.BindGin(c, c.Request, guard, &req) {
+//			return
+//		}
+//		...
+//	}
+func BindGin(c GinContext, r *http.Request, g Guard, dst any) bool {
+	if err := g.DecodeJSON(nil, r, dst); err != nil {
+		status, contentType, body := Response(err)
+		c.Data(status, contentType, body)
+		c.Abort()
+		return false
+	}
+	return true
+}
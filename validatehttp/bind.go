@@ -0,0 +1,61 @@
+package validatehttp
+
+import (
+	"context"
+	"net/http"
+)
+
+// DecodeJSON decodes and validates the JSON body of r into dst with the
+// zero Guard: default limits and validate.New(). See Guard.DecodeJSON.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	return Guard{}.DecodeJSON(w, r, dst)
+}
+
+// Bind decodes and validates the JSON body of r into dst with the zero
+// Guard. See Guard.Bind.
+func Bind(w http.ResponseWriter, r *http.Request, dst any) bool {
+	return Guard{}.Bind(w, r, dst)
+}
+
+// Bind decodes and validates the JSON body of r into dst, a pointer to
+// struct. On failure it answers the request with WriteError and returns
+// false, so handlers only need to return:
+//
+//	var req Signup
+//	if !guard.Bind(w, r, &req) {
+//		return
+//	}
+func (g Guard) Bind(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := g.DecodeJSON(w, r, dst); err != nil {
+		WriteError(w, err)
+		return false
+	}
+	return true
+}
+
+type bodyKey[T any] struct{}
+
+// Validated returns net/http middleware that decodes and validates each
+// request body into a new T with g, answers failures with WriteError, and
+// otherwise passes the value to next in the request context; read it with
+// Body. The middleware has the standard func(http.Handler) http.Handler
+// shape, so chi routers take it directly:
+//
+//	r.With(validatehttp.Validated[Signup](guard)).Post("/signup", signup)
+func Validated[T any](g Guard) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return Handle(g, func(w http.ResponseWriter, r *http.Request, v *T) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), bodyKey[T]{}, v)))
+		})
+	}
+}
+
+// Body returns the request body validated by Validated[T].
+//
+// Returns:
+//   - *T: The decoded body.
+//   - bool: False when the request did not pass through Validated[T].
+func Body[T any](r *http.Request) (*T, bool) {
+	v, ok := r.Context().Value(bodyKey[T]{}).(*T)
+	return v, ok
+}
@@ -0,0 +1,111 @@
+package validatehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+func post(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+}
+
+func TestBind(t *testing.T) {
+	var m guardMessage
+	rec := httptest.NewRecorder()
+	if !Bind(rec, post(`{"subject":"hi"}`), &m) || m.Subject != "hi" {
+		t.Fatalf("bind failed: %s", rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	if Bind(rec, post(`{"subject":""}`), &m) {
+		t.Fatal("invalid body bound")
+	}
+	var p verrs.ProblemDetails
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusBadRequest || p.Status != 400 || len(p.Errors) != 1 || p.Errors[0].Path != "subject" || p.Errors[0].Code != verrs.CodeRequired {
+		t.Fatalf("status %d, problem %+v", rec.Code, p)
+	}
+	if err := DecodeJSON(nil, post(`{"subject":"hi"}`), &m); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidated(t *testing.T) {
+	var got *guardMessage
+	h := Validated[guardMessage](Guard{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = Body[guardMessage](r)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, post(`{"subject":"hi"}`))
+	if rec.Code != http.StatusOK || got == nil || got.Subject != "hi" {
+		t.Fatalf("status %d, body %+v", rec.Code, got)
+	}
+	got = nil
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, post(`{"subject":"`+strings.Repeat("x", 21)+`"}`))
+	if rec.Code != http.StatusBadRequest || got != nil {
+		t.Fatalf("status %d, handler called: %v", rec.Code, got != nil)
+	}
+	if _, ok := Body[guardMessage](post("")); ok {
+		t.Fatal("Body found without Validated")
+	}
+}
+
+func TestResponse(t *testing.T) {
+	status, contentType, body := Response(verrs.Errors{{Code: verrs.CodeBodySize, Param: int64(10)}})
+	if status != http.StatusRequestEntityTooLarge || contentType != verrs.ProblemContentType || !strings.Contains(string(body), `"code":"body.size"`) {
+		t.Fatalf("%d %s %s", status, contentType, body)
+	}
+	if status, contentType, _ := Response(http.ErrBodyNotAllowed); status != http.StatusInternalServerError || !strings.HasPrefix(contentType, "text/plain") {
+		t.Fatalf("%d %s", status, contentType)
+	}
+}
+
+type fakeEcho struct {
+	req         *http.Request
+	status      int
+	contentType string
+}
+
+func (c *fakeEcho) Request() *http.Request { return c.req }
+
+func (c *fakeEcho) Blob(code int, contentType string, _ []byte) error {
+	c.status, c.contentType = code, contentType
+	return nil
+}
+
+type fakeGin struct {
+	status  int
+	aborted bool
+}
+
+func (c *fakeGin) Data(code int, _ string, _ []byte) { c.status = code }
+func (c *fakeGin) Abort()                            { c.aborted = true }
+
+func TestAdapters(t *testing.T) {
+	var m guardMessage
+	echo := &fakeEcho{req: post(`{"subject":"hi"}`)}
+	if ok, err := BindEcho(echo, Guard{}, &m); !ok || err != nil || echo.status != 0 {
+		t.Fatalf("echo bind = %v, %v, status %d", ok, err, echo.status)
+	}
+	echo = &fakeEcho{req: post(`{"subject":""}`)}
+	if ok, _ := BindEcho(echo, Guard{}, &m); ok || echo.status != http.StatusBadRequest || echo.contentType != verrs.ProblemContentType {
+		t.Fatalf("echo bind = %v, status %d", ok, echo.status)
+	}
+
+	gin := &fakeGin{}
+	if !BindGin(gin, post(`{"subject":"hi"}`), Guard{}, &m) || gin.aborted {
+		t.Fatal("gin bind failed")
+	}
+	if BindGin(gin, post(`{"subject":`), Guard{}, &m) || !gin.aborted || gin.status != http.StatusBadRequest {
+		t.Fatalf("gin bind status %d, aborted %v", gin.status, gin.aborted)
+	}
+}
@@ -0,0 +1,7 @@
+module github.com/aatuh/validate/v3/validatehttp
+
+go 1.23
+
+require github.com/aatuh/validate/v3 v3.0.7
+
+replace github.com/aatuh/validate/v3 => ../
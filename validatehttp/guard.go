@@ -0,0 +1,327 @@
+// Package validatehttp hardens and validates JSON request bodies.
+//
+// A Guard bounds the body size with http.MaxBytesReader, rejects JSON
+// nested too deeply or holding too many values before decoding it, and
+// validates the decoded struct, engine quotas included. Every violation is
+// reported as errors.Errors, so clients get the same structured codes for
+// hostile input as for invalid fields.
+//
+// Bind, Validated and Handle bind a validated body in net/http and chi
+// handlers and answer failures with an RFC 7807 problem document; BindEcho
+// and BindGin do the same for echo and gin without importing them.
+//
+// The package is a separate module, so the validate module itself carries
+// no HTTP binding, middleware or framework adapters.
+package validatehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	validate "github.com/aatuh/validate/v3"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+const (
+	// DefaultMaxBytes is the body size limit of a Guard without MaxBytes.
+	DefaultMaxBytes int64 = 1 << 20
+	// DefaultMaxDepth is the JSON nesting limit of a Guard without MaxDepth.
+	DefaultMaxDepth = 32
+)
+
+// Guard configures input hardening for JSON request bodies. The zero value
+// is ready to use and applies the default size and depth limits.
+//
+// Fields:
+//   - Validate: Validates decoded bodies; its quotas (see WithQuota) bound
+//     aggregate totals. Nil uses validate.New().
+//   - MaxBytes: Maximum body size in bytes; 0 uses DefaultMaxBytes and a
+//     negative value disables the limit.
+//   - MaxDepth: Maximum nesting of JSON objects and arrays; 0 uses
+//     DefaultMaxDepth and a negative value disables the limit.
+//   - MaxValues: Maximum number of JSON values in the body, counting every
+//     object member and array element; 0 disables the limit.
+//   - Opts: Options for struct validation. Error paths use JSON field names
+//     unless Opts selects other names.
+type Guard struct {
+	Validate  *validate.Validate
+	MaxBytes  int64
+	MaxDepth  int
+	MaxValues int
+	Opts      validate.ValidateOpts
+}
+
+// DecodeJSON reads the body of r under g's limits, decodes it into dst, a
+// pointer to struct, and validates the result.
+//
+// Parameters:
+//   - w: The response writer, used by http.MaxBytesReader to close the
+//     connection after an oversized body; may be nil.
+//   - r: The request whose body is read.
+//   - dst: The non-nil pointer to decode into.
+//
+// Returns:
+//   - error: nil, errors.Errors for limit, syntax and validation failures,
+//     the context error, or the error reading the body.
+func (g Guard) DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	if rv := reflect.ValueOf(dst); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("DecodeJSON: expected non-nil pointer, got %T", dst)
+	}
+	body, err := g.readBody(w, r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, dst); err != nil {
+		return g.decodeErrors(err)
+	}
+	opts := g.Opts
+	if opts.FieldNameFunc == nil && !opts.UseXMLNames {
+		opts.UseJSONNames = true
+	}
+	return g.validator().ValidateStructContextWithOpts(r.Context(), dst, opts)
+}
+
+// Middleware returns middleware that enforces the size, depth and value
+// limits on every request body before calling next, which reads the
+// checked body as usual. Violations are answered with WriteError. Requests
+// without a body pass through.
+func (g Guard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := g.readBody(w, r)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Handle returns a handler that decodes each request body into a new T
+// with g.DecodeJSON and calls fn with it. Violations are answered with
+// WriteError and fn is not called.
+func Handle[T any](g Guard, fn func(w http.ResponseWriter, r *http.Request, v *T)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := new(T)
+		if err := g.DecodeJSON(w, r, v); err != nil {
+			WriteError(w, err)
+			return
+		}
+		fn(w, r, v)
+	})
+}
+
+// WriteError writes err as an RFC 7807 problem+json response, with the
+// field errors in its "errors" member; see errors.Errors.ToProblemDetails.
+// errors.Errors get status 413 when the body was too large and 400
+// otherwise; other errors get a plain 500 without details.
+func WriteError(w http.ResponseWriter, err error) {
+	status, contentType, body := Response(err)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// WriteProblem writes p as a problem+json response with p's status, or 400
+// when Status is zero.
+func WriteProblem(w http.ResponseWriter, p verrs.ProblemDetails) {
+	if p.Status == 0 {
+		p.Status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", verrs.ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// Response renders err as WriteError does, for frameworks that write
+// responses through their own context, such as gin or echo.
+//
+// Returns:
+//   - status: 413 for oversized bodies, 400 for other errors.Errors and
+//     500 otherwise.
+//   - contentType: errors.ProblemContentType, or text/plain for 500.
+//   - body: The encoded response body.
+func Response(err error) (status int, contentType string, body []byte) {
+	var es verrs.Errors
+	if !stderrors.As(err, &es) {
+		status = http.StatusInternalServerError
+		return status, "text/plain; charset=utf-8", []byte(http.StatusText(status) + "\n")
+	}
+	status = http.StatusBadRequest
+	if len(es) > 0 && es[0].Code == verrs.CodeBodySize {
+		status = http.StatusRequestEntityTooLarge
+	}
+	body, _ = json.Marshal(es.ToProblemDetails(status))
+	return status, verrs.ProblemContentType, append(body, '\n')
+}
+
+// readBody reads the body of r and checks it against the size, depth and
+// value limits.
+func (g Guard) readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, g.fail("", verrs.CodeBodySyntax, nil)
+	}
+	var body []byte
+	var err error
+	if max := g.maxBytes(); max > 0 {
+		body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, max))
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			return nil, g.fail("", verrs.CodeBodySize, max)
+		}
+	} else {
+		body, err = io.ReadAll(r.Body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkJSON(body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// JSON container states on the checkJSON stack.
+const (
+	inArray = iota
+	inObjectKey
+	inObjectValue
+)
+
+// checkJSON scans body token by token, without building values, and
+// reports the first limit it breaks. Syntax errors are left to decoding
+// unless they stop the scan.
+func (g Guard) checkJSON(body []byte) error {
+	maxDepth := g.maxDepth()
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var stack []int
+	values := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return g.fail("", verrs.CodeBodySyntax, nil)
+		}
+		top := len(stack) - 1
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:top]
+			continue
+		}
+		if top >= 0 && stack[top] == inObjectKey {
+			stack[top] = inObjectValue
+			continue
+		}
+		if top >= 0 && stack[top] == inObjectValue {
+			stack[top] = inObjectKey
+		}
+		values++
+		if g.MaxValues > 0 && values > g.MaxValues {
+			return g.fail("", verrs.CodeBodyValues, g.MaxValues)
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, inObjectKey)
+		case json.Delim('['):
+			stack = append(stack, inArray)
+		default:
+			continue
+		}
+		if maxDepth > 0 && len(stack) > maxDepth {
+			return g.fail("", verrs.CodeBodyDepth, maxDepth)
+		}
+	}
+}
+
+// decodeErrors reports a json.Unmarshal error as errors.Errors, at the
+// field's JSON path when the value has the wrong type.
+func (g Guard) decodeErrors(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &typeErr) {
+		return g.fail(fieldPath(typeErr.Field), verrs.CodeBodyType, typeErr.Type.String())
+	}
+	return g.fail("", verrs.CodeBodySyntax, nil)
+}
+
+// fieldPath rewrites the dotted field of a json.UnmarshalTypeError, such
+// as "items.0.sku", in the index style of validation paths: "items[0].sku".
+func fieldPath(field string) string {
+	var b strings.Builder
+	for i, seg := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(seg); err == nil && i > 0 {
+			b.WriteString("[" + seg + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg)
+	}
+	return b.String()
+}
+
+// fail returns a single-error errors.Errors with a message from the
+// translator of g.Opts, falling back to English.
+func (g Guard) fail(path, code string, param any) verrs.Errors {
+	var args []any
+	if param != nil {
+		args = append(args, param)
+	}
+	msg := ""
+	if tr := g.Opts.Translator; tr != nil {
+		msg = tr.T(code, args...)
+	}
+	if msg == "" {
+		msg = fallbackMessages[code]
+		if len(args) > 0 {
+			msg = fmt.Sprintf(msg, args...)
+		}
+	}
+	return verrs.Errors{{Path: path, Code: code, Param: param, Msg: msg}}
+}
+
+var fallbackMessages = map[string]string{
+	verrs.CodeBodySize:   "request body exceeds %d bytes",
+	verrs.CodeBodyDepth:  "JSON nesting exceeds depth %d",
+	verrs.CodeBodyValues: "JSON body exceeds %d values",
+	verrs.CodeBodySyntax: "malformed JSON body",
+	verrs.CodeBodyType:   "expected %s value",
+}
+
+// defaultValidate backs Guards without a Validate, sharing one cache.
+var defaultValidate = sync.OnceValue(validate.New)
+
+func (g Guard) validator() *validate.Validate {
+	if g.Validate != nil {
+		return g.Validate
+	}
+	return defaultValidate()
+}
+
+func (g Guard) maxBytes() int64 {
+	if g.MaxBytes == 0 {
+		return DefaultMaxBytes
+	}
+	return g.MaxBytes
+}
+
+func (g Guard) maxDepth() int {
+	if g.MaxDepth == 0 {
+		return DefaultMaxDepth
+	}
+	return g.MaxDepth
+}
@@ -0,0 +1,165 @@
+package validatehttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	validate "github.com/aatuh/validate/v3"
+	verrs "github.com/aatuh/validate/v3/errors"
+)
+
+type guardMessage struct {
+	Subject     string   `json:"subject" validate:"string;required;max=20"`
+	Attachments []string `json:"attachments" validate:"slice;quota=attachments"`
+}
+
+type guardThread struct {
+	Messages []guardMessage `json:"messages" validate:"slice;dive"`
+}
+
+func decode(g Guard, body string) error {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	var dst guardThread
+	return g.DecodeJSON(httptest.NewRecorder(), r, &dst)
+}
+
+func codesOf(t *testing.T, err error) []string {
+	t.Helper()
+	es, ok := err.(verrs.Errors)
+	if !ok {
+		t.Fatalf("expected errors.Errors, got %T %v", err, err)
+	}
+	var out []string
+	for _, fe := range es {
+		out = append(out, fe.Path+" "+fe.Code)
+	}
+	return out
+}
+
+func TestGuard_DecodeJSON(t *testing.T) {
+	g := Guard{
+		Validate:  validate.New().WithQuota("attachments", 2),
+		MaxBytes:  200,
+		MaxDepth:  4,
+		MaxValues: 12,
+	}
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"valid", `{"messages":[{"subject":"hi","attachments":["a"]}]}`, ""},
+		{"size", `{"messages":[{"subject":"` + strings.Repeat("x", 200) + `"}]}`, " body.size"},
+		{"depth", `{"messages":[{"subject":"hi","attachments":[["a"]]}]}`, " body.depth"},
+		{"values", `{"messages":[{},{},{},{},{},{},{},{},{},{},{},{}]}`, " body.values"},
+		{"syntax", `{"messages":`, " body.syntax"},
+		{"trailing", `{}{}`, " body.syntax"},
+		{"type", `{"messages":[{"subject":1}]}`, "messages[0].subject body.type"},
+		{"field", `{"messages":[{"subject":""}]}`, "messages[0].subject required"},
+		{"quota", `{"messages":[{"subject":"a","attachments":["a","b"]},{"subject":"b","attachments":["c"]}]}`, " quota.exceeded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := decode(g, tt.body)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if got := codesOf(t, err); len(got) != 1 || got[0] != tt.want {
+				t.Fatalf("errors = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+
+	if err := (Guard{}).DecodeJSON(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}")), guardThread{}); err == nil {
+		t.Fatal("expected error for non-pointer destination")
+	}
+}
+
+func TestGuard_DefaultsAndDisabledLimits(t *testing.T) {
+	deep := strings.Repeat("[", DefaultMaxDepth+1) + strings.Repeat("]", DefaultMaxDepth+1)
+	if got := codesOf(t, (Guard{}).checkJSON([]byte(deep))); got[0] != " body.depth" {
+		t.Fatalf("errors = %v", got)
+	}
+	if err := (Guard{MaxDepth: -1}).checkJSON([]byte(deep)); err != nil {
+		t.Fatalf("disabled depth limit: %v", err)
+	}
+	big := `{"messages":[{"subject":"` + strings.Repeat("x", int(DefaultMaxBytes)) + `"}]}`
+	if got := codesOf(t, decode(Guard{}, big)); got[0] != " body.size" {
+		t.Fatalf("errors = %v", got)
+	}
+	if got := codesOf(t, decode(Guard{MaxBytes: -1}, big)); got[0] != "messages[0].subject string.max" {
+		t.Fatalf("errors = %v", got)
+	}
+}
+
+func TestGuard_MiddlewareAndHandle(t *testing.T) {
+	g := Guard{MaxBytes: 64, MaxDepth: 2}
+	var seen string
+	h := g.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		seen = string(b)
+	}))
+
+	serve := func(h http.Handler, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		return rec
+	}
+
+	if rec := serve(h, `{"a":[1]}`); rec.Code != http.StatusOK || seen != `{"a":[1]}` {
+		t.Fatalf("status %d, next read %q", rec.Code, seen)
+	}
+	rec := serve(h, `{"a":[[1]]}`)
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != verrs.ProblemContentType {
+		t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var resp struct {
+		Errors []verrs.FieldError `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Code != verrs.CodeBodyDepth || resp.Errors[0].Msg != "JSON nesting exceeds depth 2" {
+		t.Fatalf("response = %s", rec.Body)
+	}
+	if rec := serve(h, strings.Repeat(" ", 65)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized body status %d", rec.Code)
+	}
+
+	var got *guardMessage
+	handle := Handle(g, func(w http.ResponseWriter, r *http.Request, m *guardMessage) { got = m })
+	if rec := serve(handle, `{"subject":"hi"}`); rec.Code != http.StatusOK || got == nil || got.Subject != "hi" {
+		t.Fatalf("status %d, decoded %+v", rec.Code, got)
+	}
+	got = nil
+	if rec := serve(handle, `{"subject":""}`); rec.Code != http.StatusBadRequest || got != nil {
+		t.Fatalf("status %d, handler called: %v", rec.Code, got != nil)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	p := verrs.Errors{{Path: "email", Code: "string.email.invalid", Msg: "invalid email"}}.ToProblemDetails(http.StatusUnprocessableEntity)
+	p.Instance = "/signup"
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, p)
+	if rec.Code != http.StatusUnprocessableEntity || rec.Header().Get("Content-Type") != verrs.ProblemContentType {
+		t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	want := `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"1 validation error","instance":"/signup","errors":[{"path":"email","code":"string.email.invalid","message":"invalid email"}]}` + "\n"
+	if rec.Body.String() != want {
+		t.Fatalf("body = %s\nwant  %s", rec.Body, want)
+	}
+
+	rec = httptest.NewRecorder()
+	WriteProblem(rec, verrs.ProblemDetails{})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("zero status wrote %d", rec.Code)
+	}
+}
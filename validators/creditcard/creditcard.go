@@ -0,0 +1,223 @@
+package creditcard
+
+import (
+	"fmt"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Card-specific error codes.
+const (
+	CodeCardInvalid  = "string.creditcard.invalid"
+	CodeCardLength   = "string.creditcard.length"
+	CodeCardChecksum = "string.creditcard.checksum"
+	CodeCardBrand    = "string.creditcard.brand"
+)
+
+// DefaultCardTranslations returns default English translations for card validation errors.
+func DefaultCardTranslations() map[string]string {
+	return map[string]string{
+		"string.creditcard.invalid":  "must be a card number of digits",
+		"string.creditcard.length":   "card number has an invalid length",
+		"string.creditcard.checksum": "card number has an invalid checksum",
+		"string.creditcard.brand":    "card brand is not accepted",
+	}
+}
+
+// KCreditCard is the rule kind registered by the package.
+const KCreditCard types.Kind = "creditcard"
+
+// Card brands reported by Brand.
+const (
+	Visa       = "visa"
+	Mastercard = "mastercard"
+	Amex       = "amex"
+	Discover   = "discover"
+	JCB        = "jcb"
+	DinersClub = "dinersclub"
+	UnionPay   = "unionpay"
+	Maestro    = "maestro"
+)
+
+// brand describes the issuer number ranges and lengths of a card brand.
+// Ranges compare the leading digits of a number with inclusive bounds of
+// the same length.
+type brand struct {
+	name    string
+	ranges  [][2]string
+	lengths []int
+}
+
+// brands is ordered so that narrower ranges win over broader ones, e.g.
+// Discover's 622126-622925 over UnionPay's 62.
+var brands = []brand{
+	{Visa, [][2]string{{"4", "4"}}, []int{13, 16, 19}},
+	{Amex, [][2]string{{"34", "34"}, {"37", "37"}}, []int{15}},
+	{Mastercard, [][2]string{{"51", "55"}, {"2221", "2720"}}, []int{16}},
+	{Discover, [][2]string{{"6011", "6011"}, {"644", "649"}, {"65", "65"}, {"622126", "622925"}}, []int{16, 17, 18, 19}},
+	{JCB, [][2]string{{"3528", "3589"}}, []int{16, 17, 18, 19}},
+	{DinersClub, [][2]string{{"300", "305"}, {"36", "36"}, {"38", "39"}}, []int{14, 15, 16, 17, 18, 19}},
+	{UnionPay, [][2]string{{"62", "62"}}, []int{16, 17, 18, 19}},
+	{Maestro, [][2]string{{"50", "50"}, {"56", "69"}}, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+}
+
+func init() {
+	types.RegisterRule(KCreditCard, compileCreditCard)
+	types.RegisterKindDoc(types.KindDoc{
+		Kind:     KCreditCard,
+		Summary:  "Payment card number with Luhn checksum, optionally of given brands",
+		Params:   []types.ParamDoc{{Name: "value", Type: "string", Description: "Comma-separated accepted brands, e.g. visa,mastercard"}},
+		Examples: []string{"string;creditcard", "string;creditcard=visa,mastercard"},
+		Sample:   "4111111111111111",
+	})
+	translator.RegisterDefaultEnglishTranslations(DefaultCardTranslations())
+}
+
+// Brand returns the brand of a card number, such as "visa", or "" when the
+// number matches no known brand. Spaces and hyphens are ignored; the length
+// and checksum are not checked.
+func Brand(number string) string {
+	digits, ok := cardDigits(number)
+	if !ok {
+		return ""
+	}
+	if b := detect(digits); b != nil {
+		return b.name
+	}
+	return ""
+}
+
+func detect(digits string) *brand {
+	for i := range brands {
+		for _, r := range brands[i].ranges {
+			if n := len(r[0]); len(digits) >= n && digits[:n] >= r[0] && digits[:n] <= r[1] {
+				return &brands[i]
+			}
+		}
+	}
+	return nil
+}
+
+// cardDigits removes space and hyphen separators from s and reports
+// whether only digits remain.
+func cardDigits(s string) (string, bool) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(s) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+		default:
+			return "", false
+		}
+	}
+	return b.String(), b.Len() > 0
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func compileCreditCard(c *types.Compiler, r types.Rule) (func(any) error, error) {
+	allowed, err := parseBrands(r)
+	if err != nil {
+		return nil, err
+	}
+	return func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			msg := c.T("string.type", "expected string", nil)
+			return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+		}
+		if fe := validateCard(c, s, allowed); fe.Code != "" {
+			return verrs.Errors{fe}
+		}
+		return nil
+	}, nil
+}
+
+// parseBrands returns the brands listed in the rule value, or nil when any
+// brand is accepted.
+func parseBrands(r types.Rule) ([]string, error) {
+	value, _ := r.Args["value"].(string)
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var out []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, b := range brands {
+			known = known || b.name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("creditcard: unknown brand %q; known brands are %s", name, brandNames())
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
+
+// validateCard checks s and the brands in allowed, if any.
+func validateCard(c *types.Compiler, s string, allowed []string) verrs.FieldError {
+	digits, ok := cardDigits(s)
+	if !ok {
+		return cardError(c, CodeCardInvalid, "must be a card number of digits", nil)
+	}
+	b := detect(digits)
+	if len(digits) < 12 || len(digits) > 19 || (b != nil && !containsInt(b.lengths, len(digits))) {
+		return cardError(c, CodeCardLength, "card number has an invalid length", len(digits))
+	}
+	if !luhnValid(digits) {
+		return cardError(c, CodeCardChecksum, "card number has an invalid checksum", nil)
+	}
+	if allowed != nil && (b == nil || !containsString(allowed, b.name)) {
+		return cardError(c, CodeCardBrand, "card brand is not accepted", strings.Join(allowed, ","))
+	}
+	return verrs.FieldError{}
+}
+
+func cardError(c *types.Compiler, code, fallback string, param any) verrs.FieldError {
+	return verrs.FieldError{Code: code, Msg: c.T(code, fallback, nil), Param: param}
+}
+
+func containsInt(values []int, n int) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func brandNames() string {
+	names := make([]string, len(brands))
+	for i, b := range brands {
+		names[i] = b.name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,76 @@
+package creditcard
+
+import (
+	"errors"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestBrand(t *testing.T) {
+	tests := map[string]string{
+		"4111 1111 1111 1111": Visa,
+		"5555-5555-5555-4444": Mastercard,
+		"2223003122003222":    Mastercard,
+		"378282246310005":     Amex,
+		"6011111111111117":    Discover,
+		"6221260000000000":    Discover,
+		"6200000000000005":    UnionPay,
+		"3530111333300000":    JCB,
+		"36227206271667":      DinersClub,
+		"6759649826438453":    Maestro,
+		"9111111111111111":    "",
+		"4111x":               "",
+	}
+	for number, want := range tests {
+		if got := Brand(number); got != want {
+			t.Errorf("Brand(%q) = %q, want %q", number, got, want)
+		}
+	}
+}
+
+func TestCreditCard_Rule(t *testing.T) {
+	c := types.NewCompiler(nil)
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"string;creditcard", "4111 1111 1111 1111", ""},
+		{"string;creditcard", "378282246310005", ""},
+		{"string;creditcard", "4111 1111 1111 1112", CodeCardChecksum},
+		{"string;creditcard", "41111111111111111", CodeCardLength},
+		{"string;creditcard", "3782822463100050", CodeCardLength},
+		{"string;creditcard", "12345", CodeCardLength},
+		{"string;creditcard", "4111-1111-1111-111a", CodeCardInvalid},
+		{"string;creditcard", 4111111111111111, verrs.CodeStringType},
+		{"string;creditcard=visa,mastercard", "5555555555554444", ""},
+		{"string;creditcard=visa,mastercard", "378282246310005", CodeCardBrand},
+		{"string;creditcard=visa", "9111111111111110", CodeCardBrand},
+	}
+	for _, tt := range tests {
+		rules, err := types.ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		code := ""
+		var es verrs.Errors
+		if err := c.Compile(rules)(tt.value); errors.As(err, &es) {
+			code = es[0].Code
+		} else if err != nil {
+			t.Fatalf("%s %v: unexpected error %v", tt.tag, tt.value, err)
+		}
+		if code != tt.code {
+			t.Errorf("%s %v: code = %q, want %q", tt.tag, tt.value, code, tt.code)
+		}
+	}
+
+	rules, err := types.ParseTag("string;creditcard=visa,diners")
+	if err != nil {
+		t.Fatalf("ParseTag: %v", err)
+	}
+	if _, err := c.CompileE(rules); err == nil {
+		t.Fatal("expected unknown brand compile error")
+	}
+}
@@ -0,0 +1,9 @@
+// Package creditcard provides payment card number validation as a plugin.
+//
+// Importing the package registers the `creditcard` rule. `string;creditcard`
+// accepts card numbers of 12 to 19 digits, optionally grouped with spaces or
+// hyphens, whose length fits the detected brand and whose Luhn checksum is
+// valid. A brand list, as in `string;creditcard=visa,mastercard`, also
+// rejects numbers of other or unknown brands. Length, checksum and brand
+// failures have distinct codes, and Brand reports the detected brand.
+package creditcard
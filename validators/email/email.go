@@ -41,6 +41,7 @@ const KEmail types.Kind = "email"
 
 func init() {
 	types.RegisterRule(KEmail, compileEmail)
+	types.RegisterKindDoc(types.KindDoc{Kind: KEmail, Summary: "Bare email address without a display name", Examples: []string{"string;email"}, Sample: "user@example.com", Format: "email"})
 	translator.RegisterDefaultEnglishTranslations(DefaultEmailTranslations())
 }
 
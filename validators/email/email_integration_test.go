@@ -1,3 +1,5 @@
+//go:build !validate_lite
+
 package email_test
 
 import (
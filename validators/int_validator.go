@@ -72,11 +72,11 @@ func (v *IntValidatorImpl) SetExact(exact bool) {
 func (v *IntValidatorImpl) ruleToValidator(rule types.Rule) IntValidator {
 	switch rule.Kind {
 	case types.KMinInt:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.iv.MinInt(n)
 		}
 	case types.KMaxInt:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.iv.MaxInt(n)
 		}
 	}
@@ -0,0 +1,117 @@
+package iso
+
+import "strings"
+
+// IsLanguageTag reports whether s is a well-formed BCP 47 (RFC 5646)
+// language tag, compared case-insensitively: a language with optional
+// extended language, script, region, variant, extension and private use
+// subtags, or a private use tag such as "x-klingon". Two-letter languages
+// must be ISO 639-1 codes and two-letter regions ISO 3166-1 codes.
+// Irregular grandfathered tags such as "i-klingon" are rejected.
+func IsLanguageTag(s string) bool {
+	if s == "" || len(s) > 255 {
+		return false
+	}
+	tags := strings.Split(strings.ToLower(s), "-")
+	if tags[0] == "x" {
+		return isPrivateUse(tags[1:])
+	}
+	lang := tags[0]
+	if !isAlpha(lang, 2, 3) || (len(lang) == 2 && !languages[lang]) {
+		return false
+	}
+	i := 1
+	for n := 0; n < 3 && i < len(tags) && isAlpha(tags[i], 3, 3); n++ {
+		i++ // extlang
+	}
+	if i < len(tags) && isAlpha(tags[i], 4, 4) {
+		i++ // script
+	}
+	if i < len(tags) {
+		switch t := tags[i]; {
+		case isAlpha(t, 2, 2):
+			if !countries[strings.ToUpper(t)] {
+				return false
+			}
+			i++
+		case len(t) == 3 && isDigits(t):
+			i++
+		}
+	}
+	variants := map[string]bool{}
+	for ; i < len(tags) && isVariant(tags[i]); i++ {
+		if variants[tags[i]] {
+			return false
+		}
+		variants[tags[i]] = true
+	}
+	singletons := map[string]bool{}
+	for i < len(tags) {
+		t := tags[i]
+		if t == "x" {
+			return isPrivateUse(tags[i+1:])
+		}
+		if len(t) != 1 || !isAlnum(t, 1, 1) || singletons[t] {
+			return false
+		}
+		singletons[t] = true
+		i++
+		start := i
+		for i < len(tags) && isAlnum(tags[i], 2, 8) {
+			i++
+		}
+		if i == start {
+			return false
+		}
+	}
+	return true
+}
+
+func isPrivateUse(tags []string) bool {
+	if len(tags) == 0 {
+		return false
+	}
+	for _, t := range tags {
+		if !isAlnum(t, 1, 8) {
+			return false
+		}
+	}
+	return true
+}
+
+func isVariant(t string) bool {
+	return isAlnum(t, 5, 8) || (len(t) == 4 && t[0] >= '0' && t[0] <= '9' && isAlnum(t, 4, 4))
+}
+
+func isAlpha(s string, min, max int) bool {
+	if len(s) < min || len(s) > max {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string, min, max int) bool {
+	if len(s) < min || len(s) > max {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
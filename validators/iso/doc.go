@@ -0,0 +1,10 @@
+// Package iso provides ISO code validation as a plugin.
+//
+// Importing the package registers three rules backed by embedded code
+// tables: `iso3166_alpha2` for upper-case country codes such as "FI",
+// `iso4217` for upper-case currency codes such as "EUR", and `bcp47` for
+// language tags such as "en-US" or "zh-Hant-TW". Language tags are checked
+// against the RFC 5646 syntax, with two-letter languages and regions looked
+// up in ISO 639-1 and ISO 3166-1; three-letter languages, scripts, variants
+// and extensions are checked for syntax only.
+package iso
@@ -0,0 +1,106 @@
+package iso
+
+import (
+	"embed"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// ISO-specific error codes.
+const (
+	CodeCountryInvalid     = "string.iso3166.invalid"
+	CodeCurrencyInvalid    = "string.iso4217.invalid"
+	CodeLanguageTagInvalid = "string.bcp47.invalid"
+)
+
+// DefaultISOTranslations returns default English translations for ISO code validation errors.
+func DefaultISOTranslations() map[string]string {
+	return map[string]string{
+		"string.iso3166.invalid": "must be an ISO 3166-1 alpha-2 country code",
+		"string.iso4217.invalid": "must be an ISO 4217 currency code",
+		"string.bcp47.invalid":   "must be a valid BCP 47 language tag",
+	}
+}
+
+// Rule kinds registered by the package.
+const (
+	KCountry     types.Kind = "iso3166_alpha2"
+	KCurrency    types.Kind = "iso4217"
+	KLanguageTag types.Kind = "bcp47"
+)
+
+//go:embed data/*.txt
+var data embed.FS
+
+var (
+	countries  = loadCodes("data/countries.txt")
+	currencies = loadCodes("data/currencies.txt")
+	languages  = loadCodes("data/languages.txt")
+)
+
+// loadCodes reads a whitespace-separated code table; lines starting with
+// "#" are comments.
+func loadCodes(name string) map[string]bool {
+	b, err := data.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	codes := map[string]bool{}
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, code := range strings.Fields(line) {
+			codes[code] = true
+		}
+	}
+	return codes
+}
+
+func init() {
+	for _, rule := range []struct {
+		kind     types.Kind
+		code     string
+		fallback string
+		check    func(string) bool
+		summary  string
+		sample   string
+	}{
+		{KCountry, CodeCountryInvalid, "must be an ISO 3166-1 alpha-2 country code", IsCountry, "ISO 3166-1 alpha-2 country code", "FI"},
+		{KCurrency, CodeCurrencyInvalid, "must be an ISO 4217 currency code", IsCurrency, "ISO 4217 currency code", "EUR"},
+		{KLanguageTag, CodeLanguageTagInvalid, "must be a valid BCP 47 language tag", IsLanguageTag, "BCP 47 language tag", "en-US"},
+	} {
+		types.RegisterRule(rule.kind, compileCode(rule.code, rule.fallback, rule.check))
+		types.RegisterKindDoc(types.KindDoc{Kind: rule.kind, Summary: rule.summary, Examples: []string{"string;" + string(rule.kind)}, Sample: rule.sample})
+	}
+	translator.RegisterDefaultEnglishTranslations(DefaultISOTranslations())
+}
+
+func compileCode(code, fallback string, check func(string) bool) types.RuleCompiler {
+	return func(c *types.Compiler, _ types.Rule) (func(any) error, error) {
+		return func(v any) error {
+			s, ok := v.(string)
+			if !ok {
+				msg := c.T("string.type", "expected string", nil)
+				return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+			}
+			if !check(s) {
+				return verrs.Errors{verrs.FieldError{Path: "", Code: code, Msg: c.T(code, fallback, nil)}}
+			}
+			return nil
+		}, nil
+	}
+}
+
+// IsCountry reports whether s is an upper-case ISO 3166-1 alpha-2 code.
+func IsCountry(s string) bool {
+	return countries[s]
+}
+
+// IsCurrency reports whether s is an upper-case active ISO 4217 code.
+func IsCurrency(s string) bool {
+	return currencies[s]
+}
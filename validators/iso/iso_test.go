@@ -0,0 +1,88 @@
+package iso
+
+import (
+	"errors"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestCodeTables(t *testing.T) {
+	if len(countries) != 249 || len(languages) != 183 {
+		t.Fatalf("table sizes: %d countries, %d languages", len(countries), len(languages))
+	}
+	for _, tt := range []struct {
+		check func(string) bool
+		value string
+		want  bool
+	}{
+		{IsCountry, "FI", true},
+		{IsCountry, "fi", false},
+		{IsCountry, "XK", false},
+		{IsCountry, "FIN", false},
+		{IsCurrency, "EUR", true},
+		{IsCurrency, "XAU", true},
+		{IsCurrency, "eur", false},
+		{IsCurrency, "DEM", false},
+	} {
+		if got := tt.check(tt.value); got != tt.want {
+			t.Errorf("check(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsLanguageTag(t *testing.T) {
+	valid := []string{
+		"en", "en-US", "EN-us", "zh-Hant-TW", "es-419", "sr-Latn-RS", "fil",
+		"zh-yue-HK", "de-CH-1901", "sl-rozaj-biske", "en-US-u-ca-gregory",
+		"en-a-bbb-x-a-ccc", "x-whatever", "hy-Latn-IT-arevela",
+	}
+	for _, s := range valid {
+		if !IsLanguageTag(s) {
+			t.Errorf("IsLanguageTag(%q) = false, want true", s)
+		}
+	}
+	invalid := []string{
+		"", "e", "english", "qq", "en-", "en--US", "en-ZZ", "en_US", "i-klingon",
+		"de-1901-1901", "en-a-bbb-a-ccc", "en-a", "en-x", "x", "en-US-u-ca-toolongvalue",
+	}
+	for _, s := range invalid {
+		if IsLanguageTag(s) {
+			t.Errorf("IsLanguageTag(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestISO_Rules(t *testing.T) {
+	c := types.NewCompiler(nil)
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"string;iso3166_alpha2", "SE", ""},
+		{"string;iso3166_alpha2", "Sweden", CodeCountryInvalid},
+		{"string;iso4217", "USD", ""},
+		{"string;iso4217", "US$", CodeCurrencyInvalid},
+		{"string;bcp47", "pt-BR", ""},
+		{"string;bcp47", "pt_BR", CodeLanguageTagInvalid},
+		{"string;iso4217", 978, verrs.CodeStringType},
+	}
+	for _, tt := range tests {
+		rules, err := types.ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		code := ""
+		var es verrs.Errors
+		if err := c.Compile(rules)(tt.value); errors.As(err, &es) {
+			code = es[0].Code
+		} else if err != nil {
+			t.Fatalf("%s %v: unexpected error %v", tt.tag, tt.value, err)
+		}
+		if code != tt.code {
+			t.Errorf("%s %v: code = %q, want %q", tt.tag, tt.value, code, tt.code)
+		}
+	}
+}
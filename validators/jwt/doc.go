@@ -0,0 +1,10 @@
+// Package jwt provides JSON Web Token format validation as a plugin.
+//
+// Importing the package replaces the `jwt` rule of the domain package with
+// one that checks the compact JWS form in more depth: three base64url
+// segments, a header that is a JSON object naming its alg, and a payload
+// that is a JSON object. An algorithm list, as in `string;jwt=RS256,ES256`,
+// also rejects tokens whose alg is not listed, which screens bearer tokens
+// at the edge before signature verification. Signatures and claims are not
+// checked.
+package jwt
@@ -0,0 +1,10 @@
+// Package netname provides RFC 1123 host name validation as a plugin.
+//
+// Importing the package registers `dns_label` and replaces the built-in
+// `hostname` rule and the domain package's `fqdn` rule with stricter ASCII
+// versions: labels of letters, digits and inner hyphens, at most 63
+// characters each, in names of at most 253 characters. Names that are too
+// long fail with string.dns.length rather than the rule's own code. The
+// `lower` parameter, as in `string;dns_label=lower`, also rejects upper-case
+// letters, which matches Kubernetes resource name rules.
+package netname
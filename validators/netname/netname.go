@@ -0,0 +1,201 @@
+package netname
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+	"github.com/aatuh/validate/v3/validators/domain"
+)
+
+// Name-specific error codes. Malformed hostnames and FQDNs keep the codes
+// of the rules the package replaces.
+const (
+	CodeHostnameInvalid = verrs.CodeStringHost
+	CodeFQDNInvalid     = domain.CodeFQDNInvalid
+	CodeLabelInvalid    = "string.dns_label.invalid"
+	CodeNameLength      = "string.dns.length"
+)
+
+// DefaultNameTranslations returns default English translations for name validation errors.
+func DefaultNameTranslations() map[string]string {
+	return map[string]string{
+		"string.dns_label.invalid": "must be a valid DNS label",
+		"string.dns.length":        "DNS name or label is too long",
+	}
+}
+
+// Rule kinds registered by the package. KHostname and KFQDN override the
+// built-in and domain rules of the same names.
+const (
+	KHostname            = types.KHostname
+	KFQDN                = domain.KFQDN
+	KDNSLabel types.Kind = "dns_label"
+)
+
+// Length limits from RFC 1035, section 2.3.4.
+const (
+	MaxLabelLength = 63
+	MaxNameLength  = 253
+)
+
+var (
+	errLength  = errors.New("too long")
+	errInvalid = errors.New("invalid")
+)
+
+// nameRule describes one registered kind. check returns errLength or
+// errInvalid, and max is the limit reported with CodeNameLength.
+type nameRule struct {
+	kind     types.Kind
+	code     string
+	fallback string
+	check    func(s string, lower bool) error
+	max      int
+	summary  string
+	sample   string
+}
+
+func init() {
+	for _, rule := range []nameRule{
+		{KHostname, CodeHostnameInvalid, "must be a valid hostname", checkHostname, MaxNameLength, "RFC 1123 host name of ASCII labels", "api.example.com"},
+		{KFQDN, CodeFQDNInvalid, "must be a valid fully qualified domain name", checkFQDN, MaxNameLength, "Fully qualified domain name with an alphabetic top-level label", "example.com"},
+		{KDNSLabel, CodeLabelInvalid, "must be a valid DNS label", checkLabel, MaxLabelLength, "Single RFC 1123 DNS label", "my-app"},
+	} {
+		types.RegisterRule(rule.kind, compileName(rule))
+		types.RegisterKindDoc(types.KindDoc{
+			Kind:     rule.kind,
+			Summary:  rule.summary,
+			Params:   []types.ParamDoc{{Name: "value", Type: "string", Description: "lower to reject upper-case letters"}},
+			Examples: []string{"string;" + string(rule.kind), "string;" + string(rule.kind) + "=lower"},
+			Sample:   rule.sample,
+			Format:   hostnameFormat(rule.kind),
+		})
+	}
+	translator.RegisterDefaultEnglishTranslations(DefaultNameTranslations())
+}
+
+func hostnameFormat(kind types.Kind) string {
+	if kind == KDNSLabel {
+		return ""
+	}
+	return "hostname"
+}
+
+// IsDNSLabel reports whether s is a single RFC 1123 label.
+func IsDNSLabel(s string) bool {
+	return checkLabel(s, false) == nil
+}
+
+// IsHostname reports whether s is an RFC 1123 host name. One trailing dot
+// is allowed.
+func IsHostname(s string) bool {
+	return checkHostname(s, false) == nil
+}
+
+// IsFQDN reports whether s is a host name of at least two labels whose
+// last label starts with a letter and is at least two characters long.
+func IsFQDN(s string) bool {
+	return checkFQDN(s, false) == nil
+}
+
+func compileName(rule nameRule) types.RuleCompiler {
+	return func(c *types.Compiler, r types.Rule) (func(any) error, error) {
+		lower, err := parseLower(r)
+		if err != nil {
+			return nil, err
+		}
+		return func(v any) error {
+			s, ok := v.(string)
+			if !ok {
+				msg := c.T("string.type", "expected string", nil)
+				return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+			}
+			switch rule.check(s, lower) {
+			case nil:
+				return nil
+			case errLength:
+				msg := c.T(CodeNameLength, "DNS name or label is too long", nil)
+				return verrs.Errors{verrs.FieldError{Path: "", Code: CodeNameLength, Msg: msg, Param: rule.max}}
+			default:
+				msg := c.T(rule.code, rule.fallback, nil)
+				return verrs.Errors{verrs.FieldError{Path: "", Code: rule.code, Msg: msg}}
+			}
+		}, nil
+	}
+}
+
+// parseLower reports whether the rule value asks for lower case only.
+func parseLower(r types.Rule) (bool, error) {
+	switch value, _ := r.Args["value"].(string); strings.TrimSpace(value) {
+	case "":
+		return false, nil
+	case "lower":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s: unknown parameter %q; only lower is supported", r.Kind, value)
+	}
+}
+
+// checkLabel validates one label: letters, digits and hyphens, not starting
+// or ending with a hyphen (RFC 1123, section 2.1).
+func checkLabel(label string, lower bool) error {
+	if label == "" || label[0] == '-' || label[len(label)-1] == '-' {
+		return errInvalid
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-':
+		case c >= 'A' && c <= 'Z' && !lower:
+		default:
+			return errInvalid
+		}
+	}
+	if len(label) > MaxLabelLength {
+		return errLength
+	}
+	return nil
+}
+
+func checkHostname(s string, lower bool) error {
+	name := strings.TrimSuffix(s, ".")
+	if name == "" {
+		return errInvalid
+	}
+	// Report malformed labels before lengths so that the more actionable
+	// code wins for names that are both.
+	var length error
+	for _, label := range strings.Split(name, ".") {
+		switch err := checkLabel(label, lower); err {
+		case errInvalid:
+			return err
+		case errLength:
+			length = err
+		}
+	}
+	if length != nil || len(name) > MaxNameLength {
+		return errLength
+	}
+	return nil
+}
+
+func checkFQDN(s string, lower bool) error {
+	if err := checkHostname(s, lower); err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(s, ".")
+	i := strings.LastIndexByte(name, '.')
+	if i < 0 {
+		return errInvalid
+	}
+	// All-numeric top-level labels would make IPv4 addresses look like
+	// names (RFC 3696, section 2).
+	if tld := name[i+1:]; len(tld) < 2 || (tld[0] >= '0' && tld[0] <= '9') {
+		return errInvalid
+	}
+	return nil
+}
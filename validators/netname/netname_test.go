@@ -0,0 +1,95 @@
+package netname
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestIsHelpers(t *testing.T) {
+	long := strings.Repeat("a", 64)
+	tests := []struct {
+		s                     string
+		label, hostname, fqdn bool
+	}{
+		{"my-app", true, true, false},
+		{"3com", true, true, false},
+		{"Example.COM", false, true, true},
+		{"api.example.com.", false, true, true},
+		{"xn--bcher-kva.example", false, true, true},
+		{"10.0.0.1", false, true, false},
+		{"host.c", false, true, false},
+		{"-app", false, false, false},
+		{"app-", false, false, false},
+		{"a..b", false, false, false},
+		{"under_score.example", false, false, false},
+		{"bücher.example", false, false, false},
+		{"", false, false, false},
+		{".", false, false, false},
+		{long, false, false, false},
+		{long + ".example", false, false, false},
+	}
+	for _, tt := range tests {
+		if got := IsDNSLabel(tt.s); got != tt.label {
+			t.Errorf("IsDNSLabel(%q) = %v, want %v", tt.s, got, tt.label)
+		}
+		if got := IsHostname(tt.s); got != tt.hostname {
+			t.Errorf("IsHostname(%q) = %v, want %v", tt.s, got, tt.hostname)
+		}
+		if got := IsFQDN(tt.s); got != tt.fqdn {
+			t.Errorf("IsFQDN(%q) = %v, want %v", tt.s, got, tt.fqdn)
+		}
+	}
+}
+
+func TestNameRules(t *testing.T) {
+	c := types.NewCompiler(nil)
+	longName := strings.Repeat("abcdefghi.", 25) + "example"
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"string;dns_label", "My-App", ""},
+		{"string;dns_label=lower", "my-app", ""},
+		{"string;dns_label=lower", "My-App", CodeLabelInvalid},
+		{"string;dns_label", "my.app", CodeLabelInvalid},
+		{"string;dns_label", strings.Repeat("a", 64), CodeNameLength},
+		{"string;hostname", "api.example.com", ""},
+		{"string;hostname", "bad_host", CodeHostnameInvalid},
+		{"string;hostname", longName, CodeNameLength},
+		{"string;hostname=lower", "web-0.nginx.default", ""},
+		{"string;hostname=lower", "Web-0.nginx", CodeHostnameInvalid},
+		{"string;fqdn", "example.com", ""},
+		{"string;fqdn", "localhost", CodeFQDNInvalid},
+		{"string;fqdn", "192.168.0.1", CodeFQDNInvalid},
+		{"string;fqdn", 42, verrs.CodeStringType},
+	}
+	for _, tt := range tests {
+		rules, err := types.ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		code := ""
+		var es verrs.Errors
+		if err := c.Compile(rules)(tt.value); errors.As(err, &es) {
+			code = es[0].Code
+		} else if err != nil {
+			t.Fatalf("%s %v: unexpected error %v", tt.tag, tt.value, err)
+		}
+		if code != tt.code {
+			t.Errorf("%s %v: code = %q, want %q", tt.tag, tt.value, code, tt.code)
+		}
+	}
+
+	rules, err := types.ParseTag("string;dns_label=upper")
+	if err != nil {
+		t.Fatalf("ParseTag: %v", err)
+	}
+	if _, err := c.CompileE(rules); err == nil {
+		t.Fatal("expected unknown parameter compile error")
+	}
+}
@@ -0,0 +1,9 @@
+// Package phone provides E.164 phone number validation as a plugin.
+//
+// Importing the package registers the `phone` rule. `string;phone` accepts
+// international numbers such as "+1 (415) 555-0100" or "0044 20 7946 0958";
+// spaces, dots, hyphens, slashes and parentheses are ignored. A region
+// parameter, as in `string;phone=US`, also accepts national numbers of that
+// region and rejects numbers of other calling codes. Normalize returns the
+// E.164 form of a number for storage.
+package phone
@@ -0,0 +1,165 @@
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// Phone-specific error codes.
+const (
+	CodePhoneInvalid = "string.phone.invalid"
+	CodePhoneRegion  = "string.phone.region"
+)
+
+// DefaultPhoneTranslations returns default English translations for phone validation errors.
+func DefaultPhoneTranslations() map[string]string {
+	return map[string]string{
+		"string.phone.invalid": "must be a valid phone number",
+		"string.phone.region":  "phone number must belong to region %v",
+	}
+}
+
+// KPhone is the rule kind registered by the package.
+const KPhone types.Kind = "phone"
+
+// ErrInvalid and ErrRegion are returned by Normalize.
+var (
+	ErrInvalid = errors.New("invalid phone number")
+	ErrRegion  = errors.New("phone number is not in region")
+)
+
+// region holds the calling code of a region and the trunk prefix dropped
+// from national numbers; regions without a trunk prefix keep the leading
+// digits in the international form.
+type region struct {
+	code  string
+	trunk string
+}
+
+var regions = map[string]region{
+	"AT": {"43", "0"}, "AU": {"61", "0"}, "BE": {"32", "0"}, "BR": {"55", "0"},
+	"CA": {"1", "1"}, "CH": {"41", "0"}, "CN": {"86", "0"}, "DE": {"49", "0"},
+	"DK": {"45", ""}, "EE": {"372", ""}, "ES": {"34", ""}, "FI": {"358", "0"},
+	"FR": {"33", "0"}, "GB": {"44", "0"}, "IE": {"353", "0"}, "IN": {"91", "0"},
+	"IT": {"39", ""}, "JP": {"81", "0"}, "MX": {"52", ""}, "NL": {"31", "0"},
+	"NO": {"47", ""}, "NZ": {"64", "0"}, "PL": {"48", ""}, "PT": {"351", ""},
+	"SE": {"46", "0"}, "US": {"1", "1"}, "ZA": {"27", "0"},
+}
+
+// Regions returns the supported region codes in sorted order.
+func Regions() []string {
+	out := make([]string, 0, len(regions))
+	for r := range regions {
+		out = append(out, r)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func init() {
+	types.RegisterRule(KPhone, compilePhone)
+	types.RegisterKindDoc(types.KindDoc{
+		Kind:     KPhone,
+		Summary:  "E.164 phone number, optionally of a region",
+		Params:   []types.ParamDoc{{Name: "value", Type: "string", Description: "Region code, e.g. US; allows national numbers"}},
+		Examples: []string{"string;phone", "string;phone=US"},
+		Sample:   "+14155550100",
+	})
+	translator.RegisterDefaultEnglishTranslations(DefaultPhoneTranslations())
+}
+
+// Normalize returns s in E.164 form, e.g. "+14155550100".
+//
+// Parameters:
+//   - s: The number. Separators are ignored; "+" or "00" marks an
+//     international number.
+//   - regionCode: Optional region code. When set, national numbers are
+//     accepted and the number must use the calling code of the region.
+//
+// Returns:
+//   - string: The E.164 number.
+//   - error: ErrInvalid, ErrRegion, or an error for an unknown region.
+func Normalize(s, regionCode string) (string, error) {
+	var reg region
+	if regionCode != "" {
+		var ok bool
+		if reg, ok = regions[strings.ToUpper(regionCode)]; !ok {
+			return "", fmt.Errorf("unknown phone region %q", regionCode)
+		}
+	}
+	digits, international, ok := stripNumber(s)
+	if !ok {
+		return "", ErrInvalid
+	}
+	if !international {
+		if reg.code == "" {
+			return "", ErrInvalid
+		}
+		digits = reg.code + strings.TrimPrefix(digits, reg.trunk)
+	}
+	if len(digits) < 7 || len(digits) > 15 || digits[0] == '0' {
+		return "", ErrInvalid
+	}
+	if reg.code != "" && !strings.HasPrefix(digits, reg.code) {
+		return "", ErrRegion
+	}
+	return "+" + digits, nil
+}
+
+// stripNumber removes separators from s and reports whether it is written
+// in international form.
+func stripNumber(s string) (digits string, international bool, ok bool) {
+	s = strings.TrimSpace(s)
+	if rest, found := strings.CutPrefix(s, "+"); found {
+		s, international = rest, true
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/':
+		default:
+			return "", false, false
+		}
+	}
+	digits = b.String()
+	if !international {
+		if rest, found := strings.CutPrefix(digits, "00"); found {
+			digits, international = rest, true
+		}
+	}
+	return digits, international, digits != ""
+}
+
+func compilePhone(c *types.Compiler, r types.Rule) (func(any) error, error) {
+	regionCode, _ := r.Args["value"].(string)
+	regionCode = strings.ToUpper(strings.TrimSpace(regionCode))
+	if _, ok := regions[regionCode]; regionCode != "" && !ok {
+		return nil, fmt.Errorf("phone: unknown region %q", regionCode)
+	}
+	return func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			msg := c.T("string.type", "expected string", nil)
+			return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+		}
+		_, err := Normalize(s, regionCode)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, ErrRegion):
+			msg := c.T(CodePhoneRegion, "phone number must belong to region "+regionCode, []any{regionCode})
+			return verrs.Errors{verrs.FieldError{Path: "", Code: CodePhoneRegion, Msg: msg, Param: regionCode}}
+		default:
+			msg := c.T(CodePhoneInvalid, "must be a valid phone number", nil)
+			return verrs.Errors{verrs.FieldError{Path: "", Code: CodePhoneInvalid, Msg: msg}}
+		}
+	}, nil
+}
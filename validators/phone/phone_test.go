@@ -0,0 +1,80 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in     string
+		region string
+		want   string
+		err    error
+	}{
+		{"+1 (415) 555-0100", "", "+14155550100", nil},
+		{"0044 20 7946 0958", "", "+442079460958", nil},
+		{"+358 40 123 4567", "FI", "+358401234567", nil},
+		{"040 123 4567", "FI", "+358401234567", nil},
+		{"(415) 555-0100", "us", "+14155550100", nil},
+		{"1 415 555 0100", "US", "+14155550100", nil},
+		{"06 1234 5678", "IT", "+390612345678", nil},
+		{"415 555 0100", "", "", ErrInvalid},
+		{"+0 123 4567", "", "", ErrInvalid},
+		{"+1 234", "", "", ErrInvalid},
+		{"+1234567890123456", "", "", ErrInvalid},
+		{"+1 415 555 0100 ext 2", "", "", ErrInvalid},
+		{"+44 20 7946 0958", "US", "", ErrRegion},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.in, tt.region)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("Normalize(%q, %q) = %q, %v; want %q, %v", tt.in, tt.region, got, err, tt.want, tt.err)
+		}
+	}
+	if _, err := Normalize("+14155550100", "XX"); err == nil {
+		t.Fatal("expected unknown region error")
+	}
+}
+
+func TestPhone_Rule(t *testing.T) {
+	c := types.NewCompiler(nil)
+	tests := []struct {
+		tag   string
+		value any
+		code  string
+	}{
+		{"string;phone", "+14155550100", ""},
+		{"string;phone", "4155550100", CodePhoneInvalid},
+		{"string;phone", 14155550100, verrs.CodeStringType},
+		{"string;phone=US", "415-555-0100", ""},
+		{"string;phone=US", "+358401234567", CodePhoneRegion},
+	}
+	for _, tt := range tests {
+		rules, err := types.ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		code := ""
+		var es verrs.Errors
+		if err := c.Compile(rules)(tt.value); errors.As(err, &es) {
+			code = es[0].Code
+		} else if err != nil {
+			t.Fatalf("%s %v: unexpected error %v", tt.tag, tt.value, err)
+		}
+		if code != tt.code {
+			t.Errorf("%s %v: code = %q, want %q", tt.tag, tt.value, code, tt.code)
+		}
+	}
+
+	rules, err := types.ParseTag("string;phone=XX")
+	if err != nil {
+		t.Fatalf("ParseTag: %v", err)
+	}
+	if _, err := c.CompileE(rules); err == nil {
+		t.Fatal("expected unknown region compile error")
+	}
+}
@@ -22,6 +22,7 @@ type SliceValidator func([]any) error
 //   - translator: Optional translator for localized error messages.
 type SliceValidators struct {
 	translator translator.Translator
+	nilAsEmpty bool
 }
 
 // NewSliceValidators creates a new SliceValidators instance.
@@ -45,6 +46,18 @@ func (sv *SliceValidators) Translator() translator.Translator {
 	return sv.translator
 }
 
+// WithNilAsEmpty returns a copy that treats an untyped nil value as an empty
+// slice instead of failing with slice.notSlice.
+//
+// Parameters:
+//   - enabled: Whether nil values are treated as empty slices.
+//
+// Returns:
+//   - *SliceValidators: A new SliceValidators instance.
+func (sv *SliceValidators) WithNilAsEmpty(enabled bool) *SliceValidators {
+	return &SliceValidators{translator: sv.translator, nilAsEmpty: enabled}
+}
+
 // WithSlice applies validators to any slice type via reflection.
 //
 // Parameters:
@@ -185,6 +198,9 @@ func BuildSliceValidator(
 
 // toSlice converts any slice/array to []any for uniform validation.
 func (sv *SliceValidators) toSlice(value any) ([]any, error) {
+	if value == nil && sv.nilAsEmpty {
+		return []any{}, nil
+	}
 	rv := reflect.ValueOf(value)
 	switch rv.Kind() {
 	case reflect.Slice, reflect.Array:
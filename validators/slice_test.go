@@ -53,6 +53,17 @@ func TestSlice_NotSlice(t *testing.T) {
 	}
 }
 
+func TestSlice_NilAsEmpty(t *testing.T) {
+	sv := NewSliceValidators(dummyTr{})
+	if err := sv.WithSlice()(nil); err == nil {
+		t.Fatalf("nil should fail by default")
+	}
+	fn := sv.WithNilAsEmpty(true).WithSlice(sv.MaxSliceLength(2))
+	if err := fn(nil); err != nil {
+		t.Fatalf("nil should be treated as empty: %v", err)
+	}
+}
+
 func contains(s, sub string) bool {
 	return len(s) >= len(sub) && (s == sub || (len(sub) > 0 &&
 		indexOf(s, sub) >= 0))
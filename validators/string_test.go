@@ -157,6 +157,24 @@ func TestBuildStringValidator_FromTokens(t *testing.T) {
 	}
 }
 
+func TestStringValidatorImpl_AcceptsRulesFromAnySource(t *testing.T) {
+	parsed, err := types.ParseTag("string;min=2;max=3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rules := range [][]types.Rule{
+		parsed[1:],
+		{{Kind: types.KMinLength, Args: map[string]any{"n": 2}}, {Kind: types.KMaxLength, Args: map[string]any{"n": int64(3)}}},
+	} {
+		impl := (&StringValidatorFactory{}).CreateValidator(dummyTr{}).(*StringValidatorImpl)
+		impl.SetRules(rules)
+		fn := impl.Build()
+		if fn("a") == nil || fn("abcd") == nil || fn("ab") != nil {
+			t.Fatalf("rules %v not applied", rules)
+		}
+	}
+}
+
 type hasString string
 
 func (h hasString) String() string { return "S" }
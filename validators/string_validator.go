@@ -60,23 +60,23 @@ func (v *StringValidatorImpl) AddRule(rule types.Rule) {
 func (v *StringValidatorImpl) ruleToValidator(rule types.Rule) StringValidator {
 	switch rule.Kind {
 	case types.KLength:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.sv.Length(int(n))
 		}
 	case types.KMinLength:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.sv.MinLength(int(n))
 		}
 	case types.KMaxLength:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.sv.MaxLength(int(n))
 		}
 	case types.KMinRunes:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.sv.MinRunes(int(n))
 		}
 	case types.KMaxRunes:
-		if n, ok := rule.Args["n"].(int64); ok {
+		if n, ok := rule.IntArg("n"); ok {
 			return v.sv.MaxRunes(int(n))
 		}
 	case types.KRegex:
@@ -25,6 +25,7 @@ const KULID types.Kind = "ulid"
 
 func init() {
 	types.RegisterRule(KULID, compileULID)
+	types.RegisterKindDoc(types.KindDoc{Kind: KULID, Summary: "Canonical 26-character ULID", Examples: []string{"string;ulid"}, Sample: "01ARZ3NDEKTSV4RRFFQ69G5FAV"})
 	translator.RegisterDefaultEnglishTranslations(DefaultULIDTranslations())
 }
 
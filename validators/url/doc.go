@@ -0,0 +1,8 @@
+// Package url provides configurable URL validation as a plugin.
+//
+// Importing the package replaces the built-in `url` rule with one that
+// accepts the same absolute URLs but reports why a URL was rejected, with
+// codes such as string.url.scheme and string.url.host. Register adds
+// further rule kinds with their own Options, restricting schemes, ports,
+// paths and queries; `httpurl` accepts http and https URLs only.
+package url
@@ -0,0 +1,158 @@
+package url
+
+import (
+	"net/netip"
+	neturl "net/url"
+	"strings"
+	"unicode"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/translator"
+	"github.com/aatuh/validate/v3/types"
+)
+
+// URL-specific error codes. Values that do not parse as absolute URLs keep
+// the built-in code errors.CodeStringURL.
+const (
+	CodeURLInvalid = verrs.CodeStringURL
+	CodeURLScheme  = "string.url.scheme"
+	CodeURLHost    = "string.url.host"
+	CodeURLPort    = "string.url.port"
+	CodeURLPath    = "string.url.path"
+	CodeURLQuery   = "string.url.query"
+)
+
+// DefaultURLTranslations returns default English translations for URL validation errors.
+func DefaultURLTranslations() map[string]string {
+	return map[string]string{
+		"string.url.scheme": "URL scheme is not allowed",
+		"string.url.host":   "URL must have a valid host",
+		"string.url.port":   "URL must not include a port",
+		"string.url.path":   "URL must not include a path",
+		"string.url.query":  "URL must not include a query or fragment",
+	}
+}
+
+// Rule kinds registered by the package.
+const (
+	KURL                = types.KURL
+	KHTTPURL types.Kind = "httpurl"
+)
+
+// Options restricts the URLs a rule accepts. The zero value accepts any
+// absolute URL, with or without a host.
+//
+// Fields:
+//   - Schemes: Allowed schemes, compared case-insensitively; empty allows
+//     any scheme.
+//   - RequireHost: The URL must have a valid host name or IP address.
+//   - NoPort: The URL must not include an explicit port.
+//   - NoPath: The URL path must be empty or "/".
+//   - NoQuery: The URL must not include a query or fragment.
+type Options struct {
+	Schemes     []string
+	RequireHost bool
+	NoPort      bool
+	NoPath      bool
+	NoQuery     bool
+}
+
+func init() {
+	Register(KURL, Options{RequireHost: true})
+	Register(KHTTPURL, Options{Schemes: []string{"http", "https"}, RequireHost: true})
+	translator.RegisterDefaultEnglishTranslations(DefaultURLTranslations())
+}
+
+// Register registers kind as a URL rule with opts and documents it. Call it
+// from init, e.g. Register("webhookurl", Options{Schemes: []string{"https"},
+// RequireHost: true, NoQuery: true}) for `string;webhookurl`.
+func Register(kind types.Kind, opts Options) {
+	opts.Schemes = append([]string(nil), opts.Schemes...)
+	types.RegisterRule(kind, compileURL(opts))
+	sample := "https://example.com/"
+	if len(opts.Schemes) > 0 {
+		sample = strings.ToLower(opts.Schemes[0]) + "://example.com/"
+	}
+	summary := "Absolute URL"
+	if len(opts.Schemes) > 0 {
+		summary += " with scheme " + strings.Join(opts.Schemes, ", ")
+	}
+	types.RegisterKindDoc(types.KindDoc{Kind: kind, Summary: summary, Examples: []string{"string;" + string(kind)}, Sample: sample, Format: "uri"})
+}
+
+func compileURL(opts Options) types.RuleCompiler {
+	return func(c *types.Compiler, _ types.Rule) (func(any) error, error) {
+		return func(v any) error {
+			s, ok := v.(string)
+			if !ok {
+				msg := c.T("string.type", "expected string", nil)
+				return verrs.Errors{verrs.FieldError{Path: "", Code: verrs.CodeStringType, Msg: msg}}
+			}
+			if fe := validateURLString(c, s, opts); fe.Code != "" {
+				return verrs.Errors{fe}
+			}
+			return nil
+		}, nil
+	}
+}
+
+// validateURLString checks s against opts and uses translator.
+func validateURLString(c *types.Compiler, s string, opts Options) verrs.FieldError {
+	u, err := neturl.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return urlError(c, CodeURLInvalid, "must be a valid absolute URL", nil)
+	}
+	if len(opts.Schemes) > 0 && !containsFold(opts.Schemes, u.Scheme) {
+		return urlError(c, CodeURLScheme, "URL scheme is not allowed", opts.Schemes)
+	}
+	if opts.RequireHost && (u.Host == "" || !isValidHost(u.Hostname())) {
+		return urlError(c, CodeURLHost, "URL must have a valid host", nil)
+	}
+	if port := u.Port(); port != "" && opts.NoPort {
+		return urlError(c, CodeURLPort, "URL must not include a port", nil)
+	}
+	if opts.NoPath && u.Path != "" && u.Path != "/" {
+		return urlError(c, CodeURLPath, "URL must not include a path", nil)
+	}
+	if opts.NoQuery && (u.RawQuery != "" || u.ForceQuery || u.Fragment != "") {
+		return urlError(c, CodeURLQuery, "URL must not include a query or fragment", nil)
+	}
+	return verrs.FieldError{}
+}
+
+func urlError(c *types.Compiler, code, fallback string, param any) verrs.FieldError {
+	return verrs.FieldError{Code: code, Msg: c.T(code, fallback, nil), Param: param}
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidHost reports whether host is an IP address or a host name of
+// letter, digit and hyphen labels, as the built-in rule accepts.
+func isValidHost(host string) bool {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return addr.IsValid()
+	}
+	host = strings.TrimSuffix(host, ".")
+	if host == "" || len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 {
+			return false
+		}
+		for i, r := range label {
+			ok := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-'
+			if !ok || (r == '-' && (i == 0 || i == len(label)-1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package url
+
+import (
+	"errors"
+	"testing"
+
+	verrs "github.com/aatuh/validate/v3/errors"
+	"github.com/aatuh/validate/v3/types"
+)
+
+func TestURL_Options(t *testing.T) {
+	strict := Options{Schemes: []string{"HTTPS"}, RequireHost: true, NoPort: true, NoPath: true, NoQuery: true}
+	tests := []struct {
+		name  string
+		value string
+		opts  Options
+		code  string
+	}{
+		{"absolute", "https://example.com/a?b=1", Options{RequireHost: true}, ""},
+		{"ip host", "http://[::1]:8080/", Options{RequireHost: true}, ""},
+		{"no host allowed", "mailto:user@example.com", Options{}, ""},
+		{"relative", "example.com", Options{RequireHost: true}, CodeURLInvalid},
+		{"unparsable", "http://a b", Options{}, CodeURLInvalid},
+		{"missing host", "mailto:user@example.com", Options{RequireHost: true}, CodeURLHost},
+		{"invalid host", "http://-bad-.com", Options{RequireHost: true}, CodeURLHost},
+		{"strict ok", "https://example.com/", strict, ""},
+		{"scheme", "http://example.com", strict, CodeURLScheme},
+		{"port", "https://example.com:8443", strict, CodeURLPort},
+		{"path", "https://example.com/admin", strict, CodeURLPath},
+		{"query", "https://example.com/?a=1", strict, CodeURLQuery},
+		{"fragment", "https://example.com/#top", strict, CodeURLQuery},
+	}
+	for _, tt := range tests {
+		if fe := validateURLString(&types.Compiler{}, tt.value, tt.opts); fe.Code != tt.code {
+			t.Errorf("%s: %q code = %q, want %q", tt.name, tt.value, fe.Code, tt.code)
+		}
+	}
+}
+
+func TestURL_RegisteredKinds(t *testing.T) {
+	Register("testhttpsurl", Options{Schemes: []string{"https"}, RequireHost: true})
+	c := types.NewCompiler(nil)
+	tests := []struct {
+		tag   string
+		value string
+		code  string
+	}{
+		{"string;url", "ftp://example.com", ""},
+		{"string;url", "urn:isbn:0451450523", CodeURLHost},
+		{"string;httpurl", "ftp://example.com", CodeURLScheme},
+		{"string;testhttpsurl", "http://example.com", CodeURLScheme},
+		{"string;testhttpsurl", "https://example.com", ""},
+	}
+	for _, tt := range tests {
+		rules, err := types.ParseTag(tt.tag)
+		if err != nil {
+			t.Fatalf("ParseTag(%q): %v", tt.tag, err)
+		}
+		err = c.Compile(rules)(tt.value)
+		var es verrs.Errors
+		switch {
+		case tt.code == "" && err != nil:
+			t.Errorf("%s %q: unexpected error %v", tt.tag, tt.value, err)
+		case tt.code != "" && (!errors.As(err, &es) || es[0].Code != tt.code):
+			t.Errorf("%s %q: got %v, want code %s", tt.tag, tt.value, err, tt.code)
+		}
+	}
+}
@@ -36,6 +36,7 @@ const (
 
 func init() {
 	types.RegisterRule(KUUID, compileUUID)
+	types.RegisterKindDoc(types.KindDoc{Kind: KUUID, Summary: "Canonical hyphenated UUID", Examples: []string{"string;uuid"}, Sample: "123e4567-e89b-12d3-a456-426614174000", Format: "uuid"})
 	for _, rule := range []struct {
 		kind    types.Kind
 		version byte
@@ -49,6 +50,13 @@ func init() {
 		{KUUIDv8, '8'},
 	} {
 		types.RegisterRule(rule.kind, compileUUIDVersion(rule.version))
+		types.RegisterKindDoc(types.KindDoc{
+			Kind:     rule.kind,
+			Summary:  "Canonical UUID with version " + string(rule.version) + " and RFC variant",
+			Examples: []string{"string;" + string(rule.kind)},
+			Sample:   "123e4567-e89b-" + string(rule.version) + "3d3-a456-426614174000",
+			Format:   "uuid",
+		})
 	}
 	// Register UUID as a custom type
 	types.RegisterGlobalType("uuid", &UUIDTypeValidatorFactory{})